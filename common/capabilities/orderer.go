@@ -15,12 +15,16 @@ const (
 
 	// OrdererV1_1 is the capabilties string for standard new non-backwards compatible fabric v1.1 orderer capabilities.
 	OrdererV1_1 = "V1_1"
+
+	// OrdererV1_3 is the capabilties string for standard new non-backwards compatible fabric v1.3 orderer capabilities.
+	OrdererV1_3 = "V1_3"
 )
 
 // OrdererProvider provides capabilities information for orderer level config.
 type OrdererProvider struct {
 	*registry
 	v11BugFixes bool
+	v13         bool
 }
 
 // NewOrdererProvider creates an orderer capabilities provider.
@@ -28,6 +32,7 @@ func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider
 	cp := &OrdererProvider{}
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11BugFixes = capabilities[OrdererV1_1]
+	_, cp.v13 = capabilities[OrdererV1_3]
 	return cp
 }
 
@@ -42,6 +47,8 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 	// Add new capability names here
 	case OrdererV1_1:
 		return true
+	case OrdererV1_3:
+		return true
 	default:
 		return false
 	}
@@ -50,17 +57,29 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 // PredictableChannelTemplate specifies whether the v1.0 undesirable behavior of setting the /Channel
 // group's mod_policy to "" and copying versions from the channel config should be fixed or not.
 func (cp *OrdererProvider) PredictableChannelTemplate() bool {
-	return cp.v11BugFixes
+	return cp.v11BugFixes || cp.v13
 }
 
 // Resubmission specifies whether the v1.0 non-deterministic commitment of tx should be fixed by re-submitting
 // the re-validated tx.
 func (cp *OrdererProvider) Resubmission() bool {
-	return cp.v11BugFixes
+	return cp.v11BugFixes || cp.v13
 }
 
 // ExpirationCheck specifies whether the orderer checks for identity expiration checks
 // when validating messages
 func (cp *OrdererProvider) ExpirationCheck() bool {
-	return cp.v11BugFixes
+	return cp.v11BugFixes || cp.v13
+}
+
+// ConsensusTypeMigration specifies whether the consensus type of a channel may be changed
+// through a config update while the channel is in maintenance mode.
+func (cp *OrdererProvider) ConsensusTypeMigration() bool {
+	return cp.v13
+}
+
+// BatchClasses specifies whether messages may be sorted into classes with their own
+// batch sizes and timeouts, and prioritized within blocks.
+func (cp *OrdererProvider) BatchClasses() bool {
+	return cp.v13
 }
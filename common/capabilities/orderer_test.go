@@ -27,3 +27,21 @@ func TestOrdererV11(t *testing.T) {
 	assert.NoError(t, op.Supported())
 	assert.True(t, op.PredictableChannelTemplate())
 }
+
+func TestOrdererV13(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_3: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.PredictableChannelTemplate())
+	assert.True(t, op.Resubmission())
+	assert.True(t, op.ExpirationCheck())
+	assert.True(t, op.ConsensusTypeMigration())
+	assert.True(t, op.BatchClasses())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {},
+	})
+	assert.False(t, op.ConsensusTypeMigration())
+	assert.False(t, op.BatchClasses())
+}
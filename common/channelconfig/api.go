@@ -80,15 +80,33 @@ type Orderer interface {
 	// ConsensusType returns the configured consensus type
 	ConsensusType() string
 
+	// ConsensusMetadata returns the metadata associated with the consensus type.
+	ConsensusMetadata() []byte
+
+	// ConsensusState returns the consensus-type migration state.
+	ConsensusState() ab.ConsensusType_State
+
 	// BatchSize returns the maximum number of messages to include in a block
 	BatchSize() *ab.BatchSize
 
 	// BatchTimeout returns the amount of time to wait before creating a batch
 	BatchTimeout() time.Duration
 
+	// ClassBatchTimeouts returns the batch timeouts of the classes of the batch size
+	// which do not use the batch timeout of the channel
+	ClassBatchTimeouts() map[string]time.Duration
+
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
+	// RateLimits returns the broadcast rate limits overriding those of the orderer
+	// local configuration, or nil if the channel does not override them
+	RateLimits() *ab.RateLimits
+
+	// AdmissionRules returns the rules the orderer applies to the messages broadcast
+	// to the channel, in addition to its standard filters, or nil if there are none
+	AdmissionRules() *ab.AdmissionRules
+
 	// KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 	// Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 	// used for ordering
@@ -152,6 +170,14 @@ type OrdererCapabilities interface {
 	// ExpirationCheck specifies whether the orderer checks for identity expiration checks
 	// when validating messages
 	ExpirationCheck() bool
+
+	// ConsensusTypeMigration specifies whether the consensus type of a channel may be changed
+	// through a config update while the channel is in maintenance mode.
+	ConsensusTypeMigration() bool
+
+	// BatchClasses specifies whether messages may be sorted into classes with their own
+	// batch sizes and timeouts, and prioritized within blocks.
+	BatchClasses() bool
 }
 
 // Resources is the common set of config resources for all channels
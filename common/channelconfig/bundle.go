@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"bytes"
+
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/pkg/errors"
@@ -86,8 +89,8 @@ func (b *Bundle) ValidateNew(nb Resources) error {
 			return errors.New("Current config has orderer section, but new config does not")
 		}
 
-		if oc.ConsensusType() != noc.ConsensusType() {
-			return errors.Errorf("Attempted to change consensus type from %s to %s", oc.ConsensusType(), noc.ConsensusType())
+		if err := validateConsensusTypeMigration(oc, noc); err != nil {
+			return err
 		}
 
 		for orgName, org := range oc.Organizations() {
@@ -173,6 +176,19 @@ func NewBundleFromEnvelope(env *cb.Envelope) (*Bundle, error) {
 	return NewBundle(chdr.ChannelId, configEnvelope.Config)
 }
 
+// PolicyManagerFactory creates the policy manager of the config carried by a config envelope
+type PolicyManagerFactory func(env *cb.Envelope) (policies.Manager, error)
+
+// NewPolicyManagerFromEnvelope is a PolicyManagerFactory returning the policy manager
+// of the bundle of the config carried by the envelope
+func NewPolicyManagerFromEnvelope(env *cb.Envelope) (policies.Manager, error) {
+	bundle, err := NewBundleFromEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+	return bundle.PolicyManager(), nil
+}
+
 // NewBundle creates a new immutable bundle of configuration
 func NewBundle(channelID string, config *cb.Config) (*Bundle, error) {
 	if err := preValidate(config); err != nil {
@@ -239,3 +255,34 @@ func preValidate(config *cb.Config) error {
 
 	return nil
 }
+
+// validateConsensusTypeMigration checks the changes of the consensus type, metadata and state.
+// The consensus type and metadata may only be changed while the channel is, and stays, in
+// maintenance mode, which in turn requires the consensus type migration capability.
+func validateConsensusTypeMigration(oc, noc Orderer) error {
+	typeChanged := oc.ConsensusType() != noc.ConsensusType()
+	metadataChanged := !bytes.Equal(oc.ConsensusMetadata(), noc.ConsensusMetadata())
+	stateChanged := oc.ConsensusState() != noc.ConsensusState()
+	if !typeChanged && !metadataChanged && !stateChanged {
+		return nil
+	}
+
+	if !oc.Capabilities().ConsensusTypeMigration() {
+		if typeChanged {
+			return errors.Errorf("Attempted to change consensus type from %s to %s", oc.ConsensusType(), noc.ConsensusType())
+		}
+		if metadataChanged {
+			return errors.Errorf("Attempted to change consensus metadata of type %s, but the consensus type migration capability is not enabled", oc.ConsensusType())
+		}
+		return errors.Errorf("Attempted to change consensus state from %s to %s, but the consensus type migration capability is not enabled", oc.ConsensusState(), noc.ConsensusState())
+	}
+
+	inMaintenance := oc.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE && noc.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE
+	if typeChanged && !inMaintenance {
+		return errors.Errorf("Attempted to change consensus type from %s to %s, but the channel is not in, or is leaving, maintenance mode", oc.ConsensusType(), noc.ConsensusType())
+	}
+	if metadataChanged && !inMaintenance {
+		return errors.Errorf("Attempted to change consensus metadata of type %s, but the channel is not in, or is leaving, maintenance mode", oc.ConsensusType())
+	}
+	return nil
+}
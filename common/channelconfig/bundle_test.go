@@ -9,6 +9,7 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
@@ -94,6 +95,65 @@ func TestValidateNew(t *testing.T) {
 		assert.Regexp(t, "Attempted to change consensus type from", err.Error())
 	})
 
+	t.Run("ConsensusTypeMigration", func(t *testing.T) {
+		ordererBundle := func(consensusType string, state ab.ConsensusType_State, migration bool) *Bundle {
+			caps := map[string]*cb.Capability{}
+			if migration {
+				caps[capabilities.OrdererV1_3] = &cb.Capability{}
+			}
+			return &Bundle{
+				channelConfig: &ChannelConfig{
+					ordererConfig: &OrdererConfig{
+						protos: &OrdererProtos{
+							ConsensusType: &ab.ConsensusType{
+								Type:  consensusType,
+								State: state,
+							},
+							Capabilities: &cb.Capabilities{Capabilities: caps},
+						},
+					},
+				},
+			}
+		}
+		normal, maintenance := ab.ConsensusType_STATE_NORMAL, ab.ConsensusType_STATE_MAINTENANCE
+		withMetadata := func(bundle *Bundle, metadata string) *Bundle {
+			bundle.channelConfig.ordererConfig.protos.ConsensusType.Metadata = []byte(metadata)
+			return bundle
+		}
+
+		testCases := []struct {
+			name          string
+			current       *Bundle
+			next          *Bundle
+			expectedError string
+		}{
+			{"EnterMaintenance", ordererBundle("kafka", normal, true), ordererBundle("kafka", maintenance, true), ""},
+			{"ExitMaintenance", ordererBundle("kafka", maintenance, true), ordererBundle("kafka", normal, true), ""},
+			{"ChangeTypeInMaintenance", ordererBundle("kafka", maintenance, true), ordererBundle("solo", maintenance, true), ""},
+			{"EnterMaintenanceWithoutCapability", ordererBundle("kafka", normal, false), ordererBundle("kafka", maintenance, false), "the consensus type migration capability is not enabled"},
+			{"ChangeTypeWithoutCapability", ordererBundle("kafka", maintenance, false), ordererBundle("solo", maintenance, false), "Attempted to change consensus type from kafka to solo"},
+			{"ChangeTypeInNormal", ordererBundle("kafka", normal, true), ordererBundle("solo", normal, true), "the channel is not in, or is leaving, maintenance mode"},
+			{"ChangeTypeEnteringMaintenance", ordererBundle("kafka", normal, true), ordererBundle("solo", maintenance, true), "the channel is not in, or is leaving, maintenance mode"},
+			{"ChangeTypeLeavingMaintenance", ordererBundle("kafka", maintenance, true), ordererBundle("solo", normal, true), "the channel is not in, or is leaving, maintenance mode"},
+			{"ChangeMetadataInMaintenance", ordererBundle("other", maintenance, true), withMetadata(ordererBundle("other", maintenance, true), "metadata"), ""},
+			{"ChangeMetadataWithoutCapability", ordererBundle("other", maintenance, false), withMetadata(ordererBundle("other", maintenance, false), "metadata"), "Attempted to change consensus metadata of type other, but the consensus type migration capability is not enabled"},
+			{"ChangeMetadataInNormal", ordererBundle("other", normal, true), withMetadata(ordererBundle("other", normal, true), "metadata"), "Attempted to change consensus metadata of type other, but the channel is not in, or is leaving, maintenance mode"},
+			{"ChangeMetadataLeavingMaintenance", ordererBundle("other", maintenance, true), withMetadata(ordererBundle("other", normal, true), "metadata"), "the channel is not in, or is leaving, maintenance mode"},
+			{"ExitMaintenanceKeepingMetadata", withMetadata(ordererBundle("other", maintenance, true), "metadata"), withMetadata(ordererBundle("other", normal, true), "metadata"), ""},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.current.ValidateNew(tc.next)
+				if tc.expectedError == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.Error(t, err)
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+			})
+		}
+	})
+
 	t.Run("OrdererOrgMSPIDChange", func(t *testing.T) {
 		cb := &Bundle{
 			channelConfig: &ChannelConfig{
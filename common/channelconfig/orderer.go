@@ -40,6 +40,12 @@ const (
 
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message
 	KafkaBrokersKey = "KafkaBrokers"
+
+	// RateLimitsKey is the cb.ConfigItem type key name for the RateLimits message
+	RateLimitsKey = "RateLimits"
+
+	// AdmissionRulesKey is the cb.ConfigItem type key name for the AdmissionRules message
+	AdmissionRulesKey = "AdmissionRules"
 )
 
 // OrdererProtos is used as the source of the OrdererConfig
@@ -49,6 +55,8 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	RateLimits          *ab.RateLimits
+	AdmissionRules      *ab.AdmissionRules
 	Capabilities        *cb.Capabilities
 }
 
@@ -57,7 +65,8 @@ type OrdererConfig struct {
 	protos *OrdererProtos
 	orgs   map[string]Org
 
-	batchTimeout time.Duration
+	batchTimeout       time.Duration
+	classBatchTimeouts map[string]time.Duration
 }
 
 // NewOrdererConfig creates a new instance of the orderer config
@@ -89,6 +98,16 @@ func (oc *OrdererConfig) ConsensusType() string {
 	return oc.protos.ConsensusType.Type
 }
 
+// ConsensusMetadata returns the metadata associated with the consensus type.
+func (oc *OrdererConfig) ConsensusMetadata() []byte {
+	return oc.protos.ConsensusType.Metadata
+}
+
+// ConsensusState returns the consensus-type migration state.
+func (oc *OrdererConfig) ConsensusState() ab.ConsensusType_State {
+	return oc.protos.ConsensusType.State
+}
+
 // BatchSize returns the maximum number of messages to include in a block
 func (oc *OrdererConfig) BatchSize() *ab.BatchSize {
 	return oc.protos.BatchSize
@@ -99,6 +118,12 @@ func (oc *OrdererConfig) BatchTimeout() time.Duration {
 	return oc.batchTimeout
 }
 
+// ClassBatchTimeouts returns the batch timeouts of the classes of the batch size
+// which do not use the batch timeout of the channel
+func (oc *OrdererConfig) ClassBatchTimeouts() map[string]time.Duration {
+	return oc.classBatchTimeouts
+}
+
 // KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 // Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 // used for ordering
@@ -111,6 +136,16 @@ func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 	return oc.protos.ChannelRestrictions.MaxCount
 }
 
+// RateLimits returns the broadcast rate limits of the channel, if any
+func (oc *OrdererConfig) RateLimits() *ab.RateLimits {
+	return oc.protos.RateLimits
+}
+
+// AdmissionRules returns the admission rules of the channel, if any
+func (oc *OrdererConfig) AdmissionRules() *ab.AdmissionRules {
+	return oc.protos.AdmissionRules
+}
+
 // Organizations returns a map of the orgs in the channel
 func (oc *OrdererConfig) Organizations() map[string]Org {
 	return oc.orgs
@@ -118,7 +153,7 @@ func (oc *OrdererConfig) Organizations() map[string]Org {
 
 // Capabilities returns the capabilities the ordering network has for this channel
 func (oc *OrdererConfig) Capabilities() OrdererCapabilities {
-	return capabilities.NewOrdererProvider(oc.protos.Capabilities.Capabilities)
+	return capabilities.NewOrdererProvider(oc.protos.Capabilities.GetCapabilities())
 }
 
 func (oc *OrdererConfig) Validate() error {
@@ -148,6 +183,28 @@ func (oc *OrdererConfig) validateBatchSize() error {
 	if oc.protos.BatchSize.PreferredMaxBytes > oc.protos.BatchSize.AbsoluteMaxBytes {
 		return fmt.Errorf("Attempted to set the batch size preferred max bytes (%v) greater than the absolute max bytes (%v).", oc.protos.BatchSize.PreferredMaxBytes, oc.protos.BatchSize.AbsoluteMaxBytes)
 	}
+	if oc.protos.BatchSize.Classifier == "" && len(oc.protos.BatchSize.Classes) == 0 {
+		return nil
+	}
+	if !oc.Capabilities().BatchClasses() {
+		return fmt.Errorf("Attempted to set batch classes but the batch classes capability is not enabled")
+	}
+	if oc.protos.BatchSize.Classifier == "" {
+		return fmt.Errorf("Attempted to set batch classes without a classifier")
+	}
+	classes := make(map[string]struct{})
+	for _, class := range oc.protos.BatchSize.Classes {
+		if class.Class == "" {
+			return fmt.Errorf("Attempted to set a batch class without a name")
+		}
+		if _, exists := classes[class.Class]; exists {
+			return fmt.Errorf("Attempted to set the batch size of class %s twice", class.Class)
+		}
+		classes[class.Class] = struct{}{}
+		if class.PreferredMaxBytes > oc.protos.BatchSize.AbsoluteMaxBytes {
+			return fmt.Errorf("Attempted to set the batch size preferred max bytes of class %s (%v) greater than the absolute max bytes (%v).", class.Class, class.PreferredMaxBytes, oc.protos.BatchSize.AbsoluteMaxBytes)
+		}
+	}
 	return nil
 }
 
@@ -160,6 +217,28 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	if oc.batchTimeout <= 0 {
 		return fmt.Errorf("Attempted to set the batch timeout to a non-positive value: %s", oc.batchTimeout)
 	}
+
+	classes := make(map[string]struct{})
+	for _, class := range oc.protos.BatchSize.GetClasses() {
+		classes[class.Class] = struct{}{}
+	}
+	oc.classBatchTimeouts = make(map[string]time.Duration)
+	for _, class := range oc.protos.BatchTimeout.Classes {
+		if _, exists := classes[class.Class]; !exists {
+			return fmt.Errorf("Attempted to set the batch timeout of class %s which has no batch size", class.Class)
+		}
+		if _, exists := oc.classBatchTimeouts[class.Class]; exists {
+			return fmt.Errorf("Attempted to set the batch timeout of class %s twice", class.Class)
+		}
+		timeout, err := time.ParseDuration(class.Timeout)
+		if err != nil {
+			return fmt.Errorf("Attempted to set the batch timeout of class %s to a invalid value: %s", class.Class, err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("Attempted to set the batch timeout of class %s to a non-positive value: %s", class.Class, timeout)
+		}
+		oc.classBatchTimeouts[class.Class] = timeout
+	}
 	return nil
 }
 
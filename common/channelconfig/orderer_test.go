@@ -8,7 +8,10 @@ package channelconfig
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
 	logging "github.com/op/go-logging"
@@ -48,6 +51,46 @@ func TestBatchTimeout(t *testing.T) {
 	assert.Error(t, oc.validateBatchTimeout(), "Zero batch timeout")
 }
 
+func TestBatchClasses(t *testing.T) {
+	v13 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererV1_3: {}}}
+	batchSize := func(classifier string, classes ...*ab.ClassBatchSize) *ab.BatchSize {
+		return &ab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 500, Classifier: classifier, Classes: classes}
+	}
+
+	oc := &OrdererConfig{protos: &OrdererProtos{
+		BatchSize:    batchSize("msp", &ab.ClassBatchSize{Class: "Org1MSP", MaxMessageCount: 1}),
+		BatchTimeout: &ab.BatchTimeout{Timeout: "1s", Classes: []*ab.ClassBatchTimeout{{Class: "Org1MSP", Timeout: "10ms"}}},
+		Capabilities: v13,
+	}}
+	assert.NoError(t, oc.validateBatchSize(), "Valid batch classes")
+	assert.NoError(t, oc.validateBatchTimeout(), "Valid batch class timeouts")
+	assert.Equal(t, map[string]time.Duration{"Org1MSP": 10 * time.Millisecond}, oc.ClassBatchTimeouts())
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize("msp", &ab.ClassBatchSize{Class: "Org1MSP"})}}
+	assert.Error(t, oc.validateBatchSize(), "Batch classes without capability")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize("", &ab.ClassBatchSize{Class: "Org1MSP"}), Capabilities: v13}}
+	assert.Error(t, oc.validateBatchSize(), "Batch classes without classifier")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize("msp", &ab.ClassBatchSize{Class: "Org1MSP"}, &ab.ClassBatchSize{Class: "Org1MSP"}), Capabilities: v13}}
+	assert.Error(t, oc.validateBatchSize(), "Duplicate batch class")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize("msp", &ab.ClassBatchSize{Class: "Org1MSP", PreferredMaxBytes: 2000}), Capabilities: v13}}
+	assert.Error(t, oc.validateBatchSize(), "Batch class preferred max bytes larger than AbsoluteMaxBytes")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{
+		BatchSize:    batchSize("msp", &ab.ClassBatchSize{Class: "Org1MSP"}),
+		BatchTimeout: &ab.BatchTimeout{Timeout: "1s", Classes: []*ab.ClassBatchTimeout{{Class: "Org2MSP", Timeout: "10ms"}}},
+	}}
+	assert.Error(t, oc.validateBatchTimeout(), "Batch timeout of unknown class")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{
+		BatchSize:    batchSize("msp", &ab.ClassBatchSize{Class: "Org1MSP"}),
+		BatchTimeout: &ab.BatchTimeout{Timeout: "1s", Classes: []*ab.ClassBatchTimeout{{Class: "Org1MSP", Timeout: "0s"}}},
+	}}
+	assert.Error(t, oc.validateBatchTimeout(), "Zero batch class timeout")
+}
+
 func TestKafkaBrokers(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1:9092", "foo.bar:9092"}}}}
 	assert.NoError(t, oc.validateKafkaBrokers(), "Valid kafka brokers")
@@ -159,6 +159,24 @@ func KafkaBrokersValue(brokers []string) *StandardConfigValue {
 	}
 }
 
+// RateLimitsValue returns the config definition for the broadcast rate limits of the channel.
+// It is a value for the /Channel/Orderer group.
+func RateLimitsValue(rateLimits *ab.RateLimits) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   RateLimitsKey,
+		value: rateLimits,
+	}
+}
+
+// AdmissionRulesValue returns the config definition for the admission rules of the channel.
+// It is a value for the /Channel/Orderer group.
+func AdmissionRulesValue(admissionRules *ab.AdmissionRules) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   AdmissionRulesKey,
+		value: admissionRules,
+	}
+}
+
 // MSPValue returns the config definition for an MSP.
 // It is a value for the /Channel/Orderer/*, /Channel/Application/*, and /Channel/Consortiums/*/*/* groups.
 func MSPValue(mspDef *mspprotos.MSPConfig) *StandardConfigValue {
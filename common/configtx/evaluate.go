@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtx
+
+import (
+	"sort"
+	"strings"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ModPolicyEvaluation is the outcome of evaluating the mod_policy of
+// a config element which is modified by a config update
+type ModPolicyEvaluation struct {
+	// Key identifies the modified config element
+	Key string
+
+	// PolicyPath is the fully qualified path of the mod_policy of the element
+	PolicyPath string
+
+	// Err is nil if the signatures of the update satisfy the mod_policy
+	Err error
+}
+
+// UpdateEvaluation is the outcome of evaluating a config update against the current config
+type UpdateEvaluation struct {
+	// SignedData holds the signatures collected by the config update
+	SignedData []*cb.SignedData
+
+	// VersionErrors lists the problems with the read and write sets, such as
+	// mismatched versions or invalid mod_policies
+	VersionErrors []error
+
+	// ModPolicies holds the evaluation of the mod_policy of every modified config element
+	ModPolicies []*ModPolicyEvaluation
+
+	// ProposedConfig is the result of ProposeConfigUpdate, nil if the update is rejected
+	ProposedConfig *cb.ConfigEnvelope
+
+	// ProposeErr is the error returned by ProposeConfigUpdate, nil if the update is accepted
+	ProposeErr error
+}
+
+// EvaluateConfigUpdate evaluates an Envelope of type CONFIG_UPDATE against the current config.
+// Unlike ProposeConfigUpdate, which stops at the first problem, it collects all the version
+// mismatches and the outcome of the evaluation of every mod_policy involved in the update.
+// An error is returned only if the update is malformed.
+func (vi *ValidatorImpl) EvaluateConfigUpdate(configtx *cb.Envelope) (*UpdateEvaluation, error) {
+	configUpdateEnv, err := envelopeToConfigUpdate(configtx)
+	if err != nil {
+		return nil, errors.Errorf("error converting envelope to config update: %s", err)
+	}
+
+	configUpdate, err := UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if configUpdate.ChannelId != vi.channelID {
+		return nil, errors.Errorf("Update not for correct channel: %s for %s", configUpdate.ChannelId, vi.channelID)
+	}
+
+	readSet, err := mapConfig(configUpdate.ReadSet, vi.namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error mapping ReadSet")
+	}
+
+	writeSet, err := mapConfig(configUpdate.WriteSet, vi.namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error mapping WriteSet")
+	}
+
+	signedData, err := configUpdateEnv.AsSignedData()
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation := &UpdateEvaluation{SignedData: signedData}
+
+	for _, key := range sortedKeys(readSet) {
+		if err := vi.checkReadSetKey(key, readSet[key]); err != nil {
+			evaluation.VersionErrors = append(evaluation.VersionErrors, err)
+		}
+	}
+
+	deltaSet := computeDeltaSet(readSet, writeSet)
+	for _, key := range sortedKeys(deltaSet) {
+		if err := vi.checkDeltaSetKey(key, deltaSet[key]); err != nil {
+			evaluation.VersionErrors = append(evaluation.VersionErrors, err)
+		}
+
+		existing, ok := vi.configMap[key]
+		if !ok {
+			continue
+		}
+		evaluation.ModPolicies = append(evaluation.ModPolicies, &ModPolicyEvaluation{
+			Key:        key,
+			PolicyPath: modPolicyPath(existing, vi.namespace),
+			Err:        vi.checkModPolicy(key, existing, signedData),
+		})
+	}
+
+	evaluation.ProposedConfig, evaluation.ProposeErr = vi.proposeConfigUpdate(configtx)
+	return evaluation, nil
+}
+
+// modPolicyPath returns the fully qualified path of the mod_policy of the
+// item, resolving relative policies the same way policyForItem does
+func modPolicyPath(item comparable, namespace string) string {
+	modPolicy := item.modPolicy()
+	if strings.HasPrefix(modPolicy, pathSeparator) {
+		return modPolicy
+	}
+
+	path := []string{namespace}
+	if len(item.path) != 0 {
+		path = append(path, item.path[1:]...)
+		if item.ConfigGroup != nil {
+			path = append(path, item.key)
+		}
+	}
+	return pathSeparator + strings.Join(append(path, modPolicy), pathSeparator)
+}
+
+func sortedKeys(configMap map[string]comparable) []string {
+	keys := make([]string, 0, len(configMap))
+	for key := range configMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
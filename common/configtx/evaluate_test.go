@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtx
+
+import (
+	"fmt"
+	"testing"
+
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateConfigUpdate(t *testing.T) {
+	pm := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			"accept": &mockpolicies.Policy{},
+			"reject": &mockpolicies.Policy{Err: fmt.Errorf("signature set did not satisfy policy")},
+		},
+	}
+	vi, err := NewValidatorImpl(
+		defaultChain,
+		makeConfig(
+			makeConfigPair("foo", "accept", 0, []byte("foo")),
+			makeConfigPair("bar", "reject", 0, []byte("bar")),
+			makeConfigPair("baz", "/Other/reject", 3, []byte("baz")),
+		),
+		"foonamespace",
+		pm)
+	assert.NoError(t, err)
+
+	t.Run("Accepted", func(t *testing.T) {
+		evaluation, err := vi.EvaluateConfigUpdate(makeConfigUpdateEnvelope(defaultChain, makeConfigSet(), makeConfigSet(makeConfigPair("foo", "accept", 1, []byte("foo2")))))
+		assert.NoError(t, err)
+		assert.NoError(t, evaluation.ProposeErr)
+		assert.NotNil(t, evaluation.ProposedConfig)
+		assert.Empty(t, evaluation.VersionErrors)
+		assert.Len(t, evaluation.ModPolicies, 1)
+		assert.Equal(t, "[Value]  /foonamespace/foo", evaluation.ModPolicies[0].Key)
+		assert.Equal(t, "/foonamespace/accept", evaluation.ModPolicies[0].PolicyPath)
+		assert.NoError(t, evaluation.ModPolicies[0].Err)
+	})
+
+	t.Run("AllFailuresReported", func(t *testing.T) {
+		evaluation, err := vi.EvaluateConfigUpdate(makeConfigUpdateEnvelope(defaultChain,
+			makeConfigSet(makeConfigPair("foo", "accept", 1, nil)),
+			makeConfigSet(
+				makeConfigPair("bar", "reject", 1, []byte("bar2")),
+				makeConfigPair("baz", "/Other/reject", 5, []byte("baz2")),
+			)))
+		assert.NoError(t, err)
+		assert.Error(t, evaluation.ProposeErr)
+		assert.Nil(t, evaluation.ProposedConfig)
+
+		assert.Len(t, evaluation.VersionErrors, 2)
+		assert.Contains(t, evaluation.VersionErrors[0].Error(), "readset expected key [Value]  /foonamespace/foo at version 1, but got version 0")
+		assert.Contains(t, evaluation.VersionErrors[1].Error(), "attempt to set key [Value]  /foonamespace/baz to version 5, but key is at version 3")
+
+		assert.Len(t, evaluation.ModPolicies, 2)
+		assert.Equal(t, "/foonamespace/reject", evaluation.ModPolicies[0].PolicyPath)
+		assert.Error(t, evaluation.ModPolicies[0].Err)
+		assert.Equal(t, "/Other/reject", evaluation.ModPolicies[1].PolicyPath)
+		assert.Error(t, evaluation.ModPolicies[1].Err)
+	})
+
+	t.Run("InvalidModPolicy", func(t *testing.T) {
+		evaluation, err := vi.EvaluateConfigUpdate(makeConfigUpdateEnvelope(defaultChain, makeConfigSet(), makeConfigSet(makeConfigPair("foo", "", 1, []byte("foo2")))))
+		assert.NoError(t, err)
+		assert.Error(t, evaluation.ProposeErr)
+		assert.Len(t, evaluation.VersionErrors, 1)
+		assert.Contains(t, evaluation.VersionErrors[0].Error(), "invalid mod_policy for element [Value]  /foonamespace/foo: mod_policy not set")
+	})
+
+	t.Run("WrongChannel", func(t *testing.T) {
+		_, err := vi.EvaluateConfigUpdate(makeConfigUpdateEnvelope("wrongChain", makeConfigSet(), makeConfigSet()))
+		assert.EqualError(t, err, "Update not for correct channel: wrongChain for "+defaultChain)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := vi.EvaluateConfigUpdate(&cb.Envelope{Payload: []byte("garbage")})
+		assert.Error(t, err)
+	})
+}
+
+func TestModPolicyPath(t *testing.T) {
+	group := comparable{key: "Org1", path: []string{"Channel", "Application"}, ConfigGroup: &cb.ConfigGroup{ModPolicy: "Admins"}}
+	assert.Equal(t, "/Channel/Application/Org1/Admins", modPolicyPath(group, "Channel"))
+
+	value := comparable{key: "AnchorPeers", path: []string{"Channel", "Application", "Org1"}, ConfigValue: &cb.ConfigValue{ModPolicy: "Admins"}}
+	assert.Equal(t, "/Channel/Application/Org1/Admins", modPolicyPath(value, "Channel"))
+
+	root := comparable{key: "Channel", ConfigGroup: &cb.ConfigGroup{ModPolicy: "Admins"}}
+	assert.Equal(t, "/Channel/Admins", modPolicyPath(root, "Channel"))
+
+	absolute := comparable{key: "BatchSize", path: []string{"Channel", "Orderer"}, ConfigValue: &cb.ConfigValue{ModPolicy: "/Channel/Orderer/Admins"}}
+	assert.Equal(t, "/Channel/Orderer/Admins", modPolicyPath(absolute, "Channel"))
+}
@@ -18,14 +18,22 @@ import (
 
 func (vi *ValidatorImpl) verifyReadSet(readSet map[string]comparable) error {
 	for key, value := range readSet {
-		existing, ok := vi.configMap[key]
-		if !ok {
-			return errors.Errorf("existing config does not contain element for %s but was in the read set", key)
+		if err := vi.checkReadSetKey(key, value); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		if existing.version() != value.version() {
-			return errors.Errorf("readset expected key %s at version %d, but got version %d", key, value.version(), existing.version())
-		}
+// checkReadSetKey verifies that the config holds the element of the read set at its version
+func (vi *ValidatorImpl) checkReadSetKey(key string, value comparable) error {
+	existing, ok := vi.configMap[key]
+	if !ok {
+		return errors.Errorf("existing config does not contain element for %s but was in the read set", key)
+	}
+
+	if existing.version() != value.version() {
+		return errors.Errorf("readset expected key %s at version %d, but got version %d", key, value.version(), existing.version())
 	}
 	return nil
 }
@@ -73,31 +81,51 @@ func (vi *ValidatorImpl) verifyDeltaSet(deltaSet map[string]comparable, signedDa
 
 	for key, value := range deltaSet {
 		logger.Debugf("Processing change to key: %s", key)
-		if err := validateModPolicy(value.modPolicy()); err != nil {
-			return errors.Wrapf(err, "invalid mod_policy for element %s", key)
+		if err := vi.checkDeltaSetKey(key, value); err != nil {
+			return err
 		}
 
 		existing, ok := vi.configMap[key]
 		if !ok {
-			if value.version() != 0 {
-				return errors.Errorf("attempted to set key %s to version %d, but key does not exist", key, value.version())
-			}
-
 			continue
 		}
-		if value.version() != existing.version()+1 {
-			return errors.Errorf("attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
+		if err := vi.checkModPolicy(key, existing, signedData); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		policy, ok := vi.policyForItem(existing)
-		if !ok {
-			return errors.Errorf("unexpected missing policy %s for item %s", existing.modPolicy(), key)
-		}
+// checkDeltaSetKey verifies the mod_policy of the element of the delta set, and that
+// its version is the next one of the element of the config, if any
+func (vi *ValidatorImpl) checkDeltaSetKey(key string, value comparable) error {
+	if err := validateModPolicy(value.modPolicy()); err != nil {
+		return errors.Wrapf(err, "invalid mod_policy for element %s", key)
+	}
 
-		// Ensure the policy is satisfied
-		if err := policy.Evaluate(signedData); err != nil {
-			return errors.Wrapf(err, "policy for %s not satisfied", key)
+	existing, ok := vi.configMap[key]
+	if !ok {
+		if value.version() != 0 {
+			return errors.Errorf("attempted to set key %s to version %d, but key does not exist", key, value.version())
 		}
+		return nil
+	}
+	if value.version() != existing.version()+1 {
+		return errors.Errorf("attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
+	}
+	return nil
+}
+
+// checkModPolicy verifies that the signatures satisfy the mod_policy of the existing element
+func (vi *ValidatorImpl) checkModPolicy(key string, existing comparable, signedData []*cb.SignedData) error {
+	policy, ok := vi.policyForItem(existing)
+	if !ok {
+		return errors.Errorf("unexpected missing policy %s for item %s", existing.modPolicy(), key)
+	}
+
+	// Ensure the policy is satisfied
+	if err := policy.Evaluate(signedData); err != nil {
+		return errors.Wrapf(err, "policy for %s not satisfied", key)
 	}
 	return nil
 }
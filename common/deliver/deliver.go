@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package deliver
 
 import (
+	"fmt"
 	"io"
 	"math"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
@@ -104,7 +106,9 @@ type Receiver interface {
 // responses.
 type ResponseSender interface {
 	SendStatusResponse(status cb.Status) error
-	SendBlockResponse(block *cb.Block) error
+	// SendBlockResponse sends the block along with the token resuming the deliver
+	// after it, which is nil unless the client requested resume tokens
+	SendBlockResponse(block *cb.Block, resumeToken []byte) error
 }
 
 // Server is a polymorphic structure to support generalization of this handler
@@ -177,6 +181,12 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
 	}
 
+	if chdr.Type == int32(cb.HeaderType_DELIVER_ACK) {
+		// The client may acknowledge blocks it received after the deliver completed
+		logger.Debugf("Ignoring acknowledgment received from %s outside of a flow controlled deliver", addr)
+		return nil
+	}
+
 	err = h.validateChannelHeader(ctx, chdr)
 	if err != nil {
 		logger.Warningf("Rejecting deliver for %s due to envelope validation error: %s", addr, err)
@@ -217,6 +227,26 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
 	}
 
+	if len(seekInfo.ResumeToken) > 0 {
+		token := &ab.ResumeToken{}
+		if err := proto.Unmarshal(seekInfo.ResumeToken, token); err != nil {
+			logger.Warningf("[channel: %s] Received seekInfo message from %s with malformed resume token: %s", chdr.ChannelId, addr, err)
+			return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+		}
+		if token.ChannelId != chdr.ChannelId {
+			logger.Warningf("[channel: %s] Received seekInfo message from %s with a resume token of channel %s", chdr.ChannelId, addr, token.ChannelId)
+			return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+		}
+		if token.Next > token.Stop {
+			logger.Debugf("[channel: %s] Resumed deliver for %s has already delivered block %d", chdr.ChannelId, addr, token.Stop)
+			return srv.SendStatusResponse(cb.Status_SUCCESS)
+		}
+		seekInfo.Start = &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: token.Next}}}
+		seekInfo.Stop = &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: token.Stop}}}
+		seekInfo.Behavior = token.Behavior
+		seekInfo.ResumeTokens = true
+	}
+
 	if seekInfo.Start == nil || seekInfo.Stop == nil {
 		logger.Warningf("[channel: %s] Received seekInfo message from %s with missing start or stop %v, %v", chdr.ChannelId, addr, seekInfo.Start, seekInfo.Stop)
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
@@ -224,10 +254,22 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 	logger.Debugf("[channel: %s] Received seekInfo (%p) %v from %s", chdr.ChannelId, seekInfo, seekInfo, addr)
 
-	cursor, number := chain.Reader().Iterator(seekInfo.Start)
+	reader := chain.Reader()
+	start, err := resolveTimestamp(reader, seekInfo.Start, false)
+	if err != nil {
+		logger.Warningf("[channel: %s] Failed resolving the start position of seekInfo message from %s: %s", chdr.ChannelId, addr, err)
+		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+	}
+	stop, err := resolveTimestamp(reader, seekInfo.Stop, true)
+	if err != nil {
+		logger.Warningf("[channel: %s] Failed resolving the stop position of seekInfo message from %s: %s", chdr.ChannelId, addr, err)
+		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+	}
+
+	cursor, number := reader.Iterator(start)
 	defer cursor.Close()
 	var stopNum uint64
-	switch stop := seekInfo.Stop.Type.(type) {
+	switch stop := stop.Type.(type) {
 	case *ab.SeekPosition_Oldest:
 		stopNum = number
 	case *ab.SeekPosition_Newest:
@@ -240,7 +282,35 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		}
 	}
 
+	// acked is the number of the first block the client has not acknowledged yet
+	window, acked := uint64(seekInfo.GetFlowControl().GetWindow()), number
+
 	for {
+		for window > 0 && number-acked >= window {
+			logger.Debugf("[channel: %s] Waiting for %s to acknowledge block %d", chdr.ChannelId, addr, acked)
+			ackEnvelope, err := srv.Recv()
+			if err == io.EOF {
+				logger.Debugf("[channel: %s] Received EOF from %s while waiting for an acknowledgment, hangup", chdr.ChannelId, addr)
+				return nil
+			}
+			if err != nil {
+				logger.Warningf("[channel: %s] Error reading from %s: %s", chdr.ChannelId, addr, err)
+				return err
+			}
+			ack, err := unmarshalDeliverAck(ackEnvelope, chdr.ChannelId)
+			if err != nil {
+				logger.Warningf("[channel: %s] Received a bad acknowledgment from %s: %s", chdr.ChannelId, addr, err)
+				return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+			}
+			if ack.Number >= number {
+				logger.Warningf("[channel: %s] Received an acknowledgment from %s of block %d which was not delivered", chdr.ChannelId, addr, ack.Number)
+				return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+			}
+			if ack.Number >= acked {
+				acked = ack.Number + 1
+			}
+		}
+
 		if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
 			if number > chain.Reader().Height()-1 {
 				return srv.SendStatusResponse(cb.Status_NOT_FOUND)
@@ -264,7 +334,17 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 		logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
 
-		if err := srv.SendBlockResponse(block); err != nil {
+		var resumeToken []byte
+		if seekInfo.ResumeTokens {
+			resumeToken = utils.MarshalOrPanic(&ab.ResumeToken{
+				ChannelId: chdr.ChannelId,
+				Next:      block.Header.Number + 1,
+				Stop:      stopNum,
+				Behavior:  seekInfo.Behavior,
+			})
+		}
+
+		if err := srv.SendBlockResponse(block, resumeToken); err != nil {
 			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return err
 		}
@@ -284,6 +364,65 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	return nil
 }
 
+// resolveTimestamp converts a timestamp position into the specified position of the block
+// it designates in the ledger, and returns the other positions unchanged.  As a start
+// position, a timestamp designates the first block timestamped at or after it, which is
+// the next block to be created if there is none yet; as a stop position, it designates
+// the last block timestamped at or before it.
+func resolveTimestamp(rl blockledger.Reader, position *ab.SeekPosition, stop bool) (*ab.SeekPosition, error) {
+	seekTimestamp := position.GetTimestamp()
+	if seekTimestamp == nil {
+		return position, nil
+	}
+	t, err := ptypes.Timestamp(seekTimestamp.Timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp")
+	}
+	if stop {
+		t = t.Add(time.Nanosecond)
+	}
+
+	number, err := blockledger.SearchTimestamp(rl, t)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed searching the ledger for timestamp %s", t))
+	}
+	if stop {
+		if number == 0 {
+			return nil, errors.Errorf("no block is timestamped at or before %s", t.Add(-time.Nanosecond))
+		}
+		number--
+	}
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: number}}}, nil
+}
+
+// unmarshalDeliverAck extracts the acknowledgment carried by an envelope of type
+// DELIVER_ACK for the given channel.  The acknowledgments are not authorized as they
+// only travel over a stream which is already authorized.
+func unmarshalDeliverAck(envelope *cb.Envelope, channelID string) (*ab.DeliverAck, error) {
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.Type != int32(cb.HeaderType_DELIVER_ACK) {
+		return nil, errors.Errorf("expected a message of type %s but got %s", cb.HeaderType_DELIVER_ACK, cb.HeaderType(chdr.Type))
+	}
+	if chdr.ChannelId != channelID {
+		return nil, errors.Errorf("acknowledgment is for channel %s", chdr.ChannelId)
+	}
+	ack := &ab.DeliverAck{}
+	if err := proto.Unmarshal(payload.Data, ack); err != nil {
+		return nil, errors.Wrap(err, "malformed acknowledgment")
+	}
+	return ack, nil
+}
+
 func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
 	if chdr.GetTimestamp() == nil {
 		err := errors.New("channel header in envelope must contain timestamp")
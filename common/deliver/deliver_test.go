@@ -10,6 +10,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/deliver/mock"
@@ -265,7 +266,7 @@ var _ = Describe("Deliver", func() {
 
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
 				for i := 0; i < 5; i++ {
-					b := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					b, _ := fakeResponseSender.SendBlockResponseArgsForCall(i)
 					Expect(b).To(Equal(&cb.Block{
 						Header: &cb.BlockHeader{Number: 995 + uint64(i)},
 					}))
@@ -288,7 +289,7 @@ var _ = Describe("Deliver", func() {
 				Expect(fakeBlockIterator.NextCallCount()).To(Equal(1))
 
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
-				b := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				b, _ := fakeResponseSender.SendBlockResponseArgsForCall(0)
 				Expect(b).To(Equal(&cb.Block{
 					Header: &cb.BlockHeader{Number: 100},
 				}))
@@ -319,7 +320,7 @@ var _ = Describe("Deliver", func() {
 				Expect(fakeBlockIterator.NextCallCount()).To(Equal(2))
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
 				for i := 0; i < fakeResponseSender.SendBlockResponseCallCount(); i++ {
-					b := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					b, _ := fakeResponseSender.SendBlockResponseArgsForCall(i)
 					Expect(b).To(Equal(&cb.Block{
 						Header: &cb.BlockHeader{Number: uint64(i + 1)},
 					}))
@@ -585,6 +586,116 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when seek info positions are timestamps", func() {
+			seekTimestamp := func(seconds int64) *ab.SeekPosition {
+				return &ab.SeekPosition{
+					Type: &ab.SeekPosition_Timestamp{
+						Timestamp: &ab.SeekTimestamp{Timestamp: &timestamp.Timestamp{Seconds: seconds}},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				// Block n of the ledger is timestamped 100+10n seconds after the epoch
+				timestampedBlock := func(number uint64) *cb.Block {
+					return &cb.Block{
+						Header: &cb.BlockHeader{Number: number},
+						Data: &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+							Payload: utils.MarshalOrPanic(&cb.Payload{
+								Header: &cb.Header{
+									ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+										Timestamp: &timestamp.Timestamp{Seconds: 100 + 10*int64(number)},
+									}),
+								},
+							}),
+						})}},
+					}
+				}
+				ready := make(chan struct{})
+				close(ready)
+
+				fakeBlockReader.HeightReturns(10)
+				fakeBlockReader.IteratorStub = func(position *ab.SeekPosition) (blockledger.Iterator, uint64) {
+					number := position.GetSpecified().GetNumber()
+					next := number
+					iterator := &mock.BlockIterator{}
+					iterator.ReadyChanReturns(ready)
+					iterator.NextStub = func() (*cb.Block, cb.Status) {
+						next++
+						return timestampedBlock(next - 1), cb.Status_SUCCESS
+					}
+					return iterator, number
+				}
+
+				seekInfo = &ab.SeekInfo{Start: seekTimestamp(125), Stop: seekTimestamp(150)}
+			})
+
+			It("sends the blocks timestamped between the start and the stop", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				start := fakeBlockReader.IteratorArgsForCall(fakeBlockReader.IteratorCallCount() - 1)
+				Expect(start.GetSpecified().GetNumber()).To(Equal(uint64(3)))
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					b, _ := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					Expect(b.Header.Number).To(Equal(uint64(3 + i)))
+				}
+				Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+			})
+
+			Context("when the stop timestamp precedes all the blocks", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{Start: seekOldest, Stop: seekTimestamp(99)}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the stop timestamp precedes the start timestamp", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{Start: seekTimestamp(150), Stop: seekTimestamp(125)}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the timestamp is invalid", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{
+						Start: &ab.SeekPosition{Type: &ab.SeekPosition_Timestamp{Timestamp: &ab.SeekTimestamp{}}},
+						Stop:  seekNewest,
+					}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+		})
+
 		Context("when fail if not ready is set and the next block is unavailable", func() {
 			BeforeEach(func() {
 				fakeBlockReader.HeightReturns(1000)
@@ -626,5 +737,283 @@ var _ = Describe("Deliver", func() {
 				Expect(resp).To(Equal(cb.Status_UNKNOWN))
 			})
 		})
+
+		Context("when flow control is requested", func() {
+			var recvEnvelopes []*cb.Envelope
+			var sentAtRecv []int
+
+			makeAck := func(channelID string, number uint64) *cb.Envelope {
+				return &cb.Envelope{
+					Payload: utils.MarshalOrPanic(&cb.Payload{
+						Header: &cb.Header{
+							ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+								Type:      int32(cb.HeaderType_DELIVER_ACK),
+								ChannelId: channelID,
+							}),
+						},
+						Data: utils.MarshalOrPanic(&ab.DeliverAck{Number: number}),
+					}),
+				}
+			}
+
+			BeforeEach(func() {
+				fakeBlockIterator.NextStub = func() (*cb.Block, cb.Status) {
+					blk := &cb.Block{
+						Header: &cb.BlockHeader{Number: 994 + uint64(fakeBlockIterator.NextCallCount())},
+					}
+					return blk, cb.Status_SUCCESS
+				}
+				fakeBlockReader.IteratorReturns(fakeBlockIterator, 995)
+				seekInfo = &ab.SeekInfo{
+					Start: &ab.SeekPosition{
+						Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 995}},
+					},
+					Stop:        seekNewest,
+					FlowControl: &ab.FlowControl{Window: 2},
+				}
+
+				recvEnvelopes = []*cb.Envelope{envelope, makeAck("chain-id", 996), makeAck("chain-id", 998)}
+				sentAtRecv = nil
+				fakeReceiver.RecvStub = func() (*cb.Envelope, error) {
+					sentAtRecv = append(sentAtRecv, fakeResponseSender.SendBlockResponseCallCount())
+					i := fakeReceiver.RecvCallCount() - 1
+					if i < len(recvEnvelopes) {
+						return recvEnvelopes[i], nil
+					}
+					return nil, io.EOF
+				}
+			})
+
+			It("sends no more blocks than the window ahead of the acknowledgments", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sentAtRecv).To(Equal([]int{0, 2, 4, 5}))
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
+				for i := 0; i < 5; i++ {
+					b, token := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					Expect(b.Header.Number).To(Equal(995 + uint64(i)))
+					Expect(token).To(BeNil())
+				}
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+			})
+
+			Context("when the blocks are acknowledged one at a time", func() {
+				BeforeEach(func() {
+					recvEnvelopes = []*cb.Envelope{envelope, makeAck("chain-id", 995), makeAck("chain-id", 996), makeAck("chain-id", 998), makeAck("chain-id", 999)}
+				})
+
+				It("sends a block per acknowledgment and ignores the acknowledgments after the deliver completes", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentAtRecv).To(Equal([]int{0, 2, 3, 4, 5, 5}))
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+				})
+			})
+
+			Context("when a block which was not delivered is acknowledged", func() {
+				BeforeEach(func() {
+					recvEnvelopes = []*cb.Envelope{envelope, makeAck("chain-id", 997)}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the acknowledgment is for another channel", func() {
+				BeforeEach(func() {
+					recvEnvelopes = []*cb.Envelope{envelope, makeAck("other-chain-id", 996)}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when a seek is received instead of an acknowledgment", func() {
+				BeforeEach(func() {
+					recvEnvelopes = []*cb.Envelope{envelope, envelope}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the client hangs up while blocks are unacknowledged", func() {
+				BeforeEach(func() {
+					recvEnvelopes = []*cb.Envelope{envelope}
+				})
+
+				It("stops delivering", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when receiving the acknowledgment fails", func() {
+				BeforeEach(func() {
+					fakeReceiver.RecvStub = nil
+					fakeReceiver.RecvReturnsOnCall(1, nil, errors.New("oh bother"))
+				})
+
+				It("returns the error", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).To(MatchError("oh bother"))
+				})
+			})
+		})
+
+		Context("when resume tokens are requested", func() {
+			BeforeEach(func() {
+				ready := make(chan struct{})
+				close(ready)
+				fakeBlockReader.IteratorStub = func(position *ab.SeekPosition) (blockledger.Iterator, uint64) {
+					number := position.GetSpecified().GetNumber()
+					next := number
+					iterator := &mock.BlockIterator{}
+					iterator.ReadyChanReturns(ready)
+					iterator.NextStub = func() (*cb.Block, cb.Status) {
+						next++
+						return &cb.Block{Header: &cb.BlockHeader{Number: next - 1}}, cb.Status_SUCCESS
+					}
+					return iterator, number
+				}
+				seekInfo = &ab.SeekInfo{
+					Start: &ab.SeekPosition{
+						Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 997}},
+					},
+					Stop:         seekNewest,
+					Behavior:     ab.SeekInfo_FAIL_IF_NOT_READY,
+					ResumeTokens: true,
+				}
+			})
+
+			It("sends the token resuming the deliver after each block", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					b, token := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					Expect(b.Header.Number).To(Equal(997 + uint64(i)))
+					resumeToken := &ab.ResumeToken{}
+					Expect(proto.Unmarshal(token, resumeToken)).To(Succeed())
+					Expect(resumeToken).To(Equal(&ab.ResumeToken{
+						ChannelId: "chain-id",
+						Next:      998 + uint64(i),
+						Stop:      999,
+						Behavior:  ab.SeekInfo_FAIL_IF_NOT_READY,
+					}))
+				}
+			})
+
+			Context("when a deliver is resumed", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{
+						ResumeToken: utils.MarshalOrPanic(&ab.ResumeToken{
+							ChannelId: "chain-id",
+							Next:      998,
+							Stop:      999,
+							Behavior:  ab.SeekInfo_FAIL_IF_NOT_READY,
+						}),
+					}
+				})
+
+				It("sends the blocks following the block of the token", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(1))
+					start := fakeBlockReader.IteratorArgsForCall(0)
+					Expect(start.GetSpecified().GetNumber()).To(Equal(uint64(998)))
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+					for i := 0; i < 2; i++ {
+						b, token := fakeResponseSender.SendBlockResponseArgsForCall(i)
+						Expect(b.Header.Number).To(Equal(998 + uint64(i)))
+						Expect(token).NotTo(BeNil())
+					}
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+				})
+
+				It("evaluates access control", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePolicyChecker.CheckPolicyCallCount()).To(BeNumerically(">=", 1))
+				})
+			})
+
+			Context("when the resumed deliver has delivered all its blocks", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{
+						ResumeToken: utils.MarshalOrPanic(&ab.ResumeToken{ChannelId: "chain-id", Next: 1000, Stop: 999}),
+					}
+				})
+
+				It("sends a success response", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+				})
+			})
+
+			Context("when the resume token is for another channel", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{
+						ResumeToken: utils.MarshalOrPanic(&ab.ResumeToken{ChannelId: "other-chain-id", Next: 998, Stop: 999}),
+					}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the resume token is malformed", func() {
+				BeforeEach(func() {
+					seekInfo = &ab.SeekInfo{ResumeToken: []byte("complete-nonsense")}
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+		})
 	})
 })
@@ -20,10 +20,11 @@ type ResponseSender struct {
 	sendStatusResponseReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SendBlockResponseStub        func(block *cb.Block) error
+	SendBlockResponseStub        func(block *cb.Block, resumeToken []byte) error
 	sendBlockResponseMutex       sync.RWMutex
 	sendBlockResponseArgsForCall []struct {
-		block *cb.Block
+		block       *cb.Block
+		resumeToken []byte
 	}
 	sendBlockResponseReturns struct {
 		result1 error
@@ -83,16 +84,22 @@ func (fake *ResponseSender) SendStatusResponseReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *ResponseSender) SendBlockResponse(block *cb.Block) error {
+func (fake *ResponseSender) SendBlockResponse(block *cb.Block, resumeToken []byte) error {
+	var resumeTokenCopy []byte
+	if resumeToken != nil {
+		resumeTokenCopy = make([]byte, len(resumeToken))
+		copy(resumeTokenCopy, resumeToken)
+	}
 	fake.sendBlockResponseMutex.Lock()
 	ret, specificReturn := fake.sendBlockResponseReturnsOnCall[len(fake.sendBlockResponseArgsForCall)]
 	fake.sendBlockResponseArgsForCall = append(fake.sendBlockResponseArgsForCall, struct {
-		block *cb.Block
-	}{block})
-	fake.recordInvocation("SendBlockResponse", []interface{}{block})
+		block       *cb.Block
+		resumeToken []byte
+	}{block, resumeTokenCopy})
+	fake.recordInvocation("SendBlockResponse", []interface{}{block, resumeTokenCopy})
 	fake.sendBlockResponseMutex.Unlock()
 	if fake.SendBlockResponseStub != nil {
-		return fake.SendBlockResponseStub(block)
+		return fake.SendBlockResponseStub(block, resumeToken)
 	}
 	if specificReturn {
 		return ret.result1
@@ -106,10 +113,10 @@ func (fake *ResponseSender) SendBlockResponseCallCount() int {
 	return len(fake.sendBlockResponseArgsForCall)
 }
 
-func (fake *ResponseSender) SendBlockResponseArgsForCall(i int) *cb.Block {
+func (fake *ResponseSender) SendBlockResponseArgsForCall(i int) (*cb.Block, []byte) {
 	fake.sendBlockResponseMutex.RLock()
 	defer fake.sendBlockResponseMutex.RUnlock()
-	return fake.sendBlockResponseArgsForCall[i].block
+	return fake.sendBlockResponseArgsForCall[i].block, fake.sendBlockResponseArgsForCall[i].resumeToken
 }
 
 func (fake *ResponseSender) SendBlockResponseReturns(result1 error) {
@@ -18,6 +18,7 @@ package blkstorage
 
 import (
 	"errors"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -35,6 +36,9 @@ const (
 	IndexableAttrBlockNumTranNum  = IndexableAttr("BlockNumTranNum")
 	IndexableAttrBlockTxID        = IndexableAttr("BlockTxID")
 	IndexableAttrTxValidationCode = IndexableAttr("TxValidationCode")
+	IndexableAttrTxCreator        = IndexableAttr("TxCreator")
+	IndexableAttrTxChaincode      = IndexableAttr("TxChaincode")
+	IndexableAttrTxTimestamp      = IndexableAttr("TxTimestamp")
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
@@ -71,5 +75,19 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// RetrieveTxsByCreator returns an iterator over the transactions submitted by `creator`, a serialized
+	// identity, from the transaction `startTxNum` of the block `startBlockNum`. The iterator contains results
+	// of type *peer.IndexedTransaction
+	RetrieveTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error)
+	// RetrieveTxsByChaincode returns an iterator over the transactions invoking the chaincode `chaincodeName`,
+	// from the transaction `startTxNum` of the block `startBlockNum`. The iterator contains results of type
+	// *peer.IndexedTransaction
+	RetrieveTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error)
+	// RetrieveTxsByTimestamp returns an iterator over the transactions whose timestamp, set by their creator,
+	// is within [startTime, endTime), in the order of their timestamps. The transactions with the timestamp
+	// `startTime` are returned from the transaction `startTxNum` of the block `startBlockNum`, so that the
+	// iteration can be resumed after its last result. The iterator contains results of type
+	// *peer.IndexedTransaction
+	RetrieveTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error)
 	Shutdown()
 }
@@ -18,8 +18,10 @@ package fsblkstorage
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	ledgerutil "github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -33,6 +35,10 @@ type serializedBlockInfo struct {
 type txindexInfo struct {
 	txID string
 	loc  *locPointer
+	// the attributes of the transaction header indexed on demand
+	creator       []byte
+	chaincodeName string
+	timestamp     *timestamp.Timestamp
 }
 
 func serializeBlock(block *common.Block) ([]byte, *serializedBlockInfo, error) {
@@ -111,14 +117,14 @@ func addDataBytes(blockData *common.BlockData, buf *proto.Buffer) ([]*txindexInf
 	}
 	for _, txEnvelopeBytes := range blockData.Data {
 		offset := len(buf.Bytes())
-		txid, err := extractTxID(txEnvelopeBytes)
+		idxInfo, err := extractTxIndexInfo(txEnvelopeBytes)
 		if err != nil {
 			return nil, err
 		}
 		if err := buf.EncodeRawBytes(txEnvelopeBytes); err != nil {
 			return nil, err
 		}
-		idxInfo := &txindexInfo{txid, &locPointer{offset, len(buf.Bytes()) - offset}}
+		idxInfo.loc = &locPointer{offset, len(buf.Bytes()) - offset}
 		txOffsets = append(txOffsets, idxInfo)
 	}
 	return txOffsets, nil
@@ -169,16 +175,16 @@ func extractData(buf *ledgerutil.Buffer) (*common.BlockData, []*txindexInfo, err
 	}
 	for i := uint64(0); i < numItems; i++ {
 		var txEnvBytes []byte
-		var txid string
+		var idxInfo *txindexInfo
 		txOffset := buf.GetBytesConsumed()
 		if txEnvBytes, err = buf.DecodeRawBytes(false); err != nil {
 			return nil, nil, err
 		}
-		if txid, err = extractTxID(txEnvBytes); err != nil {
+		if idxInfo, err = extractTxIndexInfo(txEnvBytes); err != nil {
 			return nil, nil, err
 		}
 		data.Data = append(data.Data, txEnvBytes)
-		idxInfo := &txindexInfo{txid, &locPointer{txOffset, buf.GetBytesConsumed() - txOffset}}
+		idxInfo.loc = &locPointer{txOffset, buf.GetBytesConsumed() - txOffset}
 		txOffsets = append(txOffsets, idxInfo)
 	}
 	return data, txOffsets, nil
@@ -201,18 +207,32 @@ func extractMetadata(buf *ledgerutil.Buffer) (*common.BlockMetadata, error) {
 	return metadata, nil
 }
 
-func extractTxID(txEnvelopBytes []byte) (string, error) {
+// extractTxIndexInfo extracts the ID of a transaction along with its creator, the chaincode it invokes
+// and its timestamp. As for the ID, the attributes missing from a malformed transaction are left empty
+func extractTxIndexInfo(txEnvelopBytes []byte) (*txindexInfo, error) {
 	txEnvelope, err := utils.GetEnvelopeFromBlock(txEnvelopBytes)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	idxInfo := &txindexInfo{}
 	txPayload, err := utils.GetPayload(txEnvelope)
-	if err != nil {
-		return "", nil
+	if err != nil || txPayload.Header == nil {
+		return idxInfo, nil
 	}
 	chdr, err := utils.UnmarshalChannelHeader(txPayload.Header.ChannelHeader)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	idxInfo.txID = chdr.TxId
+	idxInfo.timestamp = chdr.Timestamp
+	if shdr, err := utils.GetSignatureHeader(txPayload.Header.SignatureHeader); err == nil {
+		idxInfo.creator = shdr.Creator
+	}
+	if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+		hdrExt := &peer.ChaincodeHeaderExtension{}
+		if err := proto.Unmarshal(chdr.Extension, hdrExt); err == nil && hdrExt.ChaincodeId != nil {
+			idxInfo.chaincodeName = hdrExt.ChaincodeId.Name
+		}
 	}
-	return chdr.TxId, nil
+	return idxInfo, nil
 }
@@ -36,8 +36,9 @@ func TestBlockSerialization(t *testing.T) {
 func TestExtractTxid(t *testing.T) {
 	txEnv, txid, _ := testutil.ConstructTransaction(t, testutil.ConstructRandomBytes(t, 50), "", false)
 	txEnvBytes, _ := putils.GetBytesEnvelope(txEnv)
-	extractedTxid, err := extractTxID(txEnvBytes)
+	idxInfo, err := extractTxIndexInfo(txEnvBytes)
 	testutil.AssertNoError(t, err, "")
+	extractedTxid := idxInfo.txID
 	testutil.AssertEquals(t, extractedTxid, txid)
 }
 
@@ -50,8 +51,9 @@ func TestSerializedBlockInfo(t *testing.T) {
 	testutil.AssertEquals(t, infoFromBB, info)
 	testutil.AssertEquals(t, len(info.txOffsets), len(block.Data.Data))
 	for txIndex, txEnvBytes := range block.Data.Data {
-		txid, err := extractTxID(txEnvBytes)
+		idxInfo, err := extractTxIndexInfo(txEnvBytes)
 		testutil.AssertNoError(t, err, "")
+		txid := idxInfo.txID
 
 		indexInfo := info.txOffsets[txIndex]
 		indexTxID := indexInfo.txID
@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -35,7 +34,7 @@ var ErrUnexpectedEndOfBlockfile = errors.New("unexpected end of blockfile")
 // It starts from the given offset and can traverse till the end of the file
 type blockfileStream struct {
 	fileNum       int
-	file          *os.File
+	file          *blockfile
 	reader        *bufio.Reader
 	currentOffset int64
 }
@@ -64,19 +63,11 @@ type blockPlacementInfo struct {
 func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
 	filePath := deriveBlockfilePath(rootDir, fileNum)
 	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
-	var file *os.File
+	var file *blockfile
 	var err error
-	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0600); err != nil {
+	if file, err = openBlockfile(rootDir, fileNum, startOffset); err != nil {
 		return nil, err
 	}
-	var newPosition int64
-	if newPosition, err = file.Seek(startOffset, 0); err != nil {
-		return nil, err
-	}
-	if newPosition != startOffset {
-		panic(fmt.Sprintf("Could not seek file [%s] to given startOffset [%d]. New position = [%d]",
-			filePath, startOffset, newPosition))
-	}
 	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
 	return s, nil
 }
@@ -93,17 +84,17 @@ func (s *blockfileStream) nextBlockBytes() ([]byte, error) {
 func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacementInfo, error) {
 	var lenBytes []byte
 	var err error
-	var fileInfo os.FileInfo
+	var fileSize int64
 	moreContentAvailable := true
 
-	if fileInfo, err = s.file.Stat(); err != nil {
+	if fileSize, err = s.file.size(); err != nil {
 		return nil, nil, err
 	}
-	if s.currentOffset == fileInfo.Size() {
+	if s.currentOffset == fileSize {
 		logger.Debugf("Finished reading file number [%d]", s.fileNum)
 		return nil, nil, nil
 	}
-	remainingBytes := fileInfo.Size() - s.currentOffset
+	remainingBytes := fileSize - s.currentOffset
 	// Peek 8 or smaller number of bytes (if remaining bytes are less than 8)
 	// Assumption is that a block size would be small enough to be represented in 8 bytes varint
 	peekBytes := 8
@@ -149,7 +140,7 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 }
 
 func (s *blockfileStream) close() error {
-	return s.file.Close()
+	return s.file.close()
 }
 
 ///////////////////////////////////
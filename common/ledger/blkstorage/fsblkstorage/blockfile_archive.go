@@ -0,0 +1,337 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	compressedBlockfileSuffix = ".gz"
+	archivingSuffix           = ".archiving"
+	// the gzip trailer records the uncompressed size modulo 2^32
+	maxCompressedBlockfileSize = 1 << 32
+)
+
+// blockfile is a block file opened for reading. The block files archived with compression
+// are read through a gzip reader, so that they can only be read forward, and opening one
+// at an offset decompresses all the bytes before it
+type blockfile struct {
+	file       *os.File
+	reader     io.Reader
+	compressed bool
+	// offset and uncompressedSize are only tracked for compressed block files
+	offset           int64
+	uncompressedSize int64
+}
+
+// openBlockfile opens a block file at `startOffset`, whether the block file is in place or archived.
+// An archived file is reachable from the block directory, either as a compressed file or as a
+// symbolic link to the archive directory. The archived file is always in place before the original
+// file is removed, so that looking for the original file first does not race with the archiver
+func openBlockfile(rootDir string, fileNum int, startOffset int64) (*blockfile, error) {
+	filePath := deriveBlockfilePath(rootDir, fileNum)
+	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+	if err == nil {
+		var newPosition int64
+		if newPosition, err = file.Seek(startOffset, 0); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if newPosition != startOffset {
+			panic(fmt.Sprintf("Could not seek file [%s] to given startOffset [%d]. New position = [%d]",
+				filePath, startOffset, newPosition))
+		}
+		return &blockfile{file: file, reader: file}, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	compressedFile, cerr := os.OpenFile(filePath+compressedBlockfileSuffix, os.O_RDONLY, 0600)
+	if cerr != nil {
+		if os.IsNotExist(cerr) {
+			return nil, err
+		}
+		return nil, cerr
+	}
+	f, err := newCompressedBlockfile(compressedFile)
+	if err != nil {
+		compressedFile.Close()
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to open compressed block file [%s]", compressedFile.Name()))
+	}
+	if err := f.skip(startOffset); err != nil {
+		f.close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func newCompressedBlockfile(file *os.File) (*blockfile, error) {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	trailer := make([]byte, 4)
+	if _, err := file.ReadAt(trailer, fileInfo.Size()-4); err != nil {
+		return nil, errors.Wrap(err, "failed to read the gzip trailer")
+	}
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	return &blockfile{
+		file:             file,
+		reader:           reader,
+		compressed:       true,
+		uncompressedSize: int64(binary.LittleEndian.Uint32(trailer)),
+	}, nil
+}
+
+func (f *blockfile) Read(b []byte) (int, error) {
+	n, err := f.reader.Read(b)
+	f.offset += int64(n)
+	return n, err
+}
+
+// size returns the size of the uncompressed block file. A block file in place
+// may still be growing, so that its size is read again on every call
+func (f *blockfile) size() (int64, error) {
+	if f.compressed {
+		return f.uncompressedSize, nil
+	}
+	fileInfo, err := f.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fileInfo.Size(), nil
+}
+
+// readAt reads len(b) bytes at `offset`, which may not be behind the bytes already read
+// from a compressed block file
+func (f *blockfile) readAt(b []byte, offset int64) error {
+	if !f.compressed {
+		_, err := f.file.ReadAt(b, offset)
+		return err
+	}
+	if offset < f.offset {
+		return errors.Errorf("cannot read offset [%d] of compressed block file [%s] after offset [%d]", offset, f.file.Name(), f.offset)
+	}
+	if err := f.skip(offset - f.offset); err != nil {
+		return err
+	}
+	_, err := io.ReadFull(f, b)
+	return err
+}
+
+func (f *blockfile) skip(n int64) error {
+	if n == 0 {
+		return nil
+	}
+	targetOffset := f.offset + n
+	if _, err := io.CopyN(ioutil.Discard, f, n); err != nil {
+		if err == io.EOF {
+			return errors.Errorf("could not skip to offset [%d] of compressed block file [%s], size = [%d]",
+				targetOffset, f.file.Name(), f.uncompressedSize)
+		}
+		return err
+	}
+	return nil
+}
+
+func (f *blockfile) close() error {
+	return f.file.Close()
+}
+
+// isArchivingFileName tells whether a file of a block directory is a compressed block file or
+// a file left by an interrupted archiving, and is not a block file in place
+func isArchivingFileName(name string) bool {
+	return strings.HasSuffix(name, compressedBlockfileSuffix) || strings.HasSuffix(name, archivingSuffix)
+}
+
+// blockfileArchiver archives the completed block files of a ledger in the background,
+// from the oldest one, leaving in place the `KeepBlockfiles` most recently completed ones.
+// The archiving of a block file is idempotent, so that an archiving interrupted by a crash
+// is completed when the ledger is opened again. The root and archive directories are
+// absolute paths, so that a block file archived in place is told apart
+type blockfileArchiver struct {
+	rootDir     string
+	archiveDir  string
+	conf        *ArchiveConf
+	nextFileNum int
+
+	latestFileNum chan int
+	done          chan struct{}
+	stopped       sync.WaitGroup
+}
+
+func newBlockfileArchiver(rootDir, archiveDir string, conf *ArchiveConf) *blockfileArchiver {
+	return &blockfileArchiver{
+		rootDir:       rootDir,
+		archiveDir:    archiveDir,
+		conf:          conf,
+		latestFileNum: make(chan int, 1),
+		done:          make(chan struct{}),
+	}
+}
+
+// start archives the block files completed before `latestFileNum`, the block file being written
+// to, and then the block files completed afterwards, as they get notified
+func (a *blockfileArchiver) start(latestFileNum int) {
+	a.notify(latestFileNum)
+	a.stopped.Add(1)
+	go func() {
+		defer a.stopped.Done()
+		for {
+			select {
+			case <-a.done:
+				return
+			case latestFileNum := <-a.latestFileNum:
+				a.archiveUpTo(latestFileNum - a.conf.KeepBlockfiles)
+			}
+		}
+	}()
+}
+
+// notify is called when the block file `latestFileNum` is started, and never blocks
+func (a *blockfileArchiver) notify(latestFileNum int) {
+	for {
+		select {
+		case a.latestFileNum <- latestFileNum:
+			return
+		case <-a.latestFileNum:
+			// replace the pending notification
+		}
+	}
+}
+
+func (a *blockfileArchiver) stop() {
+	close(a.done)
+	a.stopped.Wait()
+}
+
+// archiveUpTo archives the block files before `endFileNum`. The archiving stops at the first
+// failure, and is retried when the next block file is started
+func (a *blockfileArchiver) archiveUpTo(endFileNum int) {
+	for ; a.nextFileNum < endFileNum; a.nextFileNum++ {
+		select {
+		case <-a.done:
+			return
+		default:
+		}
+		if err := a.archive(a.nextFileNum); err != nil {
+			logger.Errorf("Failed to archive block file [%d] of [%s]: %s", a.nextFileNum, a.rootDir, err)
+			return
+		}
+	}
+}
+
+func (a *blockfileArchiver) archive(fileNum int) error {
+	filePath := deriveBlockfilePath(a.rootDir, fileNum)
+	fileInfo, err := os.Lstat(filePath)
+	if os.IsNotExist(err) || (err == nil && fileInfo.Mode()&os.ModeSymlink != 0) {
+		logger.Debugf("Block file [%s] is already archived", filePath)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	archivedName := filepath.Base(filePath)
+	compress := a.conf.Compress && fileInfo.Size() < maxCompressedBlockfileSize
+	if compress {
+		archivedName += compressedBlockfileSuffix
+	}
+	archivedPath := filepath.Join(a.archiveDir, archivedName)
+	if archivedPath == filePath {
+		// neither compressed nor moved, the block file would be copied onto itself
+		logger.Debugf("Block file [%s] is archived in place", filePath)
+		return nil
+	}
+	if err := writeArchivedBlockfile(filePath, archivedPath, compress); err != nil {
+		return err
+	}
+	if a.archiveDir != a.rootDir {
+		// an uncompressed block file is atomically replaced by the link
+		linkPath := filepath.Join(a.rootDir, archivedName)
+		os.Remove(linkPath + archivingSuffix)
+		if err := os.Symlink(archivedPath, linkPath+archivingSuffix); err != nil {
+			return errors.Wrap(err, "failed to link the archived block file")
+		}
+		if err := os.Rename(linkPath+archivingSuffix, linkPath); err != nil {
+			return errors.Wrap(err, "failed to link the archived block file")
+		}
+	}
+	if compress {
+		if err := syncDir(a.rootDir); err != nil {
+			return err
+		}
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrap(err, "failed to remove the archived block file")
+		}
+	}
+	logger.Infof("Archived block file [%s] to [%s]", filePath, archivedPath)
+	return syncDir(a.rootDir)
+}
+
+// writeArchivedBlockfile copies a block file to `archivedPath` through a temporary file,
+// so that a partially written archived file is never mistaken for a complete one
+func writeArchivedBlockfile(filePath, archivedPath string, compress bool) error {
+	src, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	tmpPath := archivedPath + archivingSuffix
+	dst, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return err
+	}
+	var w io.Writer = dst
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(dst)
+		w = gz
+	}
+	_, err = io.Copy(w, src)
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if err == nil {
+		err = dst.Sync()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return errors.Wrapf(err, "failed to write archived block file [%s]", archivedPath)
+	}
+	if err := os.Rename(tmpPath, archivedPath); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(archivedPath))
+}
+
+func syncDir(dirPath string) error {
+	dir, err := os.Open(dirPath)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveBlockfiles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		compress bool
+		move     bool
+	}{
+		{name: "compress", compress: true},
+		{name: "move", move: true},
+		{name: "compress and move", compress: true, move: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			archiveConf := &ArchiveConf{Compress: tc.compress, KeepBlockfiles: 2}
+			if tc.move {
+				archiveConf.ArchiveDir = testPath()
+				defer os.RemoveAll(archiveConf.ArchiveDir)
+			}
+			// a block file per block, after an empty first block file
+			env := newTestEnv(t, NewConfWithArchiving(testPath(), 1, archiveConf))
+			defer env.Cleanup()
+			w := newTestBlockfileWrapper(env, "testLedger")
+			blocks := testutil.ConstructTestBlocks(t, 10)
+			w.addBlocks(blocks)
+			rootDir := w.blockfileMgr.rootDir
+			testutil.AssertEquals(t, w.blockfileMgr.cpInfo.latestFileChunkSuffixNum, 10)
+			waitForArchived(t, rootDir, 7)
+
+			archivedName := filepath.Base(deriveBlockfilePath(rootDir, 7))
+			if tc.compress {
+				archivedName += compressedBlockfileSuffix
+			}
+			if tc.move {
+				target, err := os.Readlink(filepath.Join(rootDir, archivedName))
+				assert.NoError(t, err)
+				assert.Equal(t, filepath.Join(archiveConf.ArchiveDir, "testLedger", archivedName), target)
+			} else {
+				_, err := os.Stat(filepath.Join(rootDir, archivedName))
+				assert.NoError(t, err)
+			}
+			// the most recently completed block files are left in place
+			for fileNum := 8; fileNum <= 10; fileNum++ {
+				fileInfo, err := os.Lstat(deriveBlockfilePath(rootDir, fileNum))
+				assert.NoError(t, err)
+				assert.True(t, fileInfo.Mode().IsRegular())
+			}
+
+			testReadArchivedBlocks(t, w, blocks)
+			cpInfo, err := constructCheckpointInfoFromBlockFiles(rootDir)
+			assert.NoError(t, err)
+			assert.Equal(t, w.blockfileMgr.cpInfo, cpInfo)
+			w.close()
+
+			// the archived block files are read when the ledger is opened again
+			w = newTestBlockfileWrapper(env, "testLedger")
+			defer w.close()
+			testReadArchivedBlocks(t, w, blocks)
+		})
+	}
+}
+
+func testReadArchivedBlocks(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block) {
+	w.testGetBlockByHash(blocks)
+	w.testGetBlockByNumber(blocks, 0)
+	for _, block := range blocks {
+		for i, txEnvelopeBytes := range block.Data.Data {
+			idxInfo, err := extractTxIndexInfo(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txID := idxInfo.txID
+			txEnvelope, err := w.blockfileMgr.retrieveTransactionByBlockNumTranNum(block.Header.Number, uint64(i))
+			assert.NoError(t, err)
+			txEnvelopeByID, err := w.blockfileMgr.retrieveTransactionByID(txID)
+			assert.NoError(t, err)
+			assert.Equal(t, txEnvelope, txEnvelopeByID)
+		}
+	}
+
+	itr, err := w.blockfileMgr.retrieveBlocks(0)
+	assert.NoError(t, err)
+	defer itr.Close()
+	for _, block := range blocks {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, block, result)
+	}
+}
+
+func TestArchiveBlockfileInterrupted(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	w.addBlocks(blocks)
+
+	archiver := newBlockfileArchiver(w.blockfileMgr.rootDir, w.blockfileMgr.rootDir, &ArchiveConf{Compress: true})
+	filePath := deriveBlockfilePath(w.blockfileMgr.rootDir, 1)
+	// a partially written compressed file is left by a crash
+	assert.NoError(t, ioutil.WriteFile(filePath+compressedBlockfileSuffix+archivingSuffix, []byte("partial"), 0660))
+	lastFileNum, err := retrieveLastFileSuffix(w.blockfileMgr.rootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, lastFileNum)
+
+	assert.NoError(t, archiver.archive(1))
+	_, err = os.Stat(filePath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filePath + compressedBlockfileSuffix + archivingSuffix)
+	assert.True(t, os.IsNotExist(err))
+	// archiving again is a no-op
+	assert.NoError(t, archiver.archive(1))
+	w.testGetBlockByNumber(blocks, 0)
+
+	// a compressed block file can only be read forward
+	f, err := openBlockfile(w.blockfileMgr.rootDir, 1, 2)
+	assert.NoError(t, err)
+	defer f.close()
+	assert.Error(t, f.readAt(make([]byte, 1), 1))
+	_, err = openBlockfile(w.blockfileMgr.rootDir, 1, 1<<20)
+	assert.Contains(t, err.Error(), "could not skip to offset")
+}
+
+func TestArchiveBlockfileInPlace(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	w.addBlocks(blocks)
+
+	rootDir, err := filepath.Abs(w.blockfileMgr.rootDir)
+	assert.NoError(t, err)
+	archiver := newBlockfileArchiver(rootDir, rootDir, &ArchiveConf{})
+	filePath := deriveBlockfilePath(rootDir, 1)
+	before, err := os.Stat(filePath)
+	assert.NoError(t, err)
+
+	// a block file which is neither compressed nor moved is left as is
+	assert.NoError(t, archiver.archive(1))
+	after, err := os.Stat(filePath)
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(before, after), "The block file should not have been copied onto itself")
+	_, err = os.Stat(filePath + archivingSuffix)
+	assert.True(t, os.IsNotExist(err))
+	w.testGetBlockByNumber(blocks, 0)
+}
+
+func waitForArchived(t *testing.T, rootDir string, fileNum int) {
+	filePath := deriveBlockfilePath(rootDir, fileNum)
+	for i := 0; i < 500; i++ {
+		fileInfo, err := os.Lstat(filePath)
+		if os.IsNotExist(err) || (err == nil && fileInfo.Mode()&os.ModeSymlink != 0) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Block file [%s] was not archived", filePath)
+}
@@ -86,7 +86,7 @@ func retrieveLastFileSuffix(rootDir string) (int, error) {
 	}
 	for _, fileInfo := range filesInfo {
 		name := fileInfo.Name()
-		if fileInfo.IsDir() || !isBlockFileName(name) {
+		if fileInfo.IsDir() || !isBlockFileName(name) || isArchivingFileName(name) {
 			logger.Debugf("Skipping File name = %s", name)
 			continue
 		}
@@ -19,8 +19,10 @@ package fsblkstorage
 import (
 	"fmt"
 	"math"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 
@@ -53,6 +55,7 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	archiver          *blockfileArchiver
 }
 
 /*
@@ -157,9 +160,22 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		CurrentBlockHash:  nil,
 		PreviousBlockHash: nil}
 
+	// the attributes of the transaction headers newly added to the index configuration
+	// are only missing from the blocks indexed before the sync
+	attrsToBackfill, err := mgr.index.getTxHeaderAttrsToBackfill()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get the attributes to index: %s", err))
+	}
+	lastBlockIndexed, _ := mgr.index.getLastBlockIndexed()
+
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
+		if len(attrsToBackfill) > 0 {
+			if err := mgr.backfillIndex(attrsToBackfill, lastBlockIndexed); err != nil {
+				panic(fmt.Sprintf("Could not index the attributes %s: %s", attrsToBackfill, err))
+			}
+		}
 		lastBlockHeader, err := mgr.retrieveBlockHeaderByNumber(cpInfo.lastBlockNumber)
 		if err != nil {
 			panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
@@ -172,6 +188,25 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			PreviousBlockHash: previousBlockHash}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	if err := mgr.index.markTxHeaderAttrsIndexed(); err != nil {
+		panic(fmt.Sprintf("Could not save the attributes indexed: %s", err))
+	}
+
+	if conf.archiveConf.enabled() {
+		archiveDir, err := filepath.Abs(conf.getLedgerArchiveDir(id))
+		if err != nil {
+			panic(fmt.Sprintf("Could not resolve the archive directory: %s", err))
+		}
+		if _, err := util.CreateDirIfMissing(archiveDir); err != nil {
+			panic(fmt.Sprintf("Could not create the archive directory: %s", err))
+		}
+		blockDir, err := filepath.Abs(rootDir)
+		if err != nil {
+			panic(fmt.Sprintf("Could not resolve the block directory: %s", err))
+		}
+		mgr.archiver = newBlockfileArchiver(blockDir, archiveDir, conf.archiveConf)
+		mgr.archiver.start(cpInfo.latestFileChunkSuffixNum)
+	}
 	return mgr
 }
 
@@ -220,6 +255,9 @@ func deriveBlockfilePath(rootDir string, suffixNum int) string {
 }
 
 func (mgr *blockfileMgr) close() {
+	if mgr.archiver != nil {
+		mgr.archiver.stop()
+	}
 	mgr.currentFileWriter.close()
 }
 
@@ -242,6 +280,9 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	}
 	mgr.currentFileWriter = nextFileWriter
 	mgr.updateCheckpoint(cpInfo)
+	if mgr.archiver != nil {
+		mgr.archiver.notify(cpInfo.latestFileChunkSuffixNum)
+	}
 }
 
 func (mgr *blockfileMgr) addBlock(block *common.Block) error {
@@ -387,26 +428,10 @@ func (mgr *blockfileMgr) syncIndex() error {
 		if blockBytes == nil {
 			break
 		}
-		info, err := extractSerializedBlockInfo(blockBytes)
-		if err != nil {
+		if blockIdxInfo, err = newBlockIdxInfoFromStream(blockBytes, blockPlacementInfo); err != nil {
 			return err
 		}
 
-		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
-		}
-
-		//Update the blockIndexInfo with what was actually stored in file system
-		blockIdxInfo.blockHash = info.blockHeader.Hash()
-		blockIdxInfo.blockNum = info.blockHeader.Number
-		blockIdxInfo.flp = &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
-			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
-		blockIdxInfo.txOffsets = info.txOffsets
-		blockIdxInfo.metadata = info.metadata
-
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
 			return err
@@ -419,6 +444,66 @@ func (mgr *blockfileMgr) syncIndex() error {
 	return nil
 }
 
+// backfillIndex indexes the attributes `attrs` of the transactions of the blocks
+// up to `lastBlockNum`, which were indexed without them
+func (mgr *blockfileMgr) backfillIndex(attrs []blkstorage.IndexableAttr, lastBlockNum uint64) error {
+	logger.Infof("Start indexing the attributes %s from block [0] to block [%d]", attrs, lastBlockNum)
+	stream, err := newBlockStream(mgr.rootDir, 0, 0, mgr.cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, blockPlacementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		blockIdxInfo, err := newBlockIdxInfoFromStream(blockBytes, blockPlacementInfo)
+		if err != nil {
+			return err
+		}
+		if blockIdxInfo.blockNum > lastBlockNum {
+			break
+		}
+		if err := mgr.index.backfillTxHeaderAttrs(blockIdxInfo, attrs); err != nil {
+			return err
+		}
+		if blockIdxInfo.blockNum%10000 == 0 {
+			logger.Infof("Indexed the attributes of block number [%d]", blockIdxInfo.blockNum)
+		}
+	}
+	logger.Infof("Finished indexing the attributes %s", attrs)
+	return nil
+}
+
+// newBlockIdxInfoFromStream builds the index information of a block read from the block files
+func newBlockIdxInfoFromStream(blockBytes []byte, blockPlacementInfo *blockPlacementInfo) (*blockIdxInfo, error) {
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
+	//therefore just shift by the difference between blockBytesOffset and blockStartOffset
+	numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+	for _, offset := range info.txOffsets {
+		offset.loc.offset += numBytesToShift
+	}
+
+	//Update the blockIndexInfo with what was actually stored in file system
+	return &blockIdxInfo{
+		blockHash: info.blockHeader.Hash(),
+		blockNum:  info.blockHeader.Number,
+		flp: &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
+			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}},
+		txOffsets: info.txOffsets,
+		metadata:  info.metadata,
+	}, nil
+}
+
 func (mgr *blockfileMgr) getBlockchainInfo() *common.BlockchainInfo {
 	return mgr.bcInfo.Load().(*common.BlockchainInfo)
 }
@@ -481,6 +566,33 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 	return mgr.index.getTxValidationCodeByTxID(txID)
 }
 
+func (mgr *blockfileMgr) retrieveTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (*txsItr, error) {
+	logger.Debugf("retrieveTxsByCreator() - startBlockNum = [%d], startTxNum = [%d]", startBlockNum, startTxNum)
+	dbItr, err := mgr.index.getTxsByCreator(creator, startBlockNum, startTxNum)
+	if err != nil {
+		return nil, err
+	}
+	return newTxsItr(mgr, dbItr), nil
+}
+
+func (mgr *blockfileMgr) retrieveTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (*txsItr, error) {
+	logger.Debugf("retrieveTxsByChaincode() - chaincodeName = [%s], startBlockNum = [%d], startTxNum = [%d]", chaincodeName, startBlockNum, startTxNum)
+	dbItr, err := mgr.index.getTxsByChaincode(chaincodeName, startBlockNum, startTxNum)
+	if err != nil {
+		return nil, err
+	}
+	return newTxsItr(mgr, dbItr), nil
+}
+
+func (mgr *blockfileMgr) retrieveTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (*txsItr, error) {
+	logger.Debugf("retrieveTxsByTimestamp() - startTime = [%s], endTime = [%s], startBlockNum = [%d], startTxNum = [%d]", startTime, endTime, startBlockNum, startTxNum)
+	dbItr, err := mgr.index.getTxsByTimestamp(startTime, endTime, startBlockNum, startTxNum)
+	if err != nil {
+		return nil, err
+	}
+	return newTxsItr(mgr, dbItr), nil
+}
+
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
@@ -557,8 +669,7 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
-	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
-	reader, err := newBlockfileReader(filePath)
+	reader, err := newBlockfileReader(mgr.rootDir, lp.fileSuffixNum)
 	if err != nil {
 		return nil, err
 	}
@@ -158,8 +158,9 @@ func TestBlockfileMgrGetTxById(t *testing.T) {
 	for _, blk := range blocks {
 		for j, txEnvelopeBytes := range blk.Data.Data {
 			// blockNum starts with 0
-			txID, err := extractTxID(blk.Data.Data[j])
+			idxInfo, err := extractTxIndexInfo(blk.Data.Data[j])
 			testutil.AssertNoError(t, err, "")
+			txID := idxInfo.txID
 			txEnvelopeFromFileMgr, err := blkfileMgrWrapper.blockfileMgr.retrieveTransactionByID(txID)
 			testutil.AssertNoError(t, err, "Error while retrieving tx from blkfileMgr")
 			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
@@ -244,8 +245,9 @@ func TestBlockfileMgrGetBlockByTxID(t *testing.T) {
 	for _, blk := range blocks {
 		for j := range blk.Data.Data {
 			// blockNum starts with 1
-			txID, err := extractTxID(blk.Data.Data[j])
+			idxInfo, err := extractTxIndexInfo(blk.Data.Data[j])
 			testutil.AssertNoError(t, err, "")
+			txID := idxInfo.txID
 
 			blockFromFileMgr, err := blkfileMgrWrapper.blockfileMgr.retrieveBlockByTxID(txID)
 			testutil.AssertNoError(t, err, "Error while retrieving block from blkfileMgr")
@@ -68,11 +68,11 @@ func (w *blockfileWriter) close() error {
 
 ////  READER ////
 type blockfileReader struct {
-	file *os.File
+	file *blockfile
 }
 
-func newBlockfileReader(filePath string) (*blockfileReader, error) {
-	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+func newBlockfileReader(rootDir string, fileNum int) (*blockfileReader, error) {
+	file, err := openBlockfile(rootDir, fileNum, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +82,7 @@ func newBlockfileReader(filePath string) (*blockfileReader, error) {
 
 func (r *blockfileReader) read(offset int, length int) ([]byte, error) {
 	b := make([]byte, length)
-	_, err := r.file.ReadAt(b, int64(offset))
+	err := r.file.readAt(b, int64(offset))
 	if err != nil {
 		return nil, err
 	}
@@ -90,5 +90,5 @@ func (r *blockfileReader) read(offset int, length int) ([]byte, error) {
 }
 
 func (r *blockfileReader) close() error {
-	return r.file.Close()
+	return r.file.close()
 }
@@ -0,0 +1,256 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// BlockfileScanner reads the blocks of a ledger sequentially from its block files
+// and cross-checks the block index against them. Neither the block files nor the
+// index are ever modified, so that the ledger of a stopped peer or orderer, or a
+// copy of it, can be inspected
+type BlockfileScanner struct {
+	ledgerID         string
+	stream           *blockStream
+	indexProvider    *leveldbhelper.Provider
+	index            *blockIndex
+	lastBlockIndexed uint64
+	indexEmpty       bool
+
+	info      *serializedBlockInfo
+	placement *blockPlacementInfo
+}
+
+// NewBlockfileScanner opens the block files of the ledger `ledgerID` under `blockStorageDir`,
+// the directory containing the `chains` and `index` directories. If the `indexConfig` lists
+// attributes to index, the index is opened read only as well
+func NewBlockfileScanner(blockStorageDir string, ledgerID string, indexConfig *blkstorage.IndexConfig) (*BlockfileScanner, error) {
+	conf := NewConf(blockStorageDir, 0)
+	rootDir := conf.getLedgerBlockDir(ledgerID)
+	if _, err := os.Stat(rootDir); err != nil {
+		return nil, errors.Wrapf(err, "no block files for ledger [%s]", ledgerID)
+	}
+	lastFileNum, err := retrieveLastFileSuffix(rootDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the block files of ledger [%s]", ledgerID)
+	}
+
+	scanner := &BlockfileScanner{ledgerID: ledgerID}
+	if lastFileNum >= 0 {
+		if scanner.stream, err = newBlockStream(rootDir, 0, 0, lastFileNum); err != nil {
+			return nil, errors.Wrapf(err, "failed to open the block files of ledger [%s]", ledgerID)
+		}
+	}
+
+	if indexConfig == nil || len(indexConfig.AttrsToIndex) == 0 {
+		return scanner, nil
+	}
+	if _, err := os.Stat(conf.getIndexDir()); err != nil {
+		scanner.Close()
+		return nil, errors.Wrap(err, "no block index")
+	}
+	scanner.indexProvider = leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir(), ReadOnly: true})
+	scanner.index = newBlockIndex(indexConfig, scanner.indexProvider.GetDBHandle(ledgerID))
+	if scanner.lastBlockIndexed, err = scanner.index.getLastBlockIndexed(); err != nil {
+		if err != errIndexEmpty {
+			scanner.Close()
+			return nil, errors.Wrap(err, "failed to read the index checkpoint")
+		}
+		scanner.indexEmpty = true
+	}
+	return scanner, nil
+}
+
+// Next returns the next block in the block files, or nil once all the blocks have been read
+func (s *BlockfileScanner) Next() (*common.Block, error) {
+	s.info, s.placement = nil, nil
+	if s.stream == nil {
+		return nil, nil
+	}
+	blockBytes, placement, err := s.stream.nextBlockBytesAndPlacementInfo()
+	if err != nil {
+		return nil, err
+	}
+	if blockBytes == nil {
+		return nil, nil
+	}
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize the block at %s", placement)
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to extract the block info at %s", placement)
+	}
+	s.info, s.placement = info, placement
+	return block, nil
+}
+
+// LastBlockIndexed returns the number of the last block recorded in the index checkpoint,
+// and false if no index is used or nothing has been indexed yet
+func (s *BlockfileScanner) LastBlockIndexed() (uint64, bool) {
+	if s.index == nil || s.indexEmpty {
+		return 0, false
+	}
+	return s.lastBlockIndexed, true
+}
+
+// CheckIndex cross-checks the index entries of the block last returned by Next
+// against the location of the block and of its transactions in the block files,
+// and the indexed transaction validation codes against the block metadata.
+// Blocks after the index checkpoint are not checked, as the index is brought
+// up to date when the block store is opened
+func (s *BlockfileScanner) CheckIndex() error {
+	if s.info == nil {
+		return errors.New("no block to check")
+	}
+	blockNum := s.info.blockHeader.Number
+	if lastBlockIndexed, ok := s.LastBlockIndexed(); !ok || blockNum > lastBlockIndexed {
+		return nil
+	}
+
+	blockFLP := &fileLocPointer{fileSuffixNum: s.placement.fileNum,
+		locPointer: locPointer{offset: int(s.placement.blockStartOffset)}}
+	items := s.index.indexItemsMap
+
+	if items[blkstorage.IndexableAttrBlockNum] {
+		if err := s.checkLocation(constructBlockNumKey(blockNum), blockFLP, false); err != nil {
+			return errors.WithMessage(err, "block number index")
+		}
+	}
+	if items[blkstorage.IndexableAttrBlockHash] {
+		if err := s.checkLocation(constructBlockHashKey(s.info.blockHeader.Hash()), blockFLP, false); err != nil {
+			return errors.WithMessage(err, "block hash index")
+		}
+	}
+
+	txsfltr := ledgerUtil.TxValidationFlags(s.info.metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	numBytesToShift := int(s.placement.blockBytesOffset - s.placement.blockStartOffset)
+	for txNum, txOffset := range s.info.txOffsets {
+		txLoc := &locPointer{offset: txOffset.loc.offset + numBytesToShift, bytesLength: txOffset.loc.bytesLength}
+		txFLP := newFileLocationPointer(s.placement.fileNum, int(s.placement.blockStartOffset), txLoc)
+
+		if items[blkstorage.IndexableAttrBlockNumTranNum] {
+			if err := s.checkLocation(constructBlockNumTranNumKey(blockNum, uint64(txNum)), txFLP, false); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("block number and transaction number index of transaction %d", txNum))
+			}
+		}
+		// a transaction ID is indexed for the last transaction carrying it, so a
+		// location in a later block is expected for duplicate transaction IDs
+		if items[blkstorage.IndexableAttrTxID] {
+			if err := s.checkLocation(constructTxIDKey(txOffset.txID), txFLP, true); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("transaction ID index of transaction [%s]", txOffset.txID))
+			}
+		}
+		if items[blkstorage.IndexableAttrBlockTxID] {
+			if err := s.checkLocation(constructBlockTxIDKey(txOffset.txID), blockFLP, true); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("block by transaction ID index of transaction [%s]", txOffset.txID))
+			}
+		}
+		if items[blkstorage.IndexableAttrTxValidationCode] {
+			if err := s.checkTxValidationCode(txOffset.txID, txFLP, blockFLP, txsfltr, txNum); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkLocation checks that the index entry for `key` points to `expected`,
+// or to a later location if `laterAllowed` is set
+func (s *BlockfileScanner) checkLocation(key []byte, expected *fileLocPointer, laterAllowed bool) error {
+	flp, err := s.indexedLocation(key)
+	if err != nil {
+		return err
+	}
+	if flp.equals(expected) || (laterAllowed && flp.after(expected)) {
+		return nil
+	}
+	return errors.Errorf("indexed location [%s] differs from the location in the block files [%s]", flp, expected)
+}
+
+func (s *BlockfileScanner) checkTxValidationCode(txID string, txFLP, blockFLP *fileLocPointer,
+	txsfltr ledgerUtil.TxValidationFlags, txNum int) error {
+	// skip transactions whose ID is reused by a later transaction
+	items := s.index.indexItemsMap
+	for attr, key := range map[blkstorage.IndexableAttr][]byte{
+		blkstorage.IndexableAttrTxID:      constructTxIDKey(txID),
+		blkstorage.IndexableAttrBlockTxID: constructBlockTxIDKey(txID),
+	} {
+		if !items[attr] {
+			continue
+		}
+		flp, err := s.indexedLocation(key)
+		if err != nil {
+			return err
+		}
+		if flp.after(txFLP) && flp.after(blockFLP) {
+			return nil
+		}
+	}
+
+	if txNum >= len(txsfltr) {
+		return errors.Errorf("no validation flag for transaction %d", txNum)
+	}
+	raw, err := s.index.db.Get(constructTxValidationCodeIDKey(txID))
+	if err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		return errors.Errorf("transaction [%s] missing from the validation code index", txID)
+	}
+	if raw[0] != byte(txsfltr.Flag(txNum)) {
+		return errors.Errorf("indexed validation code [%d] of transaction [%s] differs from the block metadata [%d]",
+			raw[0], txID, byte(txsfltr.Flag(txNum)))
+	}
+	return nil
+}
+
+func (s *BlockfileScanner) indexedLocation(key []byte) (*fileLocPointer, error) {
+	b, err := s.index.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("entry missing from the index")
+	}
+	flp := &fileLocPointer{}
+	if err := flp.unmarshal(b); err != nil {
+		return nil, errors.Wrap(err, "malformed index entry")
+	}
+	return flp, nil
+}
+
+// Close releases the block files and the index
+func (s *BlockfileScanner) Close() {
+	if s.stream != nil {
+		s.stream.close()
+	}
+	if s.indexProvider != nil {
+		s.indexProvider.Close()
+	}
+}
+
+func (flp *fileLocPointer) equals(other *fileLocPointer) bool {
+	return flp.fileSuffixNum == other.fileSuffixNum && flp.locPointer == other.locPointer
+}
+
+// after tells whether the location is further in the block files than the other location
+func (flp *fileLocPointer) after(other *fileLocPointer) bool {
+	if flp.fileSuffixNum != other.fileSuffixNum {
+		return flp.fileSuffixNum > other.fileSuffixNum
+	}
+	return flp.offset > other.offset
+}
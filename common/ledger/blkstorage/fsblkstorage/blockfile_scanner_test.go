@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var allIndexAttrs = &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockHash,
+	blkstorage.IndexableAttrBlockNum,
+	blkstorage.IndexableAttrTxID,
+	blkstorage.IndexableAttrBlockNumTranNum,
+	blkstorage.IndexableAttrBlockTxID,
+	blkstorage.IndexableAttrTxValidationCode,
+}}
+
+func TestBlockfileScanner(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	defer env.removeFSPath()
+	ledgerid := "testLedger"
+	blocks := addScannerTestBlocks(t, env, ledgerid)
+	env.provider.Close()
+
+	scanner, err := NewBlockfileScanner(conf.blockStorageDir, ledgerid, allIndexAttrs)
+	assert.NoError(t, err)
+	lastBlockIndexed, ok := scanner.LastBlockIndexed()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(len(blocks)-1), lastBlockIndexed)
+	for _, expected := range blocks {
+		block, err := scanner.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Header, block.Header)
+		assert.NoError(t, scanner.CheckIndex())
+	}
+	block, err := scanner.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+	scanner.Close()
+
+	_, err = NewBlockfileScanner(conf.blockStorageDir, "missingLedger", allIndexAttrs)
+	assert.Error(t, err)
+}
+
+func TestBlockfileScannerTamperedIndex(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	defer env.removeFSPath()
+	ledgerid := "testLedger"
+	blocks := addScannerTestBlocks(t, env, ledgerid)
+	env.provider.Close()
+
+	// point the index entry of block 2 to block 1
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	db := p.GetDBHandle(ledgerid)
+	flpBytes, err := db.Get(constructBlockNumKey(1))
+	assert.NoError(t, err)
+	assert.NoError(t, db.Put(constructBlockNumKey(2), flpBytes, true))
+	p.Close()
+
+	scanner, err := NewBlockfileScanner(conf.blockStorageDir, ledgerid, allIndexAttrs)
+	assert.NoError(t, err)
+	defer scanner.Close()
+	for i := range blocks {
+		_, err := scanner.Next()
+		assert.NoError(t, err)
+		if i == 2 {
+			assert.Contains(t, scanner.CheckIndex().Error(), "block number index")
+		} else {
+			assert.NoError(t, scanner.CheckIndex())
+		}
+	}
+}
+
+func TestBlockfileScannerNoIndex(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	defer env.removeFSPath()
+	ledgerid := "testLedger"
+	blocks := addScannerTestBlocks(t, env, ledgerid)
+	env.provider.Close()
+
+	scanner, err := NewBlockfileScanner(conf.blockStorageDir, ledgerid, nil)
+	assert.NoError(t, err)
+	defer scanner.Close()
+	_, ok := scanner.LastBlockIndexed()
+	assert.False(t, ok)
+	for range blocks {
+		_, err := scanner.Next()
+		assert.NoError(t, err)
+		assert.NoError(t, scanner.CheckIndex())
+	}
+}
+
+// addScannerTestBlocks adds a few blocks to the ledger, the last one
+// reusing the transaction ID of the first transaction of block 1
+func addScannerTestBlocks(t *testing.T, env *testEnv, ledgerid string) []*common.Block {
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := []*common.Block{gb}
+	blocks = append(blocks, bg.NextBlockWithTxid([][]byte{[]byte("rwset1"), []byte("rwset2")}, []string{"txid1", "txid2"}))
+	blocks = append(blocks, bg.NextTestBlocks(2)...)
+	blocks = append(blocks, bg.NextBlockWithTxid([][]byte{[]byte("rwset3")}, []string{"txid1"}))
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+	return blocks
+}
@@ -18,8 +18,11 @@ package fsblkstorage
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -37,12 +40,25 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
+	txCreatorIdxKeyPrefix          = 'r'
+	txChaincodeIdxKeyPrefix        = 'c'
+	txTimestampIdxKeyPrefix        = 's'
 	indexCheckpointKeyStr          = "indexCheckpointKey"
+	indexedTxHeaderAttrsKeyStr     = "indexedTxHeaderAttrsKey"
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
+var indexedTxHeaderAttrsKey = []byte(indexedTxHeaderAttrsKeyStr)
 var errIndexEmpty = errors.New("NoBlockIndexed")
 
+// txHeaderAttrs are the attributes of the transaction headers which can be indexed after
+// the blocks, when they are added to the index configuration of an existing ledger
+var txHeaderAttrs = []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrTxCreator,
+	blkstorage.IndexableAttrTxChaincode,
+	blkstorage.IndexableAttrTxTimestamp,
+}
+
 type index interface {
 	getLastBlockIndexed() (uint64, error)
 	indexBlock(blockIdxInfo *blockIdxInfo) error
@@ -52,6 +68,12 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	getTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error)
+	getTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error)
+	getTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error)
+	getTxHeaderAttrsToBackfill() ([]blkstorage.IndexableAttr, error)
+	backfillTxHeaderAttrs(blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error
+	markTxHeaderAttrsIndexed() error
 }
 
 type blockIdxInfo struct {
@@ -155,6 +177,11 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	// Index7, Index8 and Index9 - Store the transactions by creator, chaincode and timestamp
+	if err := addTxHeaderAttrs(batch, blockIdxInfo, index.indexItemsMap); err != nil {
+		return err
+	}
+
 	batch.Put(indexCheckpointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -263,6 +290,119 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	return result, nil
 }
 
+// addTxHeaderAttrs adds to the batch the entries of the transactions of a block for the
+// attributes of the transaction headers in `attrs`
+func addTxHeaderAttrs(batch *leveldbhelper.UpdateBatch, blockIdxInfo *blockIdxInfo, attrs map[blkstorage.IndexableAttr]bool) error {
+	if !attrs[blkstorage.IndexableAttrTxCreator] && !attrs[blkstorage.IndexableAttrTxChaincode] && !attrs[blkstorage.IndexableAttrTxTimestamp] {
+		return nil
+	}
+	flp := blockIdxInfo.flp
+	txsfltr := ledgerUtil.TxValidationFlags(blockIdxInfo.metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txNum, txoffset := range blockIdxInfo.txOffsets {
+		entry := &txIndexEntry{
+			blockNum: blockIdxInfo.blockNum,
+			txNum:    uint64(txNum),
+			flp:      newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc),
+		}
+		if txNum < len(txsfltr) {
+			entry.validationCode = txsfltr.Flag(txNum)
+		}
+		entryBytes, err := entry.marshal()
+		if err != nil {
+			return err
+		}
+		if attrs[blkstorage.IndexableAttrTxCreator] && len(txoffset.creator) > 0 {
+			batch.Put(constructTxCreatorKey(txoffset.creator, entry.blockNum, entry.txNum), entryBytes)
+		}
+		if attrs[blkstorage.IndexableAttrTxChaincode] && txoffset.chaincodeName != "" {
+			batch.Put(constructTxChaincodeKey(txoffset.chaincodeName, entry.blockNum, entry.txNum), entryBytes)
+		}
+		if attrs[blkstorage.IndexableAttrTxTimestamp] && txoffset.timestamp != nil {
+			nanos := txoffset.timestamp.Seconds*int64(time.Second) + int64(txoffset.timestamp.Nanos)
+			batch.Put(constructTxTimestampKey(nanos, entry.blockNum, entry.txNum), entryBytes)
+		}
+	}
+	return nil
+}
+
+func (index *blockIndex) getTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxCreator]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	startKey := constructTxCreatorKey(creator, startBlockNum, startTxNum)
+	endKey := append(constructTxCreatorPrefix(creator), lastKeyIndicator)
+	return index.db.GetIterator(startKey, endKey), nil
+}
+
+func (index *blockIndex) getTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxChaincode]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	startKey := constructTxChaincodeKey(chaincodeName, startBlockNum, startTxNum)
+	endKey := append(constructTxChaincodePrefix(chaincodeName), lastKeyIndicator)
+	return index.db.GetIterator(startKey, endKey), nil
+}
+
+func (index *blockIndex) getTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxTimestamp]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	startKey := constructTxTimestampKey(startTime.UnixNano(), startBlockNum, startTxNum)
+	endKey := append([]byte{txTimestampIdxKeyPrefix}, encodeTimestamp(endTime.UnixNano())...)
+	return index.db.GetIterator(startKey, endKey), nil
+}
+
+// getTxHeaderAttrsToBackfill returns the attributes of the transaction headers to index which
+// are missing from the entries of the blocks already indexed
+func (index *blockIndex) getTxHeaderAttrsToBackfill() ([]blkstorage.IndexableAttr, error) {
+	if _, err := index.getLastBlockIndexed(); err != nil {
+		if err == errIndexEmpty {
+			return nil, nil
+		}
+		return nil, err
+	}
+	indexedAttrsBytes, err := index.db.Get(indexedTxHeaderAttrsKey)
+	if err != nil {
+		return nil, err
+	}
+	indexedAttrs := make(map[blkstorage.IndexableAttr]bool)
+	for _, attr := range strings.Split(string(indexedAttrsBytes), ",") {
+		indexedAttrs[blkstorage.IndexableAttr(attr)] = true
+	}
+	var attrs []blkstorage.IndexableAttr
+	for _, attr := range txHeaderAttrs {
+		if index.indexItemsMap[attr] && !indexedAttrs[attr] {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs, nil
+}
+
+// backfillTxHeaderAttrs indexes the transactions of a block already indexed for the attributes `attrs`
+func (index *blockIndex) backfillTxHeaderAttrs(blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error {
+	attrsMap := make(map[blkstorage.IndexableAttr]bool)
+	for _, attr := range attrs {
+		attrsMap[attr] = true
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := addTxHeaderAttrs(batch, blockIdxInfo, attrsMap); err != nil {
+		return err
+	}
+	return index.db.WriteBatch(batch, false)
+}
+
+// markTxHeaderAttrsIndexed records that all the blocks indexed hold the entries of the
+// attributes of the transaction headers to index
+func (index *blockIndex) markTxHeaderAttrsIndexed() error {
+	var attrs []string
+	for _, attr := range txHeaderAttrs {
+		if index.indexItemsMap[attr] {
+			attrs = append(attrs, string(attr))
+		}
+	}
+	return index.db.Put(indexedTxHeaderAttrsKey, []byte(strings.Join(attrs, ",")), true)
+}
+
 func constructBlockNumKey(blockNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
@@ -291,6 +431,50 @@ func constructBlockNumTranNumKey(blockNum uint64, txNum uint64) []byte {
 	return append([]byte{blockNumTranNumIdxKeyPrefix}, key...)
 }
 
+// lastKeyIndicator is greater than the first byte of any order preserving encoding,
+// so that appending it to a prefix gives the end of the range of the prefix
+const lastKeyIndicator = byte(0xff)
+
+func constructTxCreatorPrefix(creator []byte) []byte {
+	creatorHash := sha256.Sum256(creator)
+	return append([]byte{txCreatorIdxKeyPrefix}, creatorHash[:]...)
+}
+
+func constructTxCreatorKey(creator []byte, blockNum uint64, txNum uint64) []byte {
+	return appendBlockNumTranNum(constructTxCreatorPrefix(creator), blockNum, txNum)
+}
+
+// the chaincode names are followed by a separator which cannot be part of a name,
+// so that the transactions of a chaincode are not mixed with the ones of another
+// chaincode whose name starts with the same characters
+func constructTxChaincodePrefix(chaincodeName string) []byte {
+	prefix := append([]byte{txChaincodeIdxKeyPrefix}, []byte(chaincodeName)...)
+	return append(prefix, 0x00)
+}
+
+func constructTxChaincodeKey(chaincodeName string, blockNum uint64, txNum uint64) []byte {
+	return appendBlockNumTranNum(constructTxChaincodePrefix(chaincodeName), blockNum, txNum)
+}
+
+func constructTxTimestampKey(nanos int64, blockNum uint64, txNum uint64) []byte {
+	prefix := append([]byte{txTimestampIdxKeyPrefix}, encodeTimestamp(nanos)...)
+	return appendBlockNumTranNum(prefix, blockNum, txNum)
+}
+
+// encodeTimestamp encodes the nanoseconds since the epoch preserving their order.
+// Timestamps before the epoch are encoded as the epoch
+func encodeTimestamp(nanos int64) []byte {
+	if nanos < 0 {
+		nanos = 0
+	}
+	return util.EncodeOrderPreservingVarUint64(uint64(nanos))
+}
+
+func appendBlockNumTranNum(prefix []byte, blockNum uint64, txNum uint64) []byte {
+	key := append(prefix, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
 func encodeBlockNum(blockNum uint64) []byte {
 	return proto.EncodeVarint(blockNum)
 }
@@ -365,6 +549,48 @@ func (flp *fileLocPointer) String() string {
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
+// txIndexEntry is the value of the entries of the transactions indexed by the
+// attributes of their headers
+type txIndexEntry struct {
+	blockNum       uint64
+	txNum          uint64
+	validationCode peer.TxValidationCode
+	flp            *fileLocPointer
+}
+
+func (e *txIndexEntry) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	for _, n := range []uint64{e.blockNum, e.txNum, uint64(e.validationCode)} {
+		if err := buffer.EncodeVarint(n); err != nil {
+			return nil, err
+		}
+	}
+	flpBytes, err := e.flp.marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(buffer.Bytes(), flpBytes...), nil
+}
+
+func (e *txIndexEntry) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	var err error
+	if e.blockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	if e.txNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	validationCode, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	e.validationCode = peer.TxValidationCode(validationCode)
+	consumed := len(proto.EncodeVarint(e.blockNum)) + len(proto.EncodeVarint(e.txNum)) + len(proto.EncodeVarint(validationCode))
+	e.flp = &fileLocPointer{}
+	return e.flp.unmarshal(b[consumed:])
+}
+
 func (blockIdxInfo *blockIdxInfo) String() string {
 
 	var buffer bytes.Buffer
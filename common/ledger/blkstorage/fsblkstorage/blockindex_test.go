@@ -19,13 +19,16 @@ package fsblkstorage
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
 	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
 )
 
 type noopIndex struct {
@@ -58,6 +61,30 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) getTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (*leveldbhelper.Iterator, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getTxHeaderAttrsToBackfill() ([]blkstorage.IndexableAttr, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) backfillTxHeaderAttrs(blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error {
+	return nil
+}
+
+func (i *noopIndex) markTxHeaderAttrsIndexed() error {
+	return nil
+}
+
 func TestBlockIndexSync(t *testing.T) {
 	testBlockIndexSync(t, 10, 5, false)
 	testBlockIndexSync(t, 10, 5, true)
@@ -166,8 +193,9 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 		}
 
 		// test 'retrieveTransactionByID'
-		txid, err := extractTxID(blocks[0].Data.Data[0])
+		idxInfo, err := extractTxIndexInfo(blocks[0].Data.Data[0])
 		testutil.AssertNoError(t, err, "")
+		txid := idxInfo.txID
 		txEnvelope, err := blockfileMgr.retrieveTransactionByID(txid)
 		if testutil.Contains(indexItems, blkstorage.IndexableAttrTxID) {
 			testutil.AssertNoError(t, err, "Error while retrieving tx by id")
@@ -192,8 +220,9 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 		}
 
 		// test 'retrieveBlockByTxID'
-		txid, err = extractTxID(blocks[0].Data.Data[0])
+		idxInfo, err = extractTxIndexInfo(blocks[0].Data.Data[0])
 		testutil.AssertNoError(t, err, "")
+		txid = idxInfo.txID
 		block, err = blockfileMgr.retrieveBlockByTxID(txid)
 		if testutil.Contains(indexItems, blkstorage.IndexableAttrBlockTxID) {
 			testutil.AssertNoError(t, err, "Error while retrieving block by txID")
@@ -206,8 +235,9 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 			flags := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 
 			for idx, d := range block.Data.Data {
-				txid, err = extractTxID(d)
+				idxInfo, err = extractTxIndexInfo(d)
 				testutil.AssertNoError(t, err, "")
+				txid = idxInfo.txID
 
 				reason, err := blockfileMgr.retrieveTxValidationCodeByTxID(txid)
 
@@ -224,3 +254,119 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 		}
 	})
 }
+
+func TestBlockIndexTxHeaderAttrs(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnvSelectiveIndexing(t, conf, append(txHeaderAttrs, blkstorage.IndexableAttrBlockNum))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testledger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	w.addBlocks(blocks)
+	testTxHeaderAttrsIndexed(t, w.blockfileMgr, blocks)
+
+	// a chaincode with a name starting with the name of the chaincode indexed is not mixed up
+	itr, err := w.blockfileMgr.retrieveTxsByChaincode("fo", 0, 0)
+	assert.NoError(t, err)
+	result, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	itr.Close()
+}
+
+func TestBlockIndexTxHeaderAttrsBackfill(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	w := newTestBlockfileWrapper(env, "testledger")
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	w.addBlocks(blocks[:3])
+	_, err := w.blockfileMgr.retrieveTxsByChaincode("foo", 0, 0)
+	assert.Equal(t, blkstorage.ErrAttrNotIndexed, err)
+	w.close()
+	env.provider.Close()
+
+	// the attributes added to the index configuration are indexed for the existing blocks
+	env = newTestEnvSelectiveIndexing(t, conf, append(txHeaderAttrs, blkstorage.IndexableAttrBlockNum))
+	defer env.Cleanup()
+	w = newTestBlockfileWrapper(env, "testledger")
+	defer w.close()
+	attrs, err := w.blockfileMgr.index.getTxHeaderAttrsToBackfill()
+	assert.NoError(t, err)
+	assert.Empty(t, attrs)
+	w.addBlocks(blocks[3:])
+	testTxHeaderAttrsIndexed(t, w.blockfileMgr, blocks)
+}
+
+func testTxHeaderAttrsIndexed(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+	// the first block is a config block, the transactions of the other blocks invoke chaincode `foo`
+	var expected []*peer.IndexedTransaction
+	for _, block := range blocks[1:] {
+		flags := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		for txNum, txEnvelopeBytes := range block.Data.Data {
+			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			assert.NoError(t, err)
+			expected = append(expected, &peer.IndexedTransaction{
+				BlockNumber: block.Header.Number,
+				TxNumber:    uint64(txNum),
+				ProcessedTransaction: &peer.ProcessedTransaction{
+					TransactionEnvelope: txEnvelope,
+					ValidationCode:      int32(flags.Flag(txNum)),
+				},
+			})
+		}
+	}
+	creatorInfo, err := extractTxIndexInfo(blocks[1].Data.Data[0])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, creatorInfo.creator)
+
+	itr, err := mgr.retrieveTxsByChaincode("foo", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, collectIndexedTxs(t, itr))
+	itr, err = mgr.retrieveTxsByChaincode("foo", 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[len(blocks[1].Data.Data):], collectIndexedTxs(t, itr))
+	// resuming after the first transaction
+	itr, err = mgr.retrieveTxsByChaincode("foo", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[1:], collectIndexedTxs(t, itr))
+
+	itr, err = mgr.retrieveTxsByCreator(creatorInfo.creator, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, collectIndexedTxs(t, itr))
+	itr, err = mgr.retrieveTxsByCreator(creatorInfo.creator, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[1:], collectIndexedTxs(t, itr))
+	itr, err = mgr.retrieveTxsByCreator([]byte("unknown creator"), 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, collectIndexedTxs(t, itr))
+
+	itr, err = mgr.retrieveTxsByTimestamp(time.Unix(0, 0), time.Now().Add(time.Hour), 0, 0)
+	assert.NoError(t, err)
+	byTimestamp := collectIndexedTxs(t, itr)
+	assert.Len(t, byTimestamp, len(expected)+1)
+	itr, err = mgr.retrieveTxsByTimestamp(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour), 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, collectIndexedTxs(t, itr))
+
+	// resuming after the first transaction, whose timestamp starts the time window
+	first := byTimestamp[0]
+	chdr, err := putil.ChannelHeader(first.ProcessedTransaction.TransactionEnvelope)
+	assert.NoError(t, err)
+	itr, err = mgr.retrieveTxsByTimestamp(time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos)), time.Now().Add(time.Hour),
+		first.BlockNumber, first.TxNumber+1)
+	assert.NoError(t, err)
+	assert.Equal(t, byTimestamp[1:], collectIndexedTxs(t, itr))
+}
+
+func collectIndexedTxs(t *testing.T, itr *txsItr) []*peer.IndexedTransaction {
+	defer itr.Close()
+	var txs []*peer.IndexedTransaction
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			return txs
+		}
+		txs = append(txs, result.(*peer.IndexedTransaction))
+	}
+}
@@ -30,6 +30,27 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
+}
+
+// ArchiveConf configures the archiving of the completed block files of the ledgers.
+// The block files stay readable by the block store once archived, and the block
+// index is left unchanged, as it records the offsets of the uncompressed blocks
+type ArchiveConf struct {
+	// Compress enables the gzip compression of the archived block files. A compressed
+	// block file is decompressed from its start on every lookup of a block in it
+	Compress bool
+	// ArchiveDir, if set, is the directory the archived block files are moved to,
+	// such as a mount of cheaper storage. A symbolic link to each archived file is
+	// left in the block directory of the ledger
+	ArchiveDir string
+	// KeepBlockfiles is the number of the most recently completed block files
+	// which are left in place
+	KeepBlockfiles int
+}
+
+func (c *ArchiveConf) enabled() bool {
+	return c != nil && (c.Compress || c.ArchiveDir != "")
 }
 
 // NewConf constructs new `Conf`.
@@ -38,7 +59,15 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir: blockStorageDir, maxBlockfileSize: maxBlockfileSize}
+}
+
+// NewConfWithArchiving constructs a new `Conf` which archives the completed block files
+// according to `archiveConf`
+func NewConfWithArchiving(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.archiveConf = archiveConf
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -52,3 +81,10 @@ func (conf *Conf) getChainsDir() string {
 func (conf *Conf) getLedgerBlockDir(ledgerid string) string {
 	return filepath.Join(conf.getChainsDir(), ledgerid)
 }
+
+func (conf *Conf) getLedgerArchiveDir(ledgerid string) string {
+	if conf.archiveConf.ArchiveDir == "" {
+		return conf.getLedgerBlockDir(ledgerid)
+	}
+	return filepath.Join(conf.archiveConf.ArchiveDir, ledgerid)
+}
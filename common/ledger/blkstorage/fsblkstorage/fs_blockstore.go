@@ -17,6 +17,8 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -86,6 +88,33 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveTxsByCreator returns an iterator over the transactions submitted by `creator`
+func (store *fsBlockStore) RetrieveTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error) {
+	itr, err := store.fileMgr.retrieveTxsByCreator(creator, startBlockNum, startTxNum)
+	if err != nil {
+		return nil, err
+	}
+	return itr, nil
+}
+
+// RetrieveTxsByChaincode returns an iterator over the transactions invoking `chaincodeName`
+func (store *fsBlockStore) RetrieveTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error) {
+	itr, err := store.fileMgr.retrieveTxsByChaincode(chaincodeName, startBlockNum, startTxNum)
+	if err != nil {
+		return nil, err
+	}
+	return itr, nil
+}
+
+// RetrieveTxsByTimestamp returns an iterator over the transactions with a timestamp within [startTime, endTime)
+func (store *fsBlockStore) RetrieveTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error) {
+	itr, err := store.fileMgr.retrieveTxsByTimestamp(startTime, endTime, startBlockNum, startTxNum)
+	if err != nil {
+		return nil, err
+	}
+	return itr, nil
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
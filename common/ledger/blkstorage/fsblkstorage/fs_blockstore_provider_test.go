@@ -78,8 +78,9 @@ func checkBlocks(t *testing.T, expectedBlocks []*common.Block, store blkstorage.
 		for txNum := 0; txNum < len(block.Data.Data); txNum++ {
 			txEnvBytes := block.Data.Data[txNum]
 			txEnv, _ := utils.GetEnvelopeFromBlock(txEnvBytes)
-			txid, err := extractTxID(txEnvBytes)
+			idxInfo, err := extractTxIndexInfo(txEnvBytes)
 			testutil.AssertNoError(t, err, "")
+			txid := idxInfo.txID
 
 			retrievedBlock, _ := store.RetrieveBlockByTxID(txid)
 			testutil.AssertEquals(t, retrievedBlock, block)
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// txsItr - an iterator over the transactions of an index by the attributes of their headers.
+// The results are of type *peer.IndexedTransaction
+type txsItr struct {
+	mgr     *blockfileMgr
+	dbItr   *leveldbhelper.Iterator
+	started bool
+}
+
+func newTxsItr(mgr *blockfileMgr, dbItr *leveldbhelper.Iterator) *txsItr {
+	return &txsItr{mgr: mgr, dbItr: dbItr}
+}
+
+// Next moves the cursor to the next transaction and returns it, or nil when the
+// transactions are exhausted
+func (itr *txsItr) Next() (ledger.QueryResult, error) {
+	var ok bool
+	if !itr.started {
+		ok = itr.dbItr.First()
+		itr.started = true
+	} else {
+		ok = itr.dbItr.Next()
+	}
+	if !ok {
+		return nil, itr.dbItr.Error()
+	}
+	entry := &txIndexEntry{}
+	if err := entry.unmarshal(itr.dbItr.Value()); err != nil {
+		return nil, err
+	}
+	txEnvelope, err := itr.mgr.fetchTransactionEnvelope(entry.flp)
+	if err != nil {
+		return nil, err
+	}
+	return &peer.IndexedTransaction{
+		BlockNumber: entry.blockNum,
+		TxNumber:    entry.txNum,
+		ProcessedTransaction: &peer.ProcessedTransaction{
+			TransactionEnvelope: txEnvelope,
+			ValidationCode:      int32(entry.validationCode),
+		},
+	}, nil
+}
+
+// Close releases any resources held by the iterator
+func (itr *txsItr) Close() {
+	itr.dbItr.Release()
+}
@@ -19,12 +19,16 @@ package blockledger_test
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 type ledgerTestable interface {
@@ -259,3 +263,48 @@ func testMultichain(lf ledgerTestFactory, t *testing.T) {
 		t.Fatalf("Did not properly store block 1 on chain 1")
 	}
 }
+
+func TestSearchTimestamp(t *testing.T) {
+	allTest(t, testSearchTimestamp)
+}
+
+func timestampedEnvelope(seconds int64) *cb.Envelope {
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+				Timestamp: &timestamp.Timestamp{Seconds: seconds},
+			}),
+		},
+	})}
+}
+
+func testSearchTimestamp(lf ledgerTestFactory, t *testing.T) {
+	_, li := lf.New()
+	for seconds := int64(10); seconds <= 40; seconds += 10 {
+		li.Append(blockledger.CreateNextBlock(li, []*cb.Envelope{timestampedEnvelope(seconds)}))
+	}
+
+	for _, test := range []struct {
+		seconds  int64
+		expected uint64
+	}{
+		{seconds: 20, expected: 2},
+		{seconds: 25, expected: 3},
+		{seconds: 40, expected: 4},
+		{seconds: 41, expected: 5},
+	} {
+		number, err := blockledger.SearchTimestamp(li, time.Unix(test.seconds, 0))
+		if err != nil {
+			t.Fatalf("Error searching timestamp %d: %s", test.seconds, err)
+		}
+		if number != test.expected {
+			t.Fatalf("Expected block %d for timestamp %d but got block %d", test.expected, test.seconds, number)
+		}
+	}
+
+	// The genesis block of the tests carries no transaction to take a timestamp from
+	_, err := blockledger.SearchTimestamp(li, time.Unix(5, 0))
+	if err == nil || !strings.Contains(err.Error(), "block [0]") {
+		t.Fatalf("Expected an error about the genesis block but got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// chainsDBName names the logical database listing the chains of the store, which
+// cannot clash with a chain as it is not a valid chain ID
+const chainsDBName = "_chains"
+
+type kvLedgerFactory struct {
+	provider *leveldbhelper.Provider
+	chains   *leveldbhelper.DBHandle
+	ledgers  map[string]blockledger.ReadWriter
+	mutex    sync.Mutex
+}
+
+// GetOrCreate gets an existing ledger (if it exists) or creates it if it does not
+func (kvlf *kvLedgerFactory) GetOrCreate(chainID string) (blockledger.ReadWriter, error) {
+	kvlf.mutex.Lock()
+	defer kvlf.mutex.Unlock()
+
+	if ledger, ok := kvlf.ledgers[chainID]; ok {
+		return ledger, nil
+	}
+	if chainID == "" || chainID == chainsDBName {
+		return nil, errors.Errorf("invalid chain ID [%s]", chainID)
+	}
+
+	if err := kvlf.chains.Put([]byte(chainID), []byte{}, true); err != nil {
+		return nil, errors.Wrapf(err, "could not register chain %s", chainID)
+	}
+	ledger, err := newKVLedger(kvlf.provider.GetDBHandle(chainID))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not open chain "+chainID)
+	}
+	logger.Debugf("Opened chain %s at height %d", chainID, ledger.Height())
+	kvlf.ledgers[chainID] = ledger
+	return ledger, nil
+}
+
+// ChainIDs returns the chain IDs the factory is aware of
+func (kvlf *kvLedgerFactory) ChainIDs() []string {
+	itr := kvlf.chains.GetIterator(nil, nil)
+	defer itr.Release()
+
+	chainIDs := []string{}
+	for itr.Next() {
+		chainIDs = append(chainIDs, string(itr.Key()))
+	}
+	if err := itr.Error(); err != nil {
+		logger.Panicf("Error listing the chains: %s", err)
+	}
+	return chainIDs
+}
+
+// Close releases all resources acquired by the factory
+func (kvlf *kvLedgerFactory) Close() {
+	kvlf.provider.Close()
+}
+
+// New creates a new ledger factory storing its chains in a LevelDB database in the
+// given directory
+func New(directory string) blockledger.Factory {
+	logger.Debugf("Initializing ledger at: %s", directory)
+	provider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: directory})
+	return &kvLedgerFactory{
+		provider: provider,
+		chains:   provider.GetDBHandle(chainsDBName),
+		ledgers:  make(map[string]blockledger.ReadWriter),
+	}
+}
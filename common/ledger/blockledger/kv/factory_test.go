@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kvledger")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kvlf := New(dir)
+	assert.Empty(t, kvlf.ChainIDs())
+
+	t.Run("GetOrCreate", func(t *testing.T) {
+		chain, err := kvlf.GetOrCreate(genesisconfig.TestChainID)
+		assert.NoError(t, err)
+		again, err := kvlf.GetOrCreate(genesisconfig.TestChainID)
+		assert.NoError(t, err)
+		assert.True(t, chain == again, "Expected the same ledger to be returned")
+		assert.NoError(t, chain.Append(genesisBlock))
+
+		_, err = kvlf.GetOrCreate("otherchain")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{genesisconfig.TestChainID, "otherchain"}, kvlf.ChainIDs())
+	})
+
+	t.Run("InvalidChainID", func(t *testing.T) {
+		_, err := kvlf.GetOrCreate("")
+		assert.EqualError(t, err, "invalid chain ID []")
+		_, err = kvlf.GetOrCreate(chainsDBName)
+		assert.EqualError(t, err, "invalid chain ID [_chains]")
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		kvlf.Close()
+		kvlf = New(dir)
+		defer kvlf.Close()
+
+		// The chains are listed before being opened, including the empty ones
+		assert.ElementsMatch(t, []string{genesisconfig.TestChainID, "otherchain"}, kvlf.ChainIDs())
+		chain, err := kvlf.GetOrCreate(genesisconfig.TestChainID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), chain.Height())
+		other, err := kvlf.GetOrCreate("otherchain")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), other.Height())
+	})
+}
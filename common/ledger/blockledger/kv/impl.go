@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+)
+
+const pkgLogID = "common/ledger/blockledger/kv"
+
+var logger *logging.Logger
+
+var closedChan chan struct{}
+
+func init() {
+	logger = flogging.MustGetLogger(pkgLogID)
+	closedChan = make(chan struct{})
+	close(closedChan)
+}
+
+var (
+	// infoKey holds the height and the hash of the last block of a chain
+	infoKey = []byte("info")
+	// blockKeyPrefix prefixes the big endian numbers of the blocks of a chain
+	blockKeyPrefix = []byte("block")
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// encodeRecord prefixes the marshaled message with its CRC-32C checksum
+func encodeRecord(msg proto.Message) ([]byte, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	record := make([]byte, crc32.Size, crc32.Size+len(data))
+	binary.BigEndian.PutUint32(record, crc32.Checksum(data, crcTable))
+	return append(record, data...), nil
+}
+
+// decodeRecord verifies the checksum of the record and unmarshals it into msg
+func decodeRecord(record []byte, msg proto.Message) error {
+	if len(record) < crc32.Size {
+		return errors.Errorf("record of %d bytes is truncated", len(record))
+	}
+	data := record[crc32.Size:]
+	if expected, actual := binary.BigEndian.Uint32(record), crc32.Checksum(data, crcTable); expected != actual {
+		return errors.Errorf("record checksum mismatch: expected %08x but computed %08x", expected, actual)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func blockKey(number uint64) []byte {
+	key := make([]byte, len(blockKeyPrefix)+8)
+	copy(key, blockKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(blockKeyPrefix):], number)
+	return key
+}
+
+// KVLedger is a ledger storing the blocks of a chain as checksummed records of an
+// embedded LevelDB key-value store. Each block is written along with the height of
+// the chain in a single atomic batch, and LevelDB compacts its table files in the
+// background.
+type KVLedger struct {
+	db *leveldbhelper.DBHandle
+
+	mutex    sync.Mutex
+	height   uint64
+	lastHash []byte
+	signal   chan struct{}
+}
+
+// newKVLedger opens the chain stored under the given handle
+func newKVLedger(db *leveldbhelper.DBHandle) (*KVLedger, error) {
+	kvl := &KVLedger{db: db, signal: make(chan struct{})}
+	record, err := db.Get(infoKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the chain info")
+	}
+	if record == nil {
+		return kvl, nil
+	}
+	info := &cb.BlockchainInfo{}
+	if err := decodeRecord(record, info); err != nil {
+		return nil, errors.WithMessage(err, "could not decode the chain info")
+	}
+	kvl.height, kvl.lastHash = info.Height, info.CurrentBlockHash
+	return kvl, nil
+}
+
+// readBlock returns the block with the given number, which must be below the height
+func (kvl *KVLedger) readBlock(number uint64) (*cb.Block, error) {
+	record, err := kvl.db.Get(blockKey(number))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read block %d", number)
+	}
+	if record == nil {
+		return nil, errors.Errorf("block %d is missing", number)
+	}
+	block := &cb.Block{}
+	if err := decodeRecord(record, block); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not decode block %d", number))
+	}
+	return block, nil
+}
+
+type cursor struct {
+	kvl         *KVLedger
+	blockNumber uint64
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+// Next blocks until there is a new block available, or until Close is called.
+// It returns an error if the next block is no longer retrievable.
+func (cu *cursor) Next() (*cb.Block, cb.Status) {
+	for {
+		cu.kvl.mutex.Lock()
+		height, signal := cu.kvl.height, cu.kvl.signal
+		cu.kvl.mutex.Unlock()
+
+		if cu.blockNumber < height {
+			block, err := cu.kvl.readBlock(cu.blockNumber)
+			if err != nil {
+				logger.Errorf("Failed reading block: %s", err)
+				return nil, cb.Status_SERVICE_UNAVAILABLE
+			}
+			cu.blockNumber++
+			return block, cb.Status_SUCCESS
+		}
+
+		select {
+		case <-signal:
+		case <-cu.closed:
+			return nil, cb.Status_SERVICE_UNAVAILABLE
+		}
+	}
+}
+
+// ReadyChan supplies a channel which will block until Next will not block
+func (cu *cursor) ReadyChan() <-chan struct{} {
+	cu.kvl.mutex.Lock()
+	defer cu.kvl.mutex.Unlock()
+	if cu.blockNumber < cu.kvl.height {
+		return closedChan
+	}
+	return cu.kvl.signal
+}
+
+// Close releases resources acquired by the Iterator
+func (cu *cursor) Close() {
+	cu.closeOnce.Do(func() { close(cu.closed) })
+}
+
+// Iterator returns an Iterator, as specified by an ab.SeekInfo message, and its
+// starting block number
+func (kvl *KVLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iterator, uint64) {
+	height := kvl.Height()
+	var start uint64
+	switch position := startPosition.Type.(type) {
+	case *ab.SeekPosition_Oldest:
+		start = 0
+	case *ab.SeekPosition_Newest:
+		start = height - 1
+	case *ab.SeekPosition_Specified:
+		start = position.Specified.Number
+		if start > height {
+			return &blockledger.NotFoundErrorIterator{}, 0
+		}
+	default:
+		return &blockledger.NotFoundErrorIterator{}, 0
+	}
+	return &cursor{kvl: kvl, blockNumber: start, closed: make(chan struct{})}, start
+}
+
+// Height returns the number of blocks on the ledger
+func (kvl *KVLedger) Height() uint64 {
+	kvl.mutex.Lock()
+	defer kvl.mutex.Unlock()
+	return kvl.height
+}
+
+// Append appends a new block to the ledger
+func (kvl *KVLedger) Append(block *cb.Block) error {
+	kvl.mutex.Lock()
+	defer kvl.mutex.Unlock()
+
+	if block.Header.Number != kvl.height {
+		return errors.Errorf("block number should have been %d but was %d", kvl.height, block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, kvl.lastHash) {
+		return errors.Errorf("block should have had previous hash of %x but was %x", kvl.lastHash, block.Header.PreviousHash)
+	}
+
+	blockRecord, err := encodeRecord(block)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal block %d", block.Header.Number)
+	}
+	hash := block.Header.Hash()
+	infoRecord, err := encodeRecord(&cb.BlockchainInfo{
+		Height:            kvl.height + 1,
+		CurrentBlockHash:  hash,
+		PreviousBlockHash: kvl.lastHash,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not marshal the chain info")
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(blockKey(block.Header.Number), blockRecord)
+	batch.Put(infoKey, infoRecord)
+	if err := kvl.db.WriteBatch(batch, true); err != nil {
+		return errors.Wrapf(err, "could not write block %d", block.Header.Number)
+	}
+	logger.Debugf("Wrote block %d", block.Header.Number)
+
+	kvl.height++
+	kvl.lastHash = hash
+	close(kvl.signal)
+	kvl.signal = make(chan struct{})
+	return nil
+}
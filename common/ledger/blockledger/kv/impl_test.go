@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+var genesisBlock = cb.NewBlock(0, nil)
+
+func init() {
+	flogging.SetModuleLevel(pkgLogID, "DEBUG")
+}
+
+func initialize(t *testing.T) (string, blockledger.Factory, blockledger.ReadWriter) {
+	dir, err := ioutil.TempDir("", "kvledger")
+	assert.NoError(t, err)
+	kvlf := New(dir)
+	kvl, err := kvlf.GetOrCreate(genesisconfig.TestChainID)
+	assert.NoError(t, err)
+	assert.NoError(t, kvl.Append(genesisBlock))
+	return dir, kvlf, kvl
+}
+
+func TestAppendChecks(t *testing.T) {
+	dir, kvlf, kvl := initialize(t)
+	defer os.RemoveAll(dir)
+	defer kvlf.Close()
+
+	block := blockledger.CreateNextBlock(kvl, []*cb.Envelope{{Payload: []byte("My Data")}})
+	block.Header.Number = 2
+	assert.EqualError(t, kvl.Append(block), "block number should have been 1 but was 2")
+
+	block.Header.Number = 1
+	block.Header.PreviousHash = []byte("wrong hash")
+	assert.EqualError(t, kvl.Append(block), fmt.Sprintf("block should have had previous hash of %x but was %x", genesisBlock.Header.Hash(), []byte("wrong hash")))
+	assert.Equal(t, uint64(1), kvl.Height())
+}
+
+func TestCorruptedBlock(t *testing.T) {
+	dir, kvlf, kvl := initialize(t)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, kvl.Append(blockledger.CreateNextBlock(kvl, []*cb.Envelope{{Payload: []byte("My Data")}})))
+	kvlf.Close()
+
+	// Flip a byte of the record of block 1
+	provider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dir})
+	db := provider.GetDBHandle(genesisconfig.TestChainID)
+	record, err := db.Get(blockKey(1))
+	assert.NoError(t, err)
+	record[len(record)-1] ^= 0xff
+	assert.NoError(t, db.Put(blockKey(1), record, true))
+	provider.Close()
+
+	kvlf = New(dir)
+	defer kvlf.Close()
+	kvl, err = kvlf.GetOrCreate(genesisconfig.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), kvl.Height())
+
+	it, _ := kvl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	defer it.Close()
+	block, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, uint64(0), block.Header.Number)
+	block, status = it.Next()
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status)
+	assert.Nil(t, block)
+}
+
+func TestRecords(t *testing.T) {
+	record, err := encodeRecord(genesisBlock)
+	assert.NoError(t, err)
+	block := &cb.Block{}
+	assert.NoError(t, decodeRecord(record, block))
+	assert.Equal(t, genesisBlock.Header.Hash(), block.Header.Hash())
+
+	assert.EqualError(t, decodeRecord(record[:3], block), "record of 3 bytes is truncated")
+	record[0] ^= 0xff
+	err = decodeRecord(record, block)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "record checksum mismatch")
+}
+
+func TestIterator(t *testing.T) {
+	dir, kvlf, kvl := initialize(t)
+	defer os.RemoveAll(dir)
+	defer kvlf.Close()
+
+	t.Run("Newest", func(t *testing.T) {
+		it, num := kvl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Newest{}})
+		defer it.Close()
+		assert.Equal(t, uint64(0), num)
+	})
+
+	t.Run("SpecifiedTooHigh", func(t *testing.T) {
+		it, num := kvl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 2}}})
+		defer it.Close()
+		assert.IsType(t, &blockledger.NotFoundErrorIterator{}, it)
+		assert.Equal(t, uint64(0), num)
+	})
+
+	t.Run("BadSeekPosition", func(t *testing.T) {
+		it, _ := kvl.Iterator(&ab.SeekPosition{})
+		defer it.Close()
+		assert.IsType(t, &blockledger.NotFoundErrorIterator{}, it)
+	})
+
+	t.Run("CloseUnblocksNext", func(t *testing.T) {
+		it, _ := kvl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+		statuses := make(chan cb.Status)
+		go func() {
+			_, status := it.Next()
+			statuses <- status
+		}()
+		it.Close()
+		select {
+		case status := <-statuses:
+			assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status)
+		case <-time.After(time.Second):
+			t.Fatal("Next should have returned after Close")
+		}
+		it.Close()
+	})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockledger_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/hyperledger/fabric/common/ledger/blockledger"
+	kvledger "github.com/hyperledger/fabric/common/ledger/blockledger/kv"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+)
+
+func init() {
+	testables = append(testables, &kvLedgerTestEnv{})
+}
+
+type kvLedgerTestFactory struct {
+	location string
+	factory  Factory
+}
+
+type kvLedgerTestEnv struct {
+}
+
+func (env *kvLedgerTestEnv) Initialize() (ledgerTestFactory, error) {
+	location, err := ioutil.TempDir("", "hyperledger")
+	if err != nil {
+		return nil, err
+	}
+	return &kvLedgerTestFactory{location: location}, nil
+}
+
+func (env *kvLedgerTestEnv) Name() string {
+	return "kvledger"
+}
+
+func (env *kvLedgerTestFactory) Destroy() error {
+	if env.factory != nil {
+		env.factory.Close()
+	}
+	return os.RemoveAll(env.location)
+}
+
+func (env *kvLedgerTestFactory) Persistent() bool {
+	return true
+}
+
+func (env *kvLedgerTestFactory) New() (Factory, ReadWriter) {
+	// The database can only be opened once, so the factory of the previous
+	// New is closed, as it would be by a restarting orderer
+	if env.factory != nil {
+		env.factory.Close()
+	}
+	env.factory = kvledger.New(env.location)
+	kl, err := env.factory.GetOrCreate(genesisconfig.TestChainID)
+	if err != nil {
+		panic(err)
+	}
+	if kl.Height() == 0 {
+		if err = kl.Append(genesisBlock); err != nil {
+			panic(err)
+		}
+	}
+	return env.factory, kl
+}
@@ -14,9 +14,16 @@ limitations under the License.
 package blockledger
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var closedChan chan struct{}
@@ -103,3 +110,45 @@ func GetBlock(rl Reader, index uint64) *cb.Block {
 		return nil
 	}
 }
+
+// SearchTimestamp returns the number of the first block of the ledger timestamped at or
+// after the given time, or the height of the ledger if there is no such block.  A block
+// is timestamped with the channel header of its first transaction, and the search relies
+// on the timestamps growing with the block numbers, so its result is only approximate
+// when the clocks of the clients submitting the transactions are not synchronized.
+func SearchTimestamp(rl Reader, t time.Time) (uint64, error) {
+	var err error
+	number := sort.Search(int(rl.Height()), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var timestamp time.Time
+		timestamp, err = BlockTimestamp(rl, uint64(i))
+		return err != nil || !timestamp.Before(t)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(number), nil
+}
+
+// BlockTimestamp returns the timestamp of the given block of the ledger
+func BlockTimestamp(rl Reader, index uint64) (time.Time, error) {
+	block := GetBlock(rl, index)
+	if block == nil {
+		return time.Time{}, errors.Errorf("block [%d] is not available", index)
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return time.Time{}, errors.WithMessage(err, fmt.Sprintf("failed extracting the first transaction of block [%d]", index))
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return time.Time{}, errors.WithMessage(err, fmt.Sprintf("failed extracting the channel header of block [%d]", index))
+	}
+	timestamp, err := ptypes.Timestamp(chdr.Timestamp)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "block [%d] has no valid timestamp", index)
+	}
+	return timestamp, nil
+}
@@ -40,6 +40,8 @@ const (
 // Conf configuration for `DB`
 type Conf struct {
 	DBPath string
+	// ReadOnly opens an existing db without ever writing to it
+	ReadOnly bool
 }
 
 // DB - a wrapper on an actual store
@@ -79,11 +81,16 @@ func (dbInst *DB) Open() {
 	dbOpts := &opt.Options{}
 	dbPath := dbInst.conf.DBPath
 	var err error
-	var dirEmpty bool
-	if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
-		panic(fmt.Sprintf("Error while trying to create dir if missing: %s", err))
+	if dbInst.conf.ReadOnly {
+		dbOpts.ReadOnly = true
+		dbOpts.ErrorIfMissing = true
+	} else {
+		var dirEmpty bool
+		if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
+			panic(fmt.Sprintf("Error while trying to create dir if missing: %s", err))
+		}
+		dbOpts.ErrorIfMissing = !dirEmpty
 	}
-	dbOpts.ErrorIfMissing = !dirEmpty
 	if dbInst.db, err = leveldb.OpenFile(dbPath, dbOpts); err != nil {
 		panic(fmt.Sprintf("Error while trying to open DB: %s", err))
 	}
@@ -112,7 +112,7 @@ func TestLevelDBHelper(t *testing.T) {
 func TestCreateDBInEmptyDir(t *testing.T) {
 	testutil.AssertNoError(t, os.RemoveAll(testDBPath), "")
 	testutil.AssertNoError(t, os.MkdirAll(testDBPath, 0775), "")
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r != nil {
@@ -128,7 +128,7 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	file, err := os.Create(filepath.Join(testDBPath, "dummyfile.txt"))
 	testutil.AssertNoError(t, err, "")
 	file.Close()
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r == nil {
@@ -137,3 +137,33 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestReadOnlyDB(t *testing.T) {
+	testutil.AssertNoError(t, os.RemoveAll(testDBPath), "")
+	defer os.RemoveAll(testDBPath)
+
+	// a read only db is never created
+	readOnlyDB := CreateDB(&Conf{DBPath: testDBPath, ReadOnly: true})
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatalf("A panic is expected when opening a missing db read only")
+			}
+		}()
+		readOnlyDB.Open()
+	}()
+	_, err := os.Stat(testDBPath)
+	testutil.AssertEquals(t, os.IsNotExist(err), true)
+
+	db := CreateDB(&Conf{DBPath: testDBPath})
+	db.Open()
+	testutil.AssertNoError(t, db.Put([]byte("key1"), []byte("value1"), true), "")
+	db.Close()
+
+	readOnlyDB.Open()
+	defer readOnlyDB.Close()
+	val, err := readOnlyDB.Get([]byte("key1"))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, val, []byte("value1"))
+	testutil.AssertError(t, readOnlyDB.Put([]byte("key2"), []byte("value2"), true), "Writing to a read only db should fail")
+}
@@ -82,6 +82,15 @@ func Init(opts Opts) (err error) {
 	return
 }
 
+// GetRootScope returns the root metrics scope, or a scope which
+// discards all metrics if the metrics server hasn't been initialized
+func GetRootScope() Scope {
+	if RootScope == nil {
+		return newNoOpScope()
+	}
+	return RootScope
+}
+
 //Start starts metrics server
 func Start() error {
 	if atomic.CompareAndSwapUint32(&started, 0, 1) {
@@ -192,6 +192,13 @@ func TestNoOpScopeMetrics(t *testing.T) {
 	tagSubScope.Gauge("bar").Update(1.33)
 }
 
+func TestGetRootScope(t *testing.T) {
+	t.Parallel()
+	s := GetRootScope()
+	assert.NotNil(t, s)
+	s.SubScope("test").Counter("foo").Inc(1)
+}
+
 func TestNewOpts(t *testing.T) {
 	t.Parallel()
 	defer viper.Reset()
@@ -17,14 +17,24 @@ import (
 type Orderer struct {
 	// ConsensusTypeVal is returned as the result of ConsensusType()
 	ConsensusTypeVal string
+	// ConsensusMetadataVal is returned as the result of ConsensusMetadata()
+	ConsensusMetadataVal []byte
+	// ConsensusStateVal is returned as the result of ConsensusState()
+	ConsensusStateVal ab.ConsensusType_State
 	// BatchSizeVal is returned as the result of BatchSize()
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
 	BatchTimeoutVal time.Duration
+	// ClassBatchTimeoutsVal is returned as the result of ClassBatchTimeouts()
+	ClassBatchTimeoutsVal map[string]time.Duration
 	// KafkaBrokersVal is returned as the result of KafkaBrokers()
 	KafkaBrokersVal []string
 	// MaxChannelsCountVal is returns as the result of MaxChannelsCount()
 	MaxChannelsCountVal uint64
+	// RateLimitsVal is returned as the result of RateLimits()
+	RateLimitsVal *ab.RateLimits
+	// AdmissionRulesVal is returned as the result of AdmissionRules()
+	AdmissionRulesVal *ab.AdmissionRules
 	// OrganizationsVal is returned as the result of Organizations()
 	OrganizationsVal map[string]channelconfig.Org
 	// CapabilitiesVal is returned as the result of Capabilities()
@@ -36,6 +46,16 @@ func (scm *Orderer) ConsensusType() string {
 	return scm.ConsensusTypeVal
 }
 
+// ConsensusMetadata returns the ConsensusMetadataVal
+func (scm *Orderer) ConsensusMetadata() []byte {
+	return scm.ConsensusMetadataVal
+}
+
+// ConsensusState returns the ConsensusStateVal
+func (scm *Orderer) ConsensusState() ab.ConsensusType_State {
+	return scm.ConsensusStateVal
+}
+
 // BatchSize returns the BatchSizeVal
 func (scm *Orderer) BatchSize() *ab.BatchSize {
 	return scm.BatchSizeVal
@@ -46,6 +66,11 @@ func (scm *Orderer) BatchTimeout() time.Duration {
 	return scm.BatchTimeoutVal
 }
 
+// ClassBatchTimeouts returns the ClassBatchTimeoutsVal
+func (scm *Orderer) ClassBatchTimeouts() map[string]time.Duration {
+	return scm.ClassBatchTimeoutsVal
+}
+
 // KafkaBrokers returns the KafkaBrokersVal
 func (scm *Orderer) KafkaBrokers() []string {
 	return scm.KafkaBrokersVal
@@ -56,6 +81,16 @@ func (scm *Orderer) MaxChannelsCount() uint64 {
 	return scm.MaxChannelsCountVal
 }
 
+// RateLimits returns the RateLimitsVal
+func (scm *Orderer) RateLimits() *ab.RateLimits {
+	return scm.RateLimitsVal
+}
+
+// AdmissionRules returns the AdmissionRulesVal
+func (scm *Orderer) AdmissionRules() *ab.AdmissionRules {
+	return scm.AdmissionRulesVal
+}
+
 // Organizations returns OrganizationsVal
 func (scm *Orderer) Organizations() map[string]channelconfig.Org {
 	return scm.OrganizationsVal
@@ -79,6 +114,12 @@ type OrdererCapabilities struct {
 
 	// ExpirationVal is returned by ExpirationCheck()
 	ExpirationVal bool
+
+	// ConsensusTypeMigrationVal is returned by ConsensusTypeMigration()
+	ConsensusTypeMigrationVal bool
+
+	// BatchClassesVal is returned by BatchClasses()
+	BatchClassesVal bool
 }
 
 // Supported returns SupportedErr
@@ -101,3 +142,13 @@ func (oc *OrdererCapabilities) Resubmission() bool {
 func (oc *OrdererCapabilities) ExpirationCheck() bool {
 	return oc.ExpirationVal
 }
+
+// ConsensusTypeMigration returns ConsensusTypeMigrationVal
+func (oc *OrdererCapabilities) ConsensusTypeMigration() bool {
+	return oc.ConsensusTypeMigrationVal
+}
+
+// BatchClasses returns BatchClassesVal
+func (oc *OrdererCapabilities) BatchClasses() bool {
+	return oc.BatchClassesVal
+}
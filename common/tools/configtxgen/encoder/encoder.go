@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 
@@ -158,6 +159,25 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		addValue(ordererGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
 
+	if conf.RateLimits != nil {
+		addValue(ordererGroup, channelconfig.RateLimitsValue(&ab.RateLimits{
+			Channel:      rateLimit(conf.RateLimits.Channel),
+			Organization: rateLimit(conf.RateLimits.Organization),
+			Client:       rateLimit(conf.RateLimits.Client),
+		}), channelconfig.AdminsPolicyKey)
+	}
+
+	if len(conf.AdmissionRules) > 0 {
+		admissionRules := &ab.AdmissionRules{}
+		for _, rule := range conf.AdmissionRules {
+			admissionRules.Rules = append(admissionRules.Rules, &ab.AdmissionRule{
+				Name:    rule.Name,
+				Options: rule.Options,
+			})
+		}
+		addValue(ordererGroup, channelconfig.AdmissionRulesValue(admissionRules), channelconfig.AdminsPolicyKey)
+	}
+
 	switch conf.OrdererType {
 	case ConsensusTypeSolo:
 	case ConsensusTypeKafka:
@@ -178,6 +198,17 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	return ordererGroup, nil
 }
 
+// rateLimit converts a rate limit of the profile, leaving it unset if the profile does not set it
+func rateLimit(conf *genesisconfig.RateLimit) *ab.RateLimit {
+	if conf == nil {
+		return nil
+	}
+	return &ab.RateLimit{
+		MessagesPerSecond: conf.MessagesPerSecond,
+		Burst:             conf.Burst,
+	}
+}
+
 // NewOrdererOrgGroup returns an orderer org component of the channel configuration.  It defines the crypto material for the
 // organization (its MSP).  It sets the mod_policy of all elements to "Admins".
 func NewOrdererOrgGroup(conf *genesisconfig.Organization) (*cb.ConfigGroup, error) {
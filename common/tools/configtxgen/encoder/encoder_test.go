@@ -17,6 +17,7 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 
@@ -263,6 +264,44 @@ func TestNewOrdererGroup(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, group)
 	})
+
+	t.Run("Rate limits", func(t *testing.T) {
+		config := genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)
+		group, err := NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		assert.NotContains(t, group.Values, channelconfig.RateLimitsKey)
+
+		config.Orderer.RateLimits = &genesisconfig.RateLimits{
+			Client: &genesisconfig.RateLimit{MessagesPerSecond: 10, Burst: 20},
+		}
+		group, err = NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		rateLimits := &ab.RateLimits{}
+		assert.NoError(t, proto.Unmarshal(group.Values[channelconfig.RateLimitsKey].Value, rateLimits))
+		assert.Nil(t, rateLimits.Channel)
+		assert.Nil(t, rateLimits.Organization)
+		assert.Equal(t, &ab.RateLimit{MessagesPerSecond: 10, Burst: 20}, rateLimits.Client)
+	})
+
+	t.Run("Admission rules", func(t *testing.T) {
+		config := genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)
+		group, err := NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		assert.NotContains(t, group.Values, channelconfig.AdmissionRulesKey)
+
+		config.Orderer.AdmissionRules = []*genesisconfig.AdmissionRule{
+			{Name: "RevokedCreator"},
+			{Name: "MaxReadWriteSetSize", Options: map[string]string{"maxbytes": "1048576"}},
+		}
+		group, err = NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		admissionRules := &ab.AdmissionRules{}
+		assert.NoError(t, proto.Unmarshal(group.Values[channelconfig.AdmissionRulesKey].Value, admissionRules))
+		assert.Equal(t, []*ab.AdmissionRule{
+			{Name: "RevokedCreator"},
+			{Name: "MaxReadWriteSetSize", Options: map[string]string{"maxbytes": "1048576"}},
+		}, admissionRules.Rules)
+	})
 }
 
 func TestBootstrapper(t *testing.T) {
@@ -137,14 +137,36 @@ type AnchorPeer struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string          `yaml:"OrdererType"`
-	Addresses     []string        `yaml:"Addresses"`
-	BatchTimeout  time.Duration   `yaml:"BatchTimeout"`
-	BatchSize     BatchSize       `yaml:"BatchSize"`
-	Kafka         Kafka           `yaml:"Kafka"`
-	Organizations []*Organization `yaml:"Organizations"`
-	MaxChannels   uint64          `yaml:"MaxChannels"`
-	Capabilities  map[string]bool `yaml:"Capabilities"`
+	OrdererType    string           `yaml:"OrdererType"`
+	Addresses      []string         `yaml:"Addresses"`
+	BatchTimeout   time.Duration    `yaml:"BatchTimeout"`
+	BatchSize      BatchSize        `yaml:"BatchSize"`
+	Kafka          Kafka            `yaml:"Kafka"`
+	Organizations  []*Organization  `yaml:"Organizations"`
+	MaxChannels    uint64           `yaml:"MaxChannels"`
+	Capabilities   map[string]bool  `yaml:"Capabilities"`
+	RateLimits     *RateLimits      `yaml:"RateLimits"`
+	AdmissionRules []*AdmissionRule `yaml:"AdmissionRules"`
+}
+
+// AdmissionRule selects a rule of the orderer along with its options.
+type AdmissionRule struct {
+	Name    string            `yaml:"Name"`
+	Options map[string]string `yaml:"Options"`
+}
+
+// RateLimits contains the broadcast rate limits of a channel overriding those
+// of the orderer local configuration.
+type RateLimits struct {
+	Channel      *RateLimit `yaml:"Channel"`
+	Organization *RateLimit `yaml:"Organization"`
+	Client       *RateLimit `yaml:"Client"`
+}
+
+// RateLimit contains configuration of a token bucket limit.
+type RateLimit struct {
+	MessagesPerSecond uint32 `yaml:"MessagesPerSecond"`
+	Burst             uint32 `yaml:"Burst"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
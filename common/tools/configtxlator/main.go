@@ -17,17 +17,22 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"reflect"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/operations"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/simulator"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/common/tools/protolator"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/op/go-logging"
@@ -61,6 +66,47 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	addOrg      = app.Command("add_org", "Computes the config update which adds an organization to the application group of a channel.")
+	addOrgBlock = addOrg.Flag("config_block", "The current config block of the channel.").Required().File()
+	addOrgName  = addOrg.Flag("name", "The name of the organization.").Required().String()
+	addOrgDef   = addOrg.Flag("org", "A file containing the JSON definition of the organization, as printed by configtxgen -printOrg.").Required().File()
+	addOrgDest  = addOrg.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	setAnchorPeers      = app.Command("set_anchor_peers", "Computes the config update which sets the anchor peers of an application organization.")
+	setAnchorPeersBlock = setAnchorPeers.Flag("config_block", "The current config block of the channel.").Required().File()
+	setAnchorPeersOrg   = setAnchorPeers.Flag("org", "The name of the organization.").Required().String()
+	setAnchorPeersPeers = setAnchorPeers.Flag("anchor_peer", "An anchor peer of the form host:port, may be repeated.").Strings()
+	setAnchorPeersDest  = setAnchorPeers.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	setBatchSize                  = app.Command("set_batch_size", "Computes the config update which changes the batch size of the ordering service.")
+	setBatchSizeBlock             = setBatchSize.Flag("config_block", "The current config block of the channel.").Required().File()
+	setBatchSizeMaxMessageCount   = setBatchSize.Flag("max_message_count", "The maximum number of messages in a batch, 0 keeps the current value.").Uint32()
+	setBatchSizeAbsoluteMaxBytes  = setBatchSize.Flag("absolute_max_bytes", "The absolute maximum number of bytes in a batch, 0 keeps the current value.").Uint32()
+	setBatchSizePreferredMaxBytes = setBatchSize.Flag("preferred_max_bytes", "The preferred maximum number of bytes in a batch, 0 keeps the current value.").Uint32()
+	setBatchSizeDest              = setBatchSize.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	setBatchTimeout        = app.Command("set_batch_timeout", "Computes the config update which changes the batch timeout of the ordering service.")
+	setBatchTimeoutBlock   = setBatchTimeout.Flag("config_block", "The current config block of the channel.").Required().File()
+	setBatchTimeoutTimeout = setBatchTimeout.Flag("timeout", "The batch timeout, for example '2s'.").Required().String()
+	setBatchTimeoutDest    = setBatchTimeout.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	addOrdererAddress        = app.Command("add_orderer_address", "Computes the config update which adds an orderer endpoint to a channel.")
+	addOrdererAddressBlock   = addOrdererAddress.Flag("config_block", "The current config block of the channel.").Required().File()
+	addOrdererAddressAddress = addOrdererAddress.Flag("address", "The orderer endpoint of the form host:port.").Required().String()
+	addOrdererAddressDest    = addOrdererAddress.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	setMSPRootCerts             = app.Command("set_msp_root_certs", "Computes the config update which replaces the root CA certificates of an organization's MSP.")
+	setMSPRootCertsBlock        = setMSPRootCerts.Flag("config_block", "The current config block of the channel.").Required().File()
+	setMSPRootCertsOrg          = setMSPRootCerts.Flag("org", "The name of the organization.").Required().String()
+	setMSPRootCertsRoots        = setMSPRootCerts.Flag("root_cert", "A PEM file containing a root CA certificate, may be repeated.").Required().ExistingFiles()
+	setMSPRootCertsIntermediate = setMSPRootCerts.Flag("intermediate_cert", "A PEM file containing an intermediate CA certificate, may be repeated.").ExistingFiles()
+	setMSPRootCertsDest         = setMSPRootCerts.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	simulateUpdate       = app.Command("simulate_update", "Evaluates a signed config update against the current config of a channel and reports whether the ordering service would accept it.")
+	simulateUpdateBlock  = simulateUpdate.Flag("config_block", "The current config block of the channel.").Required().File()
+	simulateUpdateUpdate = simulateUpdate.Flag("update", "The signed config update envelope.").Required().File()
+	simulateUpdateDest   = simulateUpdate.Flag("output", "A file to write the JSON report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -93,6 +139,70 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case addOrg.FullCommand():
+		defer (*addOrgBlock).Close()
+		defer (*addOrgDef).Close()
+		defer (*addOrgDest).Close()
+		group, err := operations.DecodeOrg(*addOrgDef)
+		if err != nil {
+			app.Fatalf("Error reading organization: %s", err)
+		}
+		computeOperation(*addOrgBlock, *addOrgDest, &operations.AddOrg{Name: *addOrgName, Group: group})
+	case setAnchorPeers.FullCommand():
+		defer (*setAnchorPeersBlock).Close()
+		defer (*setAnchorPeersDest).Close()
+		var anchorPeers []*pb.AnchorPeer
+		for _, address := range *setAnchorPeersPeers {
+			anchorPeer, err := operations.ParseAnchorPeer(address)
+			if err != nil {
+				app.Fatalf("Error parsing anchor peer: %s", err)
+			}
+			anchorPeers = append(anchorPeers, anchorPeer)
+		}
+		computeOperation(*setAnchorPeersBlock, *setAnchorPeersDest, &operations.SetAnchorPeers{Org: *setAnchorPeersOrg, AnchorPeers: anchorPeers})
+	case setBatchSize.FullCommand():
+		defer (*setBatchSizeBlock).Close()
+		defer (*setBatchSizeDest).Close()
+		computeOperation(*setBatchSizeBlock, *setBatchSizeDest, &operations.SetBatchSize{
+			MaxMessageCount:   *setBatchSizeMaxMessageCount,
+			AbsoluteMaxBytes:  *setBatchSizeAbsoluteMaxBytes,
+			PreferredMaxBytes: *setBatchSizePreferredMaxBytes,
+		})
+	case setBatchTimeout.FullCommand():
+		defer (*setBatchTimeoutBlock).Close()
+		defer (*setBatchTimeoutDest).Close()
+		computeOperation(*setBatchTimeoutBlock, *setBatchTimeoutDest, &operations.SetBatchTimeout{Timeout: *setBatchTimeoutTimeout})
+	case addOrdererAddress.FullCommand():
+		defer (*addOrdererAddressBlock).Close()
+		defer (*addOrdererAddressDest).Close()
+		computeOperation(*addOrdererAddressBlock, *addOrdererAddressDest, &operations.AddOrdererAddress{Address: *addOrdererAddressAddress})
+	case setMSPRootCerts.FullCommand():
+		defer (*setMSPRootCertsBlock).Close()
+		defer (*setMSPRootCertsDest).Close()
+		rootCerts, err := readFiles(*setMSPRootCertsRoots)
+		if err != nil {
+			app.Fatalf("Error reading root certificates: %s", err)
+		}
+		intermediateCerts, err := readFiles(*setMSPRootCertsIntermediate)
+		if err != nil {
+			app.Fatalf("Error reading intermediate certificates: %s", err)
+		}
+		computeOperation(*setMSPRootCertsBlock, *setMSPRootCertsDest, &operations.SetMSPRootCerts{
+			Org:               *setMSPRootCertsOrg,
+			RootCerts:         rootCerts,
+			IntermediateCerts: intermediateCerts,
+		})
+	case simulateUpdate.FullCommand():
+		defer (*simulateUpdateBlock).Close()
+		defer (*simulateUpdateUpdate).Close()
+		defer (*simulateUpdateDest).Close()
+		accepted, err := simulateUpdt(*simulateUpdateBlock, *simulateUpdateUpdate, *simulateUpdateDest)
+		if err != nil {
+			app.Fatalf("Error simulating update: %s", err)
+		}
+		if !accepted {
+			os.Exit(1)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -101,6 +211,7 @@ func main() {
 }
 
 func startServer(address string) {
+	factory.InitFactories(nil)
 	logger.Infof("Serving HTTP requests on %s", address)
 	err := http.ListenAndServe(address, rest.NewRouter())
 
@@ -203,3 +314,95 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func computeOperation(configBlock, output *os.File, op operations.Operation) {
+	err := computeUpdtFromOperation(configBlock, output, op)
+	if err != nil {
+		app.Fatalf("Error computing update: %s", err)
+	}
+}
+
+func computeUpdtFromOperation(configBlock, output *os.File, op operations.Operation) error {
+	blockIn, err := ioutil.ReadAll(configBlock)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(blockIn, block)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling config block")
+	}
+
+	factory.InitFactories(nil)
+	env, err := operations.Compute(block, op)
+	if err != nil {
+		return err
+	}
+
+	outBytes, err := proto.Marshal(env)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling config update envelope")
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config update envelope to output")
+	}
+
+	return nil
+}
+
+func readFiles(paths []string) ([][]byte, error) {
+	var contents [][]byte
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading %s", path)
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+func simulateUpdt(configBlock, update, output *os.File) (bool, error) {
+	blockIn, err := ioutil.ReadAll(configBlock)
+	if err != nil {
+		return false, errors.Wrapf(err, "error reading config block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(blockIn, block)
+	if err != nil {
+		return false, errors.Wrapf(err, "error unmarshaling config block")
+	}
+
+	updateIn, err := ioutil.ReadAll(update)
+	if err != nil {
+		return false, errors.Wrapf(err, "error reading config update")
+	}
+
+	env := &cb.Envelope{}
+	err = proto.Unmarshal(updateIn, env)
+	if err != nil {
+		return false, errors.Wrapf(err, "error unmarshaling config update")
+	}
+
+	factory.InitFactories(nil)
+	report, err := simulator.Simulate(block, env)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return false, errors.Wrapf(err, "error marshaling report")
+	}
+
+	_, err = output.Write(append(out, '\n'))
+	if err != nil {
+		return false, errors.Wrapf(err, "error writing report to output")
+	}
+
+	return report.Accepted, nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operations
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Operation is a typed modification of a channel config
+type Operation interface {
+	// Apply modifies the given config in place
+	Apply(config *cb.Config) error
+}
+
+// AddOrg adds an organization definition, such as the one printed
+// by configtxgen -printOrg, to the application group of the channel
+type AddOrg struct {
+	Name  string
+	Group *cb.ConfigGroup
+}
+
+// Apply adds the organization to the config
+func (ao *AddOrg) Apply(config *cb.Config) error {
+	if ao.Name == "" {
+		return errors.New("organization name must be specified")
+	}
+	if ao.Group == nil {
+		return errors.New("organization definition must be specified")
+	}
+	application, err := subGroup(config.ChannelGroup, channelconfig.ApplicationGroupKey)
+	if err != nil {
+		return err
+	}
+	if _, exists := application.Groups[ao.Name]; exists {
+		return errors.Errorf("organization %s already exists in the channel", ao.Name)
+	}
+	if application.Groups == nil {
+		application.Groups = make(map[string]*cb.ConfigGroup)
+	}
+	application.Groups[ao.Name] = ao.Group
+	return nil
+}
+
+// SetAnchorPeers replaces the anchor peers of an application organization
+type SetAnchorPeers struct {
+	Org         string
+	AnchorPeers []*pb.AnchorPeer
+}
+
+// Apply sets the anchor peers of the organization in the config
+func (sap *SetAnchorPeers) Apply(config *cb.Config) error {
+	org, err := subGroup(config.ChannelGroup, channelconfig.ApplicationGroupKey, sap.Org)
+	if err != nil {
+		return err
+	}
+	setValue(org, channelconfig.AnchorPeersValue(sap.AnchorPeers))
+	return nil
+}
+
+// SetBatchSize changes the batch size parameters of the ordering service,
+// parameters which are zero keep their current value
+type SetBatchSize struct {
+	MaxMessageCount   uint32
+	AbsoluteMaxBytes  uint32
+	PreferredMaxBytes uint32
+}
+
+// Apply sets the batch size in the config
+func (sbs *SetBatchSize) Apply(config *cb.Config) error {
+	orderer, err := subGroup(config.ChannelGroup, channelconfig.OrdererGroupKey)
+	if err != nil {
+		return err
+	}
+	batchSize := &ab.BatchSize{}
+	if err := getValue(orderer, channelconfig.BatchSizeKey, batchSize); err != nil {
+		return err
+	}
+	if sbs.MaxMessageCount != 0 {
+		batchSize.MaxMessageCount = sbs.MaxMessageCount
+	}
+	if sbs.AbsoluteMaxBytes != 0 {
+		batchSize.AbsoluteMaxBytes = sbs.AbsoluteMaxBytes
+	}
+	if sbs.PreferredMaxBytes != 0 {
+		batchSize.PreferredMaxBytes = sbs.PreferredMaxBytes
+	}
+	setValue(orderer, channelconfig.BatchSizeValue(batchSize.MaxMessageCount, batchSize.AbsoluteMaxBytes, batchSize.PreferredMaxBytes))
+	return nil
+}
+
+// SetBatchTimeout changes the batch timeout of the ordering service
+type SetBatchTimeout struct {
+	Timeout string
+}
+
+// Apply sets the batch timeout in the config
+func (sbt *SetBatchTimeout) Apply(config *cb.Config) error {
+	if _, err := time.ParseDuration(sbt.Timeout); err != nil {
+		return errors.Wrapf(err, "invalid batch timeout %s", sbt.Timeout)
+	}
+	orderer, err := subGroup(config.ChannelGroup, channelconfig.OrdererGroupKey)
+	if err != nil {
+		return err
+	}
+	setValue(orderer, channelconfig.BatchTimeoutValue(sbt.Timeout))
+	return nil
+}
+
+// AddOrdererAddress adds an endpoint to the orderer addresses of the channel
+type AddOrdererAddress struct {
+	Address string
+}
+
+// Apply adds the orderer address to the config
+func (aoa *AddOrdererAddress) Apply(config *cb.Config) error {
+	if _, _, err := net.SplitHostPort(aoa.Address); err != nil {
+		return errors.Wrapf(err, "invalid orderer address %s", aoa.Address)
+	}
+	addresses := &cb.OrdererAddresses{}
+	if err := getValue(config.ChannelGroup, channelconfig.OrdererAddressesKey, addresses); err != nil {
+		return err
+	}
+	for _, address := range addresses.Addresses {
+		if address == aoa.Address {
+			return errors.Errorf("orderer address %s already exists in the channel", aoa.Address)
+		}
+	}
+	setValue(config.ChannelGroup, channelconfig.OrdererAddressesValue(append(addresses.Addresses, aoa.Address)))
+	return nil
+}
+
+// SetMSPRootCerts replaces the root and intermediate CA certificates of the MSP of
+// an organization, in every group of the channel config which defines it.
+// To rotate a root CA, first set both the current and the new root certificates, and
+// remove the current one after all the certificates of the organization were reissued.
+// If IntermediateCerts is nil, the current intermediate certificates are kept.
+type SetMSPRootCerts struct {
+	Org               string
+	RootCerts         [][]byte
+	IntermediateCerts [][]byte
+}
+
+// Apply sets the root certificates of the MSP in the config
+func (smrc *SetMSPRootCerts) Apply(config *cb.Config) error {
+	if len(smrc.RootCerts) == 0 {
+		return errors.New("at least one root certificate must be specified")
+	}
+	orgs := orgGroups(config.ChannelGroup, smrc.Org)
+	if len(orgs) == 0 {
+		return errors.Errorf("organization %s not found in the channel config", smrc.Org)
+	}
+	for _, org := range orgs {
+		mspConfig := &mspprotos.MSPConfig{}
+		if err := getValue(org, channelconfig.MSPKey, mspConfig); err != nil {
+			return err
+		}
+		fabricMSPConfig := &mspprotos.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+			return errors.Wrapf(err, "failed unmarshaling MSP config of organization %s", smrc.Org)
+		}
+		fabricMSPConfig.RootCerts = smrc.RootCerts
+		if smrc.IntermediateCerts != nil {
+			fabricMSPConfig.IntermediateCerts = smrc.IntermediateCerts
+		}
+		mspConfig.Config = utils.MarshalOrPanic(fabricMSPConfig)
+		setValue(org, channelconfig.MSPValue(mspConfig))
+	}
+	return nil
+}
+
+// DecodeOrg decodes the JSON representation of an organization group,
+// as printed by configtxgen -printOrg
+func DecodeOrg(r io.Reader) (*cb.ConfigGroup, error) {
+	group := &pb.DynamicApplicationOrgGroup{ConfigGroup: &cb.ConfigGroup{}}
+	if err := protolator.DeepUnmarshalJSON(r, group); err != nil {
+		return nil, errors.Wrap(err, "failed decoding organization definition")
+	}
+	return group.ConfigGroup, nil
+}
+
+// ParseAnchorPeer parses an anchor peer of the form host:port
+func ParseAnchorPeer(address string) (*pb.AnchorPeer, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid anchor peer %s", address)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid port of anchor peer %s", address)
+	}
+	return &pb.AnchorPeer{Host: host, Port: int32(port)}, nil
+}
+
+// subGroup returns the group at the given path below the given group
+func subGroup(group *cb.ConfigGroup, path ...string) (*cb.ConfigGroup, error) {
+	for i, name := range path {
+		next, ok := group.Groups[name]
+		if !ok {
+			return nil, errors.Errorf("config group %v not found", path[:i+1])
+		}
+		group = next
+	}
+	return group, nil
+}
+
+// orgGroups returns all groups below the given group which define
+// the MSP of the organization with the given name
+func orgGroups(group *cb.ConfigGroup, name string) []*cb.ConfigGroup {
+	var orgs []*cb.ConfigGroup
+	for key, sub := range group.Groups {
+		if _, hasMSP := sub.Values[channelconfig.MSPKey]; key == name && hasMSP {
+			orgs = append(orgs, sub)
+			continue
+		}
+		orgs = append(orgs, orgGroups(sub, name)...)
+	}
+	return orgs
+}
+
+// getValue unmarshals the value with the given key of the group into msg
+func getValue(group *cb.ConfigGroup, key string, msg proto.Message) error {
+	value, ok := group.Values[key]
+	if !ok {
+		return errors.Errorf("config value %s not found", key)
+	}
+	if err := proto.Unmarshal(value.Value, msg); err != nil {
+		return errors.Wrapf(err, "failed unmarshaling config value %s", key)
+	}
+	return nil
+}
+
+// setValue sets the value in the group, keeping its mod policy if it already exists
+func setValue(group *cb.ConfigGroup, value channelconfig.ConfigValue) {
+	modPolicy := channelconfig.AdminsPolicyKey
+	if existing, ok := group.Values[value.Key()]; ok {
+		modPolicy = existing.ModPolicy
+	}
+	if group.Values == nil {
+		group.Values = make(map[string]*cb.ConfigValue)
+	}
+	group.Values[value.Key()] = &cb.ConfigValue{
+		Value:     utils.MarshalOrPanic(value.Value()),
+		ModPolicy: modPolicy,
+	}
+}
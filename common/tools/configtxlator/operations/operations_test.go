@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operations
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChannelID = "foo"
+
+var (
+	profile     *genesisconfig.Profile
+	configBlock *cb.Block
+)
+
+func init() {
+	factory.InitFactories(nil)
+	profile = genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)
+	configBlock = encoder.New(profile).GenesisBlockForChannel(testChannelID)
+}
+
+// computeAndApply computes the update envelope for the operations and returns
+// the updated config which the operations resulted in
+func computeAndApply(t *testing.T, ops ...Operation) *cb.Config {
+	env, err := Compute(configBlock, ops...)
+	assert.NoError(t, err)
+
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	assert.Equal(t, testChannelID, chdr.ChannelId)
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	assert.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+	configUpdate := &cb.ConfigUpdate{}
+	assert.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate))
+	assert.Equal(t, testChannelID, configUpdate.ChannelId)
+	assert.Empty(t, configUpdateEnv.Signatures)
+
+	_, config, err := ConfigFromBlock(configBlock)
+	assert.NoError(t, err)
+	updated := proto.Clone(config).(*cb.Config)
+	for _, op := range ops {
+		assert.NoError(t, op.Apply(updated))
+	}
+	return updated
+}
+
+func TestConfigFromBlock(t *testing.T) {
+	channelID, config, err := ConfigFromBlock(configBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, testChannelID, channelID)
+	assert.NotNil(t, config.ChannelGroup.Groups[channelconfig.OrdererGroupKey])
+
+	_, _, err = ConfigFromBlock(&cb.Block{})
+	assert.EqualError(t, err, "config block is empty")
+}
+
+func TestComputeNoOperations(t *testing.T) {
+	_, err := Compute(configBlock)
+	assert.EqualError(t, err, "no operations specified")
+}
+
+func TestAddOrg(t *testing.T) {
+	org := *profile.Application.Organizations[0]
+	org.Name = "Org2"
+	org.ID = "Org2MSP"
+	org.AnchorPeers = nil
+	group, err := encoder.NewApplicationOrgGroup(&org)
+	assert.NoError(t, err)
+
+	// The organization definition round trips through its JSON representation
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, protolator.DeepMarshalJSON(buffer, &pb.DynamicApplicationOrgGroup{ConfigGroup: group}))
+	decoded, err := DecodeOrg(buffer)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(group, decoded))
+
+	updated := computeAndApply(t, &AddOrg{Name: "Org2", Group: group})
+	assert.Contains(t, updated.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups, "Org2")
+
+	_, err = Compute(configBlock, &AddOrg{Name: profile.Application.Organizations[0].Name, Group: group})
+	assert.Contains(t, err.Error(), "already exists in the channel")
+}
+
+func TestSetAnchorPeers(t *testing.T) {
+	anchorPeer, err := ParseAnchorPeer("peer0.example.com:7051")
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.AnchorPeer{Host: "peer0.example.com", Port: 7051}, anchorPeer)
+
+	orgName := profile.Application.Organizations[0].Name
+	updated := computeAndApply(t, &SetAnchorPeers{Org: orgName, AnchorPeers: []*pb.AnchorPeer{anchorPeer}})
+	anchorPeers := &pb.AnchorPeers{}
+	assert.NoError(t, getValue(updated.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups[orgName], channelconfig.AnchorPeersKey, anchorPeers))
+	assert.Equal(t, []*pb.AnchorPeer{anchorPeer}, anchorPeers.AnchorPeers)
+
+	_, err = ParseAnchorPeer("peer0.example.com")
+	assert.Error(t, err)
+	_, err = ParseAnchorPeer("peer0.example.com:port")
+	assert.Error(t, err)
+
+	_, err = Compute(configBlock, &SetAnchorPeers{Org: "NoSuchOrg"})
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestSetBatchSize(t *testing.T) {
+	updated := computeAndApply(t, &SetBatchSize{MaxMessageCount: 42})
+	batchSize := &ab.BatchSize{}
+	assert.NoError(t, getValue(updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey], channelconfig.BatchSizeKey, batchSize))
+	assert.Equal(t, uint32(42), batchSize.MaxMessageCount)
+	assert.Equal(t, profile.Orderer.BatchSize.AbsoluteMaxBytes, batchSize.AbsoluteMaxBytes)
+	assert.Equal(t, profile.Orderer.BatchSize.PreferredMaxBytes, batchSize.PreferredMaxBytes)
+
+	// The preferred max bytes may not exceed the absolute max bytes
+	_, err := Compute(configBlock, &SetBatchSize{PreferredMaxBytes: profile.Orderer.BatchSize.AbsoluteMaxBytes + 1})
+	assert.Contains(t, err.Error(), "updated config is invalid")
+}
+
+func TestSetBatchTimeout(t *testing.T) {
+	updated := computeAndApply(t, &SetBatchTimeout{Timeout: "5s"})
+	batchTimeout := &ab.BatchTimeout{}
+	assert.NoError(t, getValue(updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey], channelconfig.BatchTimeoutKey, batchTimeout))
+	assert.Equal(t, "5s", batchTimeout.Timeout)
+
+	_, err := Compute(configBlock, &SetBatchTimeout{Timeout: "forever"})
+	assert.Contains(t, err.Error(), "invalid batch timeout")
+}
+
+func TestAddOrdererAddress(t *testing.T) {
+	updated := computeAndApply(t, &AddOrdererAddress{Address: "orderer2.example.com:7050"})
+	addresses := &cb.OrdererAddresses{}
+	assert.NoError(t, getValue(updated.ChannelGroup, channelconfig.OrdererAddressesKey, addresses))
+	assert.Equal(t, append(profile.Orderer.Addresses, "orderer2.example.com:7050"), addresses.Addresses)
+
+	_, err := Compute(configBlock, &AddOrdererAddress{Address: profile.Orderer.Addresses[0]})
+	assert.Contains(t, err.Error(), "already exists in the channel")
+	_, err = Compute(configBlock, &AddOrdererAddress{Address: "orderer2.example.com"})
+	assert.Contains(t, err.Error(), "invalid orderer address")
+}
+
+func TestSetMSPRootCerts(t *testing.T) {
+	orgName := profile.Orderer.Organizations[0].Name
+	mspDir := profile.Orderer.Organizations[0].MSPDir
+	currentRoot, err := ioutil.ReadFile(filepath.Join(mspDir, "cacerts", "cacert.pem"))
+	assert.NoError(t, err)
+	newRoot, err := ioutil.ReadFile(filepath.Join(mspDir, "tlscacerts", "tlsroot.pem"))
+	assert.NoError(t, err)
+
+	updated := computeAndApply(t, &SetMSPRootCerts{Org: orgName, RootCerts: [][]byte{currentRoot, newRoot}})
+	mspConfig := &mspprotos.MSPConfig{}
+	assert.NoError(t, getValue(updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups[orgName], channelconfig.MSPKey, mspConfig))
+	fabricMSPConfig := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspConfig.Config, fabricMSPConfig))
+	assert.Equal(t, [][]byte{currentRoot, newRoot}, fabricMSPConfig.RootCerts)
+
+	// The admin certificates must still chain to one of the root certificates
+	_, err = Compute(configBlock, &SetMSPRootCerts{Org: orgName, RootCerts: [][]byte{newRoot}})
+	assert.Contains(t, err.Error(), "updated config is invalid")
+
+	_, err = Compute(configBlock, &SetMSPRootCerts{Org: orgName})
+	assert.Contains(t, err.Error(), "at least one root certificate must be specified")
+	_, err = Compute(configBlock, &SetMSPRootCerts{Org: "NoSuchOrg", RootCerts: [][]byte{newRoot}})
+	assert.Contains(t, err.Error(), "not found in the channel config")
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operations
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ConfigFromBlock extracts the channel ID and the channel config from a config block
+func ConfigFromBlock(block *cb.Block) (string, *cb.Config, error) {
+	if block == nil || block.Data == nil || len(block.Data.Data) == 0 {
+		return "", nil, errors.New("config block is empty")
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed extracting envelope from block")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed unmarshaling payload")
+	}
+	if payload.Header == nil {
+		return "", nil, errors.New("payload header is missing")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed unmarshaling channel header")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return "", nil, errors.Errorf("block is not a config block, header type is %d", chdr.Type)
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed unmarshaling config envelope")
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return "", nil, errors.New("config envelope does not contain a channel config")
+	}
+	return chdr.ChannelId, configEnv.Config, nil
+}
+
+// Compute applies the operations to the config contained in the given config block,
+// validates the resulting config against the current one and returns an unsigned
+// CONFIG_UPDATE envelope, ready to be signed and submitted to the ordering service
+func Compute(block *cb.Block, ops ...Operation) (*cb.Envelope, error) {
+	if len(ops) == 0 {
+		return nil, errors.New("no operations specified")
+	}
+	channelID, original, err := ConfigFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := proto.Clone(original).(*cb.Config)
+	for _, op := range ops {
+		if err := op.Apply(updated); err != nil {
+			return nil, errors.WithMessage(err, "failed applying operation")
+		}
+	}
+
+	originalBundle, err := channelconfig.NewBundle(channelID, original)
+	if err != nil {
+		return nil, errors.Wrap(err, "current config is invalid")
+	}
+	updatedBundle, err := channelconfig.NewBundle(channelID, updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "updated config is invalid")
+	}
+	if err := originalBundle.ValidateNew(updatedBundle); err != nil {
+		return nil, errors.Wrap(err, "updated config is not a valid transition")
+	}
+
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed computing config update")
+	}
+	configUpdate.ChannelId = channelID
+
+	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, nil, &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, 0, 0)
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/simulator"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	cb "github.com/hyperledger/fabric/protos/common"
 
@@ -123,3 +124,53 @@ func SanityCheckConfig(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(resBytes)
 }
+
+// SimulateConfigUpdate evaluates the signed config update envelope in the 'update' field against
+// the config in the 'config_block' field and responds with a JSON report of the evaluation
+func SimulateConfigUpdate(w http.ResponseWriter, r *http.Request) {
+	blockBytes, err := fieldBytes("config_block", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'config_block': %s\n", err)
+		return
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(blockBytes, block)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error unmarshaling field 'config_block': %s\n", err)
+		return
+	}
+
+	updateBytes, err := fieldBytes("update", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'update': %s\n", err)
+		return
+	}
+
+	update := &cb.Envelope{}
+	err = proto.Unmarshal(updateBytes, update)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error unmarshaling field 'update': %s\n", err)
+		return
+	}
+
+	report, err := simulator.Simulate(block, update)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error simulating update: %s\n", err)
+		return
+	}
+
+	resBytes, err := json.Marshal(report)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error marshaling result to JSON: %s\n", err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(resBytes)
+}
@@ -24,7 +24,12 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/operations"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/simulator"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 
@@ -182,3 +187,43 @@ func TestConfigtxlatorSanityCheckMalformedConfig(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func TestConfigtxlatorSimulateConfigUpdate(t *testing.T) {
+	factory.InitFactories(nil)
+	block := encoder.New(genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)).GenesisBlockForChannel("foo")
+	update, err := operations.Compute(block, &operations.SetBatchTimeout{Timeout: "3s"})
+	assert.NoError(t, err)
+
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	ffw, err := mpw.CreateFormFile("config_block", "config_block.pb")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(utils.MarshalOrPanic(block)).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	ffw, err = mpw.CreateFormFile("update", "update.pb")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(utils.MarshalOrPanic(update)).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	err = mpw.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/configtxlator/config/simulate-update", buffer)
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	r := NewRouter()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	report := &simulator.Report{}
+	err = json.Unmarshal(rec.Body.Bytes(), report)
+	assert.NoError(t, err)
+	assert.False(t, report.Accepted)
+	assert.Len(t, report.Policies, 1)
+	assert.Equal(t, "/Channel/Orderer/Admins", report.Policies[0].Policy)
+}
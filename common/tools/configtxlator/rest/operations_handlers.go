@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/operations"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// defaultMaxMemory is the amount of a multipart form kept in memory, as used by net/http
+const defaultMaxMemory = 32 << 20
+
+// operationParsers build the operation named in the URL from the form fields of the request
+var operationParsers = map[string]func(r *http.Request) (operations.Operation, error){
+	"add-org":             parseAddOrg,
+	"set-anchor-peers":    parseSetAnchorPeers,
+	"set-batch-size":      parseSetBatchSize,
+	"set-batch-timeout":   parseSetBatchTimeout,
+	"add-orderer-address": parseAddOrdererAddress,
+	"set-msp-root-certs":  parseSetMSPRootCerts,
+}
+
+// ComputeUpdateFromOperation applies the operation named in the URL to the config
+// in the 'config_block' field and responds with the unsigned config update envelope
+func ComputeUpdateFromOperation(w http.ResponseWriter, r *http.Request) {
+	opName := mux.Vars(r)["operation"]
+	parse, ok := operationParsers[opName]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "Unknown operation: %s\n", opName)
+		return
+	}
+
+	blockBytes, err := fieldBytes("config_block", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'config_block': %s\n", err)
+		return
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(blockBytes, block)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error unmarshaling field 'config_block': %s\n", err)
+		return
+	}
+
+	op, err := parse(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error parsing operation %s: %s\n", opName, err)
+		return
+	}
+
+	env, err := operations.Compute(block, op)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing update: %s\n", err)
+		return
+	}
+
+	encoded, err := proto.Marshal(env)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error marshaling config update envelope: %s\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
+
+func parseAddOrg(r *http.Request) (operations.Operation, error) {
+	orgBytes, err := fieldBytes("org", r)
+	if err != nil {
+		return nil, fmt.Errorf("error with field 'org': %s", err)
+	}
+	group, err := operations.DecodeOrg(bytes.NewReader(orgBytes))
+	if err != nil {
+		return nil, err
+	}
+	return &operations.AddOrg{Name: r.FormValue("name"), Group: group}, nil
+}
+
+func parseSetAnchorPeers(r *http.Request) (operations.Operation, error) {
+	if err := r.ParseMultipartForm(defaultMaxMemory); err != nil {
+		return nil, err
+	}
+	var anchorPeers []*pb.AnchorPeer
+	for _, address := range r.MultipartForm.Value["anchor_peer"] {
+		anchorPeer, err := operations.ParseAnchorPeer(address)
+		if err != nil {
+			return nil, err
+		}
+		anchorPeers = append(anchorPeers, anchorPeer)
+	}
+	return &operations.SetAnchorPeers{Org: r.FormValue("org"), AnchorPeers: anchorPeers}, nil
+}
+
+func parseSetBatchSize(r *http.Request) (operations.Operation, error) {
+	op := &operations.SetBatchSize{}
+	for field, dest := range map[string]*uint32{
+		"max_message_count":   &op.MaxMessageCount,
+		"absolute_max_bytes":  &op.AbsoluteMaxBytes,
+		"preferred_max_bytes": &op.PreferredMaxBytes,
+	} {
+		value := r.FormValue(field)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("error with field '%s': %s", field, err)
+		}
+		*dest = uint32(parsed)
+	}
+	return op, nil
+}
+
+func parseSetBatchTimeout(r *http.Request) (operations.Operation, error) {
+	return &operations.SetBatchTimeout{Timeout: r.FormValue("timeout")}, nil
+}
+
+func parseAddOrdererAddress(r *http.Request) (operations.Operation, error) {
+	return &operations.AddOrdererAddress{Address: r.FormValue("address")}, nil
+}
+
+func parseSetMSPRootCerts(r *http.Request) (operations.Operation, error) {
+	if err := r.ParseMultipartForm(defaultMaxMemory); err != nil {
+		return nil, err
+	}
+	rootCerts, err := multipartFiles("root_cert", r)
+	if err != nil {
+		return nil, err
+	}
+	intermediateCerts, err := multipartFiles("intermediate_cert", r)
+	if err != nil {
+		return nil, err
+	}
+	return &operations.SetMSPRootCerts{
+		Org:               r.FormValue("org"),
+		RootCerts:         rootCerts,
+		IntermediateCerts: intermediateCerts,
+	}, nil
+}
+
+// multipartFiles reads all the files uploaded under the given field name
+func multipartFiles(fieldName string, r *http.Request) ([][]byte, error) {
+	var contents [][]byte
+	for _, header := range r.MultipartForm.File[fieldName] {
+		file, err := header.Open()
+		if err != nil {
+			return nil, fmt.Errorf("error with field '%s': %s", fieldName, err)
+		}
+		content, err := ioutil.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error with field '%s': %s", fieldName, err)
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
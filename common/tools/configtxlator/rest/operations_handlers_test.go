@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func operationRequest(t *testing.T, operation string, block []byte, fields map[string][]string) *httptest.ResponseRecorder {
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	if block != nil {
+		ffw, err := mpw.CreateFormFile("config_block", "config_block.pb")
+		assert.NoError(t, err)
+		_, err = bytes.NewReader(block).WriteTo(ffw)
+		assert.NoError(t, err)
+	}
+
+	for field, values := range fields {
+		for _, value := range values {
+			assert.NoError(t, mpw.WriteField(field, value))
+		}
+	}
+
+	err := mpw.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/configtxlator/update/"+operation, buffer)
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	r := NewRouter()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestComputeUpdateFromOperation(t *testing.T) {
+	factory.InitFactories(nil)
+	block := utils.MarshalOrPanic(encoder.New(genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)).GenesisBlockForChannel("foo"))
+
+	rec := operationRequest(t, "set-batch-timeout", block, map[string][]string{"timeout": {"3s"}})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	env := &cb.Envelope{}
+	assert.NoError(t, proto.Unmarshal(rec.Body.Bytes(), env))
+	assert.NotEmpty(t, env.Payload)
+
+	rec = operationRequest(t, "set-anchor-peers", block, map[string][]string{
+		"org":         {"SampleOrg"},
+		"anchor_peer": {"peer0.example.com:7051", "peer1.example.com:7051"},
+	})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = operationRequest(t, "set-batch-size", block, map[string][]string{"max_message_count": {"7"}})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = operationRequest(t, "set-batch-size", block, map[string][]string{"max_message_count": {"many"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error parsing operation set-batch-size")
+
+	rec = operationRequest(t, "add-orderer-address", block, map[string][]string{"address": {"orderer"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error computing update")
+
+	rec = operationRequest(t, "set-batch-timeout", nil, map[string][]string{"timeout": {"3s"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error with field 'config_block'")
+
+	rec = operationRequest(t, "remove-channel", block, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -35,6 +35,12 @@ func NewRouter() *mux.Router {
 	router.
 		HandleFunc("/configtxlator/config/verify", SanityCheckConfig).
 		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/config/simulate-update", SimulateConfigUpdate).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/update/{operation}", ComputeUpdateFromOperation).
+		Methods("POST")
 
 	return router
 }
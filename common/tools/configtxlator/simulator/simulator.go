@@ -0,0 +1,263 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/operations"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// Report describes whether a config update would be accepted by the ordering service
+// and, if not, which checks fail and which signatures are still needed
+type Report struct {
+	ChannelID        string          `json:"channel_id"`
+	Accepted         bool            `json:"accepted"`
+	Error            string          `json:"error,omitempty"`
+	Signers          []string        `json:"signers"`
+	VersionErrors    []string        `json:"version_errors,omitempty"`
+	Policies         []*PolicyResult `json:"policies"`
+	CapabilityErrors []string        `json:"capability_errors,omitempty"`
+}
+
+// PolicyResult is the outcome of evaluating the mod_policy of a config element modified by the update
+type PolicyResult struct {
+	Element   string       `json:"element"`
+	Policy    string       `json:"policy"`
+	Satisfied bool         `json:"satisfied"`
+	Error     string       `json:"error,omitempty"`
+	Missing   *Requirement `json:"missing,omitempty"`
+}
+
+// Requirement describes what is still needed to satisfy a policy. Signature policies are
+// satisfied by the signatures of all the principals of any of the SignatureSets, implicit
+// meta policies by satisfying Needed more of their unsatisfied SubPolicies.
+type Requirement struct {
+	Policy        string         `json:"policy"`
+	SignatureSets [][]string     `json:"signature_sets,omitempty"`
+	Needed        int            `json:"needed,omitempty"`
+	SubPolicies   []*Requirement `json:"sub_policies,omitempty"`
+}
+
+// Simulate evaluates the signed config update envelope against the config in the config
+// block, the way the ordering service would when processing it. An error is returned only
+// if the block or the update are malformed, rejections are described by the report.
+func Simulate(block *cb.Block, update *cb.Envelope) (*Report, error) {
+	channelID, config, err := operations.ConfigFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := channelconfig.NewBundle(channelID, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "current config is invalid")
+	}
+
+	validator, err := configtx.NewValidatorImpl(channelID, config, channelconfig.RootGroupKey, bundle.PolicyManager())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating config validator")
+	}
+
+	evaluation, err := validator.EvaluateConfigUpdate(update)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed config update")
+	}
+
+	report := &Report{
+		ChannelID: channelID,
+		Signers:   signers(evaluation.SignedData),
+		Policies:  []*PolicyResult{},
+	}
+	for _, versionErr := range evaluation.VersionErrors {
+		report.VersionErrors = append(report.VersionErrors, versionErr.Error())
+	}
+	for _, modPolicy := range evaluation.ModPolicies {
+		result := &PolicyResult{
+			Element:   modPolicy.Key,
+			Policy:    modPolicy.PolicyPath,
+			Satisfied: modPolicy.Err == nil,
+		}
+		if modPolicy.Err != nil {
+			result.Error = modPolicy.Err.Error()
+			result.Missing = explain(config.ChannelGroup, bundle.PolicyManager(), modPolicy.PolicyPath, evaluation.SignedData)
+		}
+		report.Policies = append(report.Policies, result)
+	}
+
+	if evaluation.ProposeErr != nil {
+		report.Error = evaluation.ProposeErr.Error()
+		return report, nil
+	}
+
+	newBundle, err := channelconfig.NewBundle(channelID, evaluation.ProposedConfig.Config)
+	if err != nil {
+		report.Error = errors.Wrap(err, "updated config is invalid").Error()
+		return report, nil
+	}
+	report.CapabilityErrors = capabilityErrors(newBundle)
+	if err := bundle.ValidateNew(newBundle); err != nil {
+		report.Error = errors.Wrap(err, "updated config is not a valid transition").Error()
+		return report, nil
+	}
+
+	report.Accepted = len(report.CapabilityErrors) == 0
+	return report, nil
+}
+
+// capabilityErrors lists the capabilities required by the config which this binary does not support
+func capabilityErrors(bundle *channelconfig.Bundle) []string {
+	var errs []string
+	if err := bundle.ChannelConfig().Capabilities().Supported(); err != nil {
+		errs = append(errs, fmt.Sprintf("config requires unsupported channel capabilities: %s", err))
+	}
+	if oc, ok := bundle.OrdererConfig(); ok {
+		if err := oc.Capabilities().Supported(); err != nil {
+			errs = append(errs, fmt.Sprintf("config requires unsupported orderer capabilities: %s", err))
+		}
+	}
+	if ac, ok := bundle.ApplicationConfig(); ok {
+		if err := ac.Capabilities().Supported(); err != nil {
+			errs = append(errs, fmt.Sprintf("config requires unsupported application capabilities: %s", err))
+		}
+	}
+	return errs
+}
+
+// explain describes what is still needed to satisfy the policy at the given fully qualified path
+func explain(channelGroup *cb.ConfigGroup, pm policies.Manager, policyPath string, signedData []*cb.SignedData) *Requirement {
+	requirement := &Requirement{Policy: policyPath}
+
+	elements := strings.Split(strings.TrimPrefix(policyPath, policies.PathSeparator), policies.PathSeparator)
+	if len(elements) < 2 || elements[0] != channelconfig.RootGroupKey {
+		return requirement
+	}
+	groupPath, policyName := elements[1:len(elements)-1], elements[len(elements)-1]
+
+	group := channelGroup
+	for _, name := range groupPath {
+		if group = group.Groups[name]; group == nil {
+			return requirement
+		}
+	}
+	configPolicy, ok := group.Policies[policyName]
+	if !ok || configPolicy.Policy == nil {
+		return requirement
+	}
+
+	switch cb.Policy_PolicyType(configPolicy.Policy.Type) {
+	case cb.Policy_SIGNATURE:
+		sigPolicy := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(configPolicy.Policy.Value, sigPolicy); err != nil {
+			return requirement
+		}
+		for _, principalSet := range inquire.NewInquireableSignaturePolicy(sigPolicy).SatisfiedBy() {
+			var principals []string
+			for _, principal := range principalSet {
+				principals = append(principals, principalString(principal))
+			}
+			requirement.SignatureSets = append(requirement.SignatureSets, principals)
+		}
+	case cb.Policy_IMPLICIT_META:
+		metaPolicy := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(configPolicy.Policy.Value, metaPolicy); err != nil {
+			return requirement
+		}
+		subGroups := make([]string, 0, len(group.Groups))
+		for name := range group.Groups {
+			subGroups = append(subGroups, name)
+		}
+		sort.Strings(subGroups)
+
+		var threshold int
+		switch metaPolicy.Rule {
+		case cb.ImplicitMetaPolicy_ANY:
+			threshold = 1
+		case cb.ImplicitMetaPolicy_ALL:
+			threshold = len(subGroups)
+		case cb.ImplicitMetaPolicy_MAJORITY:
+			threshold = len(subGroups)/2 + 1
+		}
+
+		satisfied := 0
+		var unsatisfied []string
+		for _, name := range subGroups {
+			subPath := append(append([]string{}, groupPath...), name)
+			if manager, ok := pm.Manager(subPath); ok {
+				if policy, ok := manager.GetPolicy(metaPolicy.SubPolicy); ok && policy.Evaluate(signedData) == nil {
+					satisfied++
+					continue
+				}
+			}
+			unsatisfied = append(unsatisfied, name)
+		}
+		if satisfied < threshold {
+			requirement.Needed = threshold - satisfied
+		}
+		for _, name := range unsatisfied {
+			subPolicyPath := strings.Join([]string{policyPath[:strings.LastIndex(policyPath, policies.PathSeparator)], name, metaPolicy.SubPolicy}, policies.PathSeparator)
+			requirement.SubPolicies = append(requirement.SubPolicies, explain(channelGroup, pm, subPolicyPath, signedData))
+		}
+	}
+	return requirement
+}
+
+// principalString renders a principal the way it is written in the policy language, like 'Org1MSP.admin'
+func principalString(principal *mspprotos.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case mspprotos.MSPPrincipal_ROLE:
+		role := &mspprotos.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err == nil {
+			return fmt.Sprintf("%s.%s", role.MspIdentifier, strings.ToLower(role.Role.String()))
+		}
+	case mspprotos.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mspprotos.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err == nil {
+			return fmt.Sprintf("%s.OU=%s", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+		}
+	case mspprotos.MSPPrincipal_IDENTITY:
+		return identityString(principal.Principal)
+	}
+	return fmt.Sprintf("unknown principal of classification %s", principal.PrincipalClassification)
+}
+
+// signers describes the identities which signed the config update
+func signers(signedData []*cb.SignedData) []string {
+	result := []string{}
+	for _, sd := range signedData {
+		result = append(result, identityString(sd.Identity))
+	}
+	return result
+}
+
+// identityString renders a serialized identity as its MSP ID and certificate subject
+func identityString(serializedIdentity []byte) string {
+	sid := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sid); err != nil {
+		return "malformed identity"
+	}
+	block, _ := pem.Decode(sid.IdBytes)
+	if block == nil {
+		return sid.Mspid
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return sid.Mspid
+	}
+	return fmt.Sprintf("%s (%s)", sid.Mspid, cert.Subject.CommonName)
+}
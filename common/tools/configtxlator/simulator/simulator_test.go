@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/operations"
+	"github.com/hyperledger/fabric/common/util"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var configBlock *cb.Block
+
+func init() {
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		panic(err)
+	}
+	configBlock = encoder.New(genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)).GenesisBlockForChannel("foo")
+}
+
+// sign adds a signature of the local MSP to the config update envelope
+func sign(t *testing.T, env *cb.Envelope) *cb.Envelope {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	assert.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+
+	signer := localmsp.NewSigner()
+	sigHeader, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	configSig := &cb.ConfigSignature{SignatureHeader: utils.MarshalOrPanic(sigHeader)}
+	configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+	assert.NoError(t, err)
+	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, configSig)
+
+	signed, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "foo", signer, configUpdateEnv, 0, 0)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestSimulateMissingSignatures(t *testing.T) {
+	update, err := operations.Compute(configBlock, &operations.SetBatchTimeout{Timeout: "3s"})
+	assert.NoError(t, err)
+
+	report, err := Simulate(configBlock, update)
+	assert.NoError(t, err)
+	assert.False(t, report.Accepted)
+	assert.Equal(t, "foo", report.ChannelID)
+	assert.Empty(t, report.Signers)
+	assert.Empty(t, report.VersionErrors)
+	assert.Contains(t, report.Error, "error authorizing update")
+
+	assert.Len(t, report.Policies, 1)
+	result := report.Policies[0]
+	assert.Equal(t, "[Value]  /Channel/Orderer/BatchTimeout", result.Element)
+	assert.Equal(t, "/Channel/Orderer/Admins", result.Policy)
+	assert.False(t, result.Satisfied)
+	assert.Equal(t, &Requirement{
+		Policy: "/Channel/Orderer/Admins",
+		Needed: 1,
+		SubPolicies: []*Requirement{{
+			Policy:        "/Channel/Orderer/SampleOrg/Admins",
+			SignatureSets: [][]string{{"DEFAULT.member"}},
+		}},
+	}, result.Missing)
+}
+
+func TestSimulateAccepted(t *testing.T) {
+	update, err := operations.Compute(configBlock, &operations.SetBatchTimeout{Timeout: "3s"})
+	assert.NoError(t, err)
+
+	report, err := Simulate(configBlock, sign(t, update))
+	assert.NoError(t, err)
+	assert.True(t, report.Accepted, report.Error)
+	assert.Empty(t, report.Error)
+	assert.Len(t, report.Signers, 1)
+	assert.Contains(t, report.Signers[0], "DEFAULT")
+	assert.Len(t, report.Policies, 1)
+	assert.True(t, report.Policies[0].Satisfied)
+	assert.Nil(t, report.Policies[0].Missing)
+	assert.Empty(t, report.CapabilityErrors)
+}
+
+func TestSimulateStaleUpdate(t *testing.T) {
+	update, err := operations.Compute(configBlock, &operations.SetBatchTimeout{Timeout: "3s"})
+	assert.NoError(t, err)
+
+	// Simulate the update against a config which already advanced past it
+	stale := &cb.ConfigUpdate{}
+	payload, err := utils.UnmarshalPayload(update.Payload)
+	assert.NoError(t, err)
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	assert.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+	assert.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, stale))
+	stale.ReadSet.Groups["Orderer"].Version = 7
+	configUpdateEnv.ConfigUpdate = utils.MarshalOrPanic(stale)
+	update, err = utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "foo", nil, configUpdateEnv, 0, 0)
+	assert.NoError(t, err)
+
+	report, err := Simulate(configBlock, sign(t, update))
+	assert.NoError(t, err)
+	assert.False(t, report.Accepted)
+	assert.NotEmpty(t, report.VersionErrors)
+	assert.Contains(t, report.VersionErrors[0], "readset expected key [Group]  /Channel/Orderer at version 7, but got version 0")
+}
+
+func TestSimulateMalformed(t *testing.T) {
+	_, err := Simulate(configBlock, &cb.Envelope{Payload: []byte("garbage")})
+	assert.Contains(t, err.Error(), "malformed config update")
+
+	_, err = Simulate(&cb.Block{}, &cb.Envelope{})
+	assert.EqualError(t, err, "config block is empty")
+}
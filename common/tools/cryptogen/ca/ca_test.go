@@ -8,6 +8,7 @@ package ca_test
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
@@ -167,6 +168,122 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 }
 
+func TestNewIntermediateCA(t *testing.T) {
+	caDir := filepath.Join(testDir, "ca")
+	icaDir := filepath.Join(testDir, "ica")
+
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+	intermediateCA, err := rootCA.NewIntermediateCA(icaDir, testCAName, testCA2Name)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	assert.True(t, intermediateCA.SignCert.IsCA)
+	assert.True(t, intermediateCA.SignCert.MaxPathLenZero)
+	assert.Equal(t, rootCA.SignCert, intermediateCA.RootCert())
+	assert.Equal(t, []*x509.Certificate{intermediateCA.SignCert}, intermediateCA.IntermediateCerts())
+	assert.Empty(t, rootCA.IntermediateCerts())
+	assert.NoError(t, intermediateCA.SignCert.CheckSignatureFrom(rootCA.SignCert))
+	assert.Equal(t, true, checkForFile(filepath.Join(icaDir, "chain.crt")))
+
+	// the intermediate CA is loaded along with its chain
+	loadedCA, err := ca.LoadCA(icaDir)
+	assert.NoError(t, err, "Error loading intermediate CA")
+	assert.Equal(t, testCA2Name, loadedCA.Name)
+	assert.Len(t, loadedCA.ParentCerts, 1)
+	assert.True(t, loadedCA.RootCert().Equal(rootCA.SignCert))
+
+	_, err = ca.LoadCA(filepath.Join(testDir, "missing"))
+	assert.Error(t, err, "Loading a missing CA should fail")
+	cleanup(testDir)
+}
+
+func TestImportCA(t *testing.T) {
+	caDir := filepath.Join(testDir, "ca")
+	icaDir := filepath.Join(testDir, "ica")
+	importDir := filepath.Join(testDir, "import")
+
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+	_, err = rootCA.NewIntermediateCA(icaDir, testCAName, testCA2Name)
+	assert.NoError(t, err, "Error generating intermediate CA")
+
+	// the certificate file of an intermediate CA holds its chain
+	certFile := filepath.Join(testDir, "ica-cert.pem")
+	leaf, err := ioutil.ReadFile(filepath.Join(icaDir, testCA2Name+"-cert.pem"))
+	assert.NoError(t, err)
+	chain, err := ioutil.ReadFile(filepath.Join(icaDir, "chain.crt"))
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(certFile, append(leaf, chain...), 0644))
+	keyFile := findKeyFile(t, icaDir)
+
+	importedCA, err := ca.ImportCA(importDir, certFile, keyFile)
+	assert.NoError(t, err, "Error importing CA")
+	assert.Equal(t, testCA2Name, importedCA.Name)
+	assert.True(t, importedCA.RootCert().Equal(rootCA.SignCert))
+
+	// the key must match the certificate
+	_, err = ca.ImportCA(filepath.Join(testDir, "mismatch"), certFile, findKeyFile(t, caDir))
+	assert.Error(t, err, "Importing a CA with a mismatching key should fail")
+
+	// the certificate must be a CA certificate
+	leafDir := filepath.Join(testDir, "leaf")
+	priv, _, err := csp.GeneratePrivateKey(leafDir)
+	assert.NoError(t, err)
+	ecPubKey, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err)
+	_, err = rootCA.SignCertificate(leafDir, testName, nil, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err)
+	_, err = ca.ImportCA(filepath.Join(testDir, "notca"), filepath.Join(leafDir, testName+"-cert.pem"), findKeyFile(t, leafDir))
+	assert.Error(t, err, "Importing a non CA certificate should fail")
+	cleanup(testDir)
+}
+
+func TestRenewCA(t *testing.T) {
+	caDir := filepath.Join(testDir, "ca")
+	icaDir := filepath.Join(testDir, "ica")
+	certDir := filepath.Join(testDir, "certs")
+
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+	intermediateCA, err := rootCA.NewIntermediateCA(icaDir, testCAName, testCA2Name)
+	assert.NoError(t, err, "Error generating intermediate CA")
+
+	priv, _, err := csp.GeneratePrivateKey(certDir)
+	assert.NoError(t, err)
+	ecPubKey, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err)
+	cert, err := intermediateCA.SignCertificate(certDir, testName, []string{"peer"}, []string{testName2}, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err)
+
+	err = intermediateCA.RenewSelfSigned(icaDir)
+	assert.Error(t, err, "Renewing an intermediate CA as a root CA should fail")
+
+	oldRootCert := rootCA.SignCert
+	assert.NoError(t, rootCA.RenewSelfSigned(caDir))
+	assert.NotEqual(t, oldRootCert.SerialNumber, rootCA.SignCert.SerialNumber)
+	assert.Equal(t, oldRootCert.Subject.String(), rootCA.SignCert.Subject.String())
+
+	assert.NoError(t, rootCA.RenewIntermediateCA(icaDir, intermediateCA))
+	assert.NoError(t, intermediateCA.SignCert.CheckSignatureFrom(rootCA.SignCert))
+	assert.True(t, intermediateCA.RootCert().Equal(rootCA.SignCert))
+
+	renewed, err := intermediateCA.RenewCertificate(certDir, testName, cert)
+	assert.NoError(t, err, "Error renewing certificate")
+	assert.Equal(t, cert.PublicKey, renewed.PublicKey)
+	assert.Equal(t, cert.Subject.OrganizationalUnit, renewed.Subject.OrganizationalUnit)
+	assert.Equal(t, cert.DNSNames, renewed.DNSNames)
+	assert.NoError(t, renewed.CheckSignatureFrom(intermediateCA.SignCert))
+	cleanup(testDir)
+}
+
+func findKeyFile(t *testing.T, dir string) string {
+	keyFiles, err := filepath.Glob(filepath.Join(dir, "*_sk"))
+	assert.NoError(t, err)
+	assert.Len(t, keyFiles, 1)
+	return keyFiles[0]
+}
+
 func cleanup(dir string) {
 	os.RemoveAll(dir)
 }
@@ -12,6 +12,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net"
@@ -35,8 +36,14 @@ type CA struct {
 	//SignKey  *ecdsa.PrivateKey
 	Signer   crypto.Signer
 	SignCert *x509.Certificate
+	// ParentCerts holds the certificates of the CAs which issued SignCert,
+	// from the immediate issuer up to the root, and is empty for root CAs
+	ParentCerts []*x509.Certificate
 }
 
+// chainFile is the name of the file holding the parent certificates of a non root CA
+const chainFile = "chain.crt"
+
 // NewCA creates an instance of CA and saves the signing key pair in
 // baseDir/name
 func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode string) (*CA, error) {
@@ -91,6 +98,217 @@ func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddre
 	return ca, response
 }
 
+// NewIntermediateCA creates an intermediate CA issued by ca and saves its
+// signing key pair and the certificates of its parent CAs in baseDir
+func (ca *CA) NewIntermediateCA(baseDir, org, name string) (*CA, error) {
+	err := os.MkdirAll(baseDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	priv, signer, err := csp.GeneratePrivateKey(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	ecPubKey, err := csp.GetECPublicKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	template := x509Template()
+	template.IsCA = true
+	template.MaxPathLenZero = true
+	template.KeyUsage |= x509.KeyUsageDigitalSignature |
+		x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign |
+		x509.KeyUsageCRLSign
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+
+	subject := subjectTemplateAdditional(ca.Country, ca.Province, ca.Locality, ca.OrganizationalUnit, ca.StreetAddress, ca.PostalCode)
+	subject.Organization = []string{org}
+	subject.CommonName = name
+
+	template.Subject = subject
+	template.SubjectKeyId = priv.SKI()
+
+	x509Cert, err := genCertificateECDSA(baseDir, name, &template, ca.SignCert, ecPubKey, ca.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	intermediate := &CA{
+		Name:               name,
+		Signer:             signer,
+		SignCert:           x509Cert,
+		ParentCerts:        append([]*x509.Certificate{ca.SignCert}, ca.ParentCerts...),
+		Country:            ca.Country,
+		Province:           ca.Province,
+		Locality:           ca.Locality,
+		OrganizationalUnit: ca.OrganizationalUnit,
+		StreetAddress:      ca.StreetAddress,
+		PostalCode:         ca.PostalCode,
+	}
+	err = writeCertificates(filepath.Join(baseDir, chainFile), intermediate.ParentCerts)
+	if err != nil {
+		return nil, err
+	}
+	return intermediate, nil
+}
+
+// ImportCA creates a CA from an existing certificate and private key, both PEM
+// encoded, and saves them in baseDir. If the CA is not a root CA, certFile must
+// also contain the certificates of its parent CAs, up to the root.
+func ImportCA(baseDir, certFile, keyFile string) (*CA, error) {
+	rawCerts, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(rawCerts); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing certificate in %s: %s", certFile, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found in %s", certFile)
+	}
+	if !certs[0].IsCA {
+		return nil, fmt.Errorf("certificate in %s is not a CA certificate", certFile)
+	}
+
+	rawKey, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(rawKey); block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found in %s", keyFile)
+	}
+
+	err = os.MkdirAll(baseDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(baseDir, "priv_sk"), rawKey, 0600)
+	if err != nil {
+		return nil, err
+	}
+	err = writeCertificates(filepath.Join(baseDir, certs[0].Subject.CommonName+"-cert.pem"), certs[:1])
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) > 1 {
+		err = writeCertificates(filepath.Join(baseDir, chainFile), certs[1:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ca, err := LoadCA(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if !publicKeysMatch(ca.Signer.Public(), ca.SignCert.PublicKey) {
+		return nil, fmt.Errorf("private key in %s does not match the certificate in %s", keyFile, certFile)
+	}
+	return ca, nil
+}
+
+// LoadCA loads the signing key pair of a CA saved in baseDir, along with the
+// certificates of its parent CAs if it is not a root CA
+func LoadCA(baseDir string) (*CA, error) {
+	_, signer, err := csp.LoadPrivateKey(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("no private key found in %s", baseDir)
+	}
+	cert, err := LoadCertificateECDSA(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("no certificate found in %s", baseDir)
+	}
+
+	ca := &CA{
+		Name:     cert.Subject.CommonName,
+		Signer:   signer,
+		SignCert: cert,
+	}
+
+	rawChain, err := ioutil.ReadFile(filepath.Join(baseDir, chainFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for block, rest := pem.Decode(rawChain); block != nil; block, rest = pem.Decode(rest) {
+		parent, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		ca.ParentCerts = append(ca.ParentCerts, parent)
+	}
+	return ca, nil
+}
+
+// RootCert returns the certificate of the root CA of the chain of ca
+func (ca *CA) RootCert() *x509.Certificate {
+	if len(ca.ParentCerts) == 0 {
+		return ca.SignCert
+	}
+	return ca.ParentCerts[len(ca.ParentCerts)-1]
+}
+
+// IntermediateCerts returns the certificates of the intermediate CAs of the
+// chain of ca, starting with its own, or nothing if ca is a root CA
+func (ca *CA) IntermediateCerts() []*x509.Certificate {
+	if len(ca.ParentCerts) == 0 {
+		return nil
+	}
+	return append([]*x509.Certificate{ca.SignCert}, ca.ParentCerts[:len(ca.ParentCerts)-1]...)
+}
+
+// RenewSelfSigned issues a new self-signed certificate for a root CA, with the same
+// subject and key as its current one, and saves it in baseDir. Certificates issued
+// by the CA remain valid under the new certificate.
+func (ca *CA) RenewSelfSigned(baseDir string) error {
+	if len(ca.ParentCerts) != 0 {
+		return fmt.Errorf("CA %s is not a root CA", ca.Name)
+	}
+	template := renewalTemplate(ca.SignCert)
+	cert, err := genCertificateECDSA(baseDir, ca.Name, &template, &template,
+		ca.SignCert.PublicKey.(*ecdsa.PublicKey), ca.Signer)
+	if err != nil {
+		return err
+	}
+	ca.SignCert = cert
+	return nil
+}
+
+// RenewIntermediateCA issues a new certificate for the intermediate CA, with the
+// same subject and key as its current one, and saves it and the current
+// certificates of its parent CAs in baseDir
+func (ca *CA) RenewIntermediateCA(baseDir string, intermediate *CA) error {
+	cert, err := ca.RenewCertificate(baseDir, intermediate.Name, intermediate.SignCert)
+	if err != nil {
+		return err
+	}
+	intermediate.SignCert = cert
+	intermediate.ParentCerts = append([]*x509.Certificate{ca.SignCert}, ca.ParentCerts...)
+	return writeCertificates(filepath.Join(baseDir, chainFile), intermediate.ParentCerts)
+}
+
+// RenewCertificate issues a new certificate with the same subject, key, key usages
+// and subject alternative names as cert, and saves it in baseDir/name
+func (ca *CA) RenewCertificate(baseDir, name string, cert *x509.Certificate) (*x509.Certificate, error) {
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("certificate %s does not hold an ECDSA public key", cert.Subject.CommonName)
+	}
+	template := renewalTemplate(cert)
+	return genCertificateECDSA(baseDir, name, &template, ca.SignCert, pub, ca.Signer)
+}
+
 // SignCertificate creates a signed certificate based on a built-in template
 // and saves it in baseDir/name
 func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub *ecdsa.PublicKey,
@@ -184,6 +402,37 @@ func x509Template() x509.Certificate {
 
 }
 
+// renewalTemplate returns a template for a certificate identical
+// to cert except for its serial number and validity period
+func renewalTemplate(cert *x509.Certificate) x509.Certificate {
+	template := x509Template()
+	template.Subject = cert.Subject
+	template.SubjectKeyId = cert.SubjectKeyId
+	template.KeyUsage = cert.KeyUsage
+	template.ExtKeyUsage = cert.ExtKeyUsage
+	template.IsCA = cert.IsCA
+	template.MaxPathLen = cert.MaxPathLen
+	template.MaxPathLenZero = cert.MaxPathLenZero
+	template.DNSNames = cert.DNSNames
+	template.IPAddresses = cert.IPAddresses
+	return template
+}
+
+// writeCertificates PEM encodes the certificates into a single file
+func writeCertificates(path string, certs []*x509.Certificate) error {
+	var out []byte
+	for _, cert := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return ioutil.WriteFile(path, out, 0644)
+}
+
+func publicKeysMatch(a, b interface{}) bool {
+	aKey, aOK := a.(*ecdsa.PublicKey)
+	bKey, bOK := b.(*ecdsa.PublicKey)
+	return aOK && bOK && aKey.X.Cmp(bKey.X) == 0 && aKey.Y.Cmp(bKey.Y) == 0
+}
+
 // generate a signed X509 certificate using ECDSA
 func genCertificateECDSA(baseDir, name string, template, parent *x509.Certificate, pub *ecdsa.PublicKey,
 	priv interface{}) (*x509.Certificate, error) {
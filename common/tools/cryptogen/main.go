@@ -13,9 +13,9 @@ import (
 	"os"
 	"path/filepath"
 	"text/template"
+	"time"
 
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
-	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/metadata"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -64,14 +64,30 @@ type UsersSpec struct {
 	Count int `yaml:"Count"`
 }
 
+type NodeOUsSpec struct {
+	ClientOUIdentifier string `yaml:"ClientOUIdentifier"`
+	PeerOUIdentifier   string `yaml:"PeerOUIdentifier"`
+}
+
+type ExternalCASpec struct {
+	CertFile    string `yaml:"CertFile"`
+	KeyFile     string `yaml:"KeyFile"`
+	TLSCertFile string `yaml:"TLSCertFile"`
+	TLSKeyFile  string `yaml:"TLSKeyFile"`
+}
+
 type OrgSpec struct {
-	Name          string       `yaml:"Name"`
-	Domain        string       `yaml:"Domain"`
-	EnableNodeOUs bool         `yaml:"EnableNodeOUs"`
-	CA            NodeSpec     `yaml:"CA"`
-	Template      NodeTemplate `yaml:"Template"`
-	Specs         []NodeSpec   `yaml:"Specs"`
-	Users         UsersSpec    `yaml:"Users"`
+	Name                 string         `yaml:"Name"`
+	Domain               string         `yaml:"Domain"`
+	EnableNodeOUs        bool           `yaml:"EnableNodeOUs"`
+	NodeOUs              NodeOUsSpec    `yaml:"NodeOUs"`
+	CA                   NodeSpec       `yaml:"CA"`
+	ExternalCA           ExternalCASpec `yaml:"ExternalCA"`
+	EnableIntermediateCA bool           `yaml:"EnableIntermediateCA"`
+	IntermediateCA       NodeSpec       `yaml:"IntermediateCA"`
+	Template             NodeTemplate   `yaml:"Template"`
+	Specs                []NodeSpec     `yaml:"Specs"`
+	Users                UsersSpec      `yaml:"Users"`
 }
 
 type Config struct {
@@ -107,6 +123,18 @@ PeerOrgs:
     Domain: org1.example.com
     EnableNodeOUs: false
 
+    # ---------------------------------------------------------------------------
+    # "NodeOUs"
+    # ---------------------------------------------------------------------------
+    # When EnableNodeOUs is true, the certificates of peers and users carry an
+    # organizational unit which classifies them, and the MSPs of the
+    # organization get a config.yaml enforcing the classification.  Uncomment
+    # this section to override the organizational unit identifiers.
+    # ---------------------------------------------------------------------------
+    # NodeOUs:
+    #   ClientOUIdentifier: client # default
+    #   PeerOUIdentifier: peer # default
+
     # ---------------------------------------------------------------------------
     # "CA"
     # ---------------------------------------------------------------------------
@@ -122,6 +150,33 @@ PeerOrgs:
     #    StreetAddress: address for org # default nil
     #    PostalCode: postalCode for org # default nil
 
+    # ---------------------------------------------------------------------------
+    # "ExternalCA"
+    # ---------------------------------------------------------------------------
+    # Uncomment this section to issue the certificates of this organization from
+    # an existing CA instead of generating a self-signed one.  The files are PEM
+    # encoded.  If the CA is not a root CA, CertFile must also contain the
+    # certificates of its parent CAs, up to the root.  The TLS CA is generated
+    # unless TLSCertFile and TLSKeyFile are specified.
+    # ---------------------------------------------------------------------------
+    # ExternalCA:
+    #    CertFile: /path/to/ca-cert.pem
+    #    KeyFile: /path/to/ca-key.pem
+    #    TLSCertFile: /path/to/tlsca-cert.pem
+    #    TLSKeyFile: /path/to/tlsca-key.pem
+
+    # ---------------------------------------------------------------------------
+    # "IntermediateCA"
+    # ---------------------------------------------------------------------------
+    # When EnableIntermediateCA is true, an intermediate CA and an intermediate
+    # TLS CA are issued by the CAs of the organization, and issue the
+    # certificates of its nodes and users in turn.  The IntermediateCA entry is
+    # a Spec, see "Specs" section below for details.
+    # ---------------------------------------------------------------------------
+    # EnableIntermediateCA: true
+    # IntermediateCA:
+    #    Hostname: ica # implicitly ica.org1.example.com
+
     # ---------------------------------------------------------------------------
     # "Specs"
     # ---------------------------------------------------------------------------
@@ -196,7 +251,7 @@ PeerOrgs:
       Count: 1
 `
 
-//command line flags
+// command line flags
 var (
 	app = kingpin.New("cryptogen", "Utility for generating Hyperledger Fabric key material")
 
@@ -210,6 +265,12 @@ var (
 	ext           = app.Command("extend", "Extend existing network")
 	inputDir      = ext.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
 	extConfigFile = ext.Flag("config", "The configuration template to use").File()
+
+	renew               = app.Command("renew", "Renew the certificates of an existing network")
+	renewInputDir       = renew.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
+	renewConfigFile     = renew.Flag("config", "The configuration template to use").File()
+	renewCAs            = renew.Flag("ca", "Also renew the certificates of the CAs").Bool()
+	renewExpiringWithin = renew.Flag("expiring_within", "Only renew the certificates which expire within this duration, for example 720h; all of them by default").Duration()
 )
 
 func main() {
@@ -223,6 +284,9 @@ func main() {
 	case ext.FullCommand():
 		extend()
 
+	case renew.FullCommand():
+		renewCerts()
+
 		// "showtemplate" command
 	case showtemplate.FullCommand():
 		fmt.Print(defaultConfig)
@@ -251,6 +315,13 @@ func getConfig() (*Config, error) {
 			return nil, fmt.Errorf("Error reading configuration: %s", err)
 		}
 
+		configData = string(data)
+	} else if *renewConfigFile != nil {
+		data, err := ioutil.ReadAll(*renewConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading configuration: %s", err)
+		}
+
 		configData = string(data)
 	} else {
 		configData = defaultConfig
@@ -302,13 +373,10 @@ func extendPeerOrg(orgSpec OrgSpec) {
 
 	peersDir := filepath.Join(orgDir, "peers")
 	usersDir := filepath.Join(orgDir, "users")
-	caDir := filepath.Join(orgDir, "ca")
-	tlscaDir := filepath.Join(orgDir, "tlsca")
 
-	signCA := getCA(caDir, orgSpec, orgSpec.CA.CommonName)
-	tlsCA := getCA(tlscaDir, orgSpec, "tls"+orgSpec.CA.CommonName)
+	signCA, tlsCA := getCAs(orgDir, orgSpec)
 
-	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
+	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, nodeOUs(orgSpec))
 
 	adminUser := NodeSpec{
 		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
@@ -334,26 +402,23 @@ func extendPeerOrg(orgSpec OrgSpec) {
 		users = append(users, user)
 	}
 
-	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, orgSpec.EnableNodeOUs)
+	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, nodeOUs(orgSpec))
 }
 
 func extendOrdererOrg(orgSpec OrgSpec) {
 	orgName := orgSpec.Domain
 
 	orgDir := filepath.Join(*inputDir, "ordererOrganizations", orgName)
-	caDir := filepath.Join(orgDir, "ca")
 	usersDir := filepath.Join(orgDir, "users")
-	tlscaDir := filepath.Join(orgDir, "tlsca")
 	orderersDir := filepath.Join(orgDir, "orderers")
 	if _, err := os.Stat(orgDir); os.IsNotExist(err) {
 		generateOrdererOrg(*inputDir, orgSpec)
 		return
 	}
 
-	signCA := getCA(caDir, orgSpec, orgSpec.CA.CommonName)
-	tlsCA := getCA(tlscaDir, orgSpec, "tls"+orgSpec.CA.CommonName)
+	signCA, tlsCA := getCAs(orgDir, orgSpec)
 
-	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, false)
+	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, nil)
 
 	adminUser := NodeSpec{
 		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
@@ -370,6 +435,135 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 	}
 }
 
+func renewCerts() {
+	config, err := getConfig()
+	if err != nil {
+		fmt.Printf("Error reading config: %s", err)
+		os.Exit(-1)
+	}
+
+	// renew all the certificates unless a renewal window is specified
+	expiringBefore := time.Unix(1<<62, 0)
+	if *renewExpiringWithin > 0 {
+		expiringBefore = time.Now().Add(*renewExpiringWithin)
+	}
+
+	for _, orgSpec := range config.PeerOrgs {
+		err = renderOrgSpec(&orgSpec, "peer")
+		if err != nil {
+			fmt.Printf("Error processing peer configuration: %s", err)
+			os.Exit(-1)
+		}
+		orgDir := filepath.Join(*renewInputDir, "peerOrganizations", orgSpec.Domain)
+		renewOrg(orgDir, orgSpec, "peers", msp.PEER, expiringBefore)
+	}
+
+	for _, orgSpec := range config.OrdererOrgs {
+		err = renderOrgSpec(&orgSpec, "orderer")
+		if err != nil {
+			fmt.Printf("Error processing orderer configuration: %s", err)
+			os.Exit(-1)
+		}
+		orgDir := filepath.Join(*renewInputDir, "ordererOrganizations", orgSpec.Domain)
+		renewOrg(orgDir, orgSpec, "orderers", msp.ORDERER, expiringBefore)
+	}
+}
+
+// renewOrg renews the certificates of the nodes and users of an existing organization,
+// along with the certificates of its CAs if requested, and refreshes its MSPs
+func renewOrg(orgDir string, orgSpec OrgSpec, nodesDirName string, nodeType int, expiringBefore time.Time) {
+	orgName := orgSpec.Domain
+	if _, err := os.Stat(orgDir); os.IsNotExist(err) {
+		fmt.Printf("Skipping org %s which was not generated\n", orgName)
+		return
+	}
+
+	signCA, tlsCA := getCAs(orgDir, orgSpec)
+	if *renewCAs {
+		err := renewCA(filepath.Join(orgDir, "ca"), filepath.Join(orgDir, "ica"), signCA, orgSpec)
+		if err != nil {
+			fmt.Printf("Error renewing signCA for org %s:\n%v\n", orgName, err)
+			os.Exit(1)
+		}
+		err = renewCA(filepath.Join(orgDir, "tlsca"), filepath.Join(orgDir, "tlsica"), tlsCA, orgSpec)
+		if err != nil {
+			fmt.Printf("Error renewing tlsCA for org %s:\n%v\n", orgName, err)
+			os.Exit(1)
+		}
+	}
+
+	err := msp.RenewVerifyingMSP(filepath.Join(orgDir, "msp"), signCA, tlsCA)
+	if err != nil {
+		fmt.Printf("Error renewing MSP for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+
+	nodesDir := filepath.Join(orgDir, nodesDirName)
+	usersDir := filepath.Join(orgDir, "users")
+	renewNodes(nodesDir, signCA, tlsCA, nodeType, expiringBefore)
+	renewNodes(usersDir, signCA, tlsCA, msp.CLIENT, expiringBefore)
+
+	// the admin certificate may have been renewed, copy it again
+	adminUserName := fmt.Sprintf("%s@%s", adminBaseName, orgName)
+	adminCertsDirs := []string{filepath.Join(orgDir, "msp", "admincerts")}
+	nodes, err := ioutil.ReadDir(nodesDir)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Error reading %s:\n%v\n", nodesDir, err)
+		os.Exit(1)
+	}
+	for _, node := range nodes {
+		if node.IsDir() {
+			adminCertsDirs = append(adminCertsDirs, filepath.Join(nodesDir, node.Name(), "msp", "admincerts"))
+		}
+	}
+	for _, adminCertsDir := range adminCertsDirs {
+		os.Remove(filepath.Join(adminCertsDir, adminUserName+"-cert.pem"))
+		err = copyAdminCert(usersDir, adminCertsDir, adminUserName)
+		if err != nil {
+			fmt.Printf("Error copying admin cert for org %s to %s:\n%v\n", orgName, adminCertsDir, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// renewCA renews the certificate of the root CA in caDir and, if the organization
+// uses an intermediate CA, the certificate of the intermediate CA in icaDir
+func renewCA(caDir, icaDir string, issuingCA *ca.CA, orgSpec OrgSpec) error {
+	if len(issuingCA.ParentCerts) == 0 {
+		return issuingCA.RenewSelfSigned(caDir)
+	}
+	rootCA := getCA(caDir, orgSpec)
+	if len(rootCA.ParentCerts) == 0 {
+		err := rootCA.RenewSelfSigned(caDir)
+		if err != nil {
+			return err
+		}
+	}
+	return rootCA.RenewIntermediateCA(icaDir, issuingCA)
+}
+
+// renewNodes renews the certificates of all the nodes generated in baseDir
+func renewNodes(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeType int, expiringBefore time.Time) {
+	nodes, err := ioutil.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+		fmt.Printf("Error reading %s:\n%v\n", baseDir, err)
+		os.Exit(1)
+	}
+	for _, node := range nodes {
+		if !node.IsDir() {
+			continue
+		}
+		err = msp.RenewLocalMSP(filepath.Join(baseDir, node.Name()), node.Name(), signCA, tlsCA, nodeType, expiringBefore)
+		if err != nil {
+			fmt.Printf("Error renewing local MSP for %s:\n%v\n", node.Name(), err)
+			os.Exit(1)
+		}
+	}
+}
+
 func generate() {
 
 	config, err := getConfig()
@@ -497,6 +691,15 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		return err
 	}
 
+	// And the intermediate CA node-spec
+	if len(orgSpec.IntermediateCA.Hostname) == 0 {
+		orgSpec.IntermediateCA.Hostname = "ica"
+	}
+	err = renderNodeSpec(orgSpec.Domain, &orgSpec.IntermediateCA)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -507,32 +710,20 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	fmt.Println(orgName)
 	// generate CAs
 	orgDir := filepath.Join(baseDir, "peerOrganizations", orgName)
-	caDir := filepath.Join(orgDir, "ca")
-	tlsCADir := filepath.Join(orgDir, "tlsca")
 	mspDir := filepath.Join(orgDir, "msp")
 	peersDir := filepath.Join(orgDir, "peers")
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
-	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
-	if err != nil {
-		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
-	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
-	if err != nil {
-		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
+	// generate signing and TLS CAs
+	signCA, tlsCA := generateCAs(orgDir, orgSpec)
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs)
+	err := msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs(orgSpec))
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
+	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, nodeOUs(orgSpec))
 
 	// TODO: add ability to specify usernames
 	users := []NodeSpec{}
@@ -549,7 +740,7 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	}
 
 	users = append(users, adminUser)
-	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, orgSpec.EnableNodeOUs)
+	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, nodeOUs(orgSpec))
 
 	// copy the admin cert to the org's MSP admincerts
 	err = copyAdminCert(usersDir, adminCertsDir, adminUser.CommonName)
@@ -596,7 +787,7 @@ func copyAdminCert(usersDir, adminCertsDir, adminUserName string) error {
 
 }
 
-func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA, nodeType int, nodeOUs bool) {
+func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA, nodeType int, nodeOUs *msp.NodeOUs) {
 
 	for _, node := range nodes {
 		nodeDir := filepath.Join(baseDir, node.CommonName)
@@ -616,32 +807,20 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 
 	// generate CAs
 	orgDir := filepath.Join(baseDir, "ordererOrganizations", orgName)
-	caDir := filepath.Join(orgDir, "ca")
-	tlsCADir := filepath.Join(orgDir, "tlsca")
 	mspDir := filepath.Join(orgDir, "msp")
 	orderersDir := filepath.Join(orgDir, "orderers")
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
-	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
-	if err != nil {
-		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
-	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
-	if err != nil {
-		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
+	// generate signing and TLS CAs
+	signCA, tlsCA := generateCAs(orgDir, orgSpec)
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, false)
+	err := msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nil)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, false)
+	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, nil)
 
 	adminUser := NodeSpec{
 		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
@@ -651,7 +830,7 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	// add an admin user
 	users = append(users, adminUser)
-	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, false)
+	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, nil)
 
 	// copy the admin cert to the org's MSP admincerts
 	err = copyAdminCert(usersDir, adminCertsDir, adminUser.CommonName)
@@ -697,19 +876,99 @@ func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
 
-func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
-	_, signer, _ := csp.LoadPrivateKey(caDir)
-	cert, _ := ca.LoadCertificateECDSA(caDir)
+// nodeOUs returns the organizational unit identifiers classifying
+// the identities of the organization, or nil if it doesn't use them
+func nodeOUs(orgSpec OrgSpec) *msp.NodeOUs {
+	if !orgSpec.EnableNodeOUs {
+		return nil
+	}
+	nodeOUs := *msp.DefaultNodeOUs
+	if len(orgSpec.NodeOUs.ClientOUIdentifier) != 0 {
+		nodeOUs.ClientOUIdentifier = orgSpec.NodeOUs.ClientOUIdentifier
+	}
+	if len(orgSpec.NodeOUs.PeerOUIdentifier) != 0 {
+		nodeOUs.PeerOUIdentifier = orgSpec.NodeOUs.PeerOUIdentifier
+	}
+	return &nodeOUs
+}
+
+// generateCAs generates, or imports, the signing and TLS CAs of the organization,
+// along with their intermediate CAs if enabled, and returns the CAs which issue
+// the certificates of its nodes and users
+func generateCAs(orgDir string, orgSpec OrgSpec) (*ca.CA, *ca.CA) {
+	orgName := orgSpec.Domain
+
+	signCA, err := generateCA(filepath.Join(orgDir, "ca"), orgName, orgSpec.CA.CommonName, orgSpec,
+		orgSpec.ExternalCA.CertFile, orgSpec.ExternalCA.KeyFile)
+	if err != nil {
+		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	tlsCA, err := generateCA(filepath.Join(orgDir, "tlsca"), orgName, "tls"+orgSpec.CA.CommonName, orgSpec,
+		orgSpec.ExternalCA.TLSCertFile, orgSpec.ExternalCA.TLSKeyFile)
+	if err != nil {
+		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+
+	if !orgSpec.EnableIntermediateCA {
+		return signCA, tlsCA
+	}
+
+	signICA, err := signCA.NewIntermediateCA(filepath.Join(orgDir, "ica"), orgName, orgSpec.IntermediateCA.CommonName)
+	if err != nil {
+		fmt.Printf("Error generating intermediate signCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	tlsICA, err := tlsCA.NewIntermediateCA(filepath.Join(orgDir, "tlsica"), orgName, "tls"+orgSpec.IntermediateCA.CommonName)
+	if err != nil {
+		fmt.Printf("Error generating intermediate tlsCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	return signICA, tlsICA
+}
 
-	return &ca.CA{
-		Name:               name,
-		Signer:             signer,
-		SignCert:           cert,
-		Country:            spec.CA.Country,
-		Province:           spec.CA.Province,
-		Locality:           spec.CA.Locality,
-		OrganizationalUnit: spec.CA.OrganizationalUnit,
-		StreetAddress:      spec.CA.StreetAddress,
-		PostalCode:         spec.CA.PostalCode,
+func generateCA(caDir, orgName, name string, orgSpec OrgSpec, certFile, keyFile string) (*ca.CA, error) {
+	if len(certFile) == 0 && len(keyFile) == 0 {
+		return ca.NewCA(caDir, orgName, name, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	}
+	if len(certFile) == 0 || len(keyFile) == 0 {
+		return nil, fmt.Errorf("both the certificate and the key of the external CA %s must be specified", name)
 	}
+	importedCA, err := ca.ImportCA(caDir, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	setSubject(importedCA, orgSpec)
+	return importedCA, nil
+}
+
+// getCAs loads the CAs which issue the certificates of the nodes and users of an
+// existing organization, that is its intermediate CAs if it has some
+func getCAs(orgDir string, orgSpec OrgSpec) (*ca.CA, *ca.CA) {
+	signCADir, tlsCADir := filepath.Join(orgDir, "ca"), filepath.Join(orgDir, "tlsca")
+	if _, err := os.Stat(filepath.Join(orgDir, "ica")); err == nil {
+		signCADir, tlsCADir = filepath.Join(orgDir, "ica"), filepath.Join(orgDir, "tlsica")
+	}
+	return getCA(signCADir, orgSpec), getCA(tlsCADir, orgSpec)
+}
+
+func getCA(caDir string, spec OrgSpec) *ca.CA {
+	loadedCA, err := ca.LoadCA(caDir)
+	if err != nil {
+		fmt.Printf("Error loading CA from %s:\n%v\n", caDir, err)
+		os.Exit(1)
+	}
+	setSubject(loadedCA, spec)
+	return loadedCA
+}
+
+// setSubject sets the subject attributes of the certificates issued by the CA
+func setSubject(c *ca.CA, spec OrgSpec) {
+	c.Country = spec.CA.Country
+	c.Province = spec.CA.Province
+	c.Locality = spec.CA.Locality
+	c.OrganizationalUnit = spec.CA.OrganizationalUnit
+	c.StreetAddress = spec.CA.StreetAddress
+	c.PostalCode = spec.CA.PostalCode
 }
@@ -9,8 +9,12 @@ import (
 	"crypto/x509"
 	"encoding/hex"
 	"encoding/pem"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"gopkg.in/yaml.v2"
 
@@ -32,13 +36,34 @@ const (
 	PEEROU   = "peer"
 )
 
-var nodeOUMap = map[int]string{
-	CLIENT: CLIENTOU,
-	PEER:   PEEROU,
+// NodeOUs holds the organizational unit identifiers which
+// classify the identities of an organization as clients or peers
+type NodeOUs struct {
+	ClientOUIdentifier string
+	PeerOUIdentifier   string
+}
+
+// DefaultNodeOUs are the organizational unit identifiers used unless configured otherwise
+var DefaultNodeOUs = &NodeOUs{
+	ClientOUIdentifier: CLIENTOU,
+	PeerOUIdentifier:   PEEROU,
+}
+
+func (n *NodeOUs) identifier(nodeType int) string {
+	switch nodeType {
+	case CLIENT:
+		return n.ClientOUIdentifier
+	case PEER:
+		return n.PeerOUIdentifier
+	}
+	return ""
 }
 
+// GenerateLocalMSP generates the local MSP and TLS artifacts of a node. If
+// nodeOUs is not nil, the signing certificate is classified with the
+// organizational unit identifier of its node type.
 func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
-	tlsCA *ca.CA, nodeType int, nodeOUs bool) error {
+	tlsCA *ca.CA, nodeType int, nodeOUs *NodeOUs) error {
 
 	// create folder structure
 	mspDir := filepath.Join(baseDir, "msp")
@@ -73,8 +98,8 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	}
 	// generate X509 certificate using signing CA
 	var ous []string
-	if nodeOUs {
-		ous = []string{nodeOUMap[nodeType]}
+	if nodeOUs != nil && nodeOUs.identifier(nodeType) != "" {
+		ous = []string{nodeOUs.identifier(nodeType)}
 	}
 	cert, err := signCA.SignCertificate(filepath.Join(mspDir, "signcerts"),
 		name, ous, nil, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
@@ -83,21 +108,17 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	}
 
 	// write artifacts to MSP folders
-
-	// the signing CA certificate goes into cacerts
-	err = x509Export(filepath.Join(mspDir, "cacerts", x509Filename(signCA.Name)), signCA.SignCert)
-	if err != nil {
-		return err
-	}
-	// the TLS CA certificate goes into tlscacerts
-	err = x509Export(filepath.Join(mspDir, "tlscacerts", x509Filename(tlsCA.Name)), tlsCA.SignCert)
+	err = exportCACerts(mspDir, signCA, tlsCA)
 	if err != nil {
 		return err
 	}
 
 	// generate config.yaml if required
-	if nodeOUs && nodeType == PEER {
-		exportConfig(mspDir, "cacerts/"+x509Filename(signCA.Name), true)
+	if nodeOUs != nil {
+		err = exportConfig(mspDir, ouCertificate(signCA), nodeOUs)
+		if err != nil {
+			return err
+		}
 	}
 
 	// the signing identity goes into admincerts.
@@ -127,27 +148,18 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 		return err
 	}
 	// generate X509 certificate using TLS CA
-	_, err = tlsCA.SignCertificate(filepath.Join(tlsDir),
+	tlsCert, err := tlsCA.SignCertificate(filepath.Join(tlsDir),
 		name, nil, sans, tlsPubKey, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth})
 	if err != nil {
 		return err
 	}
-	err = x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.SignCert)
+	err = exportTLSCerts(tlsDir, name, tlsCert, tlsCA, nodeType)
 	if err != nil {
 		return err
 	}
 
-	// rename the generated TLS X509 cert
-	tlsFilePrefix := "server"
-	if nodeType == CLIENT {
-		tlsFilePrefix = "client"
-	}
-	err = os.Rename(filepath.Join(tlsDir, x509Filename(name)),
-		filepath.Join(tlsDir, tlsFilePrefix+".crt"))
-	if err != nil {
-		return err
-	}
+	tlsFilePrefix := tlsFilePrefix(nodeType)
 
 	err = keyExport(tlsDir, filepath.Join(tlsDir, tlsFilePrefix+".key"), tlsPrivKey)
 	if err != nil {
@@ -157,26 +169,25 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	return nil
 }
 
-func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs bool) error {
+// GenerateVerifyingMSP generates the MSP of an organization. If nodeOUs is not
+// nil, the MSP classifies identities by their organizational unit identifiers.
+func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs *NodeOUs) error {
 
 	// create folder structure and write artifacts to proper locations
 	err := createFolderStructure(baseDir, false)
 	if err == nil {
-		// the signing CA certificate goes into cacerts
-		err = x509Export(filepath.Join(baseDir, "cacerts", x509Filename(signCA.Name)), signCA.SignCert)
-		if err != nil {
-			return err
-		}
-		// the TLS CA certificate goes into tlscacerts
-		err = x509Export(filepath.Join(baseDir, "tlscacerts", x509Filename(tlsCA.Name)), tlsCA.SignCert)
+		err = exportCACerts(baseDir, signCA, tlsCA)
 		if err != nil {
 			return err
 		}
 	}
 
 	// generate config.yaml if required
-	if nodeOUs {
-		exportConfig(baseDir, "cacerts/"+x509Filename(signCA.Name), true)
+	if nodeOUs != nil {
+		err = exportConfig(baseDir, ouCertificate(signCA), nodeOUs)
+		if err != nil {
+			return err
+		}
 	}
 
 	// create a throwaway cert to act as an admin cert
@@ -200,6 +211,153 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs b
 	return nil
 }
 
+// RenewLocalMSP issues new signing and TLS certificates, with the same keys, for the
+// node whose artifacts were generated in baseDir by GenerateLocalMSP, provided they
+// expire before the given time, and refreshes the CA certificates of the MSP
+func RenewLocalMSP(baseDir, name string, signCA *ca.CA, tlsCA *ca.CA, nodeType int, expiringBefore time.Time) error {
+	mspDir := filepath.Join(baseDir, "msp")
+	tlsDir := filepath.Join(baseDir, "tls")
+
+	signCertsDir := filepath.Join(mspDir, "signcerts")
+	cert, err := loadCertificate(filepath.Join(signCertsDir, x509Filename(name)))
+	if err != nil {
+		return err
+	}
+	if cert.NotAfter.Before(expiringBefore) {
+		cert, err = signCA.RenewCertificate(signCertsDir, name, cert)
+		if err != nil {
+			return err
+		}
+		// the signing identity of the MSP is also one of its admins
+		adminCert := filepath.Join(mspDir, "admincerts", x509Filename(name))
+		if _, err := os.Stat(adminCert); err == nil {
+			err = x509Export(adminCert, cert)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = RenewVerifyingMSP(mspDir, signCA, tlsCA)
+	if err != nil {
+		return err
+	}
+
+	tlsCertFile := filepath.Join(tlsDir, tlsFilePrefix(nodeType)+".crt")
+	tlsCert, err := loadCertificate(tlsCertFile)
+	if err != nil {
+		return err
+	}
+	if tlsCert.NotAfter.Before(expiringBefore) {
+		tlsCert, err = tlsCA.RenewCertificate(tlsDir, name, tlsCert)
+		if err != nil {
+			return err
+		}
+	}
+	return exportTLSCerts(tlsDir, name, tlsCert, tlsCA, nodeType)
+}
+
+// RenewVerifyingMSP refreshes the CA certificates of an MSP, after they were renewed
+func RenewVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA) error {
+	return exportCACerts(baseDir, signCA, tlsCA)
+}
+
+// exportCACerts writes the root and intermediate certificates of the
+// signing and TLS CAs into the corresponding folders of the MSP
+func exportCACerts(mspDir string, signCA *ca.CA, tlsCA *ca.CA) error {
+	if signCA.SignCert == nil || tlsCA.SignCert == nil {
+		return errors.New("CA certificate is missing")
+	}
+	folders := []struct {
+		name  string
+		ca    *ca.CA
+		certs []*x509.Certificate
+	}{
+		// the signing CA certificates go into cacerts and intermediatecerts
+		{"cacerts", signCA, []*x509.Certificate{signCA.RootCert()}},
+		{"intermediatecerts", signCA, signCA.IntermediateCerts()},
+		// the TLS CA certificates go into tlscacerts and tlsintermediatecerts
+		{"tlscacerts", tlsCA, []*x509.Certificate{tlsCA.RootCert()}},
+		{"tlsintermediatecerts", tlsCA, tlsCA.IntermediateCerts()},
+	}
+	for _, folder := range folders {
+		if len(folder.certs) == 0 {
+			continue
+		}
+		err := os.MkdirAll(filepath.Join(mspDir, folder.name), 0755)
+		if err != nil {
+			return err
+		}
+		for _, cert := range folder.certs {
+			// the certificate of the CA itself is named after the CA,
+			// those of its parents after their common names
+			name := cert.Subject.CommonName
+			if cert == folder.ca.SignCert {
+				name = folder.ca.Name
+			}
+			err = x509Export(filepath.Join(mspDir, folder.name, x509Filename(name)), cert)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// exportTLSCerts writes the TLS certificate of the node, followed by the
+// intermediate certificates of its TLS CA, and the TLS root CA certificate
+func exportTLSCerts(tlsDir, name string, tlsCert *x509.Certificate, tlsCA *ca.CA, nodeType int) error {
+	err := x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.RootCert())
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(tlsDir, x509Filename(name)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	file, err := os.Create(filepath.Join(tlsDir, tlsFilePrefix(nodeType)+".crt"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, cert := range append([]*x509.Certificate{tlsCert}, tlsCA.IntermediateCerts()...) {
+		err = pem.Encode(file, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ouCertificate returns the path, relative to the MSP folder, of the certificate
+// of the CA which issues the identities classified by organizational units
+func ouCertificate(signCA *ca.CA) string {
+	if len(signCA.ParentCerts) != 0 {
+		return "intermediatecerts/" + x509Filename(signCA.Name)
+	}
+	return "cacerts/" + x509Filename(signCA.Name)
+}
+
+func tlsFilePrefix(nodeType int) string {
+	if nodeType == CLIENT {
+		return "client"
+	}
+	return "server"
+}
+
+func loadCertificate(path string) (*x509.Certificate, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.Errorf("no PEM encoded certificate found in %s", path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
 func createFolderStructure(rootDir string, local bool) error {
 
 	var folders []string
@@ -249,17 +407,17 @@ func pemExport(path, pemType string, bytes []byte) error {
 	return pem.Encode(file, &pem.Block{Type: pemType, Bytes: bytes})
 }
 
-func exportConfig(mspDir, caFile string, enable bool) error {
+func exportConfig(mspDir, caFile string, nodeOUs *NodeOUs) error {
 	var config = &fabricmsp.Configuration{
 		NodeOUs: &fabricmsp.NodeOUs{
-			Enable: enable,
+			Enable: true,
 			ClientOUIdentifier: &fabricmsp.OrganizationalUnitIdentifiersConfiguration{
 				Certificate:                  caFile,
-				OrganizationalUnitIdentifier: CLIENTOU,
+				OrganizationalUnitIdentifier: nodeOUs.ClientOUIdentifier,
 			},
 			PeerOUIdentifier: &fabricmsp.OrganizationalUnitIdentifiersConfiguration{
 				Certificate:                  caFile,
-				OrganizationalUnitIdentifier: PEEROU,
+				OrganizationalUnitIdentifier: nodeOUs.PeerOUIdentifier,
 			},
 		},
 	}
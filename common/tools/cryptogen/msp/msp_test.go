@@ -9,7 +9,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
@@ -37,7 +39,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 
 	cleanup(testDir)
 
-	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, true)
+	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, msp.DefaultNodeOUs)
 	assert.Error(t, err, "Empty CA should have failed")
 
 	caDir := filepath.Join(testDir, "ca")
@@ -66,7 +68,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 	assert.Equal(t, testPostalCode, signCA.SignCert.Subject.PostalCode[0], "Failed to match postalCode")
 
 	// generate local MSP for nodeType=PEER
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, msp.DefaultNodeOUs)
 	assert.NoError(t, err, "Failed to generate local MSP")
 
 	// check to see that the right files were generated/saved
@@ -94,7 +96,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 	}
 
 	// generate local MSP for nodeType=CLIENT
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, msp.DefaultNodeOUs)
 	assert.NoError(t, err, "Failed to generate local MSP")
 	//only need to check for the TLS certs
 	tlsFiles = []string{
@@ -117,10 +119,10 @@ func TestGenerateLocalMSP(t *testing.T) {
 	assert.NoError(t, err, "Error setting up local MSP")
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, msp.DefaultNodeOUs)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.ORDERER, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.ORDERER, msp.DefaultNodeOUs)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
@@ -139,7 +141,7 @@ func TestGenerateVerifyingMSP(t *testing.T) {
 	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
 	assert.NoError(t, err, "Error generating CA")
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, msp.DefaultNodeOUs)
 	assert.NoError(t, err, "Failed to generate verifying MSP")
 
 	// check to see that the right files were generated/saved
@@ -163,15 +165,79 @@ func TestGenerateVerifyingMSP(t *testing.T) {
 	assert.NoError(t, err, "Error setting up verifying MSP")
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, msp.DefaultNodeOUs)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, msp.DefaultNodeOUs)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
 }
 
+func TestGenerateLocalMSPWithIntermediateCA(t *testing.T) {
+	cleanup(testDir)
+
+	signCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, "tls"+testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+	signICA, err := signCA.NewIntermediateCA(filepath.Join(testDir, "ica"), testCAOrg, "i"+testCAName)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	tlsICA, err := tlsCA.NewIntermediateCA(filepath.Join(testDir, "tlsica"), testCAOrg, "tlsi"+testCAName)
+	assert.NoError(t, err, "Error generating intermediate CA")
+
+	// the BCCSP factory is initialized once, with the keystore of the first local MSP
+	nodeDir := testDir
+	mspDir := filepath.Join(nodeDir, "msp")
+	tlsDir := filepath.Join(nodeDir, "tls")
+	err = msp.GenerateLocalMSP(nodeDir, testName, nil, signICA, tlsICA, msp.PEER, msp.DefaultNodeOUs)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	files := []string{
+		filepath.Join(mspDir, "cacerts", testCAName+"-cert.pem"),
+		filepath.Join(mspDir, "intermediatecerts", "i"+testCAName+"-cert.pem"),
+		filepath.Join(mspDir, "tlscacerts", "tls"+testCAName+"-cert.pem"),
+		filepath.Join(mspDir, "tlsintermediatecerts", "tlsi"+testCAName+"-cert.pem"),
+	}
+	for _, file := range files {
+		assert.Equal(t, true, checkForFile(file),
+			"Expected to find file "+file)
+	}
+	setupLocalMSP(t, mspDir)
+
+	// the TLS certificate is followed by the intermediate certificates
+	serverCerts, err := ioutil.ReadFile(filepath.Join(tlsDir, "server.crt"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(serverCerts), "BEGIN CERTIFICATE"))
+
+	// renew the certificates, the MSP must still be valid
+	signCert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	err = msp.RenewLocalMSP(nodeDir, testName, signICA, tlsICA, msp.PEER, time.Now())
+	assert.NoError(t, err, "Failed to renew local MSP")
+	unchanged, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.True(t, signCert.Equal(unchanged), "Certificates which don't expire soon should not be renewed")
+
+	err = msp.RenewLocalMSP(nodeDir, testName, signICA, tlsICA, msp.PEER, signCert.NotAfter.Add(time.Hour))
+	assert.NoError(t, err, "Failed to renew local MSP")
+	renewed, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, signCert.SerialNumber, renewed.SerialNumber)
+	assert.Equal(t, signCert.PublicKey, renewed.PublicKey)
+	setupLocalMSP(t, mspDir)
+	cleanup(testDir)
+}
+
+func setupLocalMSP(t *testing.T, mspDir string) {
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_0}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+}
+
 func TestExportConfig(t *testing.T) {
 	path := filepath.Join(testDir, "export-test")
 	configFile := filepath.Join(path, "config.yaml")
@@ -182,7 +248,7 @@ func TestExportConfig(t *testing.T) {
 		t.Fatalf("failed to create test directory: [%s]", err)
 	}
 
-	err = msp.ExportConfig(path, caFile, true)
+	err = msp.ExportConfig(path, caFile, msp.DefaultNodeOUs)
 	assert.NoError(t, err)
 
 	configBytes, err := ioutil.ReadFile(configFile)
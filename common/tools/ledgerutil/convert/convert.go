@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package convert
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	kvledger "github.com/hyperledger/fabric/common/ledger/blockledger/kv"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil.convert")
+
+// Options holds the parameters of a conversion
+type Options struct {
+	// FileLedgerDir is the location of the file ledger of the orderer, holding the `chains` directory
+	FileLedgerDir string
+	// KVLedgerDir is the location of the kv ledger the blocks are copied to
+	KVLedgerDir string
+	// ChainIDs lists the chains to convert, all the chains of the file ledger if empty
+	ChainIDs []string
+}
+
+// Report is the machine-readable summary of a conversion
+type Report struct {
+	Chains []*ChainResult `json:"chains"`
+}
+
+// ChainResult is the outcome of the conversion of a single chain. Copied is the number
+// of blocks copied by this conversion, which is less than Height when a previous
+// conversion of the chain was interrupted.
+type ChainResult struct {
+	ChainID string `json:"chain_id"`
+	Height  uint64 `json:"height"`
+	Copied  uint64 `json:"copied"`
+}
+
+// Run copies the blocks of the chains selected by the options from the file ledger
+// to the kv ledger. The file ledger is only read, and a conversion which was
+// interrupted resumes from the height the chains reached in the kv ledger.
+func Run(opts *Options) (*Report, error) {
+	chainIDs := opts.ChainIDs
+	if len(chainIDs) == 0 {
+		var err error
+		if chainIDs, err = util.ListSubdirs(filepath.Join(opts.FileLedgerDir, fsblkstorage.ChainsDir)); err != nil {
+			return nil, errors.Wrapf(err, "failed to list the chains in [%s]", opts.FileLedgerDir)
+		}
+	}
+
+	kvlf := kvledger.New(opts.KVLedgerDir)
+	defer kvlf.Close()
+
+	report := &Report{Chains: []*ChainResult{}}
+	for _, chainID := range chainIDs {
+		result, err := convertChain(opts.FileLedgerDir, chainID, kvlf)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to convert chain "+chainID)
+		}
+		report.Chains = append(report.Chains, result)
+	}
+	return report, nil
+}
+
+// convertChain appends to the kv ledger the blocks of the chain it does not hold yet
+func convertChain(fileLedgerDir, chainID string, kvlf blockledger.Factory) (*ChainResult, error) {
+	scanner, err := fsblkstorage.NewBlockfileScanner(fileLedgerDir, chainID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer scanner.Close()
+
+	kvl, err := kvlf.GetOrCreate(chainID)
+	if err != nil {
+		return nil, err
+	}
+	result := &ChainResult{ChainID: chainID}
+	resumeHeight := kvl.Height()
+	if resumeHeight > 0 {
+		logger.Infof("Resuming the conversion of chain %s at block %d", chainID, resumeHeight)
+	}
+
+	for {
+		block, err := scanner.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read the block files")
+		}
+		if block == nil {
+			break
+		}
+		if block.Header == nil || block.Data == nil {
+			return nil, errors.Errorf("block after block %d is malformed", result.Height)
+		}
+		if block.Header.Number < resumeHeight {
+			result.Height++
+			continue
+		}
+		if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+			return nil, errors.Errorf("the data hash of block %d does not match its data", block.Header.Number)
+		}
+		if err := kvl.Append(block); err != nil {
+			return nil, err
+		}
+		result.Height++
+		result.Copied++
+	}
+
+	if result.Height < resumeHeight {
+		return nil, errors.Errorf("the kv ledger holds %d blocks, more than the %d blocks of the file ledger", resumeHeight, result.Height)
+	}
+	logger.Infof("Converted chain %s: copied %d blocks, height is %d", chainID, result.Copied, result.Height)
+	return result, nil
+}
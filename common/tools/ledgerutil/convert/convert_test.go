@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package convert
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
+	kvledger "github.com/hyperledger/fabric/common/ledger/blockledger/kv"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// appendBlocks appends numBlocks blocks carrying the given tag to the chain of the
+// file ledger in dir, starting with a genesis block if the chain is empty
+func appendBlocks(t *testing.T, dir, chainID, tag string, numBlocks int) {
+	flf := fileledger.New(dir)
+	defer flf.Close()
+	fl, err := flf.GetOrCreate(chainID)
+	assert.NoError(t, err)
+	for i := 0; i < numBlocks; i++ {
+		var block *cb.Block
+		if fl.Height() == 0 {
+			block = cb.NewBlock(0, nil)
+			block.Data = &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(&cb.Envelope{Payload: []byte(chainID + " " + tag)})}}
+			block.Header.DataHash = block.Data.Hash()
+		} else {
+			block = blockledger.CreateNextBlock(fl, []*cb.Envelope{{Payload: []byte(fmt.Sprintf("%s %s block %d", chainID, tag, fl.Height()))}})
+		}
+		assert.NoError(t, fl.Append(block))
+	}
+}
+
+// readBlocks returns the blocks of the chain
+func readBlocks(t *testing.T, lf blockledger.Factory, chainID string) []*cb.Block {
+	l, err := lf.GetOrCreate(chainID)
+	assert.NoError(t, err)
+	var blocks []*cb.Block
+	it, _ := l.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	defer it.Close()
+	for uint64(len(blocks)) < l.Height() {
+		block, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// assertSameBlocks checks that the kv ledger holds the same blocks as the file ledger
+func assertSameBlocks(t *testing.T, fileDir, kvDir string, chainIDs ...string) {
+	flf := fileledger.New(fileDir)
+	defer flf.Close()
+	kvlf := kvledger.New(kvDir)
+	defer kvlf.Close()
+	for _, chainID := range chainIDs {
+		assert.Equal(t, readBlocks(t, flf, chainID), readBlocks(t, kvlf, chainID))
+	}
+}
+
+func TestConvert(t *testing.T) {
+	fileDir, err := ioutil.TempDir("", "ledgerutil-convert-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(fileDir)
+	kvDir, err := ioutil.TempDir("", "ledgerutil-convert-kv")
+	assert.NoError(t, err)
+	defer os.RemoveAll(kvDir)
+
+	appendBlocks(t, fileDir, "syschannel", "", 3)
+	appendBlocks(t, fileDir, "mychannel", "", 5)
+
+	report, err := Run(&Options{FileLedgerDir: fileDir, KVLedgerDir: kvDir})
+	assert.NoError(t, err)
+	assert.Equal(t, []*ChainResult{
+		{ChainID: "mychannel", Height: 5, Copied: 5},
+		{ChainID: "syschannel", Height: 3, Copied: 3},
+	}, report.Chains)
+	assertSameBlocks(t, fileDir, kvDir, "mychannel", "syschannel")
+
+	t.Run("Resume", func(t *testing.T) {
+		appendBlocks(t, fileDir, "mychannel", "", 2)
+		report, err := Run(&Options{FileLedgerDir: fileDir, KVLedgerDir: kvDir, ChainIDs: []string{"mychannel"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []*ChainResult{{ChainID: "mychannel", Height: 7, Copied: 2}}, report.Chains)
+		assertSameBlocks(t, fileDir, kvDir, "mychannel")
+	})
+
+	t.Run("UnknownChain", func(t *testing.T) {
+		_, err := Run(&Options{FileLedgerDir: fileDir, KVLedgerDir: kvDir, ChainIDs: []string{"nochannel"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to convert chain nochannel: no block files for ledger [nochannel]")
+	})
+
+	t.Run("DivergedChain", func(t *testing.T) {
+		otherFileDir, err := ioutil.TempDir("", "ledgerutil-convert-file")
+		assert.NoError(t, err)
+		defer os.RemoveAll(otherFileDir)
+		appendBlocks(t, otherFileDir, "syschannel", "other", 4)
+
+		_, err = Run(&Options{FileLedgerDir: otherFileDir, KVLedgerDir: kvDir})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to convert chain syschannel: block should have had previous hash")
+	})
+
+	t.Run("ShorterChain", func(t *testing.T) {
+		otherFileDir, err := ioutil.TempDir("", "ledgerutil-convert-file")
+		assert.NoError(t, err)
+		defer os.RemoveAll(otherFileDir)
+		appendBlocks(t, otherFileDir, "syschannel", "", 2)
+
+		_, err = Run(&Options{FileLedgerDir: otherFileDir, KVLedgerDir: kvDir})
+		assert.EqualError(t, err, "failed to convert chain syschannel: the kv ledger holds 3 blocks, more than the 2 blocks of the file ledger")
+	})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/convert"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/metadata"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/statedump"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/verify"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const couchMaxRetries = 3
+
+// command line flags
+var (
+	app = kingpin.New("ledgerutil", "Utility for inspecting Hyperledger Fabric ledgers offline")
+
+	verifyCmd        = app.Command("verify", "Verifies the integrity of the block files and block index of stopped or copied ledgers.")
+	verifyDir        = verifyCmd.Flag("blockstore", "The block store directory, containing the 'chains' and 'index' directories. For a peer, this is <fileSystemPath>/ledgersData/chains.").Required().ExistingDir()
+	verifyLedgerType = verifyCmd.Flag("ledger-type", "The type of the ledgers, 'peer' or 'orderer'.").Default(string(verify.PeerLedger)).Enum(string(verify.PeerLedger), string(verify.OrdererLedger))
+	verifyChannels   = verifyCmd.Flag("channel", "A channel to verify, may be repeated. All the channels are verified by default.").Strings()
+	verifySkipIndex  = verifyCmd.Flag("skip-index", "Do not cross-check the block index, for copies without the 'index' directory.").Bool()
+	verifyOutput     = verifyCmd.Flag("output", "A file to write the JSON report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	dumpStateCmd          = app.Command("dump-state", "Dumps the state database of a channel of a stopped peer in a canonical form, with a digest per namespace.")
+	dumpStateChannel      = dumpStateCmd.Flag("channel", "The channel whose state is dumped.").Required().String()
+	dumpStateLevelDB      = dumpStateCmd.Flag("leveldb", "The LevelDB state database directory, <fileSystemPath>/ledgersData/stateLeveldb.").ExistingDir()
+	dumpStateCouchDB      = dumpStateCmd.Flag("couchdb-address", "The address of the CouchDB state database, of the form host:port.").String()
+	dumpStateCouchUser    = dumpStateCmd.Flag("couchdb-username", "The user name to authenticate to CouchDB with.").String()
+	dumpStateCouchPass    = dumpStateCmd.Flag("couchdb-password", "The password to authenticate to CouchDB with.").String()
+	dumpStateCouchTimeout = dumpStateCmd.Flag("couchdb-timeout", "The timeout of the requests to CouchDB.").Default("35s").Duration()
+	dumpStateOutput       = dumpStateCmd.Flag("output", "A file to write the state dump to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	compareStateCmd    = app.Command("compare-state", "Lists the keys whose values or versions differ between two state dumps of a channel.")
+	compareStateFirst  = compareStateCmd.Flag("first", "The state dump of the first peer.").Required().File()
+	compareStateSecond = compareStateCmd.Flag("second", "The state dump of the second peer.").Required().File()
+	compareStateOutput = compareStateCmd.Flag("output", "A file to write the JSON report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	convertCmd      = app.Command("convert-orderer", "Copies the blocks of the file ledger of a stopped orderer to a kv ledger, resuming an interrupted conversion.")
+	convertFrom     = convertCmd.Flag("from", "The file ledger directory, the FileLedger.Location of the orderer, containing the 'chains' directory.").Required().ExistingDir()
+	convertTo       = convertCmd.Flag("to", "The kv ledger directory, which becomes the FileLedger.Location of the orderer once its LedgerType is kv.").Required().String()
+	convertChannels = convertCmd.Flag("channel", "A channel to convert, may be repeated. All the channels are converted by default.").Strings()
+	convertOutput   = convertCmd.Flag("output", "A file to write the JSON report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	kingpin.Version("0.0.1")
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	case verifyCmd.FullCommand():
+		defer (*verifyOutput).Close()
+		valid, err := runVerify(&verify.Options{
+			BlockStorageDir: *verifyDir,
+			LedgerType:      verify.LedgerType(*verifyLedgerType),
+			LedgerIDs:       *verifyChannels,
+			SkipIndex:       *verifySkipIndex,
+		}, *verifyOutput)
+		if err != nil {
+			app.Fatalf("Error verifying ledgers: %s", err)
+		}
+		if !valid {
+			(*verifyOutput).Close()
+			os.Exit(1)
+		}
+	case dumpStateCmd.FullCommand():
+		defer (*dumpStateOutput).Close()
+		if err := dumpState(*dumpStateChannel, *dumpStateOutput); err != nil {
+			app.Fatalf("Error dumping state: %s", err)
+		}
+	case compareStateCmd.FullCommand():
+		defer (*compareStateFirst).Close()
+		defer (*compareStateSecond).Close()
+		defer (*compareStateOutput).Close()
+		identical, err := compareState(*compareStateFirst, *compareStateSecond, *compareStateOutput)
+		if err != nil {
+			app.Fatalf("Error comparing states: %s", err)
+		}
+		if !identical {
+			(*compareStateOutput).Close()
+			os.Exit(1)
+		}
+	case convertCmd.FullCommand():
+		defer (*convertOutput).Close()
+		if err := runConvert(&convert.Options{
+			FileLedgerDir: *convertFrom,
+			KVLedgerDir:   *convertTo,
+			ChainIDs:      *convertChannels,
+		}, *convertOutput); err != nil {
+			app.Fatalf("Error converting ledgers: %s", err)
+		}
+	// "version" command
+	case version.FullCommand():
+		printVersion()
+	}
+}
+
+func printVersion() {
+	fmt.Println(metadata.GetVersionInfo())
+}
+
+// runVerify writes the report of the verification and tells whether all the ledgers are valid
+func runVerify(opts *verify.Options, output io.Writer) (bool, error) {
+	if err := factory.InitFactories(nil); err != nil {
+		return false, errors.Wrap(err, "failed to initialize the crypto provider")
+	}
+	report, err := verify.Run(opts)
+	if err != nil {
+		return false, err
+	}
+	out, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal the report")
+	}
+	if _, err := fmt.Fprintln(output, string(out)); err != nil {
+		return false, errors.Wrap(err, "failed to write the report")
+	}
+	return report.Valid, nil
+}
+
+func dumpState(channel string, output io.Writer) error {
+	var provider statedb.VersionedDBProvider
+	switch {
+	case *dumpStateLevelDB != "" && *dumpStateCouchDB != "":
+		return errors.New("only one of --leveldb and --couchdb-address can be specified")
+	case *dumpStateLevelDB != "":
+		// opening a directory which does not hold a LevelDB database panics
+		if _, err := os.Stat(filepath.Join(*dumpStateLevelDB, "CURRENT")); err != nil {
+			return errors.Errorf("[%s] does not hold a LevelDB database", *dumpStateLevelDB)
+		}
+		provider = stateleveldb.NewReadOnlyVersionedDBProvider(*dumpStateLevelDB)
+	case *dumpStateCouchDB != "":
+		couchInstance, err := couchdb.CreateCouchInstance(*dumpStateCouchDB, *dumpStateCouchUser, *dumpStateCouchPass,
+			couchMaxRetries, couchMaxRetries, *dumpStateCouchTimeout)
+		if err != nil {
+			return errors.WithMessage(err, "failed to connect to CouchDB")
+		}
+		provider = statecouchdb.NewVersionedDBProviderWithInstance(couchInstance)
+	default:
+		return errors.New("one of --leveldb and --couchdb-address is required")
+	}
+	defer provider.Close()
+
+	db, err := provider.GetDBHandle(channel)
+	if err != nil {
+		return errors.WithMessage(err, "failed to open the state database")
+	}
+	dump, err := statedump.Create(channel, db)
+	if err != nil {
+		return err
+	}
+	return dump.Write(output)
+}
+
+// compareState writes the differences between two state dumps and tells whether they are identical
+func compareState(first, second io.Reader, output io.Writer) (bool, error) {
+	firstDump, err := statedump.Read(first)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to read the first dump")
+	}
+	secondDump, err := statedump.Read(second)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to read the second dump")
+	}
+	comparison, err := statedump.Compare(firstDump, secondDump)
+	if err != nil {
+		return false, err
+	}
+	out, err := json.MarshalIndent(comparison, "", "\t")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal the report")
+	}
+	if _, err := fmt.Fprintln(output, string(out)); err != nil {
+		return false, errors.Wrap(err, "failed to write the report")
+	}
+	return comparison.Identical, nil
+}
+
+// runConvert writes the report of the conversion
+func runConvert(opts *convert.Options, output io.Writer) error {
+	report, err := convert.Run(opts)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the report")
+	}
+	if _, err := fmt.Fprintln(output, string(out)); err != nil {
+		return errors.Wrap(err, "failed to write the report")
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedump
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Kinds of differences between two dumps
+const (
+	// DiffMissingInFirst denotes a key only present in the second dump
+	DiffMissingInFirst = "missing_in_first"
+	// DiffMissingInSecond denotes a key only present in the first dump
+	DiffMissingInSecond = "missing_in_second"
+	// DiffValue denotes a key with different values
+	DiffValue = "value"
+	// DiffVersion denotes a key with the same value, last written at different heights
+	DiffVersion = "version"
+)
+
+// Comparison lists the differences between two dumps of the state of a channel.
+// Dumps taken at different savepoints are expected to differ by the keys
+// written between the two savepoints
+type Comparison struct {
+	Identical       bool          `json:"identical"`
+	FirstSavepoint  *Height       `json:"first_savepoint,omitempty"`
+	SecondSavepoint *Height       `json:"second_savepoint,omitempty"`
+	Differences     []*Difference `json:"differences"`
+}
+
+// Difference is a key which differs between two dumps, with its entry in each dump
+type Difference struct {
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection,omitempty"`
+	Key        string `json:"key"`
+	Kind       string `json:"kind"`
+	First      *Entry `json:"first,omitempty"`
+	Second     *Entry `json:"second,omitempty"`
+}
+
+// Read reads a dump written by Write
+func Read(r io.Reader) (*Dump, error) {
+	dump := &Dump{}
+	if err := json.NewDecoder(r).Decode(dump); err != nil {
+		return nil, errors.Wrap(err, "malformed state dump")
+	}
+	return dump, nil
+}
+
+// Write writes a dump in JSON
+func (d *Dump) Write(w io.Writer) error {
+	out, err := json.MarshalIndent(d, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the state dump")
+	}
+	_, err = w.Write(append(out, '\n'))
+	return err
+}
+
+// Compare lists the keys which differ between two dumps of the same channel.
+// The namespaces with the same digest are not compared key by key
+func Compare(first, second *Dump) (*Comparison, error) {
+	if first.Channel != second.Channel {
+		return nil, errors.Errorf("the dumps are for different channels [%s] and [%s]", first.Channel, second.Channel)
+	}
+	comparison := &Comparison{
+		FirstSavepoint:  first.Savepoint,
+		SecondSavepoint: second.Savepoint,
+		Differences:     []*Difference{},
+	}
+
+	firstNamespaces := namespacesByName(first)
+	secondNamespaces := namespacesByName(second)
+	var names []string
+	for name := range firstNamespaces {
+		names = append(names, name)
+	}
+	for name := range secondNamespaces {
+		if _, ok := firstNamespaces[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		firstNs, secondNs := firstNamespaces[name], secondNamespaces[name]
+		if firstNs != nil && secondNs != nil && firstNs.Digest == secondNs.Digest {
+			continue
+		}
+		comparison.Differences = append(comparison.Differences, compareNamespaces(firstNs, secondNs)...)
+	}
+	comparison.Identical = len(comparison.Differences) == 0
+	return comparison, nil
+}
+
+func namespacesByName(dump *Dump) map[string]*Namespace {
+	namespaces := make(map[string]*Namespace)
+	for _, namespace := range dump.Namespaces {
+		namespaces[namespace.name()] = namespace
+	}
+	return namespaces
+}
+
+// compareNamespaces compares the entries of a namespace, either of which may be missing
+func compareNamespaces(first, second *Namespace) []*Difference {
+	ns := first
+	if ns == nil {
+		ns = second
+	}
+	firstEntries, secondEntries := entriesByKey(first), entriesByKey(second)
+	var keys []string
+	for key := range firstEntries {
+		keys = append(keys, key)
+	}
+	for key := range secondEntries {
+		if _, ok := firstEntries[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var differences []*Difference
+	for _, key := range keys {
+		firstEntry, secondEntry := firstEntries[key], secondEntries[key]
+		var kind string
+		switch {
+		case firstEntry == nil:
+			kind = DiffMissingInFirst
+		case secondEntry == nil:
+			kind = DiffMissingInSecond
+		case firstEntry.Value != secondEntry.Value || firstEntry.ValueEncoding != secondEntry.ValueEncoding:
+			kind = DiffValue
+		case firstEntry.Version != secondEntry.Version:
+			kind = DiffVersion
+		default:
+			continue
+		}
+		entry := firstEntry
+		if entry == nil {
+			entry = secondEntry
+		}
+		differences = append(differences, &Difference{
+			Namespace:  ns.Namespace,
+			Collection: ns.Collection,
+			Key:        entry.Key,
+			Kind:       kind,
+			First:      firstEntry,
+			Second:     secondEntry,
+		})
+	}
+	return differences
+}
+
+func entriesByKey(ns *Namespace) map[string]*Entry {
+	entries := make(map[string]*Entry)
+	if ns == nil {
+		return entries
+	}
+	for _, entry := range ns.Entries {
+		entries[entry.KeyEncoding+":"+entry.Key] = entry
+	}
+	return entries
+}
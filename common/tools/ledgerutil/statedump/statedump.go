@@ -0,0 +1,263 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedump
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil.statedump")
+
+const (
+	nsJoiner       = "$$"
+	pvtDataPrefix  = "p"
+	hashDataPrefix = "h"
+
+	// EncodingHex denotes a hex encoded key or value
+	EncodingHex = "hex"
+	// EncodingBase64 denotes a base64 encoded value
+	EncodingBase64 = "base64"
+)
+
+// Dump is the canonical form of the state of a channel. The namespaces are sorted by
+// name and the entries by key, and the JSON values are re-encoded with sorted fields,
+// so that the dumps of peers using different state databases can be compared
+type Dump struct {
+	Channel    string       `json:"channel"`
+	Savepoint  *Height      `json:"savepoint,omitempty"`
+	Digest     string       `json:"digest"`
+	Namespaces []*Namespace `json:"namespaces"`
+}
+
+// Height is the position of a transaction in the chain
+type Height struct {
+	BlockNum uint64 `json:"block_num"`
+	TxNum    uint64 `json:"tx_num"`
+}
+
+// Namespace holds the entries of the public state of a chaincode, or the hashes
+// of the private data of a collection of the chaincode if Collection is set
+type Namespace struct {
+	Namespace  string   `json:"namespace"`
+	Collection string   `json:"collection,omitempty"`
+	Digest     string   `json:"digest"`
+	Entries    []*Entry `json:"entries"`
+}
+
+// Entry is a key with its value and the height of the transaction which last wrote it.
+// Keys and values are strings unless an encoding is specified. The keys and values of
+// private data hashes are hex encoded
+type Entry struct {
+	Key           string `json:"key"`
+	KeyEncoding   string `json:"key_encoding,omitempty"`
+	Value         string `json:"value"`
+	ValueEncoding string `json:"value_encoding,omitempty"`
+	Version       Height `json:"version"`
+}
+
+// Create dumps the state of a channel. The private data itself is left out, as it is
+// only held by the peers of the members of the collections
+func Create(channel string, db statedb.VersionedDB) (*Dump, error) {
+	lister, ok := db.(statedb.NamespaceLister)
+	if !ok {
+		return nil, errors.New("the state database cannot list its namespaces")
+	}
+	namespaces, err := lister.GetNamespaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the namespaces")
+	}
+
+	dump := &Dump{Channel: channel, Namespaces: []*Namespace{}}
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the savepoint")
+	}
+	if savepoint != nil {
+		dump.Savepoint = &Height{BlockNum: savepoint.BlockNum, TxNum: savepoint.TxNum}
+	}
+
+	for _, ns := range namespaces {
+		namespace := &Namespace{Namespace: ns}
+		hashed := false
+		if split := strings.SplitN(ns, nsJoiner, 2); len(split) == 2 {
+			if strings.HasPrefix(split[1], pvtDataPrefix) {
+				logger.Debugf("Skipping private data namespace [%s]", ns)
+				continue
+			}
+			if !strings.HasPrefix(split[1], hashDataPrefix) {
+				return nil, errors.Errorf("unexpected namespace [%s]", ns)
+			}
+			namespace.Namespace, namespace.Collection = split[0], split[1][len(hashDataPrefix):]
+			hashed = true
+		}
+		kvs, err := readNamespace(db, ns)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read namespace "+ns)
+		}
+		if err := namespace.setEntries(kvs, hashed, !db.BytesKeySuppoted()); err != nil {
+			return nil, errors.WithMessage(err, "failed to read namespace "+ns)
+		}
+		dump.Namespaces = append(dump.Namespaces, namespace)
+	}
+
+	sort.Slice(dump.Namespaces, func(i, j int) bool {
+		return dump.Namespaces[i].name() < dump.Namespaces[j].name()
+	})
+	h := sha256.New()
+	for _, namespace := range dump.Namespaces {
+		writeBytes(h, []byte(namespace.name()))
+		writeBytes(h, []byte(namespace.Digest))
+	}
+	dump.Digest = hex.EncodeToString(h.Sum(nil))
+	return dump, nil
+}
+
+// readNamespace reads all the keys of a namespace. The range scans of some databases
+// return a limited number of results, so that the scan is resumed from the last key read
+// until no new key is returned
+func readNamespace(db statedb.VersionedDB, ns string) ([]*statedb.VersionedKV, error) {
+	var kvs []*statedb.VersionedKV
+	seen := make(map[string]bool)
+	startKey := ""
+	for {
+		itr, err := db.GetStateRangeScanIterator(ns, startKey, "")
+		if err != nil {
+			return nil, err
+		}
+		newKeys := 0
+		for {
+			result, err := itr.Next()
+			if err != nil {
+				itr.Close()
+				return nil, err
+			}
+			if result == nil {
+				break
+			}
+			kv := result.(*statedb.VersionedKV)
+			if seen[kv.Key] {
+				continue
+			}
+			seen[kv.Key] = true
+			kvs = append(kvs, kv)
+			startKey = kv.Key
+			newKeys++
+		}
+		itr.Close()
+		if newKeys == 0 {
+			return kvs, nil
+		}
+	}
+}
+
+func (n *Namespace) setEntries(kvs []*statedb.VersionedKV, hashed, base64Keys bool) error {
+	type rawEntry struct {
+		key, value []byte
+		version    *version.Height
+	}
+	rawEntries := make([]*rawEntry, 0, len(kvs))
+	for _, kv := range kvs {
+		key := []byte(kv.Key)
+		if hashed && base64Keys {
+			var err error
+			if key, err = base64.StdEncoding.DecodeString(kv.Key); err != nil {
+				return errors.Wrapf(err, "malformed key hash [%s]", kv.Key)
+			}
+		}
+		value := kv.Value
+		if !hashed {
+			value = canonicalValue(value)
+		}
+		rawEntries = append(rawEntries, &rawEntry{key, value, kv.Version})
+	}
+	sort.Slice(rawEntries, func(i, j int) bool {
+		return bytes.Compare(rawEntries[i].key, rawEntries[j].key) < 0
+	})
+
+	h := sha256.New()
+	n.Entries = make([]*Entry, 0, len(rawEntries))
+	for _, e := range rawEntries {
+		writeBytes(h, e.key)
+		writeBytes(h, e.value)
+		writeBytes(h, e.version.ToBytes())
+
+		entry := &Entry{Version: Height{BlockNum: e.version.BlockNum, TxNum: e.version.TxNum}}
+		switch {
+		case hashed:
+			entry.Key, entry.KeyEncoding = hex.EncodeToString(e.key), EncodingHex
+			entry.Value, entry.ValueEncoding = hex.EncodeToString(e.value), EncodingHex
+		default:
+			entry.Key, entry.KeyEncoding = encodeKey(e.key)
+			entry.Value, entry.ValueEncoding = encodeValue(e.value)
+		}
+		n.Entries = append(n.Entries, entry)
+	}
+	n.Digest = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
+
+func (n *Namespace) name() string {
+	if n.Collection == "" {
+		return n.Namespace
+	}
+	return n.Namespace + nsJoiner + hashDataPrefix + n.Collection
+}
+
+// canonicalValue re-encodes JSON objects with sorted fields, as CouchDB does not preserve
+// the encoding of the JSON values it stores
+func canonicalValue(value []byte) []byte {
+	if len(value) == 0 || value[0] != '{' {
+		return value
+	}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return value
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return value
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+func encodeKey(key []byte) (string, string) {
+	if utf8.Valid(key) {
+		return string(key), ""
+	}
+	return hex.EncodeToString(key), EncodingHex
+}
+
+func encodeValue(value []byte) (string, string) {
+	if utf8.Valid(value) {
+		return string(value), ""
+	}
+	return base64.StdEncoding.EncodeToString(value), EncodingBase64
+}
+
+func writeBytes(w io.Writer, b []byte) {
+	var l [binary.MaxVarintLen64]byte
+	w.Write(l[:binary.PutUvarint(l[:], uint64(len(b)))])
+	w.Write(b)
+}
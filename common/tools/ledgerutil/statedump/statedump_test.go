@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedump
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "statedump")
+	if err != nil {
+		panic(err)
+	}
+	viper.Set("peer.fileSystemPath", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+var keyHash = string([]byte{0x00, 0xff, 0x10})
+
+func populate(t *testing.T, db statedb.VersionedDB, overrides func(batch *statedb.UpdateBatch)) {
+	batch := statedb.NewUpdateBatch()
+	batch.Put("mycc", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 0))
+	batch.Put("mycc", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.Put("mycc", string([]byte{0xff, 0xfe}), []byte{0xff}, version.NewHeight(1, 1))
+	batch.Put("othercc", "key1", []byte("value1"), version.NewHeight(2, 0))
+	batch.Put("mycc$$hcoll1", keyHash, []byte{0x01, 0x02}, version.NewHeight(2, 1))
+	batch.Put("mycc$$pcoll1", "key1", []byte("private"), version.NewHeight(2, 1))
+	if overrides != nil {
+		overrides(batch)
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+}
+
+func TestCreate(t *testing.T) {
+	env := stateleveldb.NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("mychannel")
+	assert.NoError(t, err)
+	populate(t, db, nil)
+
+	dump, err := Create("mychannel", db)
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", dump.Channel)
+	assert.Equal(t, &Height{BlockNum: 2, TxNum: 1}, dump.Savepoint)
+	assert.Len(t, dump.Namespaces, 3)
+
+	mycc := dump.Namespaces[0]
+	assert.Equal(t, "mycc", mycc.Namespace)
+	assert.Equal(t, []*Entry{
+		{Key: "key1", Value: `{"owner":"tom","size":1}`, Version: Height{BlockNum: 1}},
+		{Key: "key2", Value: "value2", Version: Height{BlockNum: 1, TxNum: 1}},
+		{Key: "fffe", KeyEncoding: EncodingHex, Value: "/w==", ValueEncoding: EncodingBase64, Version: Height{BlockNum: 1, TxNum: 1}},
+	}, mycc.Entries)
+
+	hashes := dump.Namespaces[1]
+	assert.Equal(t, "mycc", hashes.Namespace)
+	assert.Equal(t, "coll1", hashes.Collection)
+	assert.Equal(t, []*Entry{
+		{Key: "00ff10", KeyEncoding: EncodingHex, Value: "0102", ValueEncoding: EncodingHex, Version: Height{BlockNum: 2, TxNum: 1}},
+	}, hashes.Entries)
+
+	assert.Equal(t, "othercc", dump.Namespaces[2].Namespace)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, dump.Write(buf))
+	read, err := Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, dump, read)
+}
+
+func TestCreateMatchesAcrossDatabases(t *testing.T) {
+	env := stateleveldb.NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("mychannel")
+	assert.NoError(t, err)
+	populate(t, db, nil)
+	dump, err := Create("mychannel", db)
+	assert.NoError(t, err)
+
+	// a database returning a few results per range scan, with base64 encoded key hashes
+	// and JSON values encoded differently, as CouchDB does
+	couchLikeDB, err := env.DBProvider.GetDBHandle("couchlike")
+	assert.NoError(t, err)
+	populate(t, couchLikeDB, func(batch *statedb.UpdateBatch) {
+		batch.Put("mycc", "key1", []byte(`{ "size": 1, "owner": "tom" }`), version.NewHeight(1, 0))
+		batch.Delete("mycc$$hcoll1", keyHash, version.NewHeight(2, 1))
+		batch.Put("mycc$$hcoll1", base64.StdEncoding.EncodeToString([]byte(keyHash)), []byte{0x01, 0x02}, version.NewHeight(2, 1))
+	})
+	otherDump, err := Create("mychannel", &pagingDB{VersionedDB: couchLikeDB, pageSize: 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, dump.Digest, otherDump.Digest)
+	comparison, err := Compare(dump, otherDump)
+	assert.NoError(t, err)
+	assert.True(t, comparison.Identical)
+}
+
+func TestCompare(t *testing.T) {
+	env := stateleveldb.NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db1, err := env.DBProvider.GetDBHandle("peer1")
+	assert.NoError(t, err)
+	populate(t, db1, nil)
+	db2, err := env.DBProvider.GetDBHandle("peer2")
+	assert.NoError(t, err)
+	populate(t, db2, func(batch *statedb.UpdateBatch) {
+		batch.Put("mycc", "key2", []byte("diverged"), version.NewHeight(1, 1))
+		batch.Put("mycc", "key3", []byte("value3"), version.NewHeight(2, 0))
+		batch.Put("othercc", "key1", []byte("value1"), version.NewHeight(1, 5))
+		batch.Delete("mycc$$hcoll1", keyHash, version.NewHeight(2, 1))
+		batch.Put("newcc", "key1", []byte("value1"), version.NewHeight(2, 1))
+	})
+
+	dump1, err := Create("mychannel", db1)
+	assert.NoError(t, err)
+	dump2, err := Create("mychannel", db2)
+	assert.NoError(t, err)
+	assert.NotEqual(t, dump1.Digest, dump2.Digest)
+
+	comparison, err := Compare(dump1, dump2)
+	assert.NoError(t, err)
+	assert.False(t, comparison.Identical)
+	var summary [][]string
+	for _, d := range comparison.Differences {
+		summary = append(summary, []string{d.Namespace, d.Collection, d.Key, d.Kind})
+	}
+	assert.Equal(t, [][]string{
+		{"mycc", "", "key2", DiffValue},
+		{"mycc", "", "key3", DiffMissingInFirst},
+		{"mycc", "coll1", "00ff10", DiffMissingInSecond},
+		{"newcc", "", "key1", DiffMissingInFirst},
+		{"othercc", "", "key1", DiffVersion},
+	}, summary)
+	assert.Equal(t, "value2", comparison.Differences[0].First.Value)
+	assert.Equal(t, "diverged", comparison.Differences[0].Second.Value)
+	assert.Equal(t, Height{BlockNum: 1, TxNum: 5}, comparison.Differences[4].Second.Version)
+
+	comparison, err = Compare(dump1, dump1)
+	assert.NoError(t, err)
+	assert.True(t, comparison.Identical)
+	assert.Empty(t, comparison.Differences)
+
+	_, err = Compare(dump1, &Dump{Channel: "otherchannel"})
+	assert.EqualError(t, err, "the dumps are for different channels [mychannel] and [otherchannel]")
+}
+
+func TestCreateUnsupportedDB(t *testing.T) {
+	_, err := Create("mychannel", struct{ statedb.VersionedDB }{})
+	assert.EqualError(t, err, "the state database cannot list its namespaces")
+}
+
+// pagingDB limits the number of results of the range scans, and does not support bytes keys
+type pagingDB struct {
+	statedb.VersionedDB
+	pageSize int
+}
+
+func (db *pagingDB) GetNamespaces() ([]string, error) {
+	return db.VersionedDB.(statedb.NamespaceLister).GetNamespaces()
+}
+
+func (db *pagingDB) BytesKeySuppoted() bool {
+	return false
+}
+
+func (db *pagingDB) GetStateRangeScanIterator(namespace, startKey, endKey string) (statedb.ResultsIterator, error) {
+	itr, err := db.VersionedDB.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &pagingIterator{ResultsIterator: itr, remaining: db.pageSize}, nil
+}
+
+type pagingIterator struct {
+	statedb.ResultsIterator
+	remaining int
+}
+
+func (itr *pagingIterator) Next() (statedb.QueryResult, error) {
+	if itr.remaining == 0 {
+		return nil, nil
+	}
+	itr.remaining--
+	return itr.ResultsIterator.Next()
+}
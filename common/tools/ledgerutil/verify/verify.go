@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verify
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/policies"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil.verify")
+
+// LedgerType tells whether the block files were written by a peer or by an orderer,
+// which determines the attributes in the block index and the expected validation flags
+type LedgerType string
+
+const (
+	// PeerLedger is the type of the ledgers under the `chains` directory of a peer
+	PeerLedger LedgerType = "peer"
+	// OrdererLedger is the type of the ledgers of an orderer using the file ledger
+	OrdererLedger LedgerType = "orderer"
+)
+
+// Names of the checks reported for the first bad block of a ledger
+const (
+	CheckBlockFiles      = "block_files"
+	CheckBlockNumber     = "block_number"
+	CheckDataHash        = "data_hash"
+	CheckPreviousHash    = "previous_hash"
+	CheckConfig          = "config"
+	CheckSignature       = "signature"
+	CheckValidationFlags = "validation_flags"
+	CheckIndex           = "index"
+)
+
+// Options holds the parameters of a verification
+type Options struct {
+	// BlockStorageDir is the directory holding the `chains` and `index` directories
+	BlockStorageDir string
+	// LedgerType is the type of the ledgers in BlockStorageDir
+	LedgerType LedgerType
+	// LedgerIDs lists the ledgers to verify, all the ledgers in BlockStorageDir if empty
+	LedgerIDs []string
+	// SkipIndex disables the cross-check of the block index, for copies without it
+	SkipIndex bool
+}
+
+// Report is the machine-readable summary of a verification
+type Report struct {
+	Valid   bool            `json:"valid"`
+	Ledgers []*LedgerResult `json:"ledgers"`
+}
+
+// LedgerResult is the outcome of the verification of a single ledger.
+// Height is the number of blocks which passed all the checks
+type LedgerResult struct {
+	LedgerID      string  `json:"ledger_id"`
+	Height        uint64  `json:"height"`
+	Valid         bool    `json:"valid"`
+	FirstBadBlock *uint64 `json:"first_bad_block,omitempty"`
+	Check         string  `json:"check,omitempty"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// Run verifies the ledgers selected by the options
+func Run(opts *Options) (*Report, error) {
+	if opts.LedgerType != PeerLedger && opts.LedgerType != OrdererLedger {
+		return nil, errors.Errorf("unknown ledger type [%s]", opts.LedgerType)
+	}
+	ledgerIDs := opts.LedgerIDs
+	if len(ledgerIDs) == 0 {
+		var err error
+		if ledgerIDs, err = util.ListSubdirs(filepath.Join(opts.BlockStorageDir, fsblkstorage.ChainsDir)); err != nil {
+			return nil, errors.Wrapf(err, "failed to list the ledgers in [%s]", opts.BlockStorageDir)
+		}
+	}
+
+	report := &Report{Valid: true, Ledgers: []*LedgerResult{}}
+	for _, ledgerID := range ledgerIDs {
+		result := VerifyLedger(opts.BlockStorageDir, ledgerID, opts.LedgerType, opts.SkipIndex)
+		report.Valid = report.Valid && result.Valid
+		report.Ledgers = append(report.Ledgers, result)
+	}
+	return report, nil
+}
+
+// VerifyLedger reads the blocks of a ledger in order and stops at the first block failing a check
+func VerifyLedger(blockStorageDir, ledgerID string, ledgerType LedgerType, skipIndex bool) *LedgerResult {
+	result := &LedgerResult{LedgerID: ledgerID}
+	var indexConfig *blkstorage.IndexConfig
+	if !skipIndex {
+		indexConfig = indexConfigFor(ledgerType)
+	}
+	scanner, err := fsblkstorage.NewBlockfileScanner(blockStorageDir, ledgerID, indexConfig)
+	if err != nil {
+		result.fail(0, CheckBlockFiles, err)
+		return result
+	}
+	defer scanner.Close()
+
+	v := &ledgerVerifier{ledgerID: ledgerID, ledgerType: ledgerType}
+	for {
+		block, err := scanner.Next()
+		if err != nil {
+			result.fail(result.Height, CheckBlockFiles, err)
+			return result
+		}
+		if block == nil {
+			break
+		}
+		if check, err := v.verifyBlock(block); err != nil {
+			result.fail(result.Height, check, err)
+			return result
+		}
+		if !skipIndex {
+			if err := scanner.CheckIndex(); err != nil {
+				result.fail(result.Height, CheckIndex, err)
+				return result
+			}
+		}
+		result.Height++
+	}
+	result.Valid = true
+	logger.Infof("Verified %d blocks of ledger [%s]", result.Height, ledgerID)
+	return result
+}
+
+func (r *LedgerResult) fail(blockNum uint64, check string, err error) {
+	logger.Warningf("Ledger [%s] failed the %s check at block %d: %s", r.LedgerID, check, blockNum, err)
+	r.FirstBadBlock = &blockNum
+	r.Check = check
+	r.Error = err.Error()
+}
+
+func indexConfigFor(ledgerType LedgerType) *blkstorage.IndexConfig {
+	if ledgerType == OrdererLedger {
+		return &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}}
+	}
+	return &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+		blkstorage.IndexableAttrBlockNumTranNum,
+		blkstorage.IndexableAttrBlockTxID,
+		blkstorage.IndexableAttrTxValidationCode,
+	}}
+}
+
+// ledgerVerifier keeps the state carried from one block to the next
+type ledgerVerifier struct {
+	ledgerID   string
+	ledgerType LedgerType
+	prevHeader *cb.BlockHeader
+	bundle     *channelconfig.Bundle
+}
+
+// verifyBlock checks a block against the previous one and returns the name of the failed check
+func (v *ledgerVerifier) verifyBlock(block *cb.Block) (string, error) {
+	if block.Header == nil || block.Data == nil || block.Metadata == nil {
+		return CheckBlockFiles, errors.New("incomplete block")
+	}
+
+	var expectedNum uint64
+	if v.prevHeader != nil {
+		expectedNum = v.prevHeader.Number + 1
+	}
+	if block.Header.Number != expectedNum {
+		return CheckBlockNumber, errors.Errorf("expected block number %d, found %d", expectedNum, block.Header.Number)
+	}
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return CheckDataHash, errors.New("the data hash in the header differs from the hash of the block data")
+	}
+	if v.prevHeader != nil && !bytes.Equal(v.prevHeader.Hash(), block.Header.PreviousHash) {
+		return CheckPreviousHash, errors.New("the previous hash in the header differs from the hash of the previous block header")
+	}
+
+	// the signatures are checked against the config in effect before the block
+	if v.bundle == nil {
+		if !utils.IsConfigBlock(block) {
+			return CheckConfig, errors.New("the first block is not a config block")
+		}
+	} else if err := v.verifySignatures(block); err != nil {
+		return CheckSignature, err
+	}
+	if utils.IsConfigBlock(block) {
+		if err := v.updateConfig(block); err != nil {
+			return CheckConfig, err
+		}
+	}
+
+	if err := v.verifyValidationFlags(block); err != nil {
+		return CheckValidationFlags, err
+	}
+	v.prevHeader = block.Header
+	return "", nil
+}
+
+func (v *ledgerVerifier) verifySignatures(block *cb.Block) error {
+	signatureSet, err := utils.GetSignatureSetFromBlock(block)
+	if err != nil {
+		return err
+	}
+	if len(signatureSet) == 0 {
+		return errors.New("the block is not signed")
+	}
+
+	policy, ok := v.bundle.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("no %s policy in the channel config", policies.BlockValidation)
+	}
+	return errors.WithMessage(policy.Evaluate(signatureSet), "block validation policy not satisfied")
+}
+
+func (v *ledgerVerifier) updateConfig(block *cb.Block) error {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed to extract the config envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to build the channel config")
+	}
+	if bundle.ConfigtxValidator().ChainID() != v.ledgerID {
+		return errors.Errorf("the config is for channel [%s]", bundle.ConfigtxValidator().ChainID())
+	}
+	v.bundle = bundle
+	return nil
+}
+
+// verifyValidationFlags checks that a peer recorded a known validation code for every
+// transaction. Orderers do not validate transactions, so that the flags may be absent
+func (v *ledgerVerifier) verifyValidationFlags(block *cb.Block) error {
+	if len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		if v.ledgerType == OrdererLedger {
+			return nil
+		}
+		return errors.New("no validation flags in the block metadata")
+	}
+	txsfltr := ledgerUtil.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsfltr) == 0 && v.ledgerType == OrdererLedger {
+		return nil
+	}
+	if len(txsfltr) != len(block.Data.Data) {
+		return errors.Errorf("found %d validation flags for %d transactions", len(txsfltr), len(block.Data.Data))
+	}
+	for i := range txsfltr {
+		flag := txsfltr.Flag(i)
+		if _, ok := pb.TxValidationCode_name[int32(flag)]; !ok {
+			return errors.Errorf("unexpected validation code %d for transaction %d", flag, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	commonutil "github.com/hyperledger/fabric/common/util"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		panic(err)
+	}
+}
+
+const testLedgerID = "testchain"
+
+// createTestLedger writes a genesis block and `numBlocks` signed blocks to a new block store indexed
+// as for `ledgerType`, applying `tamper` to the blocks before they are written, and returns the
+// block store directory
+func createTestLedger(t *testing.T, ledgerType LedgerType, numBlocks int, tamper func(block *cb.Block)) string {
+	dir, err := ioutil.TempDir("", "ledgerutil-verify")
+	assert.NoError(t, err)
+	provider := fsblkstorage.NewProvider(fsblkstorage.NewConf(dir, 0), indexConfigFor(ledgerType))
+	defer provider.Close()
+	store, err := provider.OpenBlockStore(testLedgerID)
+	assert.NoError(t, err)
+
+	genesis := encoder.New(genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)).GenesisBlockForChannel(testLedgerID)
+	genesis.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerUtil.NewTxValidationFlags(len(genesis.Data.Data))
+	blocks := []*cb.Block{genesis}
+	for i := 1; i <= numBlocks; i++ {
+		env, _, err := testutil.ConstructTransaction(t, []byte(fmt.Sprintf("rwset%d", i)), fmt.Sprintf("txid%d", i), false)
+		assert.NoError(t, err)
+		block := testutil.NewBlock([]*cb.Envelope{env}, uint64(i), blocks[i-1].Header.Hash())
+		signBlock(t, block)
+		blocks = append(blocks, block)
+	}
+
+	for _, block := range blocks {
+		if tamper != nil {
+			tamper(block)
+		}
+		assert.NoError(t, store.AddBlock(block))
+	}
+	return dir
+}
+
+func signBlock(t *testing.T, block *cb.Block) {
+	signer := localmsp.NewSigner()
+	sigHeader, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	blockSignature := &cb.MetadataSignature{SignatureHeader: utils.MarshalOrPanic(sigHeader)}
+	blockSignature.Signature, err = signer.Sign(commonutil.ConcatenateBytes(nil, blockSignature.SignatureHeader, block.Header.Bytes()))
+	assert.NoError(t, err)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Signatures: []*cb.MetadataSignature{blockSignature},
+	})
+}
+
+func TestVerifyValidLedger(t *testing.T) {
+	dir := createTestLedger(t, PeerLedger, 5, nil)
+	defer os.RemoveAll(dir)
+
+	report, err := Run(&Options{BlockStorageDir: dir, LedgerType: PeerLedger})
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, []*LedgerResult{{LedgerID: testLedgerID, Height: 6, Valid: true}}, report.Ledgers)
+
+	report, err = Run(&Options{BlockStorageDir: dir, LedgerType: OrdererLedger, LedgerIDs: []string{testLedgerID}})
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+
+	report, err = Run(&Options{BlockStorageDir: dir, LedgerType: PeerLedger, LedgerIDs: []string{"missing"}})
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Equal(t, CheckBlockFiles, report.Ledgers[0].Check)
+
+	_, err = Run(&Options{BlockStorageDir: dir, LedgerType: "client"})
+	assert.EqualError(t, err, "unknown ledger type [client]")
+}
+
+func TestVerifyTamperedBlocks(t *testing.T) {
+	otherEnv, _, err := testutil.ConstructTransaction(t, []byte("other rwset"), "txid3", false)
+	assert.NoError(t, err)
+	otherEnvBytes := utils.MarshalOrPanic(otherEnv)
+
+	testCases := []struct {
+		name   string
+		tamper func(block *cb.Block)
+		check  string
+	}{
+		{
+			name:   "data",
+			tamper: func(block *cb.Block) { block.Data.Data[0] = otherEnvBytes },
+			check:  CheckDataHash,
+		},
+		{
+			name:   "previous hash",
+			tamper: func(block *cb.Block) { block.Header.PreviousHash = []byte("tampered") },
+			check:  CheckPreviousHash,
+		},
+		{
+			name:   "signature",
+			tamper: func(block *cb.Block) { block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = nil },
+			check:  CheckSignature,
+		},
+		{
+			name: "signed header",
+			tamper: func(block *cb.Block) {
+				metadata, _ := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+				block.Data.Data = append(block.Data.Data, otherEnvBytes)
+				block.Header.DataHash = block.Data.Hash()
+				block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(metadata)
+				block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerUtil.NewTxValidationFlags(2)
+			},
+			check: CheckSignature,
+		},
+		{
+			name: "validation flags",
+			tamper: func(block *cb.Block) {
+				block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{0, 0}
+			},
+			check: CheckValidationFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := createTestLedger(t, PeerLedger, 5, func(block *cb.Block) {
+				if block.Header.Number == 3 {
+					tc.tamper(block)
+				}
+			})
+			defer os.RemoveAll(dir)
+
+			result := VerifyLedger(dir, testLedgerID, PeerLedger, false)
+			assert.False(t, result.Valid)
+			assert.Equal(t, uint64(3), result.Height)
+			assert.Equal(t, uint64(3), *result.FirstBadBlock)
+			assert.Equal(t, tc.check, result.Check)
+			assert.NotEmpty(t, result.Error)
+		})
+	}
+}
+
+func TestVerifyOrdererLedgerWithoutFlags(t *testing.T) {
+	dir := createTestLedger(t, OrdererLedger, 2, func(block *cb.Block) {
+		block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = nil
+	})
+	defer os.RemoveAll(dir)
+
+	assert.True(t, VerifyLedger(dir, testLedgerID, OrdererLedger, false).Valid)
+	result := VerifyLedger(dir, testLedgerID, PeerLedger, true)
+	assert.False(t, result.Valid)
+	assert.Equal(t, CheckValidationFlags, result.Check)
+}
+
+func TestVerifyTamperedIndex(t *testing.T) {
+	dir := createTestLedger(t, PeerLedger, 5, nil)
+	defer os.RemoveAll(dir)
+
+	// point the index entry of block 4 to block 2
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: filepath.Join(dir, fsblkstorage.IndexDir)})
+	db := p.GetDBHandle(testLedgerID)
+	blockNumKey := func(num uint64) []byte { return append([]byte("n"), util.EncodeOrderPreservingVarUint64(num)...) }
+	flpBytes, err := db.Get(blockNumKey(2))
+	assert.NoError(t, err)
+	assert.NotNil(t, flpBytes)
+	assert.NoError(t, db.Put(blockNumKey(4), flpBytes, true))
+	p.Close()
+
+	result := VerifyLedger(dir, testLedgerID, OrdererLedger, false)
+	assert.False(t, result.Valid)
+	assert.Equal(t, uint64(4), *result.FirstBadBlock)
+	assert.Equal(t, CheckIndex, result.Check)
+
+	// the block files alone are still consistent
+	assert.True(t, VerifyLedger(dir, testLedgerID, OrdererLedger, true).Valid)
+
+	// a missing index is reported unless the cross-check is skipped
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, fsblkstorage.IndexDir)))
+	result = VerifyLedger(dir, testLedgerID, PeerLedger, false)
+	assert.Equal(t, CheckBlockFiles, result.Check)
+	assert.Contains(t, result.Error, "no block index")
+}
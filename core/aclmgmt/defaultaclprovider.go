@@ -74,6 +74,10 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.QSCC_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.QSCC_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.QSCC_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetHistoryForKey] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTransactionsByCreator] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTransactionsByChaincode] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTransactionsByTimestamp] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
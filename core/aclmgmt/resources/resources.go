@@ -29,6 +29,11 @@ const (
 	QSCC_GetBlockByHash     = "QSCC.GetBlockByHash"
 	QSCC_GetTransactionByID = "QSCC.GetTransactionByID"
 	QSCC_GetBlockByTxID     = "QSCC.GetBlockByTxID"
+	QSCC_GetHistoryForKey   = "QSCC.GetHistoryForKey"
+
+	QSCC_GetTransactionsByCreator   = "QSCC.GetTransactionsByCreator"
+	QSCC_GetTransactionsByChaincode = "QSCC.GetTransactionsByChaincode"
+	QSCC_GetTransactionsByTimestamp = "QSCC.GetTransactionsByTimestamp"
 
 	//CSCC resources
 	CSCC_JoinChain                = "CSCC.JoinChain"
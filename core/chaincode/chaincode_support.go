@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -187,9 +188,44 @@ func NewChaincodeSupport(
 	theChaincodeSupport.shimLogLevel = getLogLevelFromViper("shim")
 	theChaincodeSupport.logFormat = viper.GetString("chaincode.logging.format")
 
+	switch vmType := viper.GetString("vm.type"); strings.ToLower(vmType) {
+	case "", "docker":
+		theChaincodeSupport.vmType = container.DOCKER
+	case "process":
+		theChaincodeSupport.vmType = container.PROCESS
+	default:
+		chaincodeLogger.Warningf("unknown vm.type %s, using docker", vmType)
+		theChaincodeSupport.vmType = container.DOCKER
+	}
+	chaincodeLogger.Infof("Chaincode support using %s vms for user chaincodes", theChaincodeSupport.vmType)
+
+	theChaincodeSupport.additionalParams = getAdditionalParamsFromViper()
+
 	return theChaincodeSupport
 }
 
+// defaultMaxSizeBatch is the number of keys of a batch when its size is not
+// configured
+const defaultMaxSizeBatch = 1000
+
+// getAdditionalParamsFromViper gets the optional protocol features offered to
+// the chaincodes from viper
+func getAdditionalParamsFromViper() *pb.ChaincodeAdditionalParams {
+	maxSize := func(key string) uint32 {
+		size := viper.GetInt(key)
+		if size <= 0 {
+			return defaultMaxSizeBatch
+		}
+		return uint32(size)
+	}
+	return &pb.ChaincodeAdditionalParams{
+		UseWriteBatch:          viper.GetBool("chaincode.runtimeParams.useWriteBatch"),
+		MaxSizeWriteBatch:      maxSize("chaincode.runtimeParams.maxSizeWriteBatch"),
+		UseGetMultipleKeys:     viper.GetBool("chaincode.runtimeParams.useGetMultipleKeys"),
+		MaxSizeGetMultipleKeys: maxSize("chaincode.runtimeParams.maxSizeGetMultipleKeys"),
+	}
+}
+
 // getLogLevelFromViper gets the chaincode container log levels from viper
 func getLogLevelFromViper(module string) string {
 	levelString := viper.GetString("chaincode.logging." + module)
@@ -227,6 +263,11 @@ type ChaincodeSupport struct {
 	executetimeout    time.Duration
 	userRunsCC        bool
 	peerTLS           bool
+	// vmType is the type of vm running user chaincodes
+	vmType string
+	// additionalParams are the optional protocol features offered to the
+	// chaincodes when they register
+	additionalParams *pb.ChaincodeAdditionalParams
 }
 
 // DuplicateChaincodeHandlerError returned if attempt to register same chaincodeID while a stream already exists.
@@ -260,9 +301,10 @@ func (chaincodeSupport *ChaincodeSupport) registerHandler(chaincodehandler *Hand
 		chaincodehandler.readyNotify = chrte2.handler.readyNotify
 		chrte2.handler = chaincodehandler
 	} else {
-		if chaincodeSupport.userRunsCC == false {
+		if chaincodeSupport.userRunsCC == false && !chaincodehandler.reconnected {
 			//this chaincode was not launched by the peer and is attempting
-			//to register. Don't allow this.
+			//to register. Don't allow this. Chaincodes the peer reconnected
+			//to after their stream was lost were launched before.
 			return errors.Errorf("peer will not accept external chaincode connection %v (except in dev mode)", chaincodehandler.ChaincodeID)
 		}
 		chaincodeSupport.runningChaincodes.chaincodeMap[key] = &chaincodeRTEnv{handler: chaincodehandler}
@@ -688,6 +730,12 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, cccid
 			version:       cccid.Version,
 			cds:           cds,
 			builder: func() (io.Reader, error) {
+				if cds.ExecEnv == pb.ChaincodeDeploymentSpec_EXTERNAL {
+					return bytes.NewReader(cds.CodePackage), nil
+				}
+				if chaincodeSupport.vmType == container.PROCESS {
+					return platforms.GenerateLocalBuild(cds)
+				}
 				return platforms.GenerateDockerBuild(cds)
 			},
 		}
@@ -709,6 +757,12 @@ func (chaincodeSupport *ChaincodeSupport) getVMType(cds *pb.ChaincodeDeploymentS
 	if cds.ExecEnv == pb.ChaincodeDeploymentSpec_SYSTEM {
 		return container.SYSTEM, nil
 	}
+	if cds.ExecEnv == pb.ChaincodeDeploymentSpec_EXTERNAL {
+		return container.EXTERNAL, nil
+	}
+	if chaincodeSupport.vmType == container.PROCESS {
+		return container.PROCESS, nil
+	}
 	return container.DOCKER, nil
 }
 
@@ -79,6 +79,14 @@ func (meqe *mockExecQuerySimulator) GetHistoryForKey(namespace, query string) (c
 	return meqe.commonQuery(namespace, query)
 }
 
+func (meqe *mockExecQuerySimulator) GetHistoryForKeyWithOptions(namespace, query string, options *ledger.HistoryQueryOptions) (commonledger.ResultsIterator, error) {
+	return meqe.commonQuery(namespace, query)
+}
+
+func (meqe *mockExecQuerySimulator) GetPrivateDataHashHistoryForKey(namespace, collection string, keyHash []byte, options *ledger.HistoryQueryOptions) (commonledger.ResultsIterator, error) {
+	return meqe.commonQuery(namespace, collection)
+}
+
 func (meqe *mockExecQuerySimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	return meqe.commonQuery(namespace, query)
 }
@@ -932,6 +940,34 @@ func TestLaunchAndWaitLaunchError(t *testing.T) {
 	}
 }
 
+func TestGetVMType(t *testing.T) {
+	userCDS := &pb.ChaincodeDeploymentSpec{}
+	systemCDS := &pb.ChaincodeDeploymentSpec{ExecEnv: pb.ChaincodeDeploymentSpec_SYSTEM}
+	externalCDS := &pb.ChaincodeDeploymentSpec{ExecEnv: pb.ChaincodeDeploymentSpec_EXTERNAL}
+
+	for _, tc := range []struct {
+		vmType   string
+		cds      *pb.ChaincodeDeploymentSpec
+		expected string
+	}{
+		{"", userCDS, container.DOCKER},
+		{container.DOCKER, userCDS, container.DOCKER},
+		{container.PROCESS, userCDS, container.PROCESS},
+		{container.PROCESS, systemCDS, container.SYSTEM},
+		{container.DOCKER, externalCDS, container.EXTERNAL},
+		{container.PROCESS, externalCDS, container.EXTERNAL},
+	} {
+		ccSupport := &ChaincodeSupport{vmType: tc.vmType}
+		vmType, err := ccSupport.getVMType(tc.cds)
+		if err != nil {
+			t.Fatalf("getVMType failed: %s", err)
+		}
+		if vmType != tc.expected {
+			t.Fatalf("expected vm type %s for %s, got %s", tc.expected, tc.vmType, vmType)
+		}
+	}
+}
+
 func TestGetTxContextFromHandler(t *testing.T) {
 	h := Handler{txCtxs: map[string]*transactionContext{}}
 
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package external handles the packages of chaincodes which are run as
+// services outside of the peer. Instead of code, such packages carry the
+// information the peer needs to connect to the chaincode server.
+package external
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ConnectionFile is the name of the file of the code package holding the
+// connection information of the chaincode server
+const ConnectionFile = "connection.json"
+
+// DefaultDialTimeout is used when the connection information does not
+// specify a dial timeout
+const DefaultDialTimeout = 10 * time.Second
+
+// Connection holds the information needed to connect to a chaincode server
+type Connection struct {
+	// Address of the chaincode server, as host:port
+	Address string `json:"address"`
+	// DialTimeout is the duration, e.g. "10s", the peer waits for the
+	// connection to be established
+	DialTimeout string `json:"dial_timeout,omitempty"`
+	// TLSRequired tells whether the chaincode server uses TLS
+	TLSRequired bool `json:"tls_required"`
+	// ClientAuthRequired tells whether the chaincode server requires the
+	// peer to authenticate with the client certificate below
+	ClientAuthRequired bool `json:"client_auth_required"`
+	// RootCert is the PEM encoded certificate of the CA of the chaincode server
+	RootCert string `json:"root_cert,omitempty"`
+	// ClientKey is the PEM encoded key the peer authenticates with
+	ClientKey string `json:"client_key,omitempty"`
+	// ClientCert is the PEM encoded certificate the peer authenticates with
+	ClientCert string `json:"client_cert,omitempty"`
+}
+
+// Timeout returns the dial timeout of the connection
+func (c *Connection) Timeout() time.Duration {
+	if c.DialTimeout == "" {
+		return DefaultDialTimeout
+	}
+	timeout, err := time.ParseDuration(c.DialTimeout)
+	if err != nil || timeout <= 0 {
+		return DefaultDialTimeout
+	}
+	return timeout
+}
+
+// Validate checks that the connection information is complete and that its
+// TLS material can be loaded
+func (c *Connection) Validate() error {
+	if c.Address == "" {
+		return errors.New("chaincode server address not provided")
+	}
+	if c.DialTimeout != "" {
+		timeout, err := time.ParseDuration(c.DialTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "invalid dial timeout %s", c.DialTimeout)
+		}
+		if timeout <= 0 {
+			return errors.Errorf("invalid dial timeout %s", c.DialTimeout)
+		}
+	}
+
+	if !c.TLSRequired {
+		if c.ClientAuthRequired {
+			return errors.New("client authentication requires TLS")
+		}
+		return nil
+	}
+	if c.RootCert == "" {
+		return errors.New("root certificate of the chaincode server not provided")
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(c.RootCert)) {
+		return errors.New("invalid root certificate of the chaincode server")
+	}
+	if c.ClientAuthRequired {
+		if c.ClientKey == "" || c.ClientCert == "" {
+			return errors.New("client key and certificate are required for client authentication")
+		}
+		if _, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey)); err != nil {
+			return errors.Wrap(err, "invalid client key pair")
+		}
+	}
+	return nil
+}
+
+// NewConnectionPackage returns a code package, a gzipped tar holding the
+// connection file, for the given connection
+func NewConnectionPackage(conn *Connection) ([]byte, error) {
+	if err := conn.Validate(); err != nil {
+		return nil, err
+	}
+	contents, err := json.MarshalIndent(conn, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling connection")
+	}
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	var zeroTime time.Time
+	err = tw.WriteHeader(&tar.Header{Name: ConnectionFile, Mode: 0100644, Size: int64(len(contents)), ModTime: zeroTime, AccessTime: zeroTime, ChangeTime: zeroTime})
+	if err != nil {
+		return nil, errors.Wrap(err, "error writing connection package")
+	}
+	if _, err = tw.Write(contents); err != nil {
+		return nil, errors.Wrap(err, "error writing connection package")
+	}
+	if err = tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error writing connection package")
+	}
+	if err = gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error writing connection package")
+	}
+	return payload.Bytes(), nil
+}
+
+// ParseConnectionPackage extracts and validates the connection from a code
+// package. Besides the connection file, the package may carry the META-INF
+// directory found in regular code packages.
+func ParseConnectionPackage(codePackage []byte) (*Connection, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return nil, errors.Wrap(err, "failure opening connection package gzip stream")
+	}
+	tr := tar.NewReader(gr)
+
+	var conn *Connection
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading connection package")
+		}
+		if header.Name != ConnectionFile {
+			continue
+		}
+
+		contents, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading connection package")
+		}
+		conn = &Connection{}
+		if err := json.Unmarshal(contents, conn); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", ConnectionFile)
+		}
+	}
+
+	if conn == nil {
+		return nil, errors.Errorf("%s not found in connection package", ConnectionFile)
+	}
+	if err := conn.Validate(); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
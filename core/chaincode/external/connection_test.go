@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package external
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSelfSignedPair returns a PEM encoded self-signed certificate and its key
+func newSelfSignedPair(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestValidate(t *testing.T) {
+	cert, key := newSelfSignedPair(t)
+
+	for _, tc := range []struct {
+		name  string
+		conn  Connection
+		error string
+	}{
+		{"insecure", Connection{Address: "cc:9999"}, ""},
+		{"no address", Connection{}, "address not provided"},
+		{"bad timeout", Connection{Address: "cc:9999", DialTimeout: "soon"}, "invalid dial timeout"},
+		{"negative timeout", Connection{Address: "cc:9999", DialTimeout: "-1s"}, "invalid dial timeout"},
+		{"client auth without TLS", Connection{Address: "cc:9999", ClientAuthRequired: true}, "requires TLS"},
+		{"TLS", Connection{Address: "cc:9999", TLSRequired: true, RootCert: cert}, ""},
+		{"TLS without root", Connection{Address: "cc:9999", TLSRequired: true}, "root certificate"},
+		{"TLS with bad root", Connection{Address: "cc:9999", TLSRequired: true, RootCert: "garbage"}, "invalid root certificate"},
+		{"client auth", Connection{Address: "cc:9999", TLSRequired: true, ClientAuthRequired: true, RootCert: cert, ClientCert: cert, ClientKey: key}, ""},
+		{"client auth without key", Connection{Address: "cc:9999", TLSRequired: true, ClientAuthRequired: true, RootCert: cert, ClientCert: cert}, "client key and certificate are required"},
+		{"client auth with bad key", Connection{Address: "cc:9999", TLSRequired: true, ClientAuthRequired: true, RootCert: cert, ClientCert: cert, ClientKey: "garbage"}, "invalid client key pair"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.conn.Validate()
+			if tc.error == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.error)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	assert.Equal(t, DefaultDialTimeout, (&Connection{}).Timeout())
+	assert.Equal(t, DefaultDialTimeout, (&Connection{DialTimeout: "soon"}).Timeout())
+	assert.Equal(t, 3*time.Second, (&Connection{DialTimeout: "3s"}).Timeout())
+}
+
+func TestConnectionPackage(t *testing.T) {
+	cert, key := newSelfSignedPair(t)
+	conn := &Connection{
+		Address:            "cc:9999",
+		DialTimeout:        "5s",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		RootCert:           cert,
+		ClientCert:         cert,
+		ClientKey:          key,
+	}
+
+	codePackage, err := NewConnectionPackage(conn)
+	require.NoError(t, err)
+	parsed, err := ParseConnectionPackage(codePackage)
+	require.NoError(t, err)
+	assert.Equal(t, conn, parsed)
+
+	_, err = NewConnectionPackage(&Connection{})
+	assert.Error(t, err)
+}
+
+func TestParseConnectionPackageErrors(t *testing.T) {
+	newPackage := func(files map[string]string) []byte {
+		payload := bytes.NewBuffer(nil)
+		gw := gzip.NewWriter(payload)
+		tw := tar.NewWriter(gw)
+		for name, contents := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents))}))
+			_, err := tw.Write([]byte(contents))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gw.Close())
+		return payload.Bytes()
+	}
+
+	// META-INF is carried along the connection file
+	conn, err := ParseConnectionPackage(newPackage(map[string]string{
+		ConnectionFile: `{"address": "cc:9999"}`,
+		"META-INF/statedb/couchdb/indexes/index.json": "{}",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "cc:9999", conn.Address)
+
+	_, err = ParseConnectionPackage([]byte("garbage"))
+	assert.Error(t, err)
+
+	_, err = ParseConnectionPackage(newPackage(map[string]string{"src/cc.go": "package main"}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection.json not found")
+
+	_, err = ParseConnectionPackage(newPackage(map[string]string{ConnectionFile: "{"}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid connection.json")
+
+	_, err = ParseConnectionPackage(newPackage(map[string]string{ConnectionFile: "{}"}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "address not provided")
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -74,6 +75,9 @@ type Handler struct {
 	chaincodeSupport *ChaincodeSupport
 	registered       bool
 	readyNotify      chan bool
+	// reconnected is set for the streams the peer established again with a
+	// chaincode it launched before, which may register without being launched
+	reconnected bool
 
 	//chan to pass error in sync and nonsync mode
 	errChan chan error
@@ -364,6 +368,7 @@ func HandleChaincodeStream(chaincodeSupport *ChaincodeSupport, ctxt context.Cont
 	deadline, ok := ctxt.Deadline()
 	chaincodeLogger.Debugf("Current context deadline = %s, ok = %v", deadline, ok)
 	handler := newChaincodeSupportHandler(chaincodeSupport, stream)
+	handler.reconnected, _ = ctxt.Value(ccintf.GetReconnectKey()).(bool)
 	return handler.processStream()
 }
 
@@ -382,6 +387,7 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 
 		//state requests from CC that require processing
 		pb.ChaincodeMessage_GET_STATE:           v.handleGetState,
+		pb.ChaincodeMessage_GET_STATE_MULTIPLE:  v.handleGetStateMultiple,
 		pb.ChaincodeMessage_GET_STATE_BY_RANGE:  v.handleGetStateByRange,
 		pb.ChaincodeMessage_GET_QUERY_RESULT:    v.handleGetQueryResult,
 		pb.ChaincodeMessage_GET_HISTORY_FOR_KEY: v.handleGetHistoryForKey,
@@ -389,6 +395,7 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 		pb.ChaincodeMessage_QUERY_STATE_CLOSE:   v.handleQueryStateClose,
 		pb.ChaincodeMessage_PUT_STATE:           v.handleModState,
 		pb.ChaincodeMessage_DEL_STATE:           v.handleModState,
+		pb.ChaincodeMessage_WRITE_BATCH_STATE:   v.handleWriteBatchState,
 		pb.ChaincodeMessage_INVOKE_CHAINCODE:    v.handleModState,
 	}
 
@@ -466,11 +473,11 @@ func (handler *Handler) notifyDuringStartup(val bool) {
 	//environment where we can attach a chaincode manually. This could be
 	//useful .... but for now lets just be conservative and allow manual
 	//chaincode only in dev mode (ie, peer started with --peer-chaincodedev=true)
-	if handler.chaincodeSupport.userRunsCC {
+	if handler.chaincodeSupport.userRunsCC || handler.reconnected {
 		if val {
 			handler.sendReady()
 		} else {
-			chaincodeLogger.Errorf("(dev mode) Error during startup .. not sending READY")
+			chaincodeLogger.Errorf("(dev mode or reconnected) Error during startup .. not sending READY")
 		}
 	} else {
 		chaincodeLogger.Warningf("trying to manually run chaincode when not in devmode ?")
@@ -499,8 +506,20 @@ func (handler *Handler) handleRegister(msg *pb.ChaincodeMessage) {
 	//name in keys
 	handler.decomposeRegisteredName(handler.ChaincodeID)
 
+	// offer the optional features of the protocol, which chaincodes built
+	// with older shims simply ignore
+	var payload []byte
+	if handler.chaincodeSupport.additionalParams != nil {
+		payload, err = proto.Marshal(handler.chaincodeSupport.additionalParams)
+		if err != nil {
+			chaincodeLogger.Errorf("Error marshalling chaincode additional params: %s", err)
+			handler.notifyDuringStartup(false)
+			return
+		}
+	}
+
 	chaincodeLogger.Debugf("Got %s for chaincodeID = %s, sending back %s", pb.ChaincodeMessage_REGISTER, chaincodeID, pb.ChaincodeMessage_REGISTERED)
-	if err := handler.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}); err != nil {
+	if err := handler.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED, Payload: payload}); err != nil {
 		chaincodeLogger.Errorf("Error sending %s: %s", pb.ChaincodeMessage_REGISTERED, err)
 		handler.notifyDuringStartup(false)
 		return
@@ -621,6 +640,73 @@ func (handler *Handler) handleGetState(msg *pb.ChaincodeMessage) {
 	}()
 }
 
+// Handles query to ledger to get the state of several keys at once
+func (handler *Handler) handleGetStateMultiple(msg *pb.ChaincodeMessage) {
+	go func() {
+		chaincodeLogger.Debugf("[%s]handling %s from chaincode", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_MULTIPLE)
+		if !handler.registerTxid(msg) {
+			return
+		}
+
+		var serialSendMsg *pb.ChaincodeMessage
+		var txContext *transactionContext
+		txContext, serialSendMsg = handler.isValidTxSim(msg.ChannelId, msg.Txid,
+			"[%s]No ledger context for GetStateMultiple. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+
+		defer func() {
+			handler.deRegisterTxid(msg, serialSendMsg, false)
+		}()
+
+		if txContext == nil {
+			return
+		}
+
+		errHandler := func(err error, errFmt string, errArgs ...interface{}) {
+			chaincodeLogger.Errorf(errFmt, errArgs...)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid, ChannelId: msg.ChannelId}
+		}
+
+		getStateMultiple := &pb.GetStateMultiple{}
+		if err := proto.Unmarshal(msg.Payload, getStateMultiple); err != nil {
+			errHandler(err, "[%s]Unable to decipher payload. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+			return
+		}
+		maxSize := handler.chaincodeSupport.additionalParams.GetMaxSizeGetMultipleKeys()
+		if maxSize > 0 && uint32(len(getStateMultiple.Keys)) > maxSize {
+			err := errors.Errorf("%d keys requested, the maximum is %d", len(getStateMultiple.Keys), maxSize)
+			errHandler(err, "[%s]Too many keys requested. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+			return
+		}
+
+		chaincodeID := handler.getCCRootName()
+		chaincodeLogger.Debugf("[%s] getting state for chaincode %s, %d keys, channel %s",
+			shorttxid(msg.Txid), chaincodeID, len(getStateMultiple.Keys), txContext.chainID)
+
+		var values [][]byte
+		var err error
+		if isCollectionSet(getStateMultiple.Collection) {
+			values, err = txContext.txsimulator.GetPrivateDataMultipleKeys(chaincodeID, getStateMultiple.Collection, getStateMultiple.Keys)
+		} else {
+			values, err = txContext.txsimulator.GetStateMultipleKeys(chaincodeID, getStateMultiple.Keys)
+		}
+		if err == nil && len(values) != len(getStateMultiple.Keys) {
+			err = errors.Errorf("%d values returned for %d keys", len(values), len(getStateMultiple.Keys))
+		}
+		if err != nil {
+			errHandler(err, "[%s]Failed to get chaincode state(%s). Sending %s", shorttxid(msg.Txid), err, pb.ChaincodeMessage_ERROR)
+			return
+		}
+
+		res, err := proto.Marshal(&pb.GetStateMultipleResult{Values: values})
+		if err != nil {
+			errHandler(err, "[%s]Failed to marshal state(%s). Sending %s", shorttxid(msg.Txid), err, pb.ChaincodeMessage_ERROR)
+			return
+		}
+		chaincodeLogger.Debugf("[%s]Got state. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_RESPONSE)
+		serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}
+	}()
+}
+
 // Handles query to ledger to rage query state
 func (handler *Handler) handleGetStateByRange(msg *pb.ChaincodeMessage) {
 	go func() {
@@ -983,7 +1069,16 @@ func (handler *Handler) handleGetHistoryForKey(msg *pb.ChaincodeMessage) {
 		}
 		chaincodeID := handler.getCCRootName()
 
-		historyIter, err := txContext.historyQueryExecutor.GetHistoryForKey(chaincodeID, getHistoryForKey.Key)
+		var historyIter commonledger.ResultsIterator
+		var err error
+		options := ledger.NewHistoryQueryOptions(getHistoryForKey.Options)
+		if getHistoryForKey.Collection != "" {
+			// only the hashes of private data are tracked in the history
+			historyIter, err = txContext.historyQueryExecutor.GetPrivateDataHashHistoryForKey(chaincodeID,
+				getHistoryForKey.Collection, ledgerutil.ComputeStringHash(getHistoryForKey.Key), options)
+		} else {
+			historyIter, err = txContext.historyQueryExecutor.GetHistoryForKeyWithOptions(chaincodeID, getHistoryForKey.Key, options)
+		}
 		if err != nil {
 			errHandler([]byte(err.Error()), nil, "Failed to get ledger history iterator. Sending %s", pb.ChaincodeMessage_ERROR)
 			return
@@ -1256,6 +1351,75 @@ func (handler *Handler) handleModState(msg *pb.ChaincodeMessage) {
 	}()
 }
 
+// Handles the puts and deletes a chaincode buffered during a transaction and
+// sends all at once before completing it
+func (handler *Handler) handleWriteBatchState(msg *pb.ChaincodeMessage) {
+	go func() {
+		chaincodeLogger.Debugf("[%s]handling %s from chaincode", shorttxid(msg.Txid), pb.ChaincodeMessage_WRITE_BATCH_STATE)
+		if !handler.registerTxid(msg) {
+			return
+		}
+
+		var serialSendMsg *pb.ChaincodeMessage
+		var txContext *transactionContext
+		txContext, serialSendMsg = handler.isValidTxSim(msg.ChannelId, msg.Txid,
+			"[%s]No ledger context for WriteBatchState. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+
+		defer func() {
+			handler.deRegisterTxid(msg, serialSendMsg, false)
+		}()
+
+		if txContext == nil {
+			return
+		}
+
+		errHandler := func(err error, errFmt string, errArgs ...interface{}) {
+			chaincodeLogger.Errorf(errFmt, errArgs...)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid, ChannelId: msg.ChannelId}
+		}
+
+		batch := &pb.WriteBatchState{}
+		if err := proto.Unmarshal(msg.Payload, batch); err != nil {
+			errHandler(err, "[%s]Unable to decipher payload. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+			return
+		}
+		maxSize := handler.chaincodeSupport.additionalParams.GetMaxSizeWriteBatch()
+		if maxSize > 0 && uint32(len(batch.Records)) > maxSize {
+			err := errors.Errorf("%d records sent, the maximum is %d", len(batch.Records), maxSize)
+			errHandler(err, "[%s]Too many records sent. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+			return
+		}
+
+		chaincodeID := handler.getCCRootName()
+		for _, record := range batch.Records {
+			var err error
+			switch record.Type {
+			case pb.ChaincodeMessage_PUT_STATE:
+				if isCollectionSet(record.Collection) {
+					err = txContext.txsimulator.SetPrivateData(chaincodeID, record.Collection, record.Key, record.Value)
+				} else {
+					err = txContext.txsimulator.SetState(chaincodeID, record.Key, record.Value)
+				}
+			case pb.ChaincodeMessage_DEL_STATE:
+				if isCollectionSet(record.Collection) {
+					err = txContext.txsimulator.DeletePrivateData(chaincodeID, record.Collection, record.Key)
+				} else {
+					err = txContext.txsimulator.DeleteState(chaincodeID, record.Key)
+				}
+			default:
+				err = errors.Errorf("invalid write record type %s for key %s", record.Type, record.Key)
+			}
+			if err != nil {
+				errHandler(err, "[%s]Failed to handle %s(%s). Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_WRITE_BATCH_STATE, err, pb.ChaincodeMessage_ERROR)
+				return
+			}
+		}
+
+		chaincodeLogger.Debugf("[%s]Completed %s of %d records. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_WRITE_BATCH_STATE, len(batch.Records), pb.ChaincodeMessage_RESPONSE)
+		serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}
+	}()
+}
+
 func (handler *Handler) setChaincodeProposal(signedProp *pb.SignedProposal, prop *pb.Proposal, msg *pb.ChaincodeMessage) error {
 	chaincodeLogger.Debug("Setting chaincode proposal context...")
 	if prop != nil {
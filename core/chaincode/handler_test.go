@@ -7,14 +7,23 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	coreledger "github.com/hyperledger/fabric/core/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetQueryResponse(t *testing.T) {
@@ -107,3 +116,288 @@ func (m *MockResultsIterator) Next() (ledger.QueryResult, error) {
 func (m *MockResultsIterator) Close() {
 	m.Called()
 }
+
+// mockChatStream collects the messages the handler sends to the chaincode
+type mockChatStream struct {
+	sent chan *pb.ChaincodeMessage
+}
+
+func (m *mockChatStream) Send(msg *pb.ChaincodeMessage) error {
+	m.sent <- msg
+	return nil
+}
+
+func (m *mockChatStream) Recv() (*pb.ChaincodeMessage, error) {
+	select {}
+}
+
+// mapTxSim is a transaction simulator over in memory state, keyed by
+// namespace, collection and key
+type mapTxSim struct {
+	coreledger.TxSimulator
+	state map[string][]byte
+}
+
+func stateKey(ns, coll, key string) string {
+	return ns + "/" + coll + "/" + key
+}
+
+func (m *mapTxSim) GetStateMultipleKeys(ns string, keys []string) ([][]byte, error) {
+	return m.GetPrivateDataMultipleKeys(ns, "", keys)
+}
+
+func (m *mapTxSim) GetPrivateDataMultipleKeys(ns, coll string, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = m.state[stateKey(ns, coll, key)]
+	}
+	return values, nil
+}
+
+func (m *mapTxSim) SetState(ns, key string, value []byte) error {
+	return m.SetPrivateData(ns, "", key, value)
+}
+
+func (m *mapTxSim) SetPrivateData(ns, coll, key string, value []byte) error {
+	if key == "bad" {
+		return errors.New("bad key")
+	}
+	m.state[stateKey(ns, coll, key)] = value
+	return nil
+}
+
+func (m *mapTxSim) DeleteState(ns, key string) error {
+	return m.DeletePrivateData(ns, "", key)
+}
+
+func (m *mapTxSim) DeletePrivateData(ns, coll, key string) error {
+	delete(m.state, stateKey(ns, coll, key))
+	return nil
+}
+
+func newTestHandlerWithTxSim(txsim coreledger.TxSimulator) (*Handler, *mockChatStream) {
+	stream := &mockChatStream{sent: make(chan *pb.ChaincodeMessage, 1)}
+	handler := newChaincodeSupportHandler(&ChaincodeSupport{
+		additionalParams: &pb.ChaincodeAdditionalParams{UseGetMultipleKeys: true, MaxSizeGetMultipleKeys: 3, UseWriteBatch: true, MaxSizeWriteBatch: 4},
+	}, stream)
+	handler.ccInstance = &sysccprovider.ChaincodeInstance{ChaincodeName: "mycc"}
+	handler.txidMap = make(map[string]bool)
+	handler.txCtxs = map[string]*transactionContext{
+		handler.getTxCtxId("testchannel", "txid"): {chainID: "testchannel", txsimulator: txsim},
+	}
+	return handler, stream
+}
+
+func receive(t *testing.T, stream *mockChatStream) *pb.ChaincodeMessage {
+	select {
+	case msg := <-stream.sent:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message sent to the chaincode")
+	}
+	return nil
+}
+
+func TestHandleGetStateMultiple(t *testing.T) {
+	txsim := &mapTxSim{state: map[string][]byte{
+		stateKey("mycc", "", "a"):     []byte("A"),
+		stateKey("mycc", "", "c"):     []byte("C"),
+		stateKey("mycc", "coll", "a"): []byte("private A"),
+	}}
+	handler, stream := newTestHandlerWithTxSim(txsim)
+
+	getStateMultiple := func(req *pb.GetStateMultiple, txid string) *pb.ChaincodeMessage {
+		payload, err := proto.Marshal(req)
+		require.NoError(t, err)
+		handler.handleGetStateMultiple(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Payload: payload, Txid: txid, ChannelId: "testchannel"})
+		return receive(t, stream)
+	}
+
+	resp := getStateMultiple(&pb.GetStateMultiple{Keys: []string{"a", "b", "c"}}, "txid")
+	require.Equal(t, pb.ChaincodeMessage_RESPONSE, resp.Type)
+	result := &pb.GetStateMultipleResult{}
+	require.NoError(t, proto.Unmarshal(resp.Payload, result))
+	// missing keys come back empty, like with GET_STATE
+	assert.Equal(t, [][]byte{[]byte("A"), {}, []byte("C")}, result.Values)
+
+	resp = getStateMultiple(&pb.GetStateMultiple{Keys: []string{"a"}, Collection: "coll"}, "txid")
+	require.Equal(t, pb.ChaincodeMessage_RESPONSE, resp.Type)
+	require.NoError(t, proto.Unmarshal(resp.Payload, result))
+	assert.Equal(t, [][]byte{[]byte("private A")}, result.Values)
+
+	// more keys than the chaincode was told it could request at once
+	resp = getStateMultiple(&pb.GetStateMultiple{Keys: []string{"a", "b", "c", "d"}}, "txid")
+	assert.Equal(t, pb.ChaincodeMessage_ERROR, resp.Type)
+	assert.Contains(t, string(resp.Payload), "4 keys requested, the maximum is 3")
+
+	// no transaction context
+	resp = getStateMultiple(&pb.GetStateMultiple{Keys: []string{"a"}}, "unknown")
+	assert.Equal(t, pb.ChaincodeMessage_ERROR, resp.Type)
+}
+
+func TestHandleWriteBatchState(t *testing.T) {
+	txsim := &mapTxSim{state: map[string][]byte{
+		stateKey("mycc", "", "old"):     []byte("old"),
+		stateKey("mycc", "coll", "old"): []byte("old"),
+	}}
+	handler, stream := newTestHandlerWithTxSim(txsim)
+
+	writeBatch := func(records ...*pb.WriteRecord) *pb.ChaincodeMessage {
+		payload, err := proto.Marshal(&pb.WriteBatchState{Records: records})
+		require.NoError(t, err)
+		handler.handleWriteBatchState(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_WRITE_BATCH_STATE, Payload: payload, Txid: "txid", ChannelId: "testchannel"})
+		return receive(t, stream)
+	}
+
+	resp := writeBatch(
+		&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: "new", Value: []byte("new")},
+		&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: "new", Collection: "coll", Value: []byte("private new")},
+		&pb.WriteRecord{Type: pb.ChaincodeMessage_DEL_STATE, Key: "old"},
+		&pb.WriteRecord{Type: pb.ChaincodeMessage_DEL_STATE, Key: "old", Collection: "coll"},
+	)
+	assert.Equal(t, pb.ChaincodeMessage_RESPONSE, resp.Type)
+	assert.Equal(t, map[string][]byte{
+		stateKey("mycc", "", "new"):     []byte("new"),
+		stateKey("mycc", "coll", "new"): []byte("private new"),
+	}, txsim.state)
+
+	resp = writeBatch(&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: "bad"})
+	assert.Equal(t, pb.ChaincodeMessage_ERROR, resp.Type)
+	assert.Contains(t, string(resp.Payload), "bad key")
+
+	resp = writeBatch(&pb.WriteRecord{Type: pb.ChaincodeMessage_GET_STATE, Key: "a"})
+	assert.Equal(t, pb.ChaincodeMessage_ERROR, resp.Type)
+	assert.Contains(t, string(resp.Payload), "invalid write record type GET_STATE")
+
+	var records []*pb.WriteRecord
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		records = append(records, &pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: key, Value: []byte(key)})
+	}
+	resp = writeBatch(records...)
+	assert.Equal(t, pb.ChaincodeMessage_ERROR, resp.Type)
+	assert.Contains(t, string(resp.Payload), "5 records sent, the maximum is 4")
+	assert.NotContains(t, txsim.state, stateKey("mycc", "", "a"), "No record of a batch which is too large is written")
+}
+
+func TestHandleRegisterAdditionalParams(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{
+		runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv)},
+		userRunsCC:        true,
+		additionalParams:  &pb.ChaincodeAdditionalParams{UseWriteBatch: true, MaxSizeWriteBatch: 10},
+	}
+	stream := &mockChatStream{sent: make(chan *pb.ChaincodeMessage, 2)}
+	handler := newChaincodeSupportHandler(chaincodeSupport, stream)
+
+	payload, err := proto.Marshal(&pb.ChaincodeID{Name: "mycc:1.0"})
+	require.NoError(t, err)
+	handler.handleRegister(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER, Payload: payload})
+
+	resp := receive(t, stream)
+	require.Equal(t, pb.ChaincodeMessage_REGISTERED, resp.Type)
+	params := &pb.ChaincodeAdditionalParams{}
+	require.NoError(t, proto.Unmarshal(resp.Payload, params))
+	assert.True(t, proto.Equal(chaincodeSupport.additionalParams, params))
+}
+
+func TestHandleRegisterReconnected(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{
+		runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv)},
+	}
+	stream := &mockChatStream{sent: make(chan *pb.ChaincodeMessage, 2)}
+	handler := newChaincodeSupportHandler(chaincodeSupport, stream)
+	handler.ChaincodeID = &pb.ChaincodeID{Name: "mycc:1.0"}
+
+	// chaincodes which were not launched are rejected outside of dev mode
+	assert.Error(t, chaincodeSupport.registerHandler(handler))
+
+	// unless the peer reconnected to them
+	handler.reconnected = true
+	payload, err := proto.Marshal(handler.ChaincodeID)
+	require.NoError(t, err)
+	handler.handleRegister(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER, Payload: payload})
+
+	assert.Equal(t, pb.ChaincodeMessage_REGISTERED, receive(t, stream).Type)
+	assert.Equal(t, pb.ChaincodeMessage_READY, receive(t, stream).Type)
+	assert.True(t, handler.registered)
+}
+
+func TestGetAdditionalParamsFromViper(t *testing.T) {
+	defer viper.Reset()
+
+	params := getAdditionalParamsFromViper()
+	assert.False(t, params.UseWriteBatch)
+	assert.False(t, params.UseGetMultipleKeys)
+	assert.Equal(t, uint32(defaultMaxSizeBatch), params.MaxSizeWriteBatch)
+	assert.Equal(t, uint32(defaultMaxSizeBatch), params.MaxSizeGetMultipleKeys)
+
+	viper.Set("chaincode.runtimeParams.useWriteBatch", true)
+	viper.Set("chaincode.runtimeParams.maxSizeWriteBatch", 10)
+	viper.Set("chaincode.runtimeParams.useGetMultipleKeys", true)
+	viper.Set("chaincode.runtimeParams.maxSizeGetMultipleKeys", 20)
+	params = getAdditionalParamsFromViper()
+	assert.True(t, params.UseWriteBatch)
+	assert.True(t, params.UseGetMultipleKeys)
+	assert.Equal(t, uint32(10), params.MaxSizeWriteBatch)
+	assert.Equal(t, uint32(20), params.MaxSizeGetMultipleKeys)
+}
+
+// recordingHistoryQueryExecutor records the last history query it received
+type recordingHistoryQueryExecutor struct {
+	coreledger.HistoryQueryExecutor
+	namespace  string
+	key        string
+	collection string
+	keyHash    []byte
+	options    *coreledger.HistoryQueryOptions
+}
+
+func (r *recordingHistoryQueryExecutor) GetHistoryForKeyWithOptions(namespace, key string, options *coreledger.HistoryQueryOptions) (ledger.ResultsIterator, error) {
+	r.namespace, r.key, r.collection, r.keyHash, r.options = namespace, key, "", nil, options
+	return &mockResultsIterator{}, nil
+}
+
+func (r *recordingHistoryQueryExecutor) GetPrivateDataHashHistoryForKey(namespace, collection string, keyHash []byte, options *coreledger.HistoryQueryOptions) (ledger.ResultsIterator, error) {
+	if collection == "missing" {
+		return nil, errors.New("history not enabled")
+	}
+	r.namespace, r.key, r.collection, r.keyHash, r.options = namespace, "", collection, keyHash, options
+	return &mockResultsIterator{}, nil
+}
+
+func TestHandleGetHistoryForKey(t *testing.T) {
+	handler, stream := newTestHandlerWithTxSim(&mapTxSim{})
+	historyQueryExecutor := &recordingHistoryQueryExecutor{}
+	txContext := handler.txCtxs[handler.getTxCtxId("testchannel", "txid")]
+	txContext.historyQueryExecutor = historyQueryExecutor
+	txContext.queryIteratorMap = make(map[string]ledger.ResultsIterator)
+	txContext.pendingQueryResults = make(map[string]*pendingQueryResult)
+
+	getHistoryForKey := func(req *pb.GetHistoryForKey) *pb.ChaincodeMessage {
+		payload, err := proto.Marshal(req)
+		require.NoError(t, err)
+		handler.handleGetHistoryForKey(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Payload: payload, Txid: "txid", ChannelId: "testchannel"})
+		return receive(t, stream)
+	}
+
+	resp := getHistoryForKey(&pb.GetHistoryForKey{Key: "a"})
+	require.Equal(t, pb.ChaincodeMessage_RESPONSE, resp.Type)
+	assert.Equal(t, "mycc", historyQueryExecutor.namespace)
+	assert.Equal(t, "a", historyQueryExecutor.key)
+	assert.Nil(t, historyQueryExecutor.options)
+
+	options := &pb.HistoryQueryOptions{StartBlock: 2, EndBlock: 5, Reverse: true, Limit: 10}
+	resp = getHistoryForKey(&pb.GetHistoryForKey{Key: "a", Options: options})
+	require.Equal(t, pb.ChaincodeMessage_RESPONSE, resp.Type)
+	assert.Equal(t, &coreledger.HistoryQueryOptions{StartBlock: 2, EndBlock: 5, Reverse: true, Limit: 10}, historyQueryExecutor.options)
+
+	// private data history is queried by the hash of the key
+	resp = getHistoryForKey(&pb.GetHistoryForKey{Key: "a", Collection: "coll", Options: options})
+	require.Equal(t, pb.ChaincodeMessage_RESPONSE, resp.Type)
+	assert.Equal(t, "coll", historyQueryExecutor.collection)
+	assert.Equal(t, ledgerutil.ComputeStringHash("a"), historyQueryExecutor.keyHash)
+	assert.Equal(t, uint32(10), historyQueryExecutor.options.Limit)
+
+	resp = getHistoryForKey(&pb.GetHistoryForKey{Key: "a", Collection: "missing"})
+	assert.Equal(t, pb.ChaincodeMessage_ERROR, resp.Type)
+	assert.Contains(t, string(resp.Payload), "history not enabled")
+}
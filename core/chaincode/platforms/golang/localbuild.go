@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metadata"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+)
+
+// localBuildTimeout bounds the duration of the go toolchain invocation
+const localBuildTimeout = 5 * time.Minute
+
+// GenerateLocalBuild builds the chaincode with the go toolchain of the peer's host,
+// instead of a builder container, and writes the resulting executable to the
+// package as "chaincode"
+func (goPlatform *Platform) GenerateLocalBuild(cds *pb.ChaincodeDeploymentSpec, tw *tar.Writer) error {
+	pkgname, err := decodeUrl(cds.ChaincodeSpec)
+	if err != nil {
+		return fmt.Errorf("could not decode url: %s", err)
+	}
+
+	builddir, err := ioutil.TempDir("", "chaincode-build")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(builddir)
+
+	inputdir := filepath.Join(builddir, "input")
+	err = extractCodePackage(cds.CodePackage, inputdir)
+	if err != nil {
+		return fmt.Errorf("could not extract code package: %s", err)
+	}
+
+	gotags := strings.Fields(viper.GetString("chaincode.golang.buildTags"))
+	// check if experimental features are enabled
+	if metadata.Experimental == "true" {
+		gotags = append(gotags, "experimental")
+	}
+	logger.Infof("building chaincode locally with tags: %s", gotags)
+
+	env := getEnv()
+	env["GOPATH"] = inputdir + string(os.PathListSeparator) + env["GOPATH"]
+	output := filepath.Join(builddir, "chaincode")
+	_, err = runProgram(env, localBuildTimeout, "go", "build", "-tags", strings.Join(gotags, " "), "-o", output, pkgname)
+	if err != nil {
+		return err
+	}
+
+	binary, err := ioutil.ReadFile(output)
+	if err != nil {
+		return err
+	}
+
+	var zeroTime time.Time
+	err = tw.WriteHeader(&tar.Header{Name: "chaincode", Mode: 0755, Size: int64(len(binary)), ModTime: zeroTime, AccessTime: zeroTime, ChangeTime: zeroTime})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(binary)
+	return err
+}
+
+// extractCodePackage extracts the gzipped tar code package of a chaincode,
+// as written by GetDeploymentPayload, into dir
+func extractCodePackage(codePackage []byte, dir string) error {
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("illegal file name %s in code package", header.Name)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, tr)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateLocalBuild(t *testing.T) {
+	platform := &Platform{}
+	viper.Set("chaincode.golang.buildTags", "nopkcs11")
+	defer viper.Set("chaincode.golang.buildTags", "")
+
+	cds := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{
+				Name:    "map",
+				Path:    "github.com/hyperledger/fabric/examples/chaincode/go/map",
+				Version: "0",
+			},
+		},
+	}
+	var err error
+	cds.CodePackage, err = platform.GetDeploymentPayload(cds.ChaincodeSpec)
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	tw := tar.NewWriter(buf)
+	err = platform.GenerateLocalBuild(cds, tw)
+	require.NoError(t, err)
+	assert.NoError(t, tw.Close())
+
+	tr := tar.NewReader(buf)
+	header, err := tr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "chaincode", header.Name)
+	assert.Equal(t, int64(0755), header.Mode)
+	assert.NotZero(t, header.Size)
+	_, err = tr.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestGenerateLocalBuildErrors(t *testing.T) {
+	platform := &Platform{}
+
+	// the code does not compile
+	cds, err := generateFakeCDS("bad", "path/to/bad", "/src/path/to/bad/bad.go", 0100400)
+	assert.NoError(t, err)
+	err = platform.GenerateLocalBuild(cds, tar.NewWriter(bytes.NewBuffer(nil)))
+	assert.Error(t, err)
+
+	// the code package escapes the build directory
+	cds, err = generateFakeCDS("evil", "path/to/evil", "../../evil.go", 0100400)
+	assert.NoError(t, err)
+	err = platform.GenerateLocalBuild(cds, tar.NewWriter(bytes.NewBuffer(nil)))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "illegal file name")
+
+	// the path cannot be decoded
+	cds.ChaincodeSpec.ChaincodeId.Path = "https://"
+	err = platform.GenerateLocalBuild(cds, tar.NewWriter(bytes.NewBuffer(nil)))
+	assert.Error(t, err)
+}
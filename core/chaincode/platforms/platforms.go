@@ -49,6 +49,12 @@ type Platform interface {
 	GenerateDockerBuild(spec *pb.ChaincodeDeploymentSpec, tw *tar.Writer) error
 }
 
+// LocalBuilder is implemented by the platforms which can build chaincode
+// into executables running directly on the peer's host
+type LocalBuilder interface {
+	GenerateLocalBuild(spec *pb.ChaincodeDeploymentSpec, tw *tar.Writer) error
+}
+
 var logger = flogging.MustGetLogger("chaincode-platform")
 
 // Added for unit testing purposes
@@ -193,3 +199,36 @@ func GenerateDockerBuild(cds *pb.ChaincodeDeploymentSpec) (io.Reader, error) {
 
 	return input, nil
 }
+
+// GenerateLocalBuild builds the chaincode on the peer's host and returns a gzipped
+// tar stream of the resulting executables, for the chaincode runtimes which don't
+// rely on docker
+func GenerateLocalBuild(cds *pb.ChaincodeDeploymentSpec) (io.Reader, error) {
+	platform, err := _Find(cds.ChaincodeSpec.Type)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to determine platform type: %s", err)
+	}
+
+	localBuilder, ok := platform.(LocalBuilder)
+	if !ok {
+		return nil, fmt.Errorf("Platform %s does not support local builds", cds.ChaincodeSpec.Type)
+	}
+
+	input, output := io.Pipe()
+
+	go func() {
+		gw := gzip.NewWriter(output)
+		tw := tar.NewWriter(gw)
+		err := localBuilder.GenerateLocalBuild(cds, tw)
+		if err != nil {
+			logger.Error(err)
+			err = fmt.Errorf("Failed to generate platform-specific local build: %s", err)
+		}
+
+		tw.Close()
+		gw.Close()
+		output.CloseWithError(err)
+	}()
+
+	return input, nil
+}
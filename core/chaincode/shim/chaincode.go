@@ -65,6 +65,10 @@ type ChaincodeStub struct {
 	binding   []byte
 
 	decorations map[string][]byte
+
+	// puts and deletes buffered until the end of the transaction, when the
+	// peer supports write batches
+	writeBatch *writeBatch
 }
 
 // Peer address derived from command line or env var
@@ -411,6 +415,57 @@ func (stub *ChaincodeStub) GetState(key string) ([]byte, error) {
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetMultipleStates documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	// Access public data by setting the collection to empty string
+	collection := ""
+	return stub.getMultipleStates(collection, keys)
+}
+
+// getMultipleStates gets the state of the keys of a collection, in messages of
+// at most the number of keys the peer accepts, or key by key when the peer does
+// not support getting several keys at once
+func (stub *ChaincodeStub) getMultipleStates(collection string, keys []string) ([][]byte, error) {
+	params := stub.handler.additionalParams
+	values := make([][]byte, 0, len(keys))
+	if !params.GetUseGetMultipleKeys() {
+		for _, key := range keys {
+			value, err := stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	for _, chunk := range chunkKeys(keys, int(params.GetMaxSizeGetMultipleKeys())) {
+		chunkValues, err := stub.handler.handleGetStateMultiple(collection, chunk, stub.ChannelId, stub.TxID)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, chunkValues...)
+	}
+	return values, nil
+}
+
+// chunkKeys splits keys in chunks of at most size keys, a size of 0 meaning
+// no limit
+func chunkKeys(keys []string, size int) [][]string {
+	if size <= 0 {
+		size = len(keys)
+	}
+	var chunks [][]string
+	for len(keys) > 0 {
+		if len(keys) < size {
+			size = len(keys)
+		}
+		chunks = append(chunks, keys[:size])
+		keys = keys[size:]
+	}
+	return chunks
+}
+
 // PutState documentation can be found in interfaces.go
 func (stub *ChaincodeStub) PutState(key string, value []byte) error {
 	if key == "" {
@@ -418,9 +473,79 @@ func (stub *ChaincodeStub) PutState(key string, value []byte) error {
 	}
 	// Access public data by setting the collection to empty string
 	collection := ""
+	return stub.putState(collection, key, value)
+}
+
+// putState puts the state of a key of a collection, buffering it until the end
+// of the transaction when the peer supports write batches
+func (stub *ChaincodeStub) putState(collection, key string, value []byte) error {
+	if stub.handler.additionalParams.GetUseWriteBatch() {
+		stub.bufferWrite(&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Collection: collection, Key: key, Value: value})
+		return nil
+	}
 	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
 }
 
+// delState deletes the state of a key of a collection, buffering the deletion
+// until the end of the transaction when the peer supports write batches
+func (stub *ChaincodeStub) delState(collection, key string) error {
+	if stub.handler.additionalParams.GetUseWriteBatch() {
+		stub.bufferWrite(&pb.WriteRecord{Type: pb.ChaincodeMessage_DEL_STATE, Collection: collection, Key: key})
+		return nil
+	}
+	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+}
+
+// writeBatch holds the puts and deletes of a transaction in the order of their
+// first write, a later write of a key replacing the previous one
+type writeBatch struct {
+	records []*pb.WriteRecord
+	index   map[writeKey]int
+}
+
+type writeKey struct {
+	collection string
+	key        string
+}
+
+func (stub *ChaincodeStub) bufferWrite(record *pb.WriteRecord) {
+	if stub.writeBatch == nil {
+		stub.writeBatch = &writeBatch{index: make(map[writeKey]int)}
+	}
+	wk := writeKey{collection: record.Collection, key: record.Key}
+	if i, ok := stub.writeBatch.index[wk]; ok {
+		stub.writeBatch.records[i] = record
+		return
+	}
+	stub.writeBatch.index[wk] = len(stub.writeBatch.records)
+	stub.writeBatch.records = append(stub.writeBatch.records, record)
+}
+
+// flushWriteBatch sends the buffered puts and deletes to the peer, in messages
+// of at most the number of records the peer accepts
+func (stub *ChaincodeStub) flushWriteBatch() error {
+	if stub.writeBatch == nil {
+		return nil
+	}
+	records := stub.writeBatch.records
+	stub.writeBatch = nil
+
+	size := int(stub.handler.additionalParams.GetMaxSizeWriteBatch())
+	if size <= 0 {
+		size = len(records)
+	}
+	for len(records) > 0 {
+		if len(records) < size {
+			size = len(records)
+		}
+		if err := stub.handler.handleWriteBatchState(records[:size], stub.ChannelId, stub.TxID); err != nil {
+			return err
+		}
+		records = records[size:]
+	}
+	return nil
+}
+
 // GetQueryResult documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetQueryResult(query string) (StateQueryIteratorInterface, error) {
 	// Access public data by setting the collection to empty string
@@ -436,7 +561,7 @@ func (stub *ChaincodeStub) GetQueryResult(query string) (StateQueryIteratorInter
 func (stub *ChaincodeStub) DelState(key string) error {
 	// Access public data by setting the collection to empty string
 	collection := ""
-	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+	return stub.delState(collection, key)
 }
 
 // CommonIterator documentation can be found in interfaces.go
@@ -487,7 +612,12 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	return stub.GetHistoryForKeyWithOptions(key, nil)
+}
+
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	response, err := stub.handler.handleGetHistoryForKey("", key, options, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,8 @@ package shim
 
 import (
 	"fmt"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 // private state functions
@@ -22,6 +24,14 @@ func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetMultiplePrivateData documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	return stub.getMultipleStates(collection, keys)
+}
+
 // PutPrivateData documentation can be found in interfaces.go
 func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value []byte) error {
 	if collection == "" {
@@ -30,7 +40,7 @@ func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value [
 	if key == "" {
 		return fmt.Errorf("key must not be an empty string")
 	}
-	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+	return stub.putState(collection, key, value)
 }
 
 // DelPrivateData documentation can be found in interfaces.go
@@ -38,7 +48,7 @@ func (stub *ChaincodeStub) DelPrivateData(collection string, key string) error {
 	if collection == "" {
 		return fmt.Errorf("collection must not be an empty string")
 	}
-	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+	return stub.delState(collection, key)
 }
 
 // GetPrivateDataByRange documentation can be found in interfaces.go
@@ -67,6 +77,18 @@ func (stub *ChaincodeStub) GetPrivateDataByPartialCompositeKey(collection, objec
 	}
 }
 
+// GetPrivateDataHashHistoryForKey documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataHashHistoryForKey(collection, key string, options *pb.HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	response, err := stub.handler.handleGetHistoryForKey(collection, key, options, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
+}
+
 // GetPrivateDataQueryResult documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataQueryResult(collection, query string) (StateQueryIteratorInterface, error) {
 	if collection == "" {
@@ -52,6 +52,9 @@ type Handler struct {
 	// Multiple queries (and one transaction) with different txids can be executing in parallel for this chaincode
 	// responseChannel is the channel on which responses are communicated by the shim to the chaincodeStub.
 	responseChannel map[string]chan pb.ChaincodeMessage
+	// additionalParams are the optional protocol features offered by the
+	// peer on registration, nil when the peer does not offer any
+	additionalParams *pb.ChaincodeAdditionalParams
 }
 
 func shorttxid(txid string) string {
@@ -220,6 +223,11 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 			}
 		}
 
+		err = stub.flushWriteBatch()
+		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent, "[%s]Init failed to send buffered writes. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		resBytes, err := proto.Marshal(&res)
 		if err != nil {
 			payload := []byte(err.Error())
@@ -272,6 +280,11 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage, errc chan er
 		}
 		res := handler.cc.Invoke(stub)
 
+		err = stub.flushWriteBatch()
+		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s]Transaction failed to send buffered writes. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		// Endorser will handle error contained in Response.
 		resBytes, err := proto.Marshal(&res)
 		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s]Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
@@ -299,7 +312,6 @@ func (handler *Handler) callPeerWithChaincodeMsg(msg *pb.ChaincodeMessage, chann
 	return handler.sendReceive(msg, respChan)
 }
 
-// TODO: Implement a method to get multiple keys at a time [FAB-1244]
 // handleGetState communicates with the peer to fetch the requested state information from the ledger.
 func (handler *Handler) handleGetState(collection string, key string, channelId string, txid string) ([]byte, error) {
 	// Construct payload for GET_STATE
@@ -329,7 +341,6 @@ func (handler *Handler) handleGetState(collection string, key string, channelId
 	return nil, errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
-// TODO: Implement a method to set multiple keys at a time [FAB-1244]
 // handlePutState communicates with the peer to put state information into the ledger.
 func (handler *Handler) handlePutState(collection string, key string, value []byte, channelId string, txid string) error {
 	// Construct payload for PUT_STATE
@@ -391,6 +402,82 @@ func (handler *Handler) handleDelState(collection string, key string, channelId
 	return errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetStateMultiple communicates with the peer to fetch the state of
+// several keys with a single message. The values are returned in the order of
+// the keys.
+func (handler *Handler) handleGetStateMultiple(collection string, keys []string, channelId string, txid string) ([][]byte, error) {
+	// Construct payload for GET_STATE_MULTIPLE
+	payloadBytes, _ := proto.Marshal(&pb.GetStateMultiple{Collection: collection, Keys: keys})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_MULTIPLE)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s]error sending GET_STATE_MULTIPLE", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s]GetStateMultiple received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		result := &pb.GetStateMultipleResult{}
+		if err = proto.Unmarshal(responseMsg.Payload, result); err != nil {
+			return nil, errors.Wrapf(err, "[%s]GetStateMultipleResult unmarshall error", shorttxid(responseMsg.Txid))
+		}
+		if len(result.Values) != len(keys) {
+			return nil, errors.Errorf("[%s]received %d values for %d keys", shorttxid(responseMsg.Txid), len(result.Values), len(keys))
+		}
+		// like GetState, report the keys which do not exist with nil values
+		for i, value := range result.Values {
+			if len(value) == 0 {
+				result.Values[i] = nil
+			}
+		}
+		return result.Values, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s]GetStateMultiple received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
+// handleWriteBatchState communicates with the peer to put and delete the state
+// of several keys with a single message.
+func (handler *Handler) handleWriteBatchState(records []*pb.WriteRecord, channelId string, txid string) error {
+	// Construct payload for WRITE_BATCH_STATE
+	payloadBytes, _ := proto.Marshal(&pb.WriteBatchState{Records: records})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_WRITE_BATCH_STATE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s]Sending %s of %d records", shorttxid(msg.Txid), pb.ChaincodeMessage_WRITE_BATCH_STATE, len(records))
+
+	// Execute the request and get response
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("[%s]error sending WRITE_BATCH_STATE", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s]Received %s. Successfully updated state", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return nil
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s]Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handleGetStateByRange(collection, startKey, endKey string, channelId string, txid string) (*pb.QueryResponse, error) {
 	// Send GET_STATE_BY_RANGE message to peer chaincode support
 	//we constructed a valid object. No need to check for error
@@ -560,7 +647,10 @@ func (handler *Handler) handleGetQueryResult(collection string, query string, ch
 	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
-func (handler *Handler) handleGetHistoryForKey(key string, channelId string, txid string) (*pb.QueryResponse, error) {
+// handleGetHistoryForKey communicates with the peer to fetch the history of a key, or of the hash
+// of a key if a collection is given, restricted and ordered by the options
+func (handler *Handler) handleGetHistoryForKey(collection string, key string, options *pb.HistoryQueryOptions,
+	channelId string, txid string) (*pb.QueryResponse, error) {
 	// Create the channel on which to communicate the response from validating peer
 	var respChan chan pb.ChaincodeMessage
 	var err error
@@ -573,7 +663,7 @@ func (handler *Handler) handleGetHistoryForKey(key string, channelId string, txi
 
 	// Send GET_HISTORY_FOR_KEY message to peer chaincode support
 	//we constructed a valid object. No need to check for error
-	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKey{Key: key})
+	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKey{Key: key, Collection: collection, Options: options})
 
 	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
 	chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_HISTORY_FOR_KEY)
@@ -719,6 +809,13 @@ func (handler *Handler) handleEstablished(msg *pb.ChaincodeMessage, errc chan er
 //handle created state
 func (handler *Handler) handleCreated(msg *pb.ChaincodeMessage, errc chan error) error {
 	if msg.Type == pb.ChaincodeMessage_REGISTERED {
+		// peers which do not offer optional features send an empty payload
+		params := &pb.ChaincodeAdditionalParams{}
+		if err := proto.Unmarshal(msg.Payload, params); err != nil {
+			return errors.Wrap(err, "error unmarshalling chaincode additional params")
+		}
+		chaincodeLogger.Debugf("Registered with additional params: %s", params)
+		handler.additionalParams = params
 		handler.state = established
 		return nil
 	}
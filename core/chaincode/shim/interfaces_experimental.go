@@ -85,6 +85,14 @@ type ChaincodeStubInterface interface {
 	// If the key does not exist in the state database, (nil, nil) is returned.
 	GetState(key string) ([]byte, error)
 
+	// GetMultipleStates returns the values of the specified `keys` from the
+	// ledger, in the order of the keys, a nil value standing for a key which
+	// does not exist in the state database. When the peer supports it, the
+	// keys are fetched with a few messages instead of one message per key.
+	// Like GetState, GetMultipleStates doesn't consider data modified by
+	// PutState that has not been committed.
+	GetMultipleStates(keys ...string) ([][]byte, error)
+
 	// PutState puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal. PutState doesn't effect the ledger
 	// until the transaction is validated and successfully committed.
@@ -92,11 +100,15 @@ type ChaincodeStubInterface interface {
 	// character (0x00), in order to avoid range query collisions with
 	// composite keys, which internally get prefixed with 0x00 as composite
 	// key namespace.
+	// When the peer supports write batches, the write is buffered by the shim
+	// and sent to the peer with the other writes of the transaction when the
+	// chaincode returns, a failure to write then failing the transaction.
 	PutState(key string, value []byte) error
 
 	// DelState records the specified `key` to be deleted in the writeset of
 	// the transaction proposal. The `key` and its value will be deleted from
 	// the ledger when the transaction is validated and successfully committed.
+	// Like PutState, the deletion may be buffered until the chaincode returns.
 	DelState(key string) error
 
 	// GetStateByRange returns a range iterator over a set of keys in the
@@ -162,6 +174,14 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns the history of key values like
+	// GetHistoryForKey, restricted to the block and timestamp ranges of the
+	// `options`, newest first if `options.Reverse` is set and at most
+	// `options.Limit` entries if the limit is not zero. A nil `options`
+	// returns the full history, oldest first.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -169,6 +189,22 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetMultiplePrivateData returns the values of the specified `keys` from
+	// the specified `collection`, in the order of the keys, a nil value
+	// standing for a key which does not exist. Like GetPrivateData,
+	// GetMultiplePrivateData doesn't consider data modified by PutPrivateData
+	// that has not been committed.
+	GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error)
+
+	// GetPrivateDataHashHistoryForKey returns the history of the hash of the
+	// specified `key` in the specified `collection`. Each entry carries the
+	// hash of the value written by the transaction instead of the value. The
+	// `options` apply like for GetHistoryForKeyWithOptions.
+	// GetPrivateDataHashHistoryForKey requires peer configuration
+	// core.ledger.history.enablePrivateDataHashHistory to be true.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetPrivateDataHashHistoryForKey(collection, key string, options *pb.HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
 	// PutPrivateData puts the specified `key` and `value` into the transaction's
 	// private writeset. Note that only hash of the private writeset goes into the
 	// transaction proposal response (which is sent to the client who issued the
@@ -24,6 +24,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMockStateRangeQueryIterator(t *testing.T) {
@@ -235,6 +236,23 @@ func TestGetTxTimestamp(t *testing.T) {
 	stub.MockTransactionEnd("init")
 }
 
+func TestGetMultipleStates(t *testing.T) {
+	stub := NewMockStub("GetMultipleStates", nil)
+	stub.MockTransactionStart("init")
+	stub.PutState("a", []byte("1"))
+	stub.PutState("c", []byte("3"))
+	stub.PutPrivateData("coll", "a", []byte("private 1"))
+	stub.MockTransactionEnd("init")
+
+	values, err := stub.GetMultipleStates("a", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), nil, []byte("3")}, values)
+
+	values, err = stub.GetMultiplePrivateData("coll", "a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("private 1"), nil}, values)
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -265,6 +283,8 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyWithOptions("k", nil)
+	stub.GetPrivateDataHashHistoryForKey("c", "k", nil)
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS settings of a chaincode server
+type TLSProperties struct {
+	// Disabled turns TLS off, it should only be used for development
+	Disabled bool
+	// Key is the PEM encoded private key of the server
+	Key []byte
+	// Cert is the PEM encoded certificate of the server
+	Cert []byte
+	// ClientCACerts are the PEM encoded certificates of the CAs the clients
+	// must authenticate against. When empty, client authentication is not
+	// required.
+	ClientCACerts []byte
+}
+
+// ChaincodeServer serves a chaincode to the peers which connect to it, for
+// chaincodes which are run as services instead of being launched by the peer.
+// The peers find the address and TLS settings of the server in the connection
+// package installed in place of the chaincode code.
+type ChaincodeServer struct {
+	// CCID is the name, in the name:version form, the chaincode registers
+	// with on the peers
+	CCID string
+	// Address is the listen address of the server
+	Address string
+	// CC is the chaincode served
+	CC Chaincode
+	// TLSProps are the TLS settings of the server
+	TLSProps TLSProperties
+	// KaOpts are the keepalive settings of the server, defaulting to the ones
+	// of the peer connections when nil
+	KaOpts *comm.KeepaliveOptions
+}
+
+// chaincodeServerStream adapts the server side of a chaincode stream to the
+// interface of the streams the shim opens with the peer
+type chaincodeServerStream struct {
+	pb.ChaincodeSupport_RegisterServer
+}
+
+// CloseSend does nothing, the server side of the stream being closed when
+// Register returns
+func (s *chaincodeServerStream) CloseSend() error {
+	return nil
+}
+
+// Register handles the stream opened by a peer with the chaincode, just like
+// the stream the chaincode opens with the peer when it is launched by the peer
+func (cs *ChaincodeServer) Register(stream pb.ChaincodeSupport_RegisterServer) error {
+	chaincodeLogger.Debugf("starting chat with peer using name=%s", cs.CCID)
+	return chatWithPeer(cs.CCID, &chaincodeServerStream{ChaincodeSupport_RegisterServer: stream}, cs.CC)
+}
+
+// Start serves the chaincode until the server fails
+func (cs *ChaincodeServer) Start() error {
+	server, err := cs.newGRPCServer()
+	if err != nil {
+		return err
+	}
+
+	err = factory.InitFactories(factory.GetDefaultOpts())
+	if err != nil {
+		return errors.WithMessage(err, "internal error, BCCSP could not be initialized with default options")
+	}
+
+	chaincodeLogger.Infof("chaincode %s listening on %s", cs.CCID, server.Address())
+	return server.Start()
+}
+
+// newGRPCServer validates the settings of the chaincode server and returns a
+// gRPC server serving the chaincode
+func (cs *ChaincodeServer) newGRPCServer() (*comm.GRPCServer, error) {
+	if cs.CCID == "" {
+		return nil, errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return nil, errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return nil, errors.New("chaincode must be specified")
+	}
+
+	kaOpts := cs.KaOpts
+	if kaOpts == nil {
+		kaOpts = comm.DefaultKeepaliveOptions()
+	}
+	secOpts := &comm.SecureOptions{UseTLS: !cs.TLSProps.Disabled}
+	if !cs.TLSProps.Disabled {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return nil, errors.New("key and cert must be specified when TLS is enabled")
+		}
+		secOpts.Key = cs.TLSProps.Key
+		secOpts.Certificate = cs.TLSProps.Cert
+		if len(cs.TLSProps.ClientCACerts) > 0 {
+			secOpts.RequireClientCert = true
+			secOpts.ClientRootCAs = [][]byte{cs.TLSProps.ClientCACerts}
+		}
+	}
+
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{SecOpts: secOpts, KaOpts: kaOpts})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error creating chaincode server")
+	}
+	pb.RegisterChaincodeSupportServer(server.Server(), cs)
+	return server, nil
+}
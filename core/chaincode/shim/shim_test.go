@@ -94,6 +94,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.historyq(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
+	} else if function == "multiq" {
+		return t.multiq(stub, args)
 	}
 
 	return Error("Invalid invoke function name. Expecting \"invoke\" \"delete\" \"query\"")
@@ -172,6 +174,15 @@ func (t *shimTestCC) delete(stub ChaincodeStubInterface, args []string) pb.Respo
 	return Success(nil)
 }
 
+// multiq gets the state of several keys at once
+func (t *shimTestCC) multiq(stub ChaincodeStubInterface, args []string) pb.Response {
+	values, err := stub.GetMultipleStates(args...)
+	if err != nil {
+		return Error(err.Error())
+	}
+	return Success(bytes.Join(values, []byte(",")))
+}
+
 // query callback representing the query of a chaincode
 func (t *shimTestCC) query(stub ChaincodeStubInterface, args []string) pb.Response {
 	var A string // Entities
@@ -305,7 +316,13 @@ func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Res
 
 	key := args[0]
 
-	resultsIterator, err := stub.GetHistoryForKey(key)
+	var resultsIterator HistoryQueryIteratorInterface
+	var err error
+	if len(args) > 1 && args[1] == "latest" {
+		resultsIterator, err = stub.GetHistoryForKeyWithOptions(key, &pb.HistoryQueryOptions{Reverse: true, Limit: 1})
+	} else {
+		resultsIterator, err = stub.GetHistoryForKey(key)
+	}
 	if err != nil {
 		return Error(err.Error())
 	}
@@ -779,6 +796,25 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//history query of the latest modification
+
+	historyQueryResponse = &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KeyModification{TxId: "6", Value: []byte("100")})}}}
+	payload = utils.MarshalOrPanic(historyQueryResponse)
+
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Txid: "7b", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: "7b", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7b", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7b", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyq"), []byte("A"), []byte("latest")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//error history query
 
 	//create the response
@@ -955,6 +991,113 @@ func TestCC2CC(t *testing.T) {
 	peerSide.Quit()
 }
 
+//TestWriteBatchAndGetMultipleStates tests that puts and deletes are sent in
+//batches and that several keys are got at once when the peer supports it
+func TestWriteBatchAndGetMultipleStates(t *testing.T) {
+	streamGetter = mockChaincodeStreamGetter
+	cc := &shimTestCC{}
+	var err error
+	ccname := "shimTestCC"
+	peerSide := setupcc(ccname, cc)
+	defer mockPeerCCSupport.RemoveCC(ccname)
+	//start the shim+chaincode
+	go func() {
+		err = Start(cc)
+	}()
+
+	done := setuperror()
+
+	errorFunc := func(ind int, err error) {
+		done <- err
+	}
+
+	params := utils.MarshalOrPanic(&pb.ChaincodeAdditionalParams{UseWriteBatch: true, MaxSizeWriteBatch: 1, UseGetMultipleKeys: true, MaxSizeGetMultipleKeys: 2})
+
+	//start the mock peer
+	go func() {
+		respSet := &mockpeer.MockResponseSet{errorFunc, nil, []*mockpeer.MockResponse{
+			{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED, Payload: params}}}}
+		peerSide.SetResponses(respSet)
+		peerSide.SetKeepAlive(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE})
+		err = peerSide.Run()
+	}()
+
+	//wait for init
+	processDone(t, done, false)
+
+	channelId := "testchannel"
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_READY, Txid: "1", ChannelId: channelId})
+
+	//the two puts of init are sent one per batch before completing
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("init"), []byte("A"), []byte("100"), []byte("B"), []byte("200")}, Decorations: nil}
+	payload := utils.MarshalOrPanic(ci)
+	respSet := &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_WRITE_BATCH_STATE, Txid: "2", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "2", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_WRITE_BATCH_STATE, Txid: "2", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "2", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "2", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INIT, Payload: payload, Txid: "2", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//three keys are got in two messages
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "3", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(&pb.GetStateMultipleResult{Values: [][]byte{[]byte("100"), []byte("200")}}), Txid: "3", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "3", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(&pb.GetStateMultipleResult{Values: [][]byte{nil}}), Txid: "3", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "3", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("multiq"), []byte("A"), []byte("B"), []byte("C")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "3", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//a batch the peer fails to write fails the transaction
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_WRITE_BATCH_STATE, Txid: "4", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("write failed"), Txid: "4", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "4", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("delete"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	time.Sleep(1 * time.Second)
+	peerSide.Quit()
+}
+
+func TestChunkKeys(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, chunkKeys(keys, 2))
+	assert.Equal(t, [][]string{keys}, chunkKeys(keys, 5))
+	assert.Equal(t, [][]string{keys}, chunkKeys(keys, 0))
+	assert.Empty(t, chunkKeys(nil, 2))
+}
+
+func TestBufferWrite(t *testing.T) {
+	stub := &ChaincodeStub{}
+	stub.bufferWrite(&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: "a", Value: []byte("1")})
+	stub.bufferWrite(&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: "b", Value: []byte("2")})
+	stub.bufferWrite(&pb.WriteRecord{Type: pb.ChaincodeMessage_PUT_STATE, Key: "a", Collection: "coll", Value: []byte("3")})
+	// a later write of a key replaces the previous one, keeping its place
+	stub.bufferWrite(&pb.WriteRecord{Type: pb.ChaincodeMessage_DEL_STATE, Key: "a"})
+
+	records := stub.writeBatch.records
+	assert.Len(t, records, 3)
+	assert.Equal(t, pb.ChaincodeMessage_DEL_STATE, records[0].Type)
+	assert.Equal(t, "a", records[0].Key)
+	assert.Equal(t, "b", records[1].Key)
+	assert.Equal(t, "coll", records[2].Collection)
+}
+
 func TestRealPeerStream(t *testing.T) {
 	viper.Set("peer.address", "127.0.0.1:12345")
 	_, err := userChaincodeStreamGetter("fake")
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"google.golang.org/grpc"
 )
 
@@ -20,6 +21,20 @@ var logger = flogging.MustGetLogger("ConnProducer")
 
 var EndpointDisableInterval = time.Second * 10
 
+// EndpointFailureExpiration is the period of time after which
+// failures of an endpoint no longer affect its selection
+var EndpointFailureExpiration = time.Minute
+
+const (
+	// Weight given to the latest connection latency sample when
+	// updating the moving average of an endpoint
+	latencySmoothingFactor = 0.3
+
+	// Connection latencies below this value are considered equally good,
+	// and it is also assumed for endpoints we haven't connected to yet
+	minLatency = 10 * time.Millisecond
+)
+
 // ConnectionFactory creates a connection to a certain endpoint
 type ConnectionFactory func(endpoint string) (*grpc.ClientConn, error)
 
@@ -44,6 +59,29 @@ type connProducer struct {
 	endpoints         []string
 	disabledEndpoints map[string]time.Time
 	connect           ConnectionFactory
+	// health is nil if endpoints are selected at random
+	health  map[string]*endpointHealth
+	metrics metrics.Scope
+}
+
+// endpointHealth holds the measured health of a single endpoint
+type endpointHealth struct {
+	latency     time.Duration
+	failures    int
+	lastFailure time.Time
+}
+
+// weight returns the relative likelihood of the endpoint to be selected,
+// endpoints which connect faster and fail less often get higher weights
+func (h *endpointHealth) weight() float64 {
+	if time.Since(h.lastFailure) > EndpointFailureExpiration {
+		h.failures = 0
+	}
+	latency := h.latency
+	if latency < minLatency {
+		latency = minLatency
+	}
+	return 1 / (latency.Seconds() * float64(1+h.failures))
 }
 
 // NewConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory.
@@ -55,6 +93,23 @@ func NewConnectionProducer(factory ConnectionFactory, endpoints []string) Connec
 	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time)}
 }
 
+// NewHealthAwareConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory,
+// which prefers endpoints that connect fast and haven't failed recently over choosing an endpoint at random.
+// Connection attempts, failures and latencies are reported to the given metrics scope.
+// It returns nil, if the given endpoints slice is empty.
+func NewHealthAwareConnectionProducer(factory ConnectionFactory, endpoints []string, scope metrics.Scope) ConnectionProducer {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &connProducer{
+		endpoints:         endpoints,
+		connect:           factory,
+		disabledEndpoints: make(map[string]time.Time),
+		health:            make(map[string]*endpointHealth),
+		metrics:           scope,
+	}
+}
+
 // NewConnection creates a new connection.
 // Returns the connection, the endpoint selected, nil on success.
 // Returns nil, "", error on failure
@@ -68,16 +123,24 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 		}
 	}
 
-	endpoints := shuffle(cp.endpoints)
+	var endpoints []string
+	if cp.health == nil {
+		endpoints = shuffle(cp.endpoints)
+	} else {
+		endpoints = cp.orderByHealth()
+	}
 	checkedEndpoints := make([]string, 0)
 	for _, endpoint := range endpoints {
 		if _, ok := cp.disabledEndpoints[endpoint]; !ok {
 			checkedEndpoints = append(checkedEndpoints, endpoint)
+			start := time.Now()
 			conn, err := cp.connect(endpoint)
 			if err != nil {
 				logger.Error("Failed connecting to", endpoint, ", error:", err)
+				cp.connectionFailed(endpoint)
 				continue
 			}
+			cp.connectionEstablished(endpoint, time.Since(start))
 			return conn, endpoint, nil
 		}
 	}
@@ -116,11 +179,71 @@ func (cp *connProducer) DisableEndpoint(endpoint string) {
 	for _, currEndpoint := range cp.endpoints {
 		if currEndpoint == endpoint {
 			cp.disabledEndpoints[endpoint] = time.Now()
+			cp.connectionFailed(endpoint)
 			break
 		}
 	}
 }
 
+// orderByHealth returns the endpoints in a random order where
+// healthier endpoints are more likely to appear first
+func (cp *connProducer) orderByHealth() []string {
+	remaining := make([]string, len(cp.endpoints))
+	copy(remaining, cp.endpoints)
+	ordered := make([]string, 0, len(remaining))
+	for len(remaining) > 0 {
+		weights := make([]float64, len(remaining))
+		total := float64(0)
+		for i, endpoint := range remaining {
+			weights[i] = cp.healthOf(endpoint).weight()
+			total += weights[i]
+		}
+		selected := len(remaining) - 1
+		threshold := rand.Float64() * total
+		for i, w := range weights {
+			if threshold < w {
+				selected = i
+				break
+			}
+			threshold -= w
+		}
+		ordered = append(ordered, remaining[selected])
+		remaining = append(remaining[:selected], remaining[selected+1:]...)
+	}
+	return ordered
+}
+
+func (cp *connProducer) healthOf(endpoint string) *endpointHealth {
+	h, exists := cp.health[endpoint]
+	if !exists {
+		h = &endpointHealth{latency: minLatency}
+		cp.health[endpoint] = h
+	}
+	return h
+}
+
+func (cp *connProducer) connectionEstablished(endpoint string, latency time.Duration) {
+	if cp.health == nil {
+		return
+	}
+	h := cp.healthOf(endpoint)
+	h.latency = time.Duration(latencySmoothingFactor*float64(latency) + (1-latencySmoothingFactor)*float64(h.latency))
+	h.failures /= 2
+	scope := cp.metrics.Tagged(map[string]string{"endpoint": endpoint})
+	scope.Counter("connections").Inc(1)
+	scope.Gauge("connection_latency_ms").Update(float64(h.latency) / float64(time.Millisecond))
+}
+
+func (cp *connProducer) connectionFailed(endpoint string) {
+	if cp.health == nil {
+		return
+	}
+	h := cp.healthOf(endpoint)
+	h.failures++
+	h.lastFailure = time.Now()
+	cp.metrics.Tagged(map[string]string{"endpoint": endpoint}).Counter("connection_failures").Inc(1)
+}
+
 func shuffle(a []string) []string {
 	n := len(a)
 	returnedSlice := make([]string, n)
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 )
@@ -133,3 +134,52 @@ func TestDisableEndpoint(t *testing.T) {
 	assert.Equal(t, "b", a)
 
 }
+
+func TestHealthAwareConnectionProducer(t *testing.T) {
+	t.Parallel()
+	shouldConnFail := map[string]bool{
+		"a": true,
+		"b": false,
+		"c": false,
+	}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		if !shouldConnFail[endpoint] {
+			return &grpc.ClientConn{}, nil
+		}
+		return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+	}
+	assert.Nil(t, NewHealthAwareConnectionProducer(connFactory, []string{}, metrics.GetRootScope()))
+	producer := NewHealthAwareConnectionProducer(connFactory, []string{"a"}, metrics.GetRootScope())
+
+	// Make 'a' fail several times, and then add other endpoints
+	for i := 0; i < 10; i++ {
+		_, _, err := producer.NewConnection()
+		assert.Error(t, err)
+	}
+	producer.UpdateEndpoints([]string{"a", "b", "c"})
+
+	// 'a' should now be less likely to be selected than the healthy endpoints
+	cp := producer.(*connProducer)
+	assert.True(t, cp.healthOf("a").weight() < cp.healthOf("b").weight())
+	assert.True(t, cp.healthOf("a").weight() < cp.healthOf("c").weight())
+
+	// Now, revive 'a', and ensure it regains its share of connections
+	shouldConnFail["a"] = false
+	selected := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		selected[endpoint]++
+	}
+	for _, endpoint := range []string{"a", "b", "c"} {
+		assert.True(t, selected[endpoint] > 200, "selections: %v", selected)
+	}
+
+	// Now, make every host fail
+	shouldConnFail["a"] = true
+	shouldConnFail["b"] = true
+	shouldConnFail["c"] = true
+	conn, _, err := producer.NewConnection()
+	assert.Nil(t, conn)
+	assert.Error(t, err)
+}
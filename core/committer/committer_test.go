@@ -10,6 +10,7 @@ import (
 	"errors"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger"
@@ -73,6 +74,21 @@ func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCo
 	return args.Get(0).(peer.TxValidationCode), args.Error(1)
 }
 
+func (m *mockLedger) GetTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error) {
+	args := m.Called(creator, startBlockNum)
+	return args.Get(0).(ledger.ResultsIterator), args.Error(1)
+}
+
+func (m *mockLedger) GetTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error) {
+	args := m.Called(chaincodeName, startBlockNum)
+	return args.Get(0).(ledger.ResultsIterator), args.Error(1)
+}
+
+func (m *mockLedger) GetTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (ledger.ResultsIterator, error) {
+	args := m.Called(startTime, endTime)
+	return args.Get(0).(ledger.ResultsIterator), args.Error(1)
+}
+
 func (m *mockLedger) NewTxSimulator(txid string) (ledger2.TxSimulator, error) {
 	args := m.Called(txid)
 	return args.Get(0).(ledger2.TxSimulator), args.Error(1)
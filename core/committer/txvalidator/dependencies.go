@@ -0,0 +1,234 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"sync"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// TrackingValidator validates blocks while the blocks preceding them may still
+// be committed, by recording the state read during the validation
+type TrackingValidator interface {
+	Validator
+
+	// ValidateAndTrack validates a block like Validate does, and returns the
+	// state read while validating it
+	ValidateAndTrack(block *common.Block) (*Dependencies, error)
+}
+
+// Dependencies records the state read while validating a block. A block whose
+// validation read state written by a block committed after the validation
+// started has to be validated again
+type Dependencies struct {
+	// Height is the height of the ledger when the validation started. The blocks
+	// from Height onwards may have been committed after the state was read
+	Height uint64
+
+	lock sync.Mutex
+	keys map[string]map[string]struct{}
+	// namespaces records the namespaces read by range scans, queries and
+	// private data reads, which depend on any write to the namespace
+	namespaces map[string]struct{}
+}
+
+func newDependencies(height uint64) *Dependencies {
+	return &Dependencies{
+		Height:     height,
+		keys:       make(map[string]map[string]struct{}),
+		namespaces: make(map[string]struct{}),
+	}
+}
+
+func (d *Dependencies) addKeys(namespace string, keys ...string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	nsKeys, ok := d.keys[namespace]
+	if !ok {
+		nsKeys = make(map[string]struct{})
+		d.keys[namespace] = nsKeys
+	}
+	for _, key := range keys {
+		nsKeys[key] = struct{}{}
+	}
+}
+
+func (d *Dependencies) addNamespace(namespace string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.namespaces[namespace] = struct{}{}
+}
+
+// dependsOn tells whether the state read depends on the writes of a block
+func (d *Dependencies) dependsOn(writes *blockWrites) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for ns := range d.namespaces {
+		if _, ok := writes.namespaces[ns]; ok {
+			return true
+		}
+	}
+	for ns, keys := range d.keys {
+		writtenKeys, ok := writes.keys[ns]
+		if !ok {
+			continue
+		}
+		for key := range keys {
+			if _, ok := writtenKeys[key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateAndTrack validates a block like Validate does, and returns the state
+// read while validating it
+func (v *txValidator) ValidateAndTrack(block *common.Block) (*Dependencies, error) {
+	bcInfo, err := v.support.Ledger().GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not retrieve the height of the ledger")
+	}
+	deps := newDependencies(bcInfo.Height)
+	support := &trackingSupport{
+		Support: v.support,
+		ledger:  &trackingLedger{PeerLedger: v.support.Ledger(), deps: deps},
+	}
+	tracking := &txValidator{support: support, vscc: v.vscc}
+	if vscc, ok := v.vscc.(*vsccValidatorImpl); ok {
+		tracking.vscc = &vsccValidatorImpl{
+			support:     support,
+			ccprovider:  vscc.ccprovider,
+			sccprovider: vscc.sccprovider,
+		}
+	}
+	if err := tracking.Validate(block); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// trackingSupport provides the validation with a ledger that records the state read
+type trackingSupport struct {
+	Support
+	ledger *trackingLedger
+}
+
+func (s *trackingSupport) Ledger() ledger.PeerLedger {
+	return s.ledger
+}
+
+type trackingLedger struct {
+	ledger.PeerLedger
+	deps *Dependencies
+}
+
+func (l *trackingLedger) NewQueryExecutor() (ledger.QueryExecutor, error) {
+	qe, err := l.PeerLedger.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	return &trackingQueryExecutor{QueryExecutor: qe, deps: l.deps}, nil
+}
+
+func (l *trackingLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
+	txsim, err := l.PeerLedger.NewTxSimulator(txid)
+	if err != nil {
+		return nil, err
+	}
+	return &trackingTxSimulator{
+		TxSimulator: txsim,
+		reads:       &trackingQueryExecutor{QueryExecutor: txsim, deps: l.deps},
+	}, nil
+}
+
+type trackingQueryExecutor struct {
+	ledger.QueryExecutor
+	deps *Dependencies
+}
+
+func (qe *trackingQueryExecutor) GetState(namespace string, key string) ([]byte, error) {
+	qe.deps.addKeys(namespace, key)
+	return qe.QueryExecutor.GetState(namespace, key)
+}
+
+func (qe *trackingQueryExecutor) GetStateMultipleKeys(namespace string, keys []string) ([][]byte, error) {
+	qe.deps.addKeys(namespace, keys...)
+	return qe.QueryExecutor.GetStateMultipleKeys(namespace, keys)
+}
+
+func (qe *trackingQueryExecutor) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	qe.deps.addNamespace(namespace)
+	return qe.QueryExecutor.GetStateRangeScanIterator(namespace, startKey, endKey)
+}
+
+func (qe *trackingQueryExecutor) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
+	qe.deps.addNamespace(namespace)
+	return qe.QueryExecutor.ExecuteQuery(namespace, query)
+}
+
+func (qe *trackingQueryExecutor) GetPrivateData(namespace, collection, key string) ([]byte, error) {
+	qe.deps.addNamespace(namespace)
+	return qe.QueryExecutor.GetPrivateData(namespace, collection, key)
+}
+
+func (qe *trackingQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
+	qe.deps.addNamespace(namespace)
+	return qe.QueryExecutor.GetPrivateDataMultipleKeys(namespace, collection, keys)
+}
+
+func (qe *trackingQueryExecutor) GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error) {
+	qe.deps.addNamespace(namespace)
+	return qe.QueryExecutor.GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
+}
+
+func (qe *trackingQueryExecutor) ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
+	qe.deps.addNamespace(namespace)
+	return qe.QueryExecutor.ExecuteQueryOnPrivateData(namespace, collection, query)
+}
+
+// trackingTxSimulator records the reads of the simulator used to run VSCC
+type trackingTxSimulator struct {
+	ledger.TxSimulator
+	reads *trackingQueryExecutor
+}
+
+func (s *trackingTxSimulator) GetState(namespace string, key string) ([]byte, error) {
+	return s.reads.GetState(namespace, key)
+}
+
+func (s *trackingTxSimulator) GetStateMultipleKeys(namespace string, keys []string) ([][]byte, error) {
+	return s.reads.GetStateMultipleKeys(namespace, keys)
+}
+
+func (s *trackingTxSimulator) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return s.reads.GetStateRangeScanIterator(namespace, startKey, endKey)
+}
+
+func (s *trackingTxSimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
+	return s.reads.ExecuteQuery(namespace, query)
+}
+
+func (s *trackingTxSimulator) GetPrivateData(namespace, collection, key string) ([]byte, error) {
+	return s.reads.GetPrivateData(namespace, collection, key)
+}
+
+func (s *trackingTxSimulator) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
+	return s.reads.GetPrivateDataMultipleKeys(namespace, collection, keys)
+}
+
+func (s *trackingTxSimulator) GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error) {
+	return s.reads.GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
+}
+
+func (s *trackingTxSimulator) ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
+	return s.reads.ExecuteQueryOnPrivateData(namespace, collection, query)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// CommitTracker records the state written by the blocks committed while the
+// blocks following them are validated, so that the validations that depended
+// on this state are detected
+type CommitTracker struct {
+	lock   sync.Mutex
+	blocks map[uint64]*blockWrites
+}
+
+// blockWrites records the keys written by the valid transactions of a block and
+// the IDs of all its transactions
+type blockWrites struct {
+	keys       map[string]map[string]struct{}
+	namespaces map[string]struct{}
+	txIDs      map[string]struct{}
+	// barrier is set for the blocks which are not made of endorser transactions,
+	// such as config blocks, whose effect on the validation is not tracked
+	barrier bool
+}
+
+// NewCommitTracker creates a new CommitTracker
+func NewCommitTracker() *CommitTracker {
+	return &CommitTracker{blocks: make(map[uint64]*blockWrites)}
+}
+
+// Committed records the writes of a committed block
+func (t *CommitTracker) Committed(block *common.Block) {
+	writes := extractBlockWrites(block)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.blocks[block.Header.Number] = writes
+}
+
+// Conflicts tells whether a block has to be validated again, because a block
+// committed since its validation started wrote state the validation read, or
+// has a transaction with the same ID as one of its transactions
+func (t *CommitTracker) Conflicts(block *common.Block, deps *Dependencies) bool {
+	txIDs := extractTxIDs(block)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for blockNum, writes := range t.blocks {
+		if blockNum < deps.Height {
+			continue
+		}
+		if writes.barrier || deps.dependsOn(writes) {
+			logger.Debugf("The validation of block [%d] depends on block [%d]", block.Header.Number, blockNum)
+			return true
+		}
+		for _, txID := range txIDs {
+			if _, ok := writes.txIDs[txID]; ok {
+				logger.Debugf("Transaction [%s] of block [%d] was committed in block [%d]", txID, block.Header.Number, blockNum)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Prune forgets the writes of the blocks below height
+func (t *CommitTracker) Prune(height uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for blockNum := range t.blocks {
+		if blockNum < height {
+			delete(t.blocks, blockNum)
+		}
+	}
+}
+
+func extractTxIDs(block *common.Block) []string {
+	var txIDs []string
+	for _, envBytes := range block.Data.Data {
+		chdr, err := channelHeaderFromEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		if chdr.TxId != "" {
+			txIDs = append(txIDs, chdr.TxId)
+		}
+	}
+	return txIDs
+}
+
+func extractBlockWrites(block *common.Block) *blockWrites {
+	writes := &blockWrites{
+		keys:       make(map[string]map[string]struct{}),
+		namespaces: make(map[string]struct{}),
+		txIDs:      make(map[string]struct{}),
+	}
+	var txsFilter ledgerUtil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFilter = ledgerUtil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for tIdx, envBytes := range block.Data.Data {
+		chdr, err := channelHeaderFromEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		if chdr.TxId != "" {
+			writes.txIDs[chdr.TxId] = struct{}{}
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			writes.barrier = true
+			continue
+		}
+		if tIdx >= len(txsFilter) || !txsFilter.IsValid(tIdx) {
+			continue
+		}
+		respPayload, err := utils.GetActionFromEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			continue
+		}
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			ns := nsRWSet.NameSpace
+			if len(nsRWSet.CollHashedRwSets) > 0 {
+				writes.namespaces[ns] = struct{}{}
+			}
+			if nsRWSet.KvRwSet == nil || len(nsRWSet.KvRwSet.Writes) == 0 {
+				continue
+			}
+			writes.namespaces[ns] = struct{}{}
+			keys, ok := writes.keys[ns]
+			if !ok {
+				keys = make(map[string]struct{})
+				writes.keys[ns] = keys
+			}
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				keys[kvWrite.Key] = struct{}{}
+			}
+		}
+	}
+	return writes
+}
+
+func channelHeaderFromEnvelope(envBytes []byte) (*common.ChannelHeader, error) {
+	env, err := utils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing payload header")
+	}
+	return utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createLSCCRWset(t *testing.T, ccname string) []byte {
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet("lscc", ccname, []byte("value"))
+	rwset, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := rwset.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	return rwsetBytes
+}
+
+func createCommittedBlock(blockNum uint64, txsfltr ledgerUtil.TxValidationFlags, envs ...*common.Envelope) *common.Block {
+	b := &common.Block{
+		Header:   &common.BlockHeader{Number: blockNum},
+		Data:     &common.BlockData{},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{{}, {}, {}}},
+	}
+	for _, env := range envs {
+		b.Data.Data = append(b.Data.Data, utils.MarshalOrPanic(env))
+	}
+	b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsfltr
+	return b
+}
+
+func TestValidateAndTrack(t *testing.T) {
+	l, v := setupLedgerAndValidator(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+	putCCInfo(l, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	tx := getEnv(ccID, createRWset(t, ccID), t)
+	b := &common.Block{
+		Header: &common.BlockHeader{Number: 2},
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+	}
+	deps, err := v.(TrackingValidator).ValidateAndTrack(b)
+	assert.NoError(t, err)
+	assertValid(b, t)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, bcInfo.Height, deps.Height)
+	// the validation read the definition of the chaincode
+	assert.Contains(t, deps.keys["lscc"], ccID)
+
+	tracker := NewCommitTracker()
+	assert.False(t, tracker.Conflicts(b, deps))
+
+	// a block writing the definition of another chaincode doesn't conflict
+	tracker.Committed(createCommittedBlock(deps.Height, ledgerUtil.NewTxValidationFlags(1),
+		getEnv("lscc", createLSCCRWset(t, "othercc"), t)))
+	assert.False(t, tracker.Conflicts(b, deps))
+
+	// an invalid transaction writing the definition of the chaincode doesn't conflict
+	invalid := ledgerUtil.NewTxValidationFlags(1)
+	invalid.SetFlag(0, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	tracker.Committed(createCommittedBlock(deps.Height+1, invalid, getEnv("lscc", createLSCCRWset(t, ccID), t)))
+	assert.False(t, tracker.Conflicts(b, deps))
+
+	// a valid transaction writing the definition of the chaincode conflicts
+	tracker.Committed(createCommittedBlock(deps.Height+2, ledgerUtil.NewTxValidationFlags(1),
+		getEnv("lscc", createLSCCRWset(t, ccID), t)))
+	assert.True(t, tracker.Conflicts(b, deps))
+
+	// unless it was committed before the validation
+	deps.Height += 3
+	assert.False(t, tracker.Conflicts(b, deps))
+
+	// a block committed since the validation with the same transaction ID conflicts
+	tracker.Committed(createCommittedBlock(deps.Height, ledgerUtil.NewTxValidationFlags(1), tx))
+	assert.True(t, tracker.Conflicts(b, deps))
+	tracker.Prune(deps.Height + 1)
+	assert.Empty(t, tracker.blocks)
+	assert.False(t, tracker.Conflicts(b, deps))
+
+	// a config block conflicts with any validation
+	configEnv, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, "testchainid", nil, &common.ConfigEnvelope{}, 0, 0)
+	assert.NoError(t, err)
+	tracker.Committed(createCommittedBlock(deps.Height+1, ledgerUtil.NewTxValidationFlags(1), configEnv))
+	assert.True(t, tracker.Conflicts(b, deps))
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
@@ -450,6 +451,24 @@ func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCo
 	return args.Get(0).(peer.TxValidationCode), nil
 }
 
+// GetTxsByCreator returns the transactions submitted by a creator
+func (m *mockLedger) GetTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (ledger2.ResultsIterator, error) {
+	args := m.Called(creator, startBlockNum)
+	return args.Get(0).(ledger2.ResultsIterator), nil
+}
+
+// GetTxsByChaincode returns the transactions invoking a chaincode
+func (m *mockLedger) GetTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (ledger2.ResultsIterator, error) {
+	args := m.Called(chaincodeName, startBlockNum)
+	return args.Get(0).(ledger2.ResultsIterator), nil
+}
+
+// GetTxsByTimestamp returns the transactions with a timestamp in a range
+func (m *mockLedger) GetTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (ledger2.ResultsIterator, error) {
+	args := m.Called(startTime, endTime)
+	return args.Get(0).(ledger2.ResultsIterator), nil
+}
+
 // NewTxSimulator creates new transaction simulator
 func (m *mockLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	args := m.Called()
@@ -45,6 +45,13 @@ func GetCCHandlerKey() string {
 	return "CCHANDLER"
 }
 
+// GetReconnectKey is used to mark the context of a stream the peer established
+// again with a chaincode it launched before, the chaincode being allowed to
+// register over it without a launch in progress
+func GetReconnectKey() string {
+	return "CCRECONNECT"
+}
+
 //CCID encapsulates chaincode ID
 type CCID struct {
 	ChaincodeSpec *pb.ChaincodeSpec
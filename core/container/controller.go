@@ -26,7 +26,9 @@ import (
 	"github.com/hyperledger/fabric/core/container/api"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/container/externalcontroller"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/hyperledger/fabric/core/container/processcontroller"
 )
 
 type refCountedLock struct {
@@ -49,8 +51,10 @@ var vmcontroller *VMController
 
 //constants for supported containers
 const (
-	DOCKER = "Docker"
-	SYSTEM = "System"
+	DOCKER   = "Docker"
+	SYSTEM   = "System"
+	PROCESS  = "Process"
+	EXTERNAL = "External"
 )
 
 //NewVMController - creates/returns singleton
@@ -67,6 +71,10 @@ func (vmc *VMController) newVM(typ string) api.VM {
 		v = dockercontroller.NewDockerVM()
 	case SYSTEM:
 		v = &inproccontroller.InprocVM{}
+	case PROCESS:
+		v = processcontroller.NewProcessVM()
+	case EXTERNAL:
+		v = externalcontroller.NewExternalVM()
 	default:
 		v = &dockercontroller.DockerVM{}
 	}
@@ -0,0 +1,295 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalcontroller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/external"
+	"github.com/hyperledger/fabric/core/comm"
+	container "github.com/hyperledger/fabric/core/container/api"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+var (
+	externalLogger = flogging.MustGetLogger("externalcontroller")
+	vmRegExp       = regexp.MustCompile("[^a-zA-Z0-9-_.]")
+
+	// connections to chaincode servers, by VM name
+	instances     = make(map[string]*connection)
+	instancesLock sync.Mutex
+
+	// delays between the attempts to reconnect to a chaincode server whose
+	// stream was lost, doubling from the initial delay up to the max delay
+	reconnectInitialDelay = 500 * time.Millisecond
+	reconnectMaxDelay     = 30 * time.Second
+)
+
+// connection is the stream between the peer and a chaincode server, which is
+// established again whenever it is lost until the connection is stopped
+type connection struct {
+	ctxt   context.Context
+	cancel context.CancelFunc
+	// done is closed once the connection is stopped and its last stream has ended
+	done chan struct{}
+
+	lock sync.Mutex
+	conn *grpc.ClientConn
+}
+
+// ExternalVM is a vm for chaincodes run as services outside of the peer.
+// Rather than launching the chaincode, the peer connects to the chaincode
+// server described by the connection package of the chaincode, and handles
+// the resulting stream just like the ones of the chaincodes it launched.
+type ExternalVM struct{}
+
+// NewExternalVM returns a new ExternalVM instance
+func NewExternalVM() *ExternalVM {
+	return &ExternalVM{}
+}
+
+// Deploy does nothing, chaincode servers being deployed by their operators
+func (vm *ExternalVM) Deploy(ctxt context.Context, ccid ccintf.CCID, args []string, env []string, reader io.Reader) error {
+	return nil
+}
+
+// Start connects to the chaincode server found in the connection package
+// returned by builder, and handles the chaincode stream over this connection.
+// Connecting again replaces the connection of a previous start.
+func (vm *ExternalVM) Start(ctxt context.Context, ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.BuildSpecFactory, prelaunchFunc container.PrelaunchFunc) error {
+	name, err := vm.GetVMName(ccid, nil)
+	if err != nil {
+		return err
+	}
+
+	ccSupport, ok := ctxt.Value(ccintf.GetCCHandlerKey()).(ccintf.CCSupport)
+	if !ok || ccSupport == nil {
+		return errors.New("chaincode support not supplied")
+	}
+
+	if builder == nil {
+		return errors.Errorf("connection package of chaincode %s not supplied", name)
+	}
+	reader, err := builder()
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error getting connection package of chaincode %s", name))
+	}
+	codePackage, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.Wrapf(err, "error reading connection package of chaincode %s", name)
+	}
+	info, err := external.ParseConnectionPackage(codePackage)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("invalid connection package of chaincode %s", name))
+	}
+
+	vm.Stop(ctxt, ccid, 0, false, false)
+
+	externalLogger.Infof("connecting to chaincode server %s for %s", info.Address, name)
+	conn, err := newClientConnection(info)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error connecting to chaincode server %s", info.Address))
+	}
+
+	if prelaunchFunc != nil {
+		if err = prelaunchFunc(); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	// the stream outlives the launch request, it ends when the connection is
+	// stopped or lost
+	streamCtxt, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewChaincodeSupportClient(conn).Register(streamCtxt)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return errors.Wrapf(err, "error opening stream with chaincode server %s", info.Address)
+	}
+
+	c := &connection{ctxt: streamCtxt, cancel: cancel, done: make(chan struct{}), conn: conn}
+	instancesLock.Lock()
+	instances[name] = c
+	instancesLock.Unlock()
+
+	go func() {
+		c.serve(name, info, ccSupport, stream)
+
+		instancesLock.Lock()
+		if instances[name] == c {
+			delete(instances, name)
+		}
+		instancesLock.Unlock()
+	}()
+
+	return nil
+}
+
+// serve handles the streams with the chaincode server until the connection is
+// stopped. When a stream is lost, the chaincode server is dialed again with an
+// exponential backoff, and the chaincode registers again over the new stream.
+func (c *connection) serve(name string, info *external.Connection, ccSupport ccintf.CCSupport, stream pb.ChaincodeSupport_RegisterClient) {
+	defer close(c.done)
+	defer c.close()
+
+	streamCtxt := c.ctxt
+	for {
+		err := ccSupport.HandleChaincodeStream(streamCtxt, stream)
+		if c.ctxt.Err() != nil {
+			externalLogger.Debugf("stream with chaincode server %s for %s ended", info.Address, name)
+			return
+		}
+		externalLogger.Warningf("stream with chaincode server %s for %s ended: %s", info.Address, name, err)
+
+		stream = c.reconnect(name, info)
+		if stream == nil {
+			return
+		}
+		// the chaincode was launched before, its registration must be accepted
+		// even though no launch is in progress
+		streamCtxt = context.WithValue(c.ctxt, ccintf.GetReconnectKey(), true)
+	}
+}
+
+// reconnect dials the chaincode server and opens a new stream with it, retrying
+// with an exponential backoff until it succeeds or the connection is stopped,
+// in which case it returns nil
+func (c *connection) reconnect(name string, info *external.Connection) pb.ChaincodeSupport_RegisterClient {
+	delay := reconnectInitialDelay
+	for {
+		select {
+		case <-time.After(delay):
+		case <-c.ctxt.Done():
+			return nil
+		}
+		if delay *= 2; delay > reconnectMaxDelay {
+			delay = reconnectMaxDelay
+		}
+
+		externalLogger.Infof("reconnecting to chaincode server %s for %s", info.Address, name)
+		conn, err := newClientConnection(info)
+		if err != nil {
+			externalLogger.Warningf("error reconnecting to chaincode server %s for %s: %s", info.Address, name, err)
+			continue
+		}
+		if !c.replaceConn(conn) {
+			return nil
+		}
+		stream, err := pb.NewChaincodeSupportClient(conn).Register(c.ctxt)
+		if err != nil {
+			externalLogger.Warningf("error opening stream with chaincode server %s for %s: %s", info.Address, name, err)
+			continue
+		}
+		return stream
+	}
+}
+
+// replaceConn closes the current client connection and replaces it with conn,
+// unless the connection was stopped in the meantime
+func (c *connection) replaceConn(conn *grpc.ClientConn) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.ctxt.Err() != nil {
+		conn.Close()
+		return false
+	}
+	c.conn.Close()
+	c.conn = conn
+	return true
+}
+
+// close stops the connection and closes the current client connection
+func (c *connection) close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cancel()
+	return c.conn.Close()
+}
+
+// Stop closes the connection to the chaincode server, which keeps running
+func (vm *ExternalVM) Stop(ctxt context.Context, ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	name, err := vm.GetVMName(ccid, nil)
+	if err != nil {
+		return err
+	}
+
+	instancesLock.Lock()
+	c, ok := instances[name]
+	delete(instances, name)
+	instancesLock.Unlock()
+	if !ok {
+		externalLogger.Debugf("chaincode %s not connected", name)
+		return nil
+	}
+
+	return c.close()
+}
+
+// Destroy closes the connection to the chaincode server, there being nothing
+// else to remove
+func (vm *ExternalVM) Destroy(ctxt context.Context, ccid ccintf.CCID, force bool, noprune bool) error {
+	return vm.Stop(ctxt, ccid, 0, false, false)
+}
+
+// GetVMName generates the name of the connection, made of the network and peer
+// IDs and of the chaincode name and version
+func (vm *ExternalVM) GetVMName(ccid ccintf.CCID, format func(string) (string, error)) (string, error) {
+	name := ccid.GetName()
+
+	if ccid.NetworkID != "" && ccid.PeerID != "" {
+		name = fmt.Sprintf("%s-%s-%s", ccid.NetworkID, ccid.PeerID, name)
+	} else if ccid.NetworkID != "" {
+		name = fmt.Sprintf("%s-%s", ccid.NetworkID, name)
+	} else if ccid.PeerID != "" {
+		name = fmt.Sprintf("%s-%s", ccid.PeerID, name)
+	}
+
+	if format != nil {
+		formattedName, err := format(name)
+		if err != nil {
+			return formattedName, err
+		}
+		name = formattedName
+	}
+
+	return vmRegExp.ReplaceAllString(name, "-"), nil
+}
+
+// newClientConnection dials the chaincode server, retrying until the dial
+// timeout of the connection expires
+func newClientConnection(info *external.Connection) (*grpc.ClientConn, error) {
+	secOpts := &comm.SecureOptions{UseTLS: info.TLSRequired}
+	if info.TLSRequired {
+		secOpts.ServerRootCAs = [][]byte{[]byte(info.RootCert)}
+		if info.ClientAuthRequired {
+			secOpts.RequireClientCert = true
+			secOpts.Certificate = []byte(info.ClientCert)
+			secOpts.Key = []byte(info.ClientKey)
+		}
+	}
+
+	client, err := comm.NewGRPCClient(comm.ClientConfig{
+		SecOpts: secOpts,
+		KaOpts:  comm.DefaultKeepaliveOptions(),
+		Timeout: info.Timeout(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client.NewConnection(info.Address, "")
+}
@@ -0,0 +1,348 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalcontroller
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/external"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// mockCCSupport reports the name chaincodes register with, and keeps their
+// streams open until they end, or drops the first drops streams once the
+// chaincode registered
+type mockCCSupport struct {
+	registered chan string
+	drops      int32
+	// reconnected, when not nil, reports whether the streams were marked as
+	// reconnected
+	reconnected chan bool
+}
+
+func (m *mockCCSupport) HandleChaincodeStream(ctxt context.Context, stream ccintf.ChaincodeStream) error {
+	if m.reconnected != nil {
+		reconnected, _ := ctxt.Value(ccintf.GetReconnectKey()).(bool)
+		m.reconnected <- reconnected
+	}
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if msg.Type == pb.ChaincodeMessage_REGISTER {
+		id := &pb.ChaincodeID{}
+		if err := proto.Unmarshal(msg.Payload, id); err != nil {
+			return err
+		}
+		m.registered <- id.Name
+	}
+	if atomic.AddInt32(&m.drops, -1) >= 0 {
+		return errors.New("stream dropped")
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+type testCC struct{}
+
+func (cc *testCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (cc *testCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+type keyPair struct {
+	cert, key []byte
+	template  *x509.Certificate
+	signer    *ecdsa.PrivateKey
+}
+
+// newKeyPair returns a key pair signed by the given CA, or a CA when nil
+func newKeyPair(t *testing.T, ca *keyPair) *keyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	parent, signer := template, key
+	if ca == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		parent, signer = ca.template, ca.signer
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &keyPair{
+		cert:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		template: template,
+		signer:   key,
+	}
+}
+
+// startChaincodeServer serves the test chaincode on a free local port and
+// returns its address
+func startChaincodeServer(t *testing.T, tlsProps shim.TLSProperties) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	server := &shim.ChaincodeServer{CCID: "mycc:1.0", Address: address, CC: &testCC{}, TLSProps: tlsProps}
+	go server.Start()
+	return address
+}
+
+func connectionBuilder(t *testing.T, conn *external.Connection) func() (io.Reader, error) {
+	codePackage, err := external.NewConnectionPackage(conn)
+	require.NoError(t, err)
+	return func() (io.Reader, error) {
+		return bytes.NewReader(codePackage), nil
+	}
+}
+
+func testCCID() ccintf.CCID {
+	return ccintf.CCID{
+		ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}},
+		NetworkID:     "dev",
+		PeerID:        "peer0",
+		Version:       "1.0",
+	}
+}
+
+func isConnected(name string) bool {
+	instancesLock.Lock()
+	defer instancesLock.Unlock()
+	_, connected := instances[name]
+	return connected
+}
+
+func waitForRegistration(t *testing.T, ccSupport *mockCCSupport) {
+	select {
+	case name := <-ccSupport.registered:
+		assert.Equal(t, "mycc:1.0", name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("chaincode did not register")
+	}
+}
+
+func TestGetVMName(t *testing.T) {
+	vm := NewExternalVM()
+	name, err := vm.GetVMName(testCCID(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-peer0-mycc-1.0", name)
+
+	_, err = vm.GetVMName(testCCID(), func(string) (string, error) { return "", errors.New("bad format") })
+	assert.Error(t, err)
+}
+
+func TestStartErrors(t *testing.T) {
+	vm := NewExternalVM()
+	ccSupport := &mockCCSupport{registered: make(chan string, 1)}
+	ctxt := context.WithValue(context.Background(), ccintf.GetCCHandlerKey(), ccSupport)
+
+	err := vm.Start(context.Background(), testCCID(), nil, nil, nil, connectionBuilder(t, &external.Connection{Address: "127.0.0.1:1"}), nil)
+	assert.EqualError(t, err, "chaincode support not supplied")
+
+	err = vm.Start(ctxt, testCCID(), nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+
+	err = vm.Start(ctxt, testCCID(), nil, nil, nil, func() (io.Reader, error) { return nil, errors.New("no package") }, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no package")
+
+	err = vm.Start(ctxt, testCCID(), nil, nil, nil, func() (io.Reader, error) { return bytes.NewReader([]byte("garbage")), nil }, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid connection package")
+
+	// nobody listens
+	err = vm.Start(ctxt, testCCID(), nil, nil, nil, connectionBuilder(t, &external.Connection{Address: "127.0.0.1:1", DialTimeout: "100ms"}), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error connecting to chaincode server")
+}
+
+func TestStartStop(t *testing.T) {
+	vm := NewExternalVM()
+	ccSupport := &mockCCSupport{registered: make(chan string, 1)}
+	ctxt := context.WithValue(context.Background(), ccintf.GetCCHandlerKey(), ccSupport)
+	name, _ := vm.GetVMName(testCCID(), nil)
+
+	address := startChaincodeServer(t, shim.TLSProperties{Disabled: true})
+	builder := connectionBuilder(t, &external.Connection{Address: address, DialTimeout: "5s"})
+
+	prelaunched := false
+	prelaunch := func() error {
+		prelaunched = true
+		return nil
+	}
+	err := vm.Start(ctxt, testCCID(), nil, nil, nil, builder, prelaunch)
+	require.NoError(t, err)
+	assert.True(t, prelaunched)
+	waitForRegistration(t, ccSupport)
+	assert.True(t, isConnected(name))
+
+	// starting again reconnects
+	err = vm.Start(ctxt, testCCID(), nil, nil, nil, builder, nil)
+	require.NoError(t, err)
+	waitForRegistration(t, ccSupport)
+	assert.True(t, isConnected(name))
+
+	err = vm.Stop(ctxt, testCCID(), 0, false, false)
+	assert.NoError(t, err)
+	assert.False(t, isConnected(name))
+
+	// stopping a chaincode which is not connected is not an error
+	err = vm.Destroy(ctxt, testCCID(), false, false)
+	assert.NoError(t, err)
+}
+
+func TestReconnect(t *testing.T) {
+	defer func(initial, max time.Duration) {
+		reconnectInitialDelay, reconnectMaxDelay = initial, max
+	}(reconnectInitialDelay, reconnectMaxDelay)
+	reconnectInitialDelay, reconnectMaxDelay = 10*time.Millisecond, 50*time.Millisecond
+
+	vm := NewExternalVM()
+	ccSupport := &mockCCSupport{registered: make(chan string, 1), drops: 2, reconnected: make(chan bool, 3)}
+	ctxt := context.WithValue(context.Background(), ccintf.GetCCHandlerKey(), ccSupport)
+	name, _ := vm.GetVMName(testCCID(), nil)
+
+	address := startChaincodeServer(t, shim.TLSProperties{Disabled: true})
+	err := vm.Start(ctxt, testCCID(), nil, nil, nil, connectionBuilder(t, &external.Connection{Address: address, DialTimeout: "5s"}), nil)
+	require.NoError(t, err)
+
+	// the first two streams are dropped, the peer reconnects each time and
+	// the chaincode registers again
+	for _, reconnected := range []bool{false, true, true} {
+		waitForRegistration(t, ccSupport)
+		assert.Equal(t, reconnected, <-ccSupport.reconnected)
+	}
+	assert.True(t, isConnected(name))
+
+	assert.NoError(t, vm.Stop(ctxt, testCCID(), 0, false, false))
+	assert.False(t, isConnected(name))
+	select {
+	case <-ccSupport.registered:
+		t.Fatal("stopped connection should not reconnect")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestReconnectStopped(t *testing.T) {
+	defer func(initial time.Duration) { reconnectInitialDelay = initial }(reconnectInitialDelay)
+	reconnectInitialDelay = time.Hour
+
+	vm := NewExternalVM()
+	ccSupport := &mockCCSupport{registered: make(chan string, 1), drops: 1}
+	ctxt := context.WithValue(context.Background(), ccintf.GetCCHandlerKey(), ccSupport)
+
+	address := startChaincodeServer(t, shim.TLSProperties{Disabled: true})
+	err := vm.Start(ctxt, testCCID(), nil, nil, nil, connectionBuilder(t, &external.Connection{Address: address, DialTimeout: "5s"}), nil)
+	require.NoError(t, err)
+	waitForRegistration(t, ccSupport)
+
+	instancesLock.Lock()
+	name, _ := vm.GetVMName(testCCID(), nil)
+	c := instances[name]
+	instancesLock.Unlock()
+	require.NotNil(t, c)
+
+	// stopping the connection while waiting to reconnect ends it
+	assert.NoError(t, vm.Stop(ctxt, testCCID(), 0, false, false))
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection did not end")
+	}
+}
+
+func TestStartMutualTLS(t *testing.T) {
+	vm := NewExternalVM()
+	ccSupport := &mockCCSupport{registered: make(chan string, 1)}
+	ctxt := context.WithValue(context.Background(), ccintf.GetCCHandlerKey(), ccSupport)
+
+	serverCA := newKeyPair(t, nil)
+	server := newKeyPair(t, serverCA)
+	clientCA := newKeyPair(t, nil)
+	client := newKeyPair(t, clientCA)
+	address := startChaincodeServer(t, shim.TLSProperties{Key: server.key, Cert: server.cert, ClientCACerts: clientCA.cert})
+
+	conn := &external.Connection{
+		Address:            address,
+		DialTimeout:        "5s",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		RootCert:           string(serverCA.cert),
+		ClientCert:         string(client.cert),
+		ClientKey:          string(client.key),
+	}
+	err := vm.Start(ctxt, testCCID(), nil, nil, nil, connectionBuilder(t, conn), nil)
+	require.NoError(t, err)
+	waitForRegistration(t, ccSupport)
+	assert.NoError(t, vm.Stop(ctxt, testCCID(), 0, false, false))
+
+	// the server does not trust the client
+	stranger := newKeyPair(t, serverCA)
+	conn.ClientCert, conn.ClientKey = string(stranger.cert), string(stranger.key)
+	conn.DialTimeout = "500ms"
+	err = vm.Start(ctxt, testCCID(), nil, nil, nil, connectionBuilder(t, conn), nil)
+	if err == nil {
+		// the handshake may only fail on the first read of the stream
+		select {
+		case <-ccSupport.registered:
+			t.Fatal("untrusted client should not have reached the chaincode")
+		case <-time.After(time.Second):
+		}
+	}
+	vm.Stop(ctxt, testCCID(), 0, false, false)
+}
+
+func TestChaincodeServerErrors(t *testing.T) {
+	for _, server := range []*shim.ChaincodeServer{
+		{Address: "127.0.0.1:0", CC: &testCC{}},
+		{CCID: "mycc:1.0", CC: &testCC{}},
+		{CCID: "mycc:1.0", Address: "127.0.0.1:0"},
+		{CCID: "mycc:1.0", Address: "127.0.0.1:0", CC: &testCC{}},
+	} {
+		assert.Error(t, server.Start())
+	}
+}
@@ -0,0 +1,431 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/config"
+	container "github.com/hyperledger/fabric/core/container/api"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/op/go-logging"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+const (
+	// LogFile is the name of the file, in the working directory of a chaincode
+	// process, which captures its standard output and error
+	LogFile = "chaincode.log"
+
+	buildsDir = "builds"
+	runDir    = "run"
+)
+
+var (
+	processLogger = flogging.MustGetLogger("processcontroller")
+	vmRegExp      = regexp.MustCompile("[^a-zA-Z0-9-_.]")
+
+	// running chaincode processes, by VM name
+	instances     = make(map[string]*process)
+	instancesLock sync.Mutex
+)
+
+// process is a chaincode executable running as a child process of the peer
+type process struct {
+	cmd *exec.Cmd
+	// done is closed once the process has exited
+	done     chan struct{}
+	stopping bool
+}
+
+// ProcessVM is a vm which runs chaincode executables, built on the peer's host,
+// as supervised processes of the peer. The executables of a chaincode are kept
+// in their own build directory, and each running chaincode gets a working
+// directory holding the files uploaded at start and its captured output.
+type ProcessVM struct {
+	rootDir      string
+	attachStdout bool
+}
+
+// NewProcessVM returns a new ProcessVM instance
+func NewProcessVM() *ProcessVM {
+	rootDir := config.GetPath("vm.process.fileSystemPath")
+	if rootDir == "" {
+		rootDir = filepath.Join(config.GetPath("peer.fileSystemPath"), "processvm")
+	}
+	return &ProcessVM{
+		rootDir:      rootDir,
+		attachStdout: viper.GetBool("vm.process.attachStdout"),
+	}
+}
+
+func (vm *ProcessVM) buildDir(name string) string {
+	return filepath.Join(vm.rootDir, buildsDir, name)
+}
+
+func (vm *ProcessVM) workDir(name string) string {
+	return filepath.Join(vm.rootDir, runDir, name)
+}
+
+//Deploy extracts the gzipped tar stream of executables produced by a local build
+//into the build directory of the chaincode, replacing any previous build
+func (vm *ProcessVM) Deploy(ctxt context.Context, ccid ccintf.CCID, args []string, env []string, reader io.Reader) error {
+	name, err := vm.GetVMName(ccid, nil)
+	if err != nil {
+		return err
+	}
+	return vm.deploy(name, reader)
+}
+
+func (vm *ProcessVM) deploy(name string, reader io.Reader) error {
+	if err := os.MkdirAll(filepath.Join(vm.rootDir, buildsDir), 0755); err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir(filepath.Join(vm.rootDir, buildsDir), "."+name)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = extract(reader, tmpDir); err != nil {
+		return fmt.Errorf("Error extracting build of chaincode %s: %s", name, err)
+	}
+
+	buildDir := vm.buildDir(name)
+	if err = os.RemoveAll(buildDir); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpDir, buildDir); err != nil {
+		return err
+	}
+	processLogger.Debugf("Deployed chaincode %s to %s", name, buildDir)
+	return nil
+}
+
+//Start starts the chaincode executable as a process of the peer, building it
+//first if needed. A process already running for the chaincode is killed first,
+//so that starting a chaincode again restarts it.
+func (vm *ProcessVM) Start(ctxt context.Context, ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.BuildSpecFactory, prelaunchFunc container.PrelaunchFunc) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no executable specified")
+	}
+	name, err := vm.GetVMName(ccid, nil)
+	if err != nil {
+		return err
+	}
+
+	processLogger.Debugf("Cleanup process %s", name)
+	vm.stopInternal(name, 0, false, false)
+
+	buildDir := vm.buildDir(name)
+	if _, err = os.Stat(buildDir); os.IsNotExist(err) {
+		if builder == nil {
+			return fmt.Errorf("no build found for chaincode %s", name)
+		}
+		processLogger.Debugf("start-could not find build of %s, attempt to build it", name)
+		reader, err := builder()
+		if err != nil {
+			return fmt.Errorf("Error creating builder for chaincode %s: %s", name, err)
+		}
+		if err = vm.deploy(name, reader); err != nil {
+			return err
+		}
+	}
+
+	executable, err := resolveExecutable(buildDir, args[0])
+	if err != nil {
+		return err
+	}
+
+	// the files to upload are written to the working directory, and the
+	// environment variables referring to them are updated accordingly
+	workDir := vm.workDir(name)
+	if err = os.MkdirAll(workDir, 0755); err != nil {
+		return err
+	}
+	localPaths := make(map[string]string)
+	for path, contents := range filesToUpload {
+		localPath := filepath.Join(workDir, filepath.FromSlash(path))
+		if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(localPath, contents, 0600); err != nil {
+			return fmt.Errorf("Error writing file %s for chaincode %s: %s", path, name, err)
+		}
+		localPaths[path] = localPath
+	}
+	env = remapEnv(env, localPaths)
+
+	logFile, err := os.OpenFile(filepath.Join(workDir, LogFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(executable, args[1:]...)
+	cmd.Dir = workDir
+	cmd.Env = env
+	var output io.Writer = logFile
+	var pipeWriter *io.PipeWriter
+	if vm.attachStdout {
+		var pipeReader *io.PipeReader
+		pipeReader, pipeWriter = io.Pipe()
+		output = io.MultiWriter(logFile, pipeWriter)
+		go logOutput(name, pipeReader)
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	if prelaunchFunc != nil {
+		if err = prelaunchFunc(); err != nil {
+			logFile.Close()
+			return err
+		}
+	}
+
+	if err = cmd.Start(); err != nil {
+		logFile.Close()
+		if pipeWriter != nil {
+			pipeWriter.Close()
+		}
+		processLogger.Errorf("start-could not start process %s: %s", name, err)
+		return err
+	}
+
+	p := &process{cmd: cmd, done: make(chan struct{})}
+	instancesLock.Lock()
+	instances[name] = p
+	instancesLock.Unlock()
+
+	go func() {
+		err := cmd.Wait()
+		logFile.Close()
+		if pipeWriter != nil {
+			pipeWriter.Close()
+		}
+
+		instancesLock.Lock()
+		if instances[name] == p {
+			delete(instances, name)
+		}
+		stopping := p.stopping
+		instancesLock.Unlock()
+
+		if !stopping {
+			// the peer launches the chaincode again on its next invocation
+			processLogger.Warningf("Chaincode process %s exited unexpectedly: %v", name, err)
+		} else {
+			processLogger.Debugf("Chaincode process %s exited", name)
+		}
+		close(p.done)
+	}()
+
+	processLogger.Debugf("Started process %s (pid %d)", name, cmd.Process.Pid)
+	return nil
+}
+
+//Stop stops a running chaincode process, asking it to terminate and killing it
+//after timeout seconds, and removes its working directory
+func (vm *ProcessVM) Stop(ctxt context.Context, ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	name, err := vm.GetVMName(ccid, nil)
+	if err != nil {
+		return err
+	}
+	return vm.stopInternal(name, timeout, dontkill, dontremove)
+}
+
+func (vm *ProcessVM) stopInternal(name string, timeout uint, dontkill bool, dontremove bool) error {
+	instancesLock.Lock()
+	p := instances[name]
+	if p != nil {
+		p.stopping = true
+	}
+	instancesLock.Unlock()
+
+	if p != nil {
+		if err := p.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			processLogger.Debugf("Stop process %s: %s", name, err)
+		}
+		select {
+		case <-p.done:
+		case <-time.After(time.Duration(timeout) * time.Second):
+			if dontkill {
+				return fmt.Errorf("process %s did not stop within %d seconds", name, timeout)
+			}
+			if err := p.cmd.Process.Kill(); err != nil {
+				processLogger.Debugf("Kill process %s: %s", name, err)
+			}
+			<-p.done
+		}
+		processLogger.Debugf("Stopped process %s", name)
+	}
+
+	if !dontremove {
+		if err := os.RemoveAll(vm.workDir(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Destroy removes the build of a chaincode, stopping its process first if
+//force is set
+func (vm *ProcessVM) Destroy(ctxt context.Context, ccid ccintf.CCID, force bool, noprune bool) error {
+	name, err := vm.GetVMName(ccid, nil)
+	if err != nil {
+		return err
+	}
+
+	instancesLock.Lock()
+	_, running := instances[name]
+	instancesLock.Unlock()
+	if running {
+		if !force {
+			return fmt.Errorf("chaincode process %s is running", name)
+		}
+		if err = vm.stopInternal(name, 0, false, false); err != nil {
+			return err
+		}
+	}
+
+	if err = os.RemoveAll(vm.buildDir(name)); err != nil {
+		processLogger.Errorf("error while destroying build of %s: %s", name, err)
+		return err
+	}
+	processLogger.Debugf("Destroyed build of %s", name)
+	return nil
+}
+
+//GetVMName generates the name of the process and of its directories, made of the
+//network and peer IDs and of the chaincode name and version
+func (vm *ProcessVM) GetVMName(ccid ccintf.CCID, format func(string) (string, error)) (string, error) {
+	name := ccid.GetName()
+
+	if ccid.NetworkID != "" && ccid.PeerID != "" {
+		name = fmt.Sprintf("%s-%s-%s", ccid.NetworkID, ccid.PeerID, name)
+	} else if ccid.NetworkID != "" {
+		name = fmt.Sprintf("%s-%s", ccid.NetworkID, name)
+	} else if ccid.PeerID != "" {
+		name = fmt.Sprintf("%s-%s", ccid.PeerID, name)
+	}
+
+	if format != nil {
+		formattedName, err := format(name)
+		if err != nil {
+			return formattedName, err
+		}
+		name = formattedName
+	}
+
+	return vmRegExp.ReplaceAllString(name, "-"), nil
+}
+
+// resolveExecutable returns the path of the executable of the chaincode, looked
+// up first in its build directory and then in the PATH of the peer
+func resolveExecutable(buildDir, executable string) (string, error) {
+	if filepath.Base(executable) == executable {
+		path := filepath.Join(buildDir, executable)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath(executable)
+	if err != nil {
+		return "", fmt.Errorf("executable %s not found in %s: %s", executable, buildDir, err)
+	}
+	return path, nil
+}
+
+// remapEnv replaces the values of the environment variables which refer to
+// uploaded files with the local paths of these files
+func remapEnv(env []string, localPaths map[string]string) []string {
+	remapped := make([]string, 0, len(env))
+	for _, entry := range env {
+		tokens := strings.SplitN(entry, "=", 2)
+		if len(tokens) == 2 {
+			if localPath, ok := localPaths[tokens[1]]; ok {
+				entry = tokens[0] + "=" + localPath
+			}
+		}
+		remapped = append(remapped, entry)
+	}
+	return remapped
+}
+
+// logOutput forwards the output of a chaincode process to a logger of its own,
+// one log entry per line, until the process exits
+func logOutput(name string, r io.Reader) {
+	processOutputLogger := flogging.MustGetLogger(name)
+	logging.SetLevel(logging.GetLevel("peer"), name)
+
+	is := bufio.NewReader(r)
+	for {
+		line, err := is.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				processLogger.Errorf("Error reading output of process %s: %s", name, err)
+			}
+			return
+		}
+		processOutputLogger.Info(line)
+	}
+}
+
+// extract extracts the regular files of a gzipped tar stream into dir,
+// keeping their permissions
+func extract(reader io.Reader, dir string) error {
+	gr, err := gzip.NewReader(reader)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("illegal file name %s", header.Name)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, tr)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+// chaincodeScript stands for a chaincode executable: it prints its arguments
+// and the uploaded file referred to by its environment, then waits
+const chaincodeScript = `#!/bin/sh
+echo "args: $@"
+cat "$CORE_TLS_CLIENT_CERT_PATH"
+trap 'exit 0' TERM
+while true; do sleep 0.1; done
+`
+
+func newTestVM(t *testing.T) (*ProcessVM, func()) {
+	rootDir, err := ioutil.TempDir("", "processvm")
+	assert.NoError(t, err)
+	return &ProcessVM{rootDir: rootDir}, func() { os.RemoveAll(rootDir) }
+}
+
+func testCCID() ccintf.CCID {
+	return ccintf.CCID{
+		ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}},
+		NetworkID:     "dev",
+		PeerID:        "peer0",
+		Version:       "1.0",
+	}
+}
+
+func buildPackage(t *testing.T, files map[string]string) io.Reader {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(contents))})
+		assert.NoError(t, err)
+		_, err = tw.Write([]byte(contents))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return payload
+}
+
+func waitForLog(t *testing.T, path, expected string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if contents, err := ioutil.ReadFile(path); err == nil && bytes.Contains(contents, []byte(expected)) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s not found in %s", expected, path)
+}
+
+func isRunning(name string) bool {
+	instancesLock.Lock()
+	defer instancesLock.Unlock()
+	_, running := instances[name]
+	return running
+}
+
+func TestGetVMName(t *testing.T) {
+	vm := &ProcessVM{}
+	name, err := vm.GetVMName(testCCID(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-peer0-mycc-1.0", name)
+
+	ccid := testCCID()
+	ccid.PeerID = "peer0:7051"
+	name, err = vm.GetVMName(ccid, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-peer0-7051-mycc-1.0", name)
+
+	_, err = vm.GetVMName(testCCID(), func(string) (string, error) { return "", errors.New("bad format") })
+	assert.Error(t, err)
+}
+
+func TestStartStop(t *testing.T) {
+	vm, cleanup := newTestVM(t)
+	defer cleanup()
+	ccid := testCCID()
+	name, _ := vm.GetVMName(ccid, nil)
+
+	// without a build nor builder the chaincode cannot start
+	err := vm.Start(context.Background(), ccid, []string{"chaincode"}, nil, nil, nil, nil)
+	assert.Error(t, err)
+
+	built := 0
+	builder := func() (io.Reader, error) {
+		built++
+		return buildPackage(t, map[string]string{"chaincode": chaincodeScript}), nil
+	}
+	prelaunched := false
+	prelaunch := func() error {
+		prelaunched = true
+		return nil
+	}
+	files := map[string][]byte{"/etc/hyperledger/fabric/client.crt": []byte("certificate")}
+	env := []string{"CORE_TLS_CLIENT_CERT_PATH=/etc/hyperledger/fabric/client.crt"}
+
+	err = vm.Start(context.Background(), ccid, []string{"chaincode", "-peer.address=peer0:7052"}, env, files, builder, prelaunch)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, built)
+	assert.True(t, prelaunched)
+	assert.True(t, isRunning(name))
+
+	// the output is captured, and the uploaded file is found through the environment
+	logFile := filepath.Join(vm.workDir(name), LogFile)
+	waitForLog(t, logFile, "args: -peer.address=peer0:7052")
+	waitForLog(t, logFile, "certificate")
+
+	// starting again restarts the chaincode, reusing its build
+	err = vm.Start(context.Background(), ccid, []string{"chaincode"}, env, files, builder, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, built)
+	assert.True(t, isRunning(name))
+
+	err = vm.Stop(context.Background(), ccid, 5, false, false)
+	assert.NoError(t, err)
+	assert.False(t, isRunning(name))
+	_, err = os.Stat(vm.workDir(name))
+	assert.True(t, os.IsNotExist(err), "working directory should have been removed")
+	_, err = os.Stat(filepath.Join(vm.buildDir(name), "chaincode"))
+	assert.NoError(t, err, "build should have been kept")
+
+	// stopping a chaincode which is not running is not an error
+	err = vm.Stop(context.Background(), ccid, 0, false, false)
+	assert.NoError(t, err)
+}
+
+func TestProcessExit(t *testing.T) {
+	vm, cleanup := newTestVM(t)
+	defer cleanup()
+	ccid := testCCID()
+	name, _ := vm.GetVMName(ccid, nil)
+
+	err := vm.Deploy(context.Background(), ccid, nil, nil, buildPackage(t, map[string]string{"chaincode": "#!/bin/sh\necho exiting\nexit 1\n"}))
+	assert.NoError(t, err)
+	err = vm.Start(context.Background(), ccid, []string{"chaincode"}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	waitForLog(t, filepath.Join(vm.workDir(name), LogFile), "exiting")
+	deadline := time.Now().Add(5 * time.Second)
+	for isRunning(name) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, isRunning(name), "exited process should have been unregistered")
+}
+
+func TestDestroy(t *testing.T) {
+	vm, cleanup := newTestVM(t)
+	defer cleanup()
+	ccid := testCCID()
+	name, _ := vm.GetVMName(ccid, nil)
+
+	err := vm.Deploy(context.Background(), ccid, nil, nil, buildPackage(t, map[string]string{"chaincode": chaincodeScript}))
+	assert.NoError(t, err)
+	err = vm.Start(context.Background(), ccid, []string{"chaincode"}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	err = vm.Destroy(context.Background(), ccid, false, false)
+	assert.Error(t, err, "destroying a running chaincode without force should fail")
+
+	err = vm.Destroy(context.Background(), ccid, true, false)
+	assert.NoError(t, err)
+	assert.False(t, isRunning(name))
+	_, err = os.Stat(vm.buildDir(name))
+	assert.True(t, os.IsNotExist(err), "build should have been removed")
+}
+
+func TestDeployIllegalPackage(t *testing.T) {
+	vm, cleanup := newTestVM(t)
+	defer cleanup()
+
+	err := vm.Deploy(context.Background(), testCCID(), nil, nil, buildPackage(t, map[string]string{"../chaincode": "evil"}))
+	assert.Error(t, err)
+
+	err = vm.Deploy(context.Background(), testCCID(), nil, nil, bytes.NewReader([]byte("garbage")))
+	assert.Error(t, err)
+}
+
+func TestRemapEnv(t *testing.T) {
+	env := remapEnv([]string{"A=/etc/a", "B=b", "C"}, map[string]string{"/etc/a": "/work/etc/a"})
+	assert.Equal(t, []string{"A=/work/etc/a", "B=b", "C"}, env)
+}
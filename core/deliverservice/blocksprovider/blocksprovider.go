@@ -43,6 +43,18 @@ type GossipServiceAdapter interface {
 	Gossip(msg *gossip_proto.GossipMessage)
 }
 
+// BlockAcknowledger is notified of the blocks the blocks provider added to the
+// local state buffer
+type BlockAcknowledger interface {
+	// Acknowledge acknowledges the block with the given sequence number, which was
+	// delivered along with the given resume token
+	Acknowledge(seqNum uint64, resumeToken []byte) error
+
+	// DiscardResumeToken discards the resume token of the last block acknowledged,
+	// for ordering service nodes rejecting the requests which resume the deliver
+	DiscardResumeToken()
+}
+
 // BlocksProvider used to read blocks from the ordering service
 // for specified chain it subscribed to
 type BlocksProvider interface {
@@ -78,6 +90,9 @@ type streamClient interface {
 	// GetEndpoints
 	GetEndpoints() []string
 
+	// GetEndpoint returns the endpoint of the ordering service node the client is connected to
+	GetEndpoint() string
+
 	// Close closes the stream and its underlying connection
 	Close()
 
@@ -95,6 +110,19 @@ type blocksProviderImpl struct {
 
 	mcs api.MessageCryptoService
 
+	ledgerInfo LedgerInfo
+
+	// acknowledger, if not nil, is notified of every block added to the local state
+	acknowledger BlockAcknowledger
+
+	// gossipBlocks indicates whether blocks are disseminated to
+	// other peers, or only added to the local ledger
+	gossipBlocks bool
+
+	// dedupBlocks indicates whether several peers of the organization pull the
+	// same blocks, in which case the blocks already committed aren't disseminated
+	dedupBlocks bool
+
 	done int32
 
 	wrongStatusThreshold int
@@ -111,12 +139,17 @@ func init() {
 }
 
 // NewBlocksProvider constructor function to create blocks deliverer instance
-func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService) BlocksProvider {
+func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService,
+	ledgerInfo LedgerInfo, acknowledger BlockAcknowledger, gossipBlocks, dedupBlocks bool) BlocksProvider {
 	return &blocksProviderImpl{
 		chainID:              chainID,
 		client:               client,
 		gossip:               gossip,
 		mcs:                  mcs,
+		ledgerInfo:           ledgerInfo,
+		acknowledger:         acknowledger,
+		gossipBlocks:         gossipBlocks,
+		dedupBlocks:          dedupBlocks,
 		wrongStatusThreshold: wrongStatusThreshold,
 	}
 }
@@ -126,6 +159,7 @@ func NewBlocksProvider(chainID string, client streamClient, gossip GossipService
 func (b *blocksProviderImpl) DeliverBlocks() {
 	errorStatusCounter := 0
 	statusCounter := 0
+	verifier := newBlockVerifier(b.chainID, b.mcs, b.ledgerInfo)
 	defer b.client.Close()
 	for !b.isDone() {
 		msg, err := b.client.Recv()
@@ -157,6 +191,12 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				statusCounter++
 			}
 			if t.Status == common.Status_BAD_REQUEST {
+				// Ordering service nodes which don't support resume tokens reject
+				// the requests resuming the deliver, which seeks from the ledger
+				// height after reconnecting instead
+				if b.acknowledger != nil {
+					b.acknowledger.DiscardResumeToken()
+				}
 				b.client.Disconnect(false)
 			} else {
 				b.client.Disconnect(true)
@@ -172,8 +212,10 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				logger.Errorf("[%s] Error serializing block with sequence number %d, due to %s", b.chainID, seqNum, err)
 				continue
 			}
-			if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), seqNum, marshaledBlock); err != nil {
-				logger.Errorf("[%s] Error verifying block with sequnce number %d, due to %s", b.chainID, seqNum, err)
+			if err := verifier.VerifyBlock(t.Block, marshaledBlock); err != nil {
+				logger.Errorf("[%s] Error verifying block with sequnce number %d sent by orderer %s, due to %s. Switching to another orderer",
+					b.chainID, seqNum, b.client.GetEndpoint(), err)
+				b.client.Disconnect(true)
 				continue
 			}
 
@@ -183,12 +225,31 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			// Use payload to create gossip message
 			gossipMsg := createGossipMsg(b.chainID, payload)
 
+			// Several peers may pull the same blocks from the ordering service, so
+			// blocks which were already received via gossip aren't disseminated again.
+			// The height is read before the block is added, as the block may be
+			// committed before it is gossiped otherwise.
+			committed := b.gossipBlocks && b.dedupBlocks && b.isCommitted(seqNum)
+
 			logger.Debugf("[%s] Adding payload locally, buffer seqNum = [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
 			// Add payload to local state payloads buffer
 			if err := b.gossip.AddPayload(b.chainID, payload); err != nil {
 				logger.Warning("Failed adding payload of", seqNum, "because:", err)
+			} else if b.acknowledger != nil {
+				// Only the blocks which reached the local state are acknowledged, so
+				// that the deliver doesn't resume after a block which was lost
+				if err := b.acknowledger.Acknowledge(seqNum, msg.ResumeToken); err != nil {
+					logger.Warningf("[%s] Failed acknowledging block [%d]: %s", b.chainID, seqNum, err)
+				}
 			}
 
+			if !b.gossipBlocks {
+				continue
+			}
+			if committed {
+				logger.Debugf("[%s] Block [%d] was already committed, not gossiping it", b.chainID, seqNum)
+				continue
+			}
 			// Gossip messages with other nodes
 			logger.Debugf("[%s] Gossiping block [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
 			b.gossip.Gossip(gossipMsg)
@@ -234,6 +295,20 @@ func (b *blocksProviderImpl) isEndpointsUpdated(endpoints []string) bool {
 	return false
 }
 
+// isCommitted returns whether the block with the given sequence
+// number is already in the local ledger
+func (b *blocksProviderImpl) isCommitted(seqNum uint64) bool {
+	if b.ledgerInfo == nil {
+		return false
+	}
+	height, err := b.ledgerInfo.LedgerHeight()
+	if err != nil {
+		logger.Warningf("[%s] Failed obtaining ledger height: %s", b.chainID, err)
+		return false
+	}
+	return seqNum < height
+}
+
 // Check whenever provider is stopped
 func (b *blocksProviderImpl) isDone() bool {
 	return atomic.LoadInt32(&b.done) == 1
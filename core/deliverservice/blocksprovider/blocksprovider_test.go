@@ -7,6 +7,8 @@ package blocksprovider
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -17,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	common2 "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/protos/common"
+	gossip_proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -71,7 +74,7 @@ func makeTestCase(ledgerHeight uint64, mcs api.MessageCryptoService, shouldSucce
 		gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
 		deliverer := &mocks.MockBlocksDeliverer{Pos: ledgerHeight}
 		deliverer.MockRecv = rcv
-		provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs)
+		provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs, nil, nil, true, false)
 		defer provider.Stop()
 		ready := make(chan struct{})
 		go func() {
@@ -135,6 +138,97 @@ func TestBlocksProviderImpl_GetBlockFromSpecified(t *testing.T) {
 	makeTestCase(uint64(101), mcs, true, mocks.MockRecv)(t)
 }
 
+type mockLedgerInfo struct {
+	height uint64
+}
+
+func (li *mockLedgerInfo) LedgerHeight() (uint64, error) {
+	return atomic.LoadUint64(&li.height), nil
+}
+
+func TestBlocksProviderImpl_NoDissemination(t *testing.T) {
+	// Scenario: blocks are received from the ordering service, but they are
+	// only added to the local ledger either because block gossip is disabled,
+	// or because they were already received from another leader
+	for _, testCase := range []struct {
+		name         string
+		ledgerInfo   LedgerInfo
+		gossipBlocks bool
+		dedupBlocks  bool
+	}{
+		{name: "gossip disabled", gossipBlocks: false},
+		{name: "already committed", ledgerInfo: &mockLedgerInfo{height: math.MaxUint64}, gossipBlocks: true, dedupBlocks: true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			mcs := &mockMCS{}
+			mcs.On("VerifyBlock", mock.Anything).Return(nil)
+			gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
+			deliverer := &mocks.MockBlocksDeliverer{Pos: 0}
+			deliverer.MockRecv = mocks.MockRecv
+			provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs,
+				testCase.ledgerInfo, nil, testCase.gossipBlocks, testCase.dedupBlocks)
+			defer provider.Stop()
+			go provider.DeliverBlocks()
+
+			waitUntilOrFail(t, func() bool {
+				return atomic.LoadInt32(&gossipServiceAdapter.AddPayloadsCnt) > 0
+			})
+			select {
+			case <-gossipServiceAdapter.GossipBlockDisseminations:
+				assert.Fail(t, "Block should not have been gossiped")
+			case <-time.After(time.Second):
+			}
+		})
+	}
+}
+
+// committingGossipAdapter commits the blocks as soon as they are added
+type committingGossipAdapter struct {
+	*mocks.MockGossipServiceAdapter
+	ledgerInfo *mockLedgerInfo
+}
+
+func (ga *committingGossipAdapter) AddPayload(chainID string, payload *gossip_proto.Payload) error {
+	atomic.StoreUint64(&ga.ledgerInfo.height, payload.SeqNum+1)
+	return ga.MockGossipServiceAdapter.AddPayload(chainID, payload)
+}
+
+func TestBlocksProviderImpl_DisseminationOfCommittedBlocks(t *testing.T) {
+	// Scenario: the blocks received from the ordering service are committed as soon as
+	// they are added to the local ledger, but are still gossiped either because the
+	// peer is the only leader, or because they were committed after being received
+	for _, testCase := range []struct {
+		name        string
+		dedupBlocks bool
+		height      uint64
+	}{
+		{name: "single leader", dedupBlocks: false, height: math.MaxUint64},
+		{name: "several leaders", dedupBlocks: true, height: 0},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			mcs := &mockMCS{}
+			mcs.On("VerifyBlock", mock.Anything).Return(nil)
+			ledgerInfo := &mockLedgerInfo{height: testCase.height}
+			gossipServiceAdapter := &committingGossipAdapter{
+				MockGossipServiceAdapter: &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)},
+				ledgerInfo:               ledgerInfo,
+			}
+			deliverer := &mocks.MockBlocksDeliverer{Pos: 0}
+			deliverer.MockRecv = mocks.MockRecv
+			provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs,
+				ledgerInfo, nil, true, testCase.dedupBlocks)
+			defer provider.Stop()
+			go provider.DeliverBlocks()
+
+			select {
+			case <-gossipServiceAdapter.GossipBlockDisseminations:
+			case <-time.After(time.Second * 10):
+				assert.Fail(t, "Block should have been gossiped")
+			}
+		})
+	}
+}
+
 func TestBlocksProvider_CheckTerminationDeliveryResponseStatus(t *testing.T) {
 	tmp := struct{ mocks.MockBlocksDeliverer }{}
 
@@ -152,8 +246,9 @@ func TestBlocksProvider_CheckTerminationDeliveryResponseStatus(t *testing.T) {
 	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
 	provider := &blocksProviderImpl{
 		chainID: "***TEST_CHAINID***",
-		gossip:  gossipServiceAdapter,
-		client:  &tmp,
+		gossip:       gossipServiceAdapter,
+		client:       &tmp,
+		gossipBlocks: true,
 	}
 
 	var wg sync.WaitGroup
@@ -229,6 +324,7 @@ func TestBlocksProvider_DeliveryWrongStatus(t *testing.T) {
 		client:               &bd,
 		mcs:                  mcs,
 		wrongStatusThreshold: wrongStatusThreshold,
+		gossipBlocks:         true,
 	}
 
 	attempts := int32(0)
@@ -306,6 +402,7 @@ func TestBlocksProvider_DeliveryWrongStatusClose(t *testing.T) {
 		client:               &bd,
 		mcs:                  mcs,
 		wrongStatusThreshold: 5,
+		gossipBlocks:         true,
 	}
 
 	incomingMsgs := make(chan *orderer.DeliverResponse)
@@ -386,6 +483,7 @@ func TestBlocksProvider_DeliveryServiceDisableEndpoints(t *testing.T) {
 		client:               &bd,
 		mcs:                  mcs,
 		wrongStatusThreshold: 5,
+		gossipBlocks:         true,
 	}
 
 	incomingMsgs := make(chan *orderer.DeliverResponse)
@@ -443,3 +541,112 @@ func TestBlockVerificationFailure(t *testing.T) {
 	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
 	makeTestCase(uint64(0), mcs, false, rcvr)(t)
 }
+
+func TestBlockVerificationFailureSwitchesOrderer(t *testing.T) {
+	bd := mocks.MockBlocksDeliverer{
+		DisconnectAndDisableCalled: make(chan struct{}, 10),
+		Endpoint:                   "orderer0:7050",
+	}
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 1)}
+	provider := &blocksProviderImpl{
+		chainID:              "***TEST_CHAINID***",
+		gossip:               gossipServiceAdapter,
+		client:               &bd,
+		mcs:                  mcs,
+		wrongStatusThreshold: wrongStatusThreshold,
+		gossipBlocks:         true,
+	}
+
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		if atomic.LoadInt32(&mock.RecvCnt) > 1 {
+			provider.Stop()
+			return nil, errors.New("Stopping")
+		}
+		return mocks.MockRecv(mock)
+	}
+
+	provider.DeliverBlocks()
+	assert.Len(t, bd.DisconnectAndDisableCalled, 1, "The orderer which sent the bad block should have been disabled")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&gossipServiceAdapter.AddPayloadsCnt))
+}
+
+type mockAcknowledger struct {
+	sync.Mutex
+	acks []string
+}
+
+func (ma *mockAcknowledger) Acknowledge(seqNum uint64, resumeToken []byte) error {
+	ma.Lock()
+	defer ma.Unlock()
+	ma.acks = append(ma.acks, fmt.Sprintf("%d:%s", seqNum, resumeToken))
+	return nil
+}
+
+func (ma *mockAcknowledger) DiscardResumeToken() {
+	ma.Lock()
+	defer ma.Unlock()
+	ma.acks = append(ma.acks, "discard")
+}
+
+func (ma *mockAcknowledger) acknowledged() []string {
+	ma.Lock()
+	defer ma.Unlock()
+	return append([]string{}, ma.acks...)
+}
+
+// failingGossipAdapter fails adding the payload of the given block
+type failingGossipAdapter struct {
+	*mocks.MockGossipServiceAdapter
+	seqNum uint64
+}
+
+func (ga *failingGossipAdapter) AddPayload(chainID string, payload *gossip_proto.Payload) error {
+	if payload.SeqNum == ga.seqNum {
+		return errors.New("buffer full")
+	}
+	return ga.MockGossipServiceAdapter.AddPayload(chainID, payload)
+}
+
+func TestBlocksProvider_AcknowledgeBlocks(t *testing.T) {
+	// Scenario: the blocks added to the local state buffer are acknowledged along
+	// with the resume tokens delivered with them, unlike the blocks which couldn't
+	// be added or failed verification, and the resume token is discarded when the
+	// ordering service rejects the request
+	bd := mocks.MockBlocksDeliverer{DisconnectCalled: make(chan struct{}, 10), DisconnectAndDisableCalled: make(chan struct{}, 10)}
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(nil).Times(3)
+	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
+	acknowledger := &mockAcknowledger{}
+	provider := &blocksProviderImpl{
+		chainID: "***TEST_CHAINID***",
+		gossip: &failingGossipAdapter{
+			MockGossipServiceAdapter: &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 10)},
+			seqNum:                   1,
+		},
+		client:               &bd,
+		mcs:                  mcs,
+		acknowledger:         acknowledger,
+		wrongStatusThreshold: wrongStatusThreshold,
+		gossipBlocks:         true,
+	}
+
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		switch atomic.LoadInt32(&mock.RecvCnt) {
+		case 5:
+			return &orderer.DeliverResponse{Type: &orderer.DeliverResponse_Status{Status: common.Status_BAD_REQUEST}}, nil
+		case 6:
+			provider.Stop()
+			return nil, errors.New("Stopping")
+		}
+		resp, err := mocks.MockRecv(mock)
+		resp.ResumeToken = []byte(fmt.Sprintf("token%d", resp.GetBlock().Header.Number))
+		return resp, err
+	}
+
+	provider.DeliverBlocks()
+	assert.Equal(t, []string{"0:token0", "2:token2", "discard"}, acknowledger.acknowledged())
+	assert.Len(t, bd.DisconnectAndDisableCalled, 1)
+	assert.Len(t, bd.DisconnectCalled, 1)
+}
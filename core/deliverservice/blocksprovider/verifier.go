@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// streamConfig is the config carried by a config block received from the ordering
+// service, which applies to the blocks following it even before the peer commits it
+type streamConfig struct {
+	number        uint64
+	policyManager policies.Manager
+}
+
+// blockVerifier verifies the blocks of a deliver stream against the BlockValidation
+// policy in effect at their height.  Until the stream carries a config block, this is
+// the policy of the config the peer committed, which the message crypto service
+// evaluates.  Once it does, the blocks following it are verified against the policy
+// of that config, as the peer may not have committed it yet.
+type blockVerifier struct {
+	chainID          string
+	mcs              api.MessageCryptoService
+	ledgerInfo       LedgerInfo
+	newPolicyManager channelconfig.PolicyManagerFactory
+
+	config *streamConfig
+}
+
+func newBlockVerifier(chainID string, mcs api.MessageCryptoService, ledgerInfo LedgerInfo) *blockVerifier {
+	return &blockVerifier{
+		chainID:          chainID,
+		mcs:              mcs,
+		ledgerInfo:       ledgerInfo,
+		newPolicyManager: channelconfig.NewPolicyManagerFromEnvelope,
+	}
+}
+
+// VerifyBlock verifies the given block, and tracks the config it carries if it is a config block
+func (v *blockVerifier) VerifyBlock(block *common.Block, marshaledBlock []byte) error {
+	if block.Header == nil {
+		return errors.New("the block has no header")
+	}
+	seqNum := block.Header.Number
+	if v.config != nil && (seqNum <= v.config.number || v.committed(v.config.number)) {
+		// Either the stream was restarted from a lower height, or the peer committed
+		// the config block, and the config of the peer is in effect again
+		v.config = nil
+	}
+
+	var err error
+	if v.config == nil {
+		err = v.mcs.VerifyBlock(gossipcommon.ChainID(v.chainID), seqNum, marshaledBlock)
+	} else {
+		err = v.verifyAgainst(block, v.config)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !utils.IsConfigBlock(block) {
+		return nil
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err == nil {
+		var pm policies.Manager
+		if pm, err = v.newPolicyManager(env); err == nil {
+			logger.Debugf("[%s] Verifying the blocks following config block [%d] against its config", v.chainID, seqNum)
+			v.config = &streamConfig{number: seqNum, policyManager: pm}
+			return nil
+		}
+	}
+	// The block passed verification, so it is committed anyway, and the config of the
+	// peer will apply to the blocks following it once it is
+	logger.Warningf("[%s] Failed extracting the config of config block [%d]: %s", v.chainID, seqNum, err)
+	v.config = nil
+	return nil
+}
+
+// committed returns whether the block with the given sequence number is in the local ledger
+func (v *blockVerifier) committed(seqNum uint64) bool {
+	if v.ledgerInfo == nil {
+		return false
+	}
+	height, err := v.ledgerInfo.LedgerHeight()
+	if err != nil {
+		return false
+	}
+	return seqNum < height
+}
+
+// verifyAgainst verifies that the block is consistent and signed according to the
+// BlockValidation policy of the given config
+func (v *blockVerifier) verifyAgainst(block *common.Block, config *streamConfig) error {
+	if block.Data == nil {
+		return errors.New("the block has no data")
+	}
+	channelID, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		return errors.WithMessage(err, "failed getting the channel ID of the block")
+	}
+	if channelID != v.chainID {
+		return errors.Errorf("invalid channel ID %s of the block", channelID)
+	}
+
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return errors.New("the header data hash does not match the hash of the block data")
+	}
+
+	signatureSet, err := utils.GetSignatureSetFromBlock(block)
+	if err != nil {
+		return err
+	}
+	policy, _ := config.policyManager.GetPolicy(policies.BlockValidation)
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("the block signatures do not satisfy the block validation policy of config block [%d]", config.number))
+	}
+	return nil
+}
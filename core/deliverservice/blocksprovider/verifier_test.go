@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// signerPolicy is satisfied by the signatures of the identity it is named after
+type signerPolicy string
+
+func (p signerPolicy) Evaluate(signatureSet []*common.SignedData) error {
+	for _, sd := range signatureSet {
+		if string(sd.Identity) == string(p) {
+			return nil
+		}
+	}
+	return errors.New("not signed by " + string(p))
+}
+
+type signerPolicyManager struct {
+	signerPolicy
+}
+
+func (m *signerPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	return m.signerPolicy, true
+}
+
+func (m *signerPolicyManager) Manager(path []string) (policies.Manager, bool) {
+	return m, true
+}
+
+func makeSignedBlock(number uint64, headerType common.HeaderType, signer string) (*common.Block, []byte) {
+	block := common.NewBlock(number, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: "testchain",
+				}),
+			},
+		}),
+	})}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Signatures: []*common.MetadataSignature{{
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte(signer)}),
+			Signature:       []byte("signature"),
+		}},
+	})
+	marshaledBlock, _ := proto.Marshal(block)
+	return block, marshaledBlock
+}
+
+func TestBlockVerifierTracksStreamConfig(t *testing.T) {
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(nil)
+	ledgerInfo := &mockLedgerInfo{height: 5}
+	verifier := newBlockVerifier("testchain", mcs, ledgerInfo)
+	verifier.newPolicyManager = func(env *common.Envelope) (policies.Manager, error) {
+		return &signerPolicyManager{signerPolicy("orderer2")}, nil
+	}
+
+	assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(5, common.HeaderType_ENDORSER_TRANSACTION, "orderer1")))
+	assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(6, common.HeaderType_CONFIG, "orderer1")))
+	mcs.AssertNumberOfCalls(t, "VerifyBlock", 2)
+
+	err := verifier.VerifyBlock(makeSignedBlock(7, common.HeaderType_ENDORSER_TRANSACTION, "orderer1"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the block signatures do not satisfy the block validation policy of config block [6]: not signed by orderer2")
+	assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(7, common.HeaderType_ENDORSER_TRANSACTION, "orderer2")))
+
+	block, marshaledBlock := makeSignedBlock(8, common.HeaderType_ENDORSER_TRANSACTION, "orderer2")
+	block.Header.DataHash = []byte("tampered")
+	assert.EqualError(t, verifier.VerifyBlock(block, marshaledBlock), "the header data hash does not match the hash of the block data")
+	mcs.AssertNumberOfCalls(t, "VerifyBlock", 2)
+
+	t.Run("StreamRestart", func(t *testing.T) {
+		assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(6, common.HeaderType_CONFIG, "orderer1")))
+		mcs.AssertNumberOfCalls(t, "VerifyBlock", 3)
+		assert.Error(t, verifier.VerifyBlock(makeSignedBlock(7, common.HeaderType_ENDORSER_TRANSACTION, "orderer1")))
+	})
+
+	t.Run("ConfigCommitted", func(t *testing.T) {
+		ledgerInfo.height = 7
+		assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(7, common.HeaderType_ENDORSER_TRANSACTION, "orderer1")))
+		mcs.AssertNumberOfCalls(t, "VerifyBlock", 4)
+	})
+
+	t.Run("BadConfig", func(t *testing.T) {
+		verifier.newPolicyManager = func(env *common.Envelope) (policies.Manager, error) {
+			return nil, errors.New("bad config")
+		}
+		assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(8, common.HeaderType_CONFIG, "orderer1")))
+		assert.Nil(t, verifier.config)
+		assert.NoError(t, verifier.VerifyBlock(makeSignedBlock(9, common.HeaderType_ENDORSER_TRANSACTION, "orderer1")))
+		mcs.AssertNumberOfCalls(t, "VerifyBlock", 6)
+	})
+}
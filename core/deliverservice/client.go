@@ -239,6 +239,14 @@ func (bc *broadcastClient) GetEndpoints() []string {
 	return bc.prod.GetEndpoints()
 }
 
+// GetEndpoint returns the endpoint of the ordering service node the client is connected to,
+// or an empty string if it is not connected
+func (bc *broadcastClient) GetEndpoint() string {
+	bc.Lock()
+	defer bc.Unlock()
+	return bc.endpoint
+}
+
 type connection struct {
 	sync.Once
 	*grpc.ClientConn
@@ -723,9 +723,17 @@ func TestDisconnectAndDisableEndpoint(t *testing.T) {
 	}), "Didn't get connection to orderer")
 
 	connectedToOS1 := os1.ConnCount() == 1
+	endpoint := "localhost:5614"
+	if connectedToOS1 {
+		endpoint = "localhost:5613"
+	}
+	assert.True(t, waitForWithTimeout(time.Millisecond*100, func() bool {
+		return cl.GetEndpoint() == endpoint
+	}), "Didn't report the endpoint of the orderer")
 
 	// Disconnect and disable endpoint
 	cl.Disconnect(true)
+	assert.Empty(t, cl.GetEndpoint())
 
 	// Ensure we reconnected to the other node
 	assert.True(t, waitForWithTimeout(time.Millisecond*100, func() bool {
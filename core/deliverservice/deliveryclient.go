@@ -14,9 +14,11 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
@@ -33,17 +35,63 @@ func init() {
 
 const (
 	defaultReConnectTotalTimeThreshold = time.Second * 60 * 60
+
+	// healthEndpointSelection makes the delivery client prefer ordering service
+	// endpoints by their measured health, and is the default
+	healthEndpointSelection = "health"
+	// randomEndpointSelection makes the delivery client choose
+	// ordering service endpoints at random
+	randomEndpointSelection = "random"
 )
 
 var (
-	connTimeout               = time.Second * 3
-	reConnectBackoffThreshold = float64(time.Hour)
+	defaultConnTimeout               = time.Second * 3
+	defaultReConnectBackoffThreshold = time.Hour
 )
 
 func getReConnectTotalTimeThreshold() time.Duration {
 	return util.GetDurationOrDefault("peer.deliveryclient.reconnectTotalTimeThreshold", defaultReConnectTotalTimeThreshold)
 }
 
+func getConnTimeout() time.Duration {
+	return util.GetDurationOrDefault("peer.deliveryclient.connTimeout", defaultConnTimeout)
+}
+
+func getReConnectBackoffThreshold() float64 {
+	return float64(util.GetDurationOrDefault("peer.deliveryclient.reConnectBackoffThreshold", defaultReConnectBackoffThreshold))
+}
+
+func getEndpointSelection() string {
+	if selection := viper.GetString("peer.deliveryclient.endpointSelection"); selection != "" {
+		return selection
+	}
+	return healthEndpointSelection
+}
+
+// getFlowControlWindow returns the number of blocks the ordering service may send
+// ahead of the acknowledgments of the delivery client, flow control being disabled
+// if it is zero
+func getFlowControlWindow() uint32 {
+	if window := viper.GetInt("peer.deliveryclient.flowControlWindow"); window > 0 && uint64(window) <= math.MaxUint32 {
+		return uint32(window)
+	}
+	return 0
+}
+
+// IsBlockGossipEnabled returns whether blocks pulled from the ordering service are
+// disseminated to the other peers of the organization. If disabled, every peer pulls
+// blocks from the ordering service independently.
+func IsBlockGossipEnabled() bool {
+	return !viper.IsSet("peer.deliveryclient.blockGossipEnabled") || viper.GetBool("peer.deliveryclient.blockGossipEnabled")
+}
+
+// isBlockDedupEnabled returns whether several leaders of the organization pull the
+// same blocks from the ordering service, in which case the blocks a leader already
+// committed from the gossip of another leader aren't disseminated again
+func isBlockDedupEnabled() bool {
+	return IsBlockGossipEnabled() && election.GetLeadersNum() > 1
+}
+
 // DeliverService used to communicate with orderers to obtain
 // new blocks and send them to the committer service
 type DeliverService interface {
@@ -155,9 +203,10 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		logger.Errorf(errMsg)
 		return errors.New(errMsg)
 	} else {
-		client := d.newClient(chainID, ledgerInfo)
+		client, requester := d.newClient(chainID, ledgerInfo)
 		logger.Debug("This peer will pass blocks from orderer service to other peers for channel", chainID)
-		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
+		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc,
+			ledgerInfo, requester, IsBlockGossipEnabled(), isBlockDedupEnabled())
 		go func() {
 			d.blockProviders[chainID].DeliverBlocks()
 			finalizer()
@@ -199,10 +248,11 @@ func (d *deliverServiceImpl) Stop() {
 	}
 }
 
-func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) *broadcastClient {
+func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) (*broadcastClient, *blocksRequester) {
 	requester := &blocksRequester{
-		tls:     comm.TLSEnabled(),
-		chainID: chainID,
+		tls:               comm.TLSEnabled(),
+		chainID:           chainID,
+		flowControlWindow: getFlowControlWindow(),
 	}
 	broadcastSetup := func(bd blocksprovider.BlocksDeliverer) error {
 		return requester.RequestBlocks(ledgerInfoProvider)
@@ -213,12 +263,22 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		}
 		sleepIncrement := float64(time.Millisecond * 500)
 		attempt := float64(attemptNum)
-		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, reConnectBackoffThreshold)), true
+		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, getReConnectBackoffThreshold())), true
+	}
+	var connProd comm.ConnectionProducer
+	switch selection := getEndpointSelection(); selection {
+	case randomEndpointSelection:
+		connProd = comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
+	default:
+		if selection != healthEndpointSelection {
+			logger.Warningf("Unknown endpoint selection %s, defaulting to %s", selection, healthEndpointSelection)
+		}
+		scope := metrics.GetRootScope().SubScope("deliveryclient").Tagged(map[string]string{"channel": chainID})
+		connProd = comm.NewHealthAwareConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints, scope)
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
 	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
 	requester.client = bClient
-	return bClient
+	return bClient, requester
 }
 
 func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.ClientConn, error) {
@@ -250,7 +310,7 @@ func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.Cli
 		}
 		grpc.EnableTracing = true
 		ctx := context.Background()
-		ctx, _ = context.WithTimeout(ctx, connTimeout)
+		ctx, _ = context.WithTimeout(ctx, getConnTimeout())
 		return grpc.DialContext(ctx, endpoint, dialOpts...)
 	}
 }
@@ -63,6 +63,7 @@ type MockBlocksDeliverer struct {
 	DisconnectAndDisableCalled chan struct{}
 	CloseCalled                chan struct{}
 	Pos                        uint64
+	Endpoint                   string
 	grpc.ClientStream
 	RecvCnt  int32
 	MockRecv func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
@@ -116,8 +117,14 @@ func (mock *MockBlocksDeliverer) Send(env *common.Envelope) error {
 
 func (mock *MockBlocksDeliverer) Disconnect(disableEndpoint bool) {
 	if disableEndpoint {
+		if mock.DisconnectAndDisableCalled == nil {
+			return
+		}
 		mock.DisconnectAndDisableCalled <- struct{}{}
 	} else {
+		if mock.DisconnectCalled == nil {
+			return
+		}
 		mock.DisconnectCalled <- struct{}{}
 	}
 }
@@ -137,6 +144,11 @@ func (mock *MockBlocksDeliverer) GetEndpoints() []string {
 	return []string{} // empty slice
 }
 
+// GetEndpoint returns the endpoint the mock is connected to
+func (mock *MockBlocksDeliverer) GetEndpoint() string {
+	return mock.Endpoint
+}
+
 // MockLedgerInfo mocking implementation of LedgerInfo interface, needed
 // for test initialization purposes
 type MockLedgerInfo struct {
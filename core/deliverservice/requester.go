@@ -32,6 +32,14 @@ type blocksRequester struct {
 	tls     bool
 	chainID string
 	client  blocksprovider.BlocksDeliverer
+
+	// flowControlWindow is the number of blocks the ordering service may send ahead
+	// of the acknowledgments, flow control being disabled if it is zero
+	flowControlWindow uint32
+	// resumeToken resumes the deliver after the last of the consecutive blocks
+	// acknowledged since the deliver started, whose successor is resumeNext
+	resumeToken []byte
+	resumeNext  uint64
 }
 
 func (b *blocksRequester) RequestBlocks(ledgerInfoProvider blocksprovider.LedgerInfo) error {
@@ -41,13 +49,22 @@ func (b *blocksRequester) RequestBlocks(ledgerInfoProvider blocksprovider.Ledger
 		return err
 	}
 
-	if height > 0 {
+	// Resuming the deliver avoids pulling again the blocks which were received but not
+	// committed yet, unless the ledger caught up with the blocks of other peers
+	if b.resumeToken != nil && b.resumeNext >= height {
+		logger.Debugf("Resuming deliver with block [%d] for channel %s", b.resumeNext, b.chainID)
+		if err := b.seekResume(); err != nil {
+			return err
+		}
+	} else if height > 0 {
 		logger.Debugf("Starting deliver with block [%d] for channel %s", height, b.chainID)
+		b.resumeToken, b.resumeNext = nil, height
 		if err := b.seekLatestFromCommitter(height); err != nil {
 			return err
 		}
 	} else {
 		logger.Debugf("Starting deliver with oldest block for channel %s", b.chainID)
+		b.resumeToken, b.resumeNext = nil, 0
 		if err := b.seekOldest(); err != nil {
 			return err
 		}
@@ -56,6 +73,32 @@ func (b *blocksRequester) RequestBlocks(ledgerInfoProvider blocksprovider.Ledger
 	return nil
 }
 
+// Acknowledge records the resume token of the block and, if flow control is enabled,
+// acknowledges the block to the ordering service. The token is only recorded if the
+// block follows the last one acknowledged, so that the deliver doesn't resume after
+// a block which was skipped.
+func (b *blocksRequester) Acknowledge(seqNum uint64, resumeToken []byte) error {
+	if seqNum == b.resumeNext {
+		b.resumeToken, b.resumeNext = resumeToken, seqNum+1
+	}
+	if b.flowControlWindow == 0 {
+		return nil
+	}
+
+	// Acknowledgments travel over an authorized stream, so they need no signature
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_DELIVER_ACK, b.chainID, nil, &orderer.DeliverAck{Number: seqNum}, 0, 0)
+	if err != nil {
+		return err
+	}
+	return b.client.Send(env)
+}
+
+// DiscardResumeToken discards the resume token, so that the deliver seeks the blocks
+// from the ledger height when it is requested again
+func (b *blocksRequester) DiscardResumeToken() {
+	b.resumeToken = nil
+}
+
 func (b *blocksRequester) getTLSCertHash() []byte {
 	if b.tls {
 		return util.ComputeSHA256(comm.GetCredentialSupport().GetClientCertificate().Certificate[0])
@@ -63,31 +106,44 @@ func (b *blocksRequester) getTLSCertHash() []byte {
 	return nil
 }
 
+func (b *blocksRequester) flowControl() *orderer.FlowControl {
+	if b.flowControlWindow == 0 {
+		return nil
+	}
+	return &orderer.FlowControl{Window: b.flowControlWindow}
+}
+
 func (b *blocksRequester) seekOldest() error {
 	seekInfo := &orderer.SeekInfo{
-		Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Oldest{Oldest: &orderer.SeekOldest{}}},
-		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
-		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		Start:        &orderer.SeekPosition{Type: &orderer.SeekPosition_Oldest{Oldest: &orderer.SeekOldest{}}},
+		Stop:         &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
+		Behavior:     orderer.SeekInfo_BLOCK_UNTIL_READY,
+		FlowControl:  b.flowControl(),
+		ResumeTokens: true,
 	}
+	return b.sendSeekInfo(seekInfo)
+}
 
-	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
-	msgVersion := int32(0)
-	epoch := uint64(0)
-	tlsCertHash := b.getTLSCertHash()
-	env, err := utils.CreateSignedEnvelopeWithTLSBinding(common.HeaderType_DELIVER_SEEK_INFO, b.chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch, tlsCertHash)
-	if err != nil {
-		return err
+func (b *blocksRequester) seekLatestFromCommitter(height uint64) error {
+	seekInfo := &orderer.SeekInfo{
+		Start:        &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: height}}},
+		Stop:         &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
+		Behavior:     orderer.SeekInfo_BLOCK_UNTIL_READY,
+		FlowControl:  b.flowControl(),
+		ResumeTokens: true,
 	}
-	return b.client.Send(env)
+	return b.sendSeekInfo(seekInfo)
 }
 
-func (b *blocksRequester) seekLatestFromCommitter(height uint64) error {
+func (b *blocksRequester) seekResume() error {
 	seekInfo := &orderer.SeekInfo{
-		Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: height}}},
-		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
-		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		FlowControl: b.flowControl(),
+		ResumeToken: b.resumeToken,
 	}
+	return b.sendSeekInfo(seekInfo)
+}
 
+func (b *blocksRequester) sendSeekInfo(seekInfo *orderer.SeekInfo) error {
 	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
 	msgVersion := int32(0)
 	epoch := uint64(0)
@@ -18,6 +18,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/core/deliverservice/mocks"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -153,3 +154,93 @@ func (o *mockOrderer) Deliver(stream orderer.AtomicBroadcast_DeliverServer) erro
 	})
 	return nil
 }
+
+// sentEnvelopes records the envelopes sent to the ordering service
+type sentEnvelopes struct {
+	blocksprovider.BlocksDeliverer
+	envelopes []*common.Envelope
+}
+
+func (se *sentEnvelopes) Send(env *common.Envelope) error {
+	se.envelopes = append(se.envelopes, env)
+	return nil
+}
+
+func (se *sentEnvelopes) last(t *testing.T, msg proto.Message) *common.ChannelHeader {
+	payload, err := utils.UnmarshalPayload(se.envelopes[len(se.envelopes)-1].Payload)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	assert.NoError(t, proto.Unmarshal(payload.Data, msg))
+	return chdr
+}
+
+func TestFlowControlAndResume(t *testing.T) {
+	client := &sentEnvelopes{}
+	requester := &blocksRequester{
+		chainID:           "testchainid",
+		client:            client,
+		flowControlWindow: 10,
+	}
+	ledgerInfo := &mocks.MockLedgerInfo{Height: 5}
+
+	// The first seek starts from the ledger height and requests resume tokens
+	assert.NoError(t, requester.RequestBlocks(ledgerInfo))
+	seekInfo := &orderer.SeekInfo{}
+	chdr := client.last(t, seekInfo)
+	assert.Equal(t, int32(common.HeaderType_DELIVER_SEEK_INFO), chdr.Type)
+	assert.Equal(t, uint64(5), seekInfo.Start.GetSpecified().Number)
+	assert.Equal(t, &orderer.FlowControl{Window: 10}, seekInfo.FlowControl)
+	assert.True(t, seekInfo.ResumeTokens)
+
+	// Every block is acknowledged
+	assert.NoError(t, requester.Acknowledge(5, []byte("token5")))
+	assert.NoError(t, requester.Acknowledge(6, []byte("token6")))
+	ack := &orderer.DeliverAck{}
+	chdr = client.last(t, ack)
+	assert.Equal(t, int32(common.HeaderType_DELIVER_ACK), chdr.Type)
+	assert.Equal(t, "testchainid", chdr.ChannelId)
+	assert.Equal(t, uint64(6), ack.Number)
+	assert.Len(t, client.envelopes, 3)
+
+	// A reconnection resumes the deliver after the last block acknowledged
+	assert.NoError(t, requester.RequestBlocks(ledgerInfo))
+	seekInfo = &orderer.SeekInfo{}
+	client.last(t, seekInfo)
+	assert.Equal(t, []byte("token6"), seekInfo.ResumeToken)
+	assert.Equal(t, &orderer.FlowControl{Window: 10}, seekInfo.FlowControl)
+
+	// Unless the ledger caught up with blocks received from other peers
+	ledgerInfo.Height = 8
+	assert.NoError(t, requester.RequestBlocks(ledgerInfo))
+	seekInfo = &orderer.SeekInfo{}
+	client.last(t, seekInfo)
+	assert.Nil(t, seekInfo.ResumeToken)
+	assert.Equal(t, uint64(8), seekInfo.Start.GetSpecified().Number)
+
+	// The deliver resumes after the last of the consecutive blocks acknowledged
+	assert.NoError(t, requester.Acknowledge(8, []byte("token8")))
+	assert.NoError(t, requester.Acknowledge(10, []byte("token10")))
+	assert.NoError(t, requester.RequestBlocks(ledgerInfo))
+	seekInfo = &orderer.SeekInfo{}
+	client.last(t, seekInfo)
+	assert.Equal(t, []byte("token8"), seekInfo.ResumeToken)
+
+	// Unless the ordering service rejected the resume token
+	requester.DiscardResumeToken()
+	assert.NoError(t, requester.RequestBlocks(ledgerInfo))
+	seekInfo = &orderer.SeekInfo{}
+	client.last(t, seekInfo)
+	assert.Nil(t, seekInfo.ResumeToken)
+	assert.Equal(t, uint64(8), seekInfo.Start.GetSpecified().Number)
+
+	// Blocks are not acknowledged when flow control is disabled
+	requester.flowControlWindow = 0
+	assert.NoError(t, requester.Acknowledge(8, nil))
+	assert.Len(t, client.envelopes, 9)
+	assert.NoError(t, requester.RequestBlocks(ledgerInfo))
+	seekInfo = &orderer.SeekInfo{}
+	client.last(t, seekInfo)
+	assert.Nil(t, seekInfo.FlowControl)
+	assert.Equal(t, uint64(8), seekInfo.Start.GetSpecified().Number)
+}
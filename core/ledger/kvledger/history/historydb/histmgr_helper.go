@@ -18,12 +18,17 @@ package historydb
 
 import (
 	"bytes"
+	"math"
 
 	"github.com/hyperledger/fabric/common/ledger/util"
 )
 
 var compositeKeySep = []byte{0x00}
 
+// pvtHashHistoryKeyPrefix distinguishes the history records of private data key hashes
+// from the history records of public keys, which start with the namespace
+var pvtHashHistoryKeyPrefix = []byte{0x01}
+
 //ConstructCompositeHistoryKey builds the History Key of namespace~key~blocknum~trannum
 // using an order preserving encoding so that history query results are ordered by height
 func ConstructCompositeHistoryKey(ns string, key string, blocknum uint64, trannum uint64) []byte {
@@ -53,6 +58,46 @@ func ConstructPartialCompositeHistoryKey(ns string, key string, endkey bool) []b
 	return compositeKey
 }
 
+//ConstructCompositePvtHashHistoryKey builds the History Key of a private data key hash
+// in the form 0x01~namespace~collection~keyhash~blocknum~trannum
+func ConstructCompositePvtHashHistoryKey(ns, coll string, keyHash []byte, blocknum uint64, trannum uint64) []byte {
+	compositeKey := ConstructPartialCompositePvtHashHistoryKey(ns, coll, keyHash, false)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(blocknum)...)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(trannum)...)
+	return compositeKey
+}
+
+//ConstructPartialCompositePvtHashHistoryKey builds a partial History Key 0x01~namespace~collection~keyhash~
+// for use in private data hash history key range queries
+func ConstructPartialCompositePvtHashHistoryKey(ns, coll string, keyHash []byte, endkey bool) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, pvtHashHistoryKeyPrefix...)
+	compositeKey = append(compositeKey, []byte(ns)...)
+	compositeKey = append(compositeKey, compositeKeySep...)
+	compositeKey = append(compositeKey, []byte(coll)...)
+	compositeKey = append(compositeKey, compositeKeySep...)
+	compositeKey = append(compositeKey, keyHash...)
+	compositeKey = append(compositeKey, compositeKeySep...)
+	if endkey {
+		compositeKey = append(compositeKey, []byte{0xff}...)
+	}
+	return compositeKey
+}
+
+//ConstructHistoryKeyRange builds the start and end keys for a range query over the history
+// records under the given partial key that were written in the blocks startBlock to endBlock (inclusive).
+// An endBlock of zero means that the range is not bounded at the top
+func ConstructHistoryKeyRange(partialKey []byte, startBlock, endBlock uint64) ([]byte, []byte) {
+	startKey := append(append([]byte{}, partialKey...), util.EncodeOrderPreservingVarUint64(startBlock)...)
+	endKey := append([]byte{}, partialKey...)
+	if endBlock == 0 || endBlock == math.MaxUint64 {
+		endKey = append(endKey, []byte{0xff}...)
+	} else {
+		endKey = append(endKey, util.EncodeOrderPreservingVarUint64(endBlock+1)...)
+	}
+	return startKey, endKey
+}
+
 //SplitCompositeHistoryKey splits the key bytes using a separator
 func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []byte) {
 	split := bytes.SplitN(bytesToSplit, separator, 2)
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
 )
 
 var strKeySep = string(compositeKeySep)
@@ -38,6 +39,35 @@ func TestConstructPartialCompositeKey(t *testing.T) {
 	testutil.AssertEquals(t, compositeEndKey, []byte("ns1"+strKeySep+"key1"+strKeySep+string([]byte{0xff})))
 }
 
+func TestConstructPartialCompositePvtHashKey(t *testing.T) {
+	keyHash := []byte{0x01, 0x00, 0x02}
+	compositeStartKey := ConstructPartialCompositePvtHashHistoryKey("ns1", "coll1", keyHash, false)
+	compositeEndKey := ConstructPartialCompositePvtHashHistoryKey("ns1", "coll1", keyHash, true)
+
+	expectedStartKey := string(pvtHashHistoryKeyPrefix) + "ns1" + strKeySep + "coll1" + strKeySep + string(keyHash) + strKeySep
+	testutil.AssertEquals(t, compositeStartKey, []byte(expectedStartKey))
+	testutil.AssertEquals(t, compositeEndKey, []byte(expectedStartKey+string([]byte{0xff})))
+
+	compositeKey := ConstructCompositePvtHashHistoryKey("ns1", "coll1", keyHash, 2, 3)
+	_, blockNumTranNumBytes := SplitCompositeHistoryKey(compositeKey, compositeStartKey)
+	testutil.AssertEquals(t, blockNumTranNumBytes,
+		append(util.EncodeOrderPreservingVarUint64(2), util.EncodeOrderPreservingVarUint64(3)...))
+}
+
+func TestConstructHistoryKeyRange(t *testing.T) {
+	partialKey := ConstructPartialCompositeHistoryKey("ns1", "key1", false)
+
+	startKey, endKey := ConstructHistoryKeyRange(partialKey, 0, 0)
+	testutil.AssertEquals(t, startKey, append(partialKey, util.EncodeOrderPreservingVarUint64(0)...))
+	testutil.AssertEquals(t, endKey, ConstructPartialCompositeHistoryKey("ns1", "key1", true))
+
+	startKey, endKey = ConstructHistoryKeyRange(partialKey, 5, 10)
+	testutil.AssertEquals(t, startKey, ConstructCompositeHistoryKey("ns1", "key1", 5, 0)[:len(startKey)])
+	testutil.AssertEquals(t, endKey, append(partialKey, util.EncodeOrderPreservingVarUint64(11)...))
+	// the partial key must not be modified
+	testutil.AssertEquals(t, partialKey, ConstructPartialCompositeHistoryKey("ns1", "key1", false))
+}
+
 func TestSplitCompositeKey(t *testing.T) {
 	compositeFullKey := []byte("ns1" + strKeySep + "key1" + strKeySep + "extra bytes to split")
 	compositePartialKey := ConstructPartialCompositeHistoryKey("ns1", "key1", false)
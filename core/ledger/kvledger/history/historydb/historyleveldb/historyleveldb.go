@@ -92,6 +92,8 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 	logger.Debugf("Channel [%s]: Updating history database for blockNo [%v] with [%d] transactions",
 		historyDB.dbName, blockNo, len(block.Data.Data))
 
+	trackPvtDataHashes := ledgerconfig.IsPvtDataHashHistoryEnabled()
+
 	// Get the invalidation byte array for the block
 	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	// Initialize txsFilter if it does not yet exist (e.g. during testing, for genesis block, etc)
@@ -156,6 +158,19 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
 					dbBatch.Put(compositeHistoryKey, emptyValue)
 				}
+
+				if !trackPvtDataHashes {
+					continue
+				}
+				// add a history record for each write of a private data key hash,
+				// in the form 0x01~ns~coll~keyHash~blockNo~tranNo
+				for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+					for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+						compositeHistoryKey := historydb.ConstructCompositePvtHashHistoryKey(ns,
+							collHashedRwSet.CollectionName, kvWriteHash.KeyHash, blockNo, tranNo)
+						dbBatch.Put(compositeHistoryKey, emptyValue)
+					}
+				}
 			}
 
 		} else {
@@ -17,11 +17,14 @@ limitations under the License.
 package historyleveldb
 
 import (
+	"bytes"
 	"errors"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -39,21 +42,64 @@ type LevelHistoryDBQueryExecutor struct {
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	return q.GetHistoryForKeyWithOptions(namespace, key, nil)
+}
+
+// GetHistoryForKeyWithOptions implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithOptions(namespace string, key string,
+	options *ledger.HistoryQueryOptions) (commonledger.ResultsIterator, error) {
 
 	if ledgerconfig.IsHistoryDBEnabled() == false {
 		return nil, errors.New("History tracking not enabled - historyDatabase is false")
 	}
 
-	var compositeStartKey []byte
-	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
-	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	extractor := func(tranEnvelope *common.Envelope) (*queryresult.KeyModification, error) {
+		return getKeyModificationFromTran(tranEnvelope, namespace, key)
+	}
+	return q.newHistoryScanner(compositePartialKey, namespace, key, options, extractor), nil
+}
+
+// GetPrivateDataHashHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetPrivateDataHashHistoryForKey(namespace, collection string, keyHash []byte,
+	options *ledger.HistoryQueryOptions) (commonledger.ResultsIterator, error) {
+
+	if ledgerconfig.IsPvtDataHashHistoryEnabled() == false {
+		return nil, errors.New("Private data hash history tracking not enabled - enablePrivateDataHashHistory is false")
+	}
+
+	compositePartialKey := historydb.ConstructPartialCompositePvtHashHistoryKey(namespace, collection, keyHash, false)
+	extractor := func(tranEnvelope *common.Envelope) (*queryresult.KeyModification, error) {
+		return getKeyHashModificationFromTran(tranEnvelope, namespace, collection, keyHash)
+	}
+	return q.newHistoryScanner(compositePartialKey, namespace, collection, options, extractor), nil
+}
+
+// newHistoryScanner range scans the history records starting with the compositePartialKey
+// within the block range of the options
+func (q *LevelHistoryDBQueryExecutor) newHistoryScanner(compositePartialKey []byte, namespace string, key string,
+	options *ledger.HistoryQueryOptions, extractor keyModificationExtractor) *historyScanner {
 
-	// range scan to find any history records starting with namespace~key
+	if options == nil {
+		options = &ledger.HistoryQueryOptions{}
+	}
+	compositeStartKey, compositeEndKey := historydb.ConstructHistoryKeyRange(compositePartialKey,
+		options.StartBlock, options.EndBlock)
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          q.blockStore,
+		options:             options,
+		extractor:           extractor,
+	}
 }
 
+// keyModificationExtractor retrieves the modification of the queried key from a transaction
+type keyModificationExtractor func(tranEnvelope *common.Envelope) (*queryresult.KeyModification, error)
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
@@ -61,40 +107,89 @@ type historyScanner struct {
 	key                 string
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
-}
-
-func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
-	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	options             *ledger.HistoryQueryOptions
+	extractor           keyModificationExtractor
+	positioned          bool
+	resultsReturned     uint32
 }
 
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
-	if !scanner.dbItr.Next() {
-		return nil, nil
+	for {
+		if scanner.options.Limit > 0 && scanner.resultsReturned >= scanner.options.Limit {
+			return nil, nil
+		}
+		if !scanner.advance() {
+			return nil, nil
+		}
+		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
+
+		// SplitCompositeKey(namespace~key~blocknum~trannum, namespace~key~) will return the blocknum~trannum in second position
+		_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(historyKey, scanner.compositePartialKey)
+		blockNum, bytesConsumed := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[0:])
+		tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
+		logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
+			scanner.namespace, scanner.key, blockNum, tranNum)
+
+		// Get the transaction from block storage that is associated with this history record
+		tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
+		queryResult, err := scanner.extractor(tranEnvelope)
+		if err != nil {
+			return nil, err
+		}
+		if !scanner.inTimeRange(queryResult.Timestamp) {
+			logger.Debugf("Skipping historic key value for namespace:%s key:%s from transaction %s outside of the time range\n",
+				scanner.namespace, scanner.key, queryResult.TxId)
+			continue
+		}
+		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
+			scanner.namespace, scanner.key, queryResult.TxId)
+		scanner.resultsReturned++
+		return queryResult, nil
 	}
-	historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
+}
 
-	// SplitCompositeKey(namespace~key~blocknum~trannum, namespace~key~) will return the blocknum~trannum in second position
-	_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(historyKey, scanner.compositePartialKey)
-	blockNum, bytesConsumed := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[0:])
-	tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
-	logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
-		scanner.namespace, scanner.key, blockNum, tranNum)
+// advance moves the underlying iterator to the next history record in the requested order
+func (scanner *historyScanner) advance() bool {
+	if !scanner.options.Reverse {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.positioned {
+		scanner.positioned = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
+}
 
-	// Get the transaction from block storage that is associated with this history record
-	tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
-	if err != nil {
-		return nil, err
+// inTimeRange checks the transaction timestamp against the time range of the options
+func (scanner *historyScanner) inTimeRange(ts *timestamp.Timestamp) bool {
+	if scanner.options.StartTime != nil && compareTimestamps(ts, scanner.options.StartTime) < 0 {
+		return false
+	}
+	if scanner.options.EndTime != nil && compareTimestamps(ts, scanner.options.EndTime) > 0 {
+		return false
 	}
+	return true
+}
 
-	// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
-	queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
-	if err != nil {
-		return nil, err
+// compareTimestamps returns -1, 0 or 1 if t1 is before, equal to or after t2 respectively.
+// A nil timestamp is considered to be before any other timestamp
+func compareTimestamps(t1, t2 *timestamp.Timestamp) int {
+	switch {
+	case t1.GetSeconds() < t2.GetSeconds():
+		return -1
+	case t1.GetSeconds() > t2.GetSeconds():
+		return 1
+	case t1.GetNanos() < t2.GetNanos():
+		return -1
+	case t1.GetNanos() > t2.GetNanos():
+		return 1
 	}
-	logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
-		scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
-	return queryResult, nil
+	return 0
 }
 
 func (scanner *historyScanner) Close() {
@@ -102,54 +197,92 @@ func (scanner *historyScanner) Close() {
 }
 
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
-func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
+func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (*queryresult.KeyModification, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
 
+	txID, timestamp, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	// look for the namespace and key by looping through the transaction's ReadWriteSets
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace == namespace {
+			// got the correct namespace, now find the key write
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				if kvWrite.Key == key {
+					return &queryresult.KeyModification{TxId: txID, Value: kvWrite.Value,
+						Timestamp: timestamp, IsDelete: kvWrite.IsDelete}, nil
+				}
+			} // end keys loop
+			return nil, errors.New("Key not found in namespace's writeset")
+		} // end if
+	} //end namespaces loop
+	return nil, errors.New("Namespace not found in transaction's ReadWriteSets")
+
+}
+
+// getKeyHashModificationFromTran inspects a transaction for writes to a given private data key hash.
+// The returned KeyModification carries the hash of the written value
+func getKeyHashModificationFromTran(tranEnvelope *common.Envelope, namespace, collection string,
+	keyHash []byte) (*queryresult.KeyModification, error) {
+	logger.Debugf("Entering getKeyHashModificationFromTran()\n", namespace, collection)
+
+	txID, timestamp, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != namespace {
+			continue
+		}
+		for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName != collection {
+				continue
+			}
+			for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+				if bytes.Equal(kvWriteHash.KeyHash, keyHash) {
+					return &queryresult.KeyModification{TxId: txID, Value: kvWriteHash.ValueHash,
+						Timestamp: timestamp, IsDelete: kvWriteHash.IsDelete}, nil
+				}
+			}
+			return nil, errors.New("Key hash not found in collection's hashed writeset")
+		}
+		return nil, errors.New("Collection not found in namespace's hashed ReadWriteSets")
+	}
+	return nil, errors.New("Namespace not found in transaction's ReadWriteSets")
+}
+
+// getTxRWSetFromTran extracts the txid, the timestamp and the read-write set of a transaction
+func getTxRWSetFromTran(tranEnvelope *common.Envelope) (string, *timestamp.Timestamp, *rwsetutil.TxRwSet, error) {
 	// extract action from the envelope
 	payload, err := putils.GetPayload(tranEnvelope)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
 	tx, err := putils.GetTransaction(payload.Data)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
 	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
 	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
-	txID := chdr.TxId
-	timestamp := chdr.Timestamp
-
 	txRWSet := &rwsetutil.TxRwSet{}
 
 	// Get the Result from the Action and then Unmarshal
 	// it into a TxReadWriteSet using custom unmarshalling
 	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
-
-	// look for the namespace and key by looping through the transaction's ReadWriteSets
-	for _, nsRWSet := range txRWSet.NsRwSets {
-		if nsRWSet.NameSpace == namespace {
-			// got the correct namespace, now find the key write
-			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
-				if kvWrite.Key == key {
-					return &queryresult.KeyModification{TxId: txID, Value: kvWrite.Value,
-						Timestamp: timestamp, IsDelete: kvWrite.IsDelete}, nil
-				}
-			} // end keys loop
-			return nil, errors.New("Key not found in namespace's writeset")
-		} // end if
-	} //end namespaces loop
-	return nil, errors.New("Namespace not found in transaction's ReadWriteSets")
-
+	return chdr.TxId, chdr.Timestamp, txRWSet, nil
 }
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -200,6 +202,152 @@ func TestHistory(t *testing.T) {
 	testutil.AssertEquals(t, count, 4)
 }
 
+func TestHistoryWithOptions(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	// blocks 1 to 4 each write value<blocknum> for key7
+	for i := 1; i <= 4; i++ {
+		value := []byte("value" + strconv.Itoa(i))
+		commitHistoryTestBlock(t, env, store1, bg, func(simulator ledger.TxSimulator) {
+			simulator.SetState("ns1", "key7", value)
+		})
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	queryValues := func(options *ledger.HistoryQueryOptions) []string {
+		itr, err := qhistory.GetHistoryForKeyWithOptions("ns1", "key7", options)
+		testutil.AssertNoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+		var values []string
+		for _, kmod := range collectKeyModifications(t, itr) {
+			values = append(values, string(kmod.Value))
+		}
+		return values
+	}
+
+	testutil.AssertEquals(t, queryValues(nil), []string{"value1", "value2", "value3", "value4"})
+	testutil.AssertEquals(t, queryValues(&ledger.HistoryQueryOptions{Reverse: true}),
+		[]string{"value4", "value3", "value2", "value1"})
+	testutil.AssertEquals(t, queryValues(&ledger.HistoryQueryOptions{Reverse: true, Limit: 2}),
+		[]string{"value4", "value3"})
+	testutil.AssertEquals(t, queryValues(&ledger.HistoryQueryOptions{Limit: 1}), []string{"value1"})
+	testutil.AssertEquals(t, queryValues(&ledger.HistoryQueryOptions{StartBlock: 2, EndBlock: 3}),
+		[]string{"value2", "value3"})
+	testutil.AssertEquals(t, queryValues(&ledger.HistoryQueryOptions{StartBlock: 3, Reverse: true}),
+		[]string{"value4", "value3"})
+	testutil.AssertEquals(t, queryValues(&ledger.HistoryQueryOptions{StartBlock: 5}), []string(nil))
+
+	// restrict the results to the timestamps of the second and third modification
+	itr, err := qhistory.GetHistoryForKey("ns1", "key7")
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKey()")
+	kmods := collectKeyModifications(t, itr)
+	testutil.AssertEquals(t, len(kmods), 4)
+	timeRange := &ledger.HistoryQueryOptions{StartTime: kmods[1].Timestamp, EndTime: kmods[2].Timestamp}
+	testutil.AssertEquals(t, queryValues(timeRange), []string{"value2", "value3"})
+	timeRange.Reverse = true
+	timeRange.Limit = 1
+	testutil.AssertEquals(t, queryValues(timeRange), []string{"value3"})
+}
+
+func TestPvtDataHashHistory(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enablePrivateDataHashHistory", true)
+	defer viper.Set("ledger.history.enablePrivateDataHashHistory", false)
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	commitHistoryTestBlock(t, env, store1, bg, func(simulator ledger.TxSimulator) {
+		simulator.SetPrivateData("ns1", "coll1", "key1", []byte("pvtvalue1"))
+		simulator.SetPrivateData("ns1", "coll2", "key1", []byte("othervalue"))
+	})
+	commitHistoryTestBlock(t, env, store1, bg, func(simulator ledger.TxSimulator) {
+		simulator.SetPrivateData("ns1", "coll1", "key1", []byte("pvtvalue2"))
+	})
+	commitHistoryTestBlock(t, env, store1, bg, func(simulator ledger.TxSimulator) {
+		simulator.DeletePrivateData("ns1", "coll1", "key1")
+	})
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	itr, err := qhistory.GetPrivateDataHashHistoryForKey("ns1", "coll1", util.ComputeStringHash("key1"), nil)
+	testutil.AssertNoError(t, err, "Error upon GetPrivateDataHashHistoryForKey()")
+	kmods := collectKeyModifications(t, itr)
+	testutil.AssertEquals(t, len(kmods), 3)
+	testutil.AssertEquals(t, kmods[0].Value, util.ComputeHash([]byte("pvtvalue1")))
+	testutil.AssertEquals(t, kmods[1].Value, util.ComputeHash([]byte("pvtvalue2")))
+	testutil.AssertEquals(t, kmods[2].IsDelete, true)
+
+	itr, err = qhistory.GetPrivateDataHashHistoryForKey("ns1", "coll1", util.ComputeStringHash("key1"),
+		&ledger.HistoryQueryOptions{Reverse: true, Limit: 1})
+	testutil.AssertNoError(t, err, "Error upon GetPrivateDataHashHistoryForKey()")
+	kmods = collectKeyModifications(t, itr)
+	testutil.AssertEquals(t, len(kmods), 1)
+	testutil.AssertEquals(t, kmods[0].IsDelete, true)
+
+	itr, err = qhistory.GetPrivateDataHashHistoryForKey("ns1", "coll2", util.ComputeStringHash("key1"), nil)
+	testutil.AssertNoError(t, err, "Error upon GetPrivateDataHashHistoryForKey()")
+	kmods = collectKeyModifications(t, itr)
+	testutil.AssertEquals(t, len(kmods), 1)
+	testutil.AssertEquals(t, kmods[0].Value, util.ComputeHash([]byte("othervalue")))
+
+	// the private data hashes do not show up in the history of public keys
+	itr, err = qhistory.GetHistoryForKey("ns1", "key1")
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKey()")
+	testutil.AssertEquals(t, len(collectKeyModifications(t, itr)), 0)
+
+	viper.Set("ledger.history.enablePrivateDataHashHistory", false)
+	_, err = qhistory.GetPrivateDataHashHistoryForKey("ns1", "coll1", util.ComputeStringHash("key1"), nil)
+	testutil.AssertError(t, err, "Error should have been returned when private data hash history is disabled")
+}
+
+func commitHistoryTestBlock(t *testing.T, env *levelDBLockBasedHistoryEnv, store blkstorage.BlockStore,
+	bg *testutil.BlockGenerator, simulate func(simulator ledger.TxSimulator)) {
+	simulator, err := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+	testutil.AssertNoError(t, err, "")
+	simulate(simulator)
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	testutil.AssertNoError(t, err, "")
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	testutil.AssertNoError(t, err, "")
+	block := bg.NextBlock([][]byte{pubSimResBytes})
+	testutil.AssertNoError(t, store.AddBlock(block), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(block), "")
+}
+
+func collectKeyModifications(t *testing.T, itr commonledger.ResultsIterator) []*queryresult.KeyModification {
+	defer itr.Close()
+	var kmods []*queryresult.KeyModification
+	for {
+		kmod, err := itr.Next()
+		testutil.AssertNoError(t, err, "Error upon Next()")
+		if kmod == nil {
+			return kmods
+		}
+		kmods = append(kmods, kmod.(*queryresult.KeyModification))
+	}
+}
+
 func TestHistoryForInvalidTran(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
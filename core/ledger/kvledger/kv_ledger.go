@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
@@ -200,6 +201,30 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 	return txValidationCode, err
 }
 
+// GetTxsByCreator returns an iterator over the transactions submitted by a creator
+func (l *kvLedger) GetTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (commonledger.ResultsIterator, error) {
+	txsItr, err := l.blockStore.RetrieveTxsByCreator(creator, startBlockNum, startTxNum)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txsItr, err
+}
+
+// GetTxsByChaincode returns an iterator over the transactions invoking a chaincode
+func (l *kvLedger) GetTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (commonledger.ResultsIterator, error) {
+	txsItr, err := l.blockStore.RetrieveTxsByChaincode(chaincodeName, startBlockNum, startTxNum)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txsItr, err
+}
+
+// GetTxsByTimestamp returns an iterator over the transactions with a timestamp in the range [startTime, endTime)
+func (l *kvLedger) GetTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (commonledger.ResultsIterator, error) {
+	txsItr, err := l.blockStore.RetrieveTxsByTimestamp(startTime, endTime, startBlockNum, startTxNum)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txsItr, err
+}
+
 //Prune prunes the blocks/transactions that satisfy the given policy
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
 	return errors.New("Not yet implemented")
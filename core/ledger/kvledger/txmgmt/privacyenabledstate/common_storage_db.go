@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecache"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -41,6 +42,9 @@ func NewCommonStorageDBProvider() (DBProvider, error) {
 	} else {
 		vdbProvider = stateleveldb.NewVersionedDBProvider()
 	}
+	if cacheSize := ledgerconfig.GetStateCacheSize(); cacheSize > 0 {
+		vdbProvider = statecache.NewVersionedDBProvider(vdbProvider, cacheSize)
+	}
 	return &CommonStorageDBProvider{vdbProvider}, nil
 }
 
@@ -72,7 +76,7 @@ func NewCommonStorageDB(vdb statedb.VersionedDB, ledgerid string) (DB, error) {
 
 // IsBulkOptimizable implements corresponding function in interface DB
 func (s *CommonStorageDB) IsBulkOptimizable() bool {
-	_, ok := s.VersionedDB.(statedb.BulkOptimizable)
+	_, ok := statedb.Unwrap(s.VersionedDB).(statedb.BulkOptimizable)
 	return ok
 }
 
@@ -80,7 +84,7 @@ func (s *CommonStorageDB) IsBulkOptimizable() bool {
 func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey,
 	hashedKeys []*HashedCompositeKey) error {
 
-	bulkOptimizable, ok := s.VersionedDB.(statedb.BulkOptimizable)
+	bulkOptimizable, ok := statedb.Unwrap(s.VersionedDB).(statedb.BulkOptimizable)
 	if !ok {
 		return nil
 	}
@@ -111,7 +115,7 @@ func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*sta
 
 // ClearCachedVersions implements corresponding function in interface DB
 func (s *CommonStorageDB) ClearCachedVersions() {
-	bulkOptimizable, ok := s.VersionedDB.(statedb.BulkOptimizable)
+	bulkOptimizable, ok := statedb.Unwrap(s.VersionedDB).(statedb.BulkOptimizable)
 	if ok {
 		bulkOptimizable.ClearCachedVersions()
 	}
@@ -119,7 +123,7 @@ func (s *CommonStorageDB) ClearCachedVersions() {
 
 // GetChaincodeEventListener implements corresponding function in interface DB
 func (s *CommonStorageDB) GetChaincodeEventListener() cceventmgmt.ChaincodeLifecycleEventListener {
-	ccListener, ok := s.VersionedDB.(cceventmgmt.ChaincodeLifecycleEventListener)
+	ccListener, ok := statedb.Unwrap(s.VersionedDB).(cceventmgmt.ChaincodeLifecycleEventListener)
 	if ok {
 		return ccListener
 	}
@@ -151,7 +155,7 @@ func (s *CommonStorageDB) GetKeyHashVersion(namespace, collection string, keyHas
 
 // GetCachedKeyHashVersion retrieves the keyhash version from cache
 func (s *CommonStorageDB) GetCachedKeyHashVersion(namespace, collection string, keyHash []byte) (*version.Height, bool) {
-	bulkOptimizable, ok := s.VersionedDB.(statedb.BulkOptimizable)
+	bulkOptimizable, ok := statedb.Unwrap(s.VersionedDB).(statedb.BulkOptimizable)
 	if !ok {
 		return nil, false
 	}
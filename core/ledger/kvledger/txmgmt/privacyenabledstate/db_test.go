@@ -91,7 +91,7 @@ func testDB(t *testing.T, env TestEnv) {
 	putPvtUpdates(t, updates, "ns2", "coll1", "key3", []byte("pvt_value3"), version.NewHeight(1, 6))
 	db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 6))
 	commonStorageDB := db.(*CommonStorageDB)
-	bulkOptimizable, ok := commonStorageDB.VersionedDB.(statedb.BulkOptimizable)
+	bulkOptimizable, ok := statedb.Unwrap(commonStorageDB.VersionedDB).(statedb.BulkOptimizable)
 	if ok {
 		bulkOptimizable.ClearCachedVersions()
 	}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecache
+
+import (
+	"container/list"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// lruCache holds up to maxEntries cached values, evicting the least recently used one.
+// lruCache is not safe for concurrent use
+type lruCache struct {
+	maxEntries int
+	ll         *list.List
+	entries    map[statedb.CompositeKey]*list.Element
+}
+
+type cacheEntry struct {
+	key statedb.CompositeKey
+	val *cachedValue
+}
+
+// cachedValue is the versioned value of a key. A nil versioned value records that the key is
+// absent from the db. When versionOnly is set, only the version of the versioned value is known
+type cachedValue struct {
+	vv          *statedb.VersionedValue
+	versionOnly bool
+}
+
+func newLRUCache(maxEntries int) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		entries:    make(map[statedb.CompositeKey]*list.Element),
+	}
+}
+
+// get returns the cached value of a key, or nil
+func (c *lruCache) get(key statedb.CompositeKey) *cachedValue {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).val
+}
+
+func (c *lruCache) put(key statedb.CompositeKey, val *cachedValue) {
+	if elem, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*cacheEntry).val = val
+		return
+	}
+	c.entries[key] = c.ll.PushFront(&cacheEntry{key: key, val: val})
+	if c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *lruCache) purge() {
+	c.ll.Init()
+	c.entries = make(map[statedb.CompositeKey]*list.Element)
+}
+
+func (c *lruCache) len() int {
+	return c.ll.Len()
+}
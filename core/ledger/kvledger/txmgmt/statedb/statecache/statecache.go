@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecache
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+var logger = flogging.MustGetLogger("statecache")
+
+// VersionedDBProvider wraps the VersionedDBs of another provider with a write-through
+// LRU cache of the versioned values of their keys
+type VersionedDBProvider struct {
+	dbProvider statedb.VersionedDBProvider
+	cacheSize  int
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider, with a cache of `cacheSize`
+// keys per VersionedDB
+func NewVersionedDBProvider(dbProvider statedb.VersionedDBProvider, cacheSize int) *VersionedDBProvider {
+	logger.Debugf("constructing VersionedDBProvider cacheSize=%d", cacheSize)
+	return &VersionedDBProvider{dbProvider: dbProvider, cacheSize: cacheSize}
+}
+
+// GetDBHandle gets the handle to a named database
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	db, err := provider.dbProvider.GetDBHandle(dbName)
+	if err != nil {
+		return nil, err
+	}
+	scope := metrics.GetRootScope().SubScope("statecache").Tagged(map[string]string{"channel": dbName})
+	return newVersionedDB(db, provider.cacheSize, scope), nil
+}
+
+// Close closes the wrapped provider
+func (provider *VersionedDBProvider) Close() {
+	provider.dbProvider.Close()
+}
+
+// versionedDB caches the values read from and written to the wrapped VersionedDB.
+// Range scans and queries are not cached
+type versionedDB struct {
+	statedb.VersionedDB
+	// lock is held exclusively while updates are applied, so that a value read from
+	// the wrapped db before the updates is not cached after them
+	lock sync.RWMutex
+	// cacheLock guards the cache, which is updated by concurrent reads
+	cacheLock sync.Mutex
+	cache     *lruCache
+	// exactValues tells whether the wrapped db returns the values exactly as they were
+	// written. CouchDB stores the JSON values as documents, which may be read back reformatted,
+	// so that only the versions of the keys written are cached
+	exactValues bool
+
+	hits    metrics.Counter
+	misses  metrics.Counter
+	entries metrics.Gauge
+}
+
+func newVersionedDB(db statedb.VersionedDB, cacheSize int, scope metrics.Scope) *versionedDB {
+	return &versionedDB{
+		VersionedDB: db,
+		cache:       newLRUCache(cacheSize),
+		exactValues: db.BytesKeySuppoted(),
+		hits:        scope.Counter("hits"),
+		misses:      scope.Counter("misses"),
+		entries:     scope.Gauge("entries"),
+	}
+}
+
+// Unwrap implements method in statedb.Unwrapper interface
+func (vdb *versionedDB) Unwrap() statedb.VersionedDB {
+	return vdb.VersionedDB
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	vdb.lock.RLock()
+	defer vdb.lock.RUnlock()
+
+	compositeKey := statedb.CompositeKey{Namespace: namespace, Key: key}
+	if val := vdb.getCached(compositeKey); val != nil && !val.versionOnly {
+		vdb.hits.Inc(1)
+		return val.vv, nil
+	}
+	vdb.misses.Inc(1)
+	vv, err := vdb.VersionedDB.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	vdb.putCached([]statedb.CompositeKey{compositeKey}, []*cachedValue{{vv: vv}})
+	return vv, nil
+}
+
+// GetVersion implements method in VersionedDB interface. The versions of the keys
+// which are not cached are read from the wrapped db without being cached, as their values
+// are not read
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	vdb.lock.RLock()
+	defer vdb.lock.RUnlock()
+
+	if val := vdb.getCached(statedb.CompositeKey{Namespace: namespace, Key: key}); val != nil {
+		vdb.hits.Inc(1)
+		if val.vv == nil {
+			return nil, nil
+		}
+		return val.vv.Version, nil
+	}
+	vdb.misses.Inc(1)
+	return vdb.VersionedDB.GetVersion(namespace, key)
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vdb.lock.RLock()
+	defer vdb.lock.RUnlock()
+
+	vals := make([]*statedb.VersionedValue, len(keys))
+	var missingKeys []string
+	var missingIndexes []int
+	for i, key := range keys {
+		if val := vdb.getCached(statedb.CompositeKey{Namespace: namespace, Key: key}); val != nil && !val.versionOnly {
+			vals[i] = val.vv
+			continue
+		}
+		missingKeys = append(missingKeys, key)
+		missingIndexes = append(missingIndexes, i)
+	}
+	vdb.hits.Inc(int64(len(keys) - len(missingKeys)))
+	if len(missingKeys) == 0 {
+		return vals, nil
+	}
+	vdb.misses.Inc(int64(len(missingKeys)))
+
+	missingVals, err := vdb.VersionedDB.GetStateMultipleKeys(namespace, missingKeys)
+	if err != nil {
+		return nil, err
+	}
+	compositeKeys := make([]statedb.CompositeKey, len(missingKeys))
+	cachedVals := make([]*cachedValue, len(missingKeys))
+	for i, key := range missingKeys {
+		compositeKeys[i] = statedb.CompositeKey{Namespace: namespace, Key: key}
+		cachedVals[i] = &cachedValue{vv: missingVals[i]}
+		vals[missingIndexes[i]] = missingVals[i]
+	}
+	vdb.putCached(compositeKeys, cachedVals)
+	return vals, nil
+}
+
+// ApplyUpdates implements method in VersionedDB interface. The cache is updated with
+// the batch once the batch is applied to the wrapped db. The cache is emptied when the
+// batch fails to be applied, as the batch may have been partially applied
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.lock.Lock()
+	defer vdb.lock.Unlock()
+
+	if err := vdb.VersionedDB.ApplyUpdates(batch, height); err != nil {
+		vdb.cacheLock.Lock()
+		vdb.cache.purge()
+		vdb.entries.Update(0)
+		vdb.cacheLock.Unlock()
+		return err
+	}
+
+	var keys []statedb.CompositeKey
+	var vals []*cachedValue
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		for key, vv := range batch.GetUpdates(ns) {
+			keys = append(keys, statedb.CompositeKey{Namespace: ns, Key: key})
+			if vv.Value == nil {
+				// a deleted key is absent from the db
+				vals = append(vals, &cachedValue{})
+			} else {
+				vals = append(vals, &cachedValue{vv: vv, versionOnly: !vdb.exactValues})
+			}
+		}
+	}
+	vdb.putCached(keys, vals)
+	return nil
+}
+
+// getCached returns the cached value of a key, or nil. The versioned value is a copy,
+// so that the callers cannot modify the cache
+func (vdb *versionedDB) getCached(key statedb.CompositeKey) *cachedValue {
+	vdb.cacheLock.Lock()
+	defer vdb.cacheLock.Unlock()
+	val := vdb.cache.get(key)
+	if val == nil || val.vv == nil {
+		return val
+	}
+	return &cachedValue{vv: copyVersionedValue(val.vv), versionOnly: val.versionOnly}
+}
+
+func (vdb *versionedDB) putCached(keys []statedb.CompositeKey, vals []*cachedValue) {
+	vdb.cacheLock.Lock()
+	defer vdb.cacheLock.Unlock()
+	for i, key := range keys {
+		val := vals[i]
+		if val.vv != nil {
+			val = &cachedValue{vv: copyVersionedValue(val.vv), versionOnly: val.versionOnly}
+		}
+		vdb.cache.put(key, val)
+	}
+	vdb.entries.Update(float64(vdb.cache.len()))
+}
+
+func copyVersionedValue(vv *statedb.VersionedValue) *statedb.VersionedValue {
+	return &statedb.VersionedValue{Value: vv.Value, Version: vv.Version}
+}
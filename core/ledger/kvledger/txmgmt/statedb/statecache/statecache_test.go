@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecache
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/txmgmt/statedb/statecache")
+	os.Exit(m.Run())
+}
+
+// the cache is small so that the keys are evicted by the common tests
+func TestCommonTests(t *testing.T) {
+	testCases := []struct {
+		name string
+		test func(t *testing.T, dbProvider statedb.VersionedDBProvider)
+	}{
+		{"BasicRW", commontests.TestBasicRW},
+		{"MultiDBBasicRW", commontests.TestMultiDBBasicRW},
+		{"Deletes", commontests.TestDeletes},
+		{"Iterator", commontests.TestIterator},
+		{"GetStateMultipleKeys", commontests.TestGetStateMultipleKeys},
+		{"GetVersion", commontests.TestGetVersion},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := stateleveldb.NewTestVDBEnv(t)
+			defer env.Cleanup()
+			tc.test(t, NewVersionedDBProvider(env.DBProvider, 2))
+		})
+	}
+}
+
+// countingDB counts the reads of the wrapped db
+type countingDB struct {
+	statedb.VersionedDB
+	exactValues    bool
+	applyErr       error
+	getStateCalls  int
+	getVersionCall int
+}
+
+func (db *countingDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	db.getStateCalls++
+	return db.VersionedDB.GetState(namespace, key)
+}
+
+func (db *countingDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	db.getVersionCall++
+	return db.VersionedDB.GetVersion(namespace, key)
+}
+
+func (db *countingDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	db.getStateCalls += len(keys)
+	return db.VersionedDB.GetStateMultipleKeys(namespace, keys)
+}
+
+func (db *countingDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	if db.applyErr != nil {
+		return db.applyErr
+	}
+	return db.VersionedDB.ApplyUpdates(batch, height)
+}
+
+func (db *countingDB) BytesKeySuppoted() bool {
+	return db.exactValues
+}
+
+func newCountingDB(t *testing.T, env *stateleveldb.TestVDBEnv, exactValues bool) *countingDB {
+	db, err := env.DBProvider.GetDBHandle("testcache")
+	assert.NoError(t, err)
+	return &countingDB{VersionedDB: db, exactValues: exactValues}
+}
+
+func TestCachedReadsAndWrites(t *testing.T) {
+	env := stateleveldb.NewTestVDBEnv(t)
+	defer env.Cleanup()
+	countingDB := newCountingDB(t, env, true)
+	db := newVersionedDB(countingDB, 3, metrics.GetRootScope())
+	assert.Equal(t, countingDB, statedb.Unwrap(db))
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	// the keys written are cached
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+	ver, err := db.GetVersion("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 2), ver)
+	assert.Equal(t, 0, countingDB.getStateCalls)
+	assert.Equal(t, 0, countingDB.getVersionCall)
+
+	// a modification of a value returned does not modify the cache
+	vv.Version = version.NewHeight(5, 5)
+	vv, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), vv.Version)
+
+	// an absent key is cached after being read
+	for i := 0; i < 2; i++ {
+		vv, err = db.GetState("ns1", "key3")
+		assert.NoError(t, err)
+		assert.Nil(t, vv)
+	}
+	assert.Equal(t, 1, countingDB.getStateCalls)
+
+	// a deleted key is cached as absent
+	batch = statedb.NewUpdateBatch()
+	batch.Delete("ns1", "key1", version.NewHeight(2, 1))
+	batch.Put("ns1", "key2", []byte("value2.1"), version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 2)))
+	vals, err := db.GetStateMultipleKeys("ns1", []string{"key1", "key2", "key3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*statedb.VersionedValue{nil, {Value: []byte("value2.1"), Version: version.NewHeight(2, 2)}, nil}, vals)
+	assert.Equal(t, 1, countingDB.getStateCalls)
+
+	// the least recently used key is evicted
+	vv, err = db.GetState("ns2", "key4")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	assert.Equal(t, 2, countingDB.getStateCalls)
+	vals, err = db.GetStateMultipleKeys("ns1", []string{"key1", "key2", "key3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*statedb.VersionedValue{nil, {Value: []byte("value2.1"), Version: version.NewHeight(2, 2)}, nil}, vals)
+	assert.Equal(t, 3, countingDB.getStateCalls)
+	assert.Equal(t, 3, db.cache.len())
+
+	// the cache is emptied when a batch fails to be applied
+	countingDB.applyErr = errors.New("failed to apply the batch")
+	assert.Error(t, db.ApplyUpdates(batch, version.NewHeight(3, 1)))
+	assert.Equal(t, 0, db.cache.len())
+}
+
+func TestCachedVersionsOnly(t *testing.T) {
+	env := stateleveldb.NewTestVDBEnv(t)
+	defer env.Cleanup()
+	countingDB := newCountingDB(t, env, false)
+	db := newVersionedDB(countingDB, 10, metrics.GetRootScope())
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"a": 1}`), version.NewHeight(1, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+
+	// the values written are read from the db, which may have reformatted them
+	ver, err := db.GetVersion("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), ver)
+	assert.Equal(t, 0, countingDB.getVersionCall)
+	for i := 0; i < 2; i++ {
+		vv, err := db.GetState("ns1", "key1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`{"a": 1}`), vv.Value)
+	}
+	assert.Equal(t, 1, countingDB.getStateCalls)
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	key1 := statedb.CompositeKey{Namespace: "ns", Key: "key1"}
+	key2 := statedb.CompositeKey{Namespace: "ns", Key: "key2"}
+	key3 := statedb.CompositeKey{Namespace: "ns", Key: "key3"}
+	cache.put(key1, &cachedValue{})
+	cache.put(key2, &cachedValue{})
+	assert.NotNil(t, cache.get(key1))
+	cache.put(key3, &cachedValue{})
+	assert.Nil(t, cache.get(key2))
+	assert.NotNil(t, cache.get(key1))
+	assert.NotNil(t, cache.get(key3))
+	assert.Equal(t, 2, cache.len())
+	cache.purge()
+	assert.Nil(t, cache.get(key1))
+	assert.Equal(t, 0, cache.len())
+}
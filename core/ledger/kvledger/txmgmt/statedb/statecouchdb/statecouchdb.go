@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -79,7 +80,13 @@ func NewVersionedDBProvider() (*VersionedDBProvider, error) {
 		return nil, err
 	}
 
-	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
+	return NewVersionedDBProviderWithInstance(couchInstance), nil
+}
+
+// NewVersionedDBProviderWithInstance instantiates VersionedDBProvider over a CouchDB instance
+// which is not configured by the peer configuration
+func NewVersionedDBProviderWithInstance(couchInstance *couchdb.CouchInstance) *VersionedDBProvider {
+	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}
 }
 
 //HandleChaincodeDeploy initializes database artifacts for the database associated with the namespace
@@ -1126,6 +1133,26 @@ func (vdb *VersionedDB) recordSavepoint(height *version.Height, namespaces []str
 	return nil
 }
 
+// GetNamespaces implements method in NamespaceLister interface. The namespaces are recovered from the
+// names of the namespace databases of the channel, skipping the databases whose name was truncated
+func (vdb *VersionedDB) GetNamespaces() ([]string, error) {
+	dbNames, err := vdb.couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for _, dbName := range dbNames {
+		namespace, ok := couchdb.ParseNamespaceDBName(vdb.chainName, dbName)
+		if !ok {
+			logger.Debugf("Skipping database [%s] while listing the namespaces of channel [%s]", dbName, vdb.chainName)
+			continue
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *VersionedDB) GetLatestSavePoint() (*version.Height, error) {
 
@@ -62,6 +62,29 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+// NamespaceLister is implemented by the databases which can enumerate the namespaces holding data,
+// including the namespaces holding the private data and the hashes of the private data
+type NamespaceLister interface {
+	GetNamespaces() ([]string, error)
+}
+
+// Unwrapper is implemented by the databases wrapping another database, such as a cache,
+// so that the optional interfaces implemented by the wrapped database remain reachable
+type Unwrapper interface {
+	Unwrap() VersionedDB
+}
+
+// Unwrap returns the innermost database wrapped by db, or db if it does not wrap a database
+func Unwrap(db VersionedDB) VersionedDB {
+	for {
+		unwrapper, ok := db.(Unwrapper)
+		if !ok {
+			return db
+		}
+		db = unwrapper.Unwrap()
+	}
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -36,6 +36,14 @@ func NewVersionedDBProvider() *VersionedDBProvider {
 	return &VersionedDBProvider{dbProvider}
 }
 
+// NewReadOnlyVersionedDBProvider instantiates VersionedDBProvider over an existing db at dbPath,
+// which is never written to. This allows to inspect the state of a stopped peer
+func NewReadOnlyVersionedDBProvider(dbPath string) *VersionedDBProvider {
+	logger.Debugf("constructing read only VersionedDBProvider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, ReadOnly: true})
+	return &VersionedDBProvider{dbProvider}
+}
+
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
 	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
@@ -174,6 +182,21 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// GetNamespaces implements method in NamespaceLister interface
+func (vdb *versionedDB) GetNamespaces() ([]string, error) {
+	dbItr := vdb.db.GetIterator(nil, nil)
+	defer dbItr.Release()
+	var namespaces []string
+	for dbItr.Next() {
+		ns, _ := splitCompositeKey(dbItr.Key())
+		// the savepoint key falls in the empty namespace
+		if ns != "" && (len(namespaces) == 0 || namespaces[len(namespaces)-1] != ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, dbItr.Error()
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/spf13/viper"
 )
 
@@ -130,3 +131,34 @@ func TestUtilityFunctions(t *testing.T) {
 	// ValidateKeyValue should return nil for a valid key and value
 	testutil.AssertNoError(t, db.ValidateKeyValue("testKey", []byte("testValue")), "leveldb should accept all key-values")
 }
+
+func TestGetNamespacesOnReadOnlyDB(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testnamespaces")
+	testutil.AssertNoError(t, err, "")
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 2))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns1$$hcoll1", string([]byte{0x00, 0x01}), []byte("valuehash"), version.NewHeight(1, 3))
+	testutil.AssertNoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)), "")
+	env.DBProvider.Close()
+
+	readOnlyProvider := NewReadOnlyVersionedDBProvider(ledgerconfig.GetStateLevelDBPath())
+	defer readOnlyProvider.Close()
+	db, err = readOnlyProvider.GetDBHandle("testnamespaces")
+	testutil.AssertNoError(t, err, "")
+	namespaces, err := db.(statedb.NamespaceLister).GetNamespaces()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, namespaces, []string{"ns1", "ns1$$hcoll1", "ns2"})
+	vv, err := db.GetState("ns1", "key2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, vv.Value, []byte("value2"))
+
+	db, err = readOnlyProvider.GetDBHandle("emptydb")
+	testutil.AssertNoError(t, err, "")
+	namespaces, err = db.(statedb.NamespaceLister).GetNamespaces()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(namespaces), 0)
+}
@@ -61,7 +61,7 @@ func (h *queryHelper) getStateMultipleKeys(namespace string, keys []string) ([][
 	}
 	versionedValues, err := h.txmgr.db.GetStateMultipleKeys(namespace, keys)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	values := make([][]byte, len(versionedValues))
 	for i, versionedValue := range versionedValues {
@@ -134,11 +134,20 @@ func (h *queryHelper) getPrivateDataMultipleKeys(ns, coll string, keys []string)
 	}
 	versionedValues, err := h.txmgr.db.GetPrivateDataMultipleKeys(ns, coll, keys)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	values := make([][]byte, len(versionedValues))
 	for i, versionedValue := range versionedValues {
 		val, ver := decomposeVersionedValue(versionedValue)
+		hashVersion, err := h.txmgr.db.GetKeyHashVersion(ns, coll, util.ComputeStringHash(keys[i]))
+		if err != nil {
+			return nil, err
+		}
+		if !version.AreSame(hashVersion, ver) {
+			return nil, &txmgr.ErrPvtdataNotAvailable{Msg: fmt.Sprintf(
+				"Private data matching public hash version is not available for key %s. Public hash version = %#v, Private data version = %#v",
+				keys[i], hashVersion, ver)}
+		}
 		if h.rwsetBuilder != nil {
 			h.rwsetBuilder.AddToHashedReadSet(ns, coll, keys[i], ver)
 		}
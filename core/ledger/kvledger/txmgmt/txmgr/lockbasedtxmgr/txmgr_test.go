@@ -687,6 +687,14 @@ func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	val, err = simulator.GetPrivateData("ns1", "coll4", "key4")
 	testutil.AssertNoError(t, err, "")
 	testutil.AssertNil(t, val)
+
+	_, err = simulator.GetPrivateDataMultipleKeys("ns1", "coll1", []string{"key1"})
+	_, ok = err.(*txmgr.ErrPvtdataNotAvailable)
+	testutil.AssertEquals(t, ok, true)
+
+	vals, err := simulator.GetPrivateDataMultipleKeys("ns1", "coll3", []string{"key3", "key4"})
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, vals, [][]byte{[]byte("value3"), nil})
 }
 
 func TestDeleteOnCursor(t *testing.T) {
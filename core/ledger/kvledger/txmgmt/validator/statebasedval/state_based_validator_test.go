@@ -112,7 +112,7 @@ func TestValidatorBulkLoadingOfCache(t *testing.T) {
 	if validator.db.IsBulkOptimizable() {
 
 		commonStorageDB := validator.db.(*privacyenabledstate.CommonStorageDB)
-		bulkOptimizable, _ := commonStorageDB.VersionedDB.(statedb.BulkOptimizable)
+		bulkOptimizable, _ := statedb.Unwrap(commonStorageDB.VersionedDB).(statedb.BulkOptimizable)
 
 		// Clear cache loaded during ApplyPrivacyAwareUpdates()
 		validator.db.ClearCachedVersions()
@@ -17,7 +17,10 @@ limitations under the License.
 package ledger
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -53,6 +56,18 @@ type PeerLedger interface {
 	GetBlockByTxID(txID string) (*common.Block, error)
 	// GetTxValidationCodeByTxID returns reason code of transaction validation
 	GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// GetTxsByCreator returns an iterator over the transactions submitted by a creator (a serialized identity),
+	// starting from the transaction `startTxNum` of the block `startBlockNum`. The results are of type
+	// *peer.IndexedTransaction
+	GetTxsByCreator(creator []byte, startBlockNum, startTxNum uint64) (commonledger.ResultsIterator, error)
+	// GetTxsByChaincode returns an iterator over the transactions invoking a chaincode, starting from the
+	// transaction `startTxNum` of the block `startBlockNum`. The results are of type *peer.IndexedTransaction
+	GetTxsByChaincode(chaincodeName string, startBlockNum, startTxNum uint64) (commonledger.ResultsIterator, error)
+	// GetTxsByTimestamp returns an iterator over the transactions with a timestamp in the range
+	// [startTime, endTime), in the order of their timestamps. The transactions with the timestamp `startTime`
+	// start from the transaction `startTxNum` of the block `startBlockNum`. The results are of type
+	// *peer.IndexedTransaction
+	GetTxsByTimestamp(startTime, endTime time.Time, startBlockNum, startTxNum uint64) (commonledger.ResultsIterator, error)
 	// NewTxSimulator gives handle to a transaction simulator.
 	// A client can obtain more than one 'TxSimulator's for parallel execution.
 	// Any snapshoting/synchronization should be performed at the implementation level if required
@@ -136,6 +151,48 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithOptions retrieves the history of values for a key, restricted and ordered
+	// as specified by the supplied options. A nil options behaves like `GetHistoryForKey`.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithOptions(namespace string, key string, options *HistoryQueryOptions) (commonledger.ResultsIterator, error)
+	// GetPrivateDataHashHistoryForKey retrieves the history of value hashes for a private data key,
+	// identified by the hash of the key. The history is only available if the private data hash history is enabled.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult,
+	// with the field `Value` carrying the hash of the value
+	GetPrivateDataHashHistoryForKey(namespace, collection string, keyHash []byte, options *HistoryQueryOptions) (commonledger.ResultsIterator, error)
+}
+
+// HistoryQueryOptions restricts and orders the results of a history query.
+// A zero value for a field means that the corresponding restriction is not applied
+type HistoryQueryOptions struct {
+	// StartBlock is the lowest block number (inclusive) to include
+	StartBlock uint64
+	// EndBlock is the highest block number (inclusive) to include. Zero means no upper bound
+	EndBlock uint64
+	// StartTime excludes the modifications made by transactions with an earlier timestamp
+	StartTime *timestamp.Timestamp
+	// EndTime excludes the modifications made by transactions with a later timestamp
+	EndTime *timestamp.Timestamp
+	// Reverse returns the results from newest to oldest
+	Reverse bool
+	// Limit is the maximum number of results to return
+	Limit uint32
+}
+
+// NewHistoryQueryOptions constructs the HistoryQueryOptions from their protobuf representation.
+// A nil input yields nil options, which do not restrict the results
+func NewHistoryQueryOptions(options *peer.HistoryQueryOptions) *HistoryQueryOptions {
+	if options == nil {
+		return nil
+	}
+	return &HistoryQueryOptions{
+		StartBlock: options.StartBlock,
+		EndBlock:   options.EndBlock,
+		StartTime:  options.StartTime,
+		EndTime:    options.EndTime,
+		Reverse:    options.Reverse,
+		Limit:      options.Limit,
+	}
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
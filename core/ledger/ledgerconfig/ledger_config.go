@@ -42,9 +42,14 @@ const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
 const confQueryLimit = "ledger.state.couchDBConfig.queryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
+const confEnablePvtDataHashHistory = "ledger.history.enablePrivateDataHashHistory"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confCompressArchivedBlockfiles = "ledger.blockchain.archive.compress"
+const confBlockfilesArchiveDir = "ledger.blockchain.archive.archiveDir"
+const confKeepBlockfiles = "ledger.blockchain.archive.keepBlockfiles"
+const confStateCacheSize = "ledger.state.cacheSize"
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -88,6 +93,38 @@ func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
 }
 
+//IsBlockfileCompressionEnabled exposes the archive.compress variable.
+//If enabled, the completed block files are compressed
+func IsBlockfileCompressionEnabled() bool {
+	return viper.GetBool(confCompressArchivedBlockfiles)
+}
+
+//GetBlockfilesArchiveDir exposes the archive.archiveDir variable.
+//If set, the completed block files are moved to this directory
+func GetBlockfilesArchiveDir() string {
+	return config.GetPath(confBlockfilesArchiveDir)
+}
+
+//GetKeepBlockfiles exposes the archive.keepBlockfiles variable, the number of the most
+//recently completed block files which are not archived
+func GetKeepBlockfiles() int {
+	keepBlockfiles := viper.GetInt(confKeepBlockfiles)
+	if keepBlockfiles < 0 {
+		keepBlockfiles = 0
+	}
+	return keepBlockfiles
+}
+
+//GetStateCacheSize exposes the state cacheSize variable, the number of keys per channel
+//whose versioned values are cached in front of the state database. 0 disables the cache
+func GetStateCacheSize() int {
+	cacheSize := viper.GetInt(confStateCacheSize)
+	if cacheSize < 0 {
+		cacheSize = 0
+	}
+	return cacheSize
+}
+
 //GetQueryLimit exposes the queryLimit variable
 func GetQueryLimit() int {
 	queryLimit := viper.GetInt(confQueryLimit)
@@ -113,6 +150,12 @@ func IsHistoryDBEnabled() bool {
 	return viper.GetBool(confEnableHistoryDatabase)
 }
 
+//IsPvtDataHashHistoryEnabled exposes the enablePrivateDataHashHistory variable.
+//The history of private data hashes is only tracked if the history database is enabled as well
+func IsPvtDataHashHistoryEnabled() bool {
+	return IsHistoryDBEnabled() && viper.GetBool(confEnablePvtDataHashHistory)
+}
+
 // IsQueryReadsHashingEnabled enables or disables computing of hash
 // of range query results for phantom item validation
 func IsQueryReadsHashingEnabled() bool {
@@ -128,6 +128,17 @@ func TestIsHistoryDBEnabledFalse(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, false) //test config returns false
 }
 
+func TestIsPvtDataHashHistoryEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, IsPvtDataHashHistoryEnabled(), false) //test default config is false
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	viper.Set("ledger.history.enablePrivateDataHashHistory", true)
+	testutil.AssertEquals(t, IsPvtDataHashHistoryEnabled(), true)
+	viper.Set("ledger.history.enableHistoryDatabase", false)
+	testutil.AssertEquals(t, IsPvtDataHashHistoryEnabled(), false) //requires the history database
+}
+
 func TestIsAutoWarmIndexesEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsAutoWarmIndexesEnabled()
@@ -164,6 +175,32 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, 10)
 }
 
+func TestBlockfileArchivingDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	testutil.AssertEquals(t, IsBlockfileCompressionEnabled(), false)
+	testutil.AssertEquals(t, GetBlockfilesArchiveDir(), "")
+	testutil.AssertEquals(t, GetKeepBlockfiles(), 1)
+}
+
+func TestBlockfileArchiving(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.blockchain.archive.compress", true)
+	viper.Set("ledger.blockchain.archive.archiveDir", "/mnt/archive")
+	viper.Set("ledger.blockchain.archive.keepBlockfiles", -1)
+	testutil.AssertEquals(t, IsBlockfileCompressionEnabled(), true)
+	testutil.AssertEquals(t, GetBlockfilesArchiveDir(), "/mnt/archive")
+	testutil.AssertEquals(t, GetKeepBlockfiles(), 0)
+}
+
+func TestGetStateCacheSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetStateCacheSize(), 10000)
+	viper.Set("ledger.state.cacheSize", -1)
+	testutil.AssertEquals(t, GetStateCacheSize(), 0)
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
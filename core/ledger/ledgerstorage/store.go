@@ -51,10 +51,18 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrBlockNumTranNum,
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
+		blkstorage.IndexableAttrTxCreator,
+		blkstorage.IndexableAttrTxChaincode,
+		blkstorage.IndexableAttrTxTimestamp,
 	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConfWithArchiving(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(),
+			&fsblkstorage.ArchiveConf{
+				Compress:       ledgerconfig.IsBlockfileCompressionEnabled(),
+				ArchiveDir:     ledgerconfig.GetBlockfilesArchiveDir(),
+				KeepBlockfiles: ledgerconfig.GetKeepBlockfiles(),
+			}),
 		indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
@@ -94,6 +94,10 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.blockchain.archive.compress", false)
+	viper.Set("ledger.blockchain.archive.archiveDir", "")
+	viper.Set("ledger.blockchain.archive.keepBlockfiles", 1)
+	viper.Set("ledger.state.cacheSize", 10000)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
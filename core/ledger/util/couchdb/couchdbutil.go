@@ -256,6 +256,27 @@ func mapAndValidateDatabaseName(databaseName string) (string, error) {
 	return databaseName, nil
 }
 
+// ParseNamespaceDBName returns the namespace whose data is stored in the database `dbName`
+// of the channel `chainName`. It returns false if the database does not hold the data of a
+// namespace of the channel, or if the namespace cannot be recovered because the database name
+// was truncated
+func ParseNamespaceDBName(chainName, dbName string) (string, bool) {
+	prefix := strings.Replace(chainName, ".", "$", -1) + "_"
+	if len(dbName) <= len(prefix) || !strings.HasPrefix(dbName, prefix) {
+		return "", false
+	}
+	escapedNamespace := dbName[len(prefix):]
+	if strings.Contains(escapedNamespace, "(") {
+		return "", false
+	}
+	// '$$' joins the namespace and the collection, and is not an escape sequence
+	names := strings.Split(escapedNamespace, "$$")
+	for i, name := range names {
+		names[i] = unescapeUpperCase(name)
+	}
+	return strings.Join(names, "$$"), true
+}
+
 // escapeUpperCase replaces every upper case letter with a '$' and the respective
 // lower-case letter
 func escapeUpperCase(dbName string) string {
@@ -263,3 +284,11 @@ func escapeUpperCase(dbName string) string {
 	dbName = re.ReplaceAllString(dbName, "$$"+"$1")
 	return strings.ToLower(dbName)
 }
+
+// unescapeUpperCase reverts escapeUpperCase
+func unescapeUpperCase(dbName string) string {
+	re := regexp.MustCompile(`\$([a-z])`)
+	return re.ReplaceAllStringFunc(dbName, func(escaped string) string {
+		return strings.ToUpper(escaped[1:])
+	})
+}
@@ -179,3 +179,24 @@ func TestConstructedNamespaceDBName(t *testing.T) {
 	testutil.AssertEquals(t, len(constructedDBName), expectedDBNameLength)
 	testutil.AssertEquals(t, constructedDBName, expectedDBName)
 }
+
+func TestParseNamespaceDBName(t *testing.T) {
+	for _, namespace := range []string{"mycc", "myCC", "my-cc_1$$hColl", "lscc", "myCC$$pcOLL"} {
+		dbName, err := mapAndValidateDatabaseName(ConstructNamespaceDBName("my.chain", namespace))
+		testutil.AssertNoError(t, err, "")
+		parsed, ok := ParseNamespaceDBName("my.chain", dbName)
+		testutil.AssertEquals(t, ok, true)
+		testutil.AssertEquals(t, parsed, namespace)
+	}
+
+	// the metadata database and the databases of other channels do not hold namespaces of the channel
+	_, ok := ParseNamespaceDBName("mychain", ConstructMetadataDBName("mychain"))
+	testutil.AssertEquals(t, ok, false)
+	_, ok = ParseNamespaceDBName("mychain", ConstructNamespaceDBName("otherchain", "mycc"))
+	testutil.AssertEquals(t, ok, false)
+
+	// truncated names cannot be reverted
+	longNamespace := fmt.Sprintf("%0250d", 0)
+	_, ok = ParseNamespaceDBName("mychain", ConstructNamespaceDBName("mychain", longNamespace))
+	testutil.AssertEquals(t, ok, false)
+}
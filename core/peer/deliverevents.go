@@ -64,9 +64,10 @@ func (brs *blockResponseSender) SendStatusResponse(status common.Status) error {
 }
 
 // SendBlockResponse generates deliver response with block message
-func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
+func (brs *blockResponseSender) SendBlockResponse(block *common.Block, resumeToken []byte) error {
 	response := &peer.DeliverResponse{
-		Type: &peer.DeliverResponse_Block{Block: block},
+		Type:        &peer.DeliverResponse_Block{Block: block},
+		ResumeToken: resumeToken,
 	}
 	return brs.Send(response)
 }
@@ -84,7 +85,7 @@ func (fbrs *filteredBlockResponseSender) SendStatusResponse(status common.Status
 }
 
 // SendBlockResponse generates deliver response with block message
-func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
+func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block, resumeToken []byte) error {
 	// Generates filtered block response
 	b := blockEvent(*block)
 	filteredBlock, err := b.toFilteredBlock()
@@ -93,7 +94,8 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
 	}
 	response := &peer.DeliverResponse{
-		Type: &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+		Type:        &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+		ResumeToken: resumeToken,
 	}
 	return fbrs.Send(response)
 }
@@ -19,13 +19,18 @@ package qscc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -35,6 +40,10 @@ import (
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetHistoryForKey returns the history of a key
+// - GetTransactionsByCreator returns the transactions submitted by an identity
+// - GetTransactionsByChaincode returns the transactions invoking a chaincode
+// - GetTransactionsByTimestamp returns the transactions within a time window
 type LedgerQuerier struct {
 }
 
@@ -47,8 +56,22 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+	GetHistoryForKey   string = "GetHistoryForKey"
+
+	GetTransactionsByCreator   string = "GetTransactionsByCreator"
+	GetTransactionsByChaincode string = "GetTransactionsByChaincode"
+	GetTransactionsByTimestamp string = "GetTransactionsByTimestamp"
 )
 
+// maxHistoryResults is the maximum number of history results returned
+// by GetHistoryForKey, more results are signalled by QueryResponse.HasMore
+const maxHistoryResults = 1000
+
+// maxTransactionsResults is the maximum number of transactions returned by
+// GetTransactionsByCreator, GetTransactionsByChaincode and GetTransactionsByTimestamp,
+// more results are signalled by QueryResponse.HasMore
+const maxTransactionsResults = 1000
+
 // Init is called once per chain when the chain is created.
 // This allows the chaincode to initialize any variables on the ledger prior
 // to any transaction execution on the chain.
@@ -65,6 +88,18 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetHistoryForKey: Return a QueryResponse with the history of the key of the
+//   namespace in args[2] that is specified by the marshalled GetHistoryForKey in args[3]
+// # GetTransactionsByCreator: Return a QueryResponse with the IndexedTransactions submitted
+//   by the serialized identity in args[2], from the optional block number in args[3] and
+//   transaction number in args[4]
+// # GetTransactionsByChaincode: Return a QueryResponse with the IndexedTransactions invoking
+//   the chaincode in args[2], from the optional block number in args[3] and transaction
+//   number in args[4]
+// # GetTransactionsByTimestamp: Return a QueryResponse with the IndexedTransactions with a
+//   timestamp from the RFC3339 time in args[2] (inclusive) to the one in args[3] (exclusive).
+//   The transactions with the timestamp in args[2] start from the optional block number in
+//   args[4] and transaction number in args[5]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -78,6 +113,10 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
 	}
 
+	if (fname == GetHistoryForKey || fname == GetTransactionsByTimestamp) && len(args) < 4 {
+		return shim.Error(fmt.Sprintf("missing 4th argument for %s", fname))
+	}
+
 	targetLedger := peer.GetLedger(cid)
 	if targetLedger == nil {
 		return shim.Error(fmt.Sprintf("Invalid chain ID, %s", cid))
@@ -109,6 +148,14 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetHistoryForKey:
+		return getHistoryForKey(targetLedger, string(args[2]), args[3])
+	case GetTransactionsByCreator:
+		return getTransactionsByCreator(targetLedger, args[2], args[3:])
+	case GetTransactionsByChaincode:
+		return getTransactionsByChaincode(targetLedger, string(args[2]), args[3:])
+	case GetTransactionsByTimestamp:
+		return getTransactionsByTimestamp(targetLedger, string(args[2]), string(args[3]), args[4:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -208,6 +255,162 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getHistoryForKey(vledger ledger.PeerLedger, namespace string, rawRequest []byte) pb.Response {
+	if namespace == "" {
+		return shim.Error("Namespace must not be empty.")
+	}
+	request := &pb.GetHistoryForKey{}
+	if err := proto.Unmarshal(rawRequest, request); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal history request, error %s", err))
+	}
+
+	historyQueryExecutor, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor, error %s", err))
+	}
+
+	options := ledger.NewHistoryQueryOptions(request.Options)
+	var itr commonledger.ResultsIterator
+	if request.Collection != "" {
+		itr, err = historyQueryExecutor.GetPrivateDataHashHistoryForKey(namespace, request.Collection,
+			ledgerutil.ComputeStringHash(request.Key), options)
+	} else {
+		itr, err = historyQueryExecutor.GetHistoryForKeyWithOptions(namespace, request.Key, options)
+	}
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history for key %s, error %s", request.Key, err))
+	}
+	defer itr.Close()
+
+	response := &pb.QueryResponse{}
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get history for key %s, error %s", request.Key, err))
+		}
+		if result == nil {
+			break
+		}
+		if len(response.Results) == maxHistoryResults {
+			response.HasMore = true
+			break
+		}
+		resultBytes, err := utils.Marshal(result.(*queryresult.KeyModification))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		response.Results = append(response.Results, &pb.QueryResultBytes{ResultBytes: resultBytes})
+	}
+
+	bytes, err := utils.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+func getTransactionsByCreator(vledger ledger.PeerLedger, creator []byte, optArgs [][]byte) pb.Response {
+	if len(creator) == 0 {
+		return shim.Error("Creator must not be empty.")
+	}
+	startBlockNum, startTxNum, err := getStartPosition(optArgs)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	itr, err := vledger.GetTxsByCreator(creator, startBlockNum, startTxNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions by creator, error %s", err))
+	}
+	return getIndexedTransactions(itr)
+}
+
+func getTransactionsByChaincode(vledger ledger.PeerLedger, chaincodeName string, optArgs [][]byte) pb.Response {
+	if chaincodeName == "" {
+		return shim.Error("Chaincode name must not be empty.")
+	}
+	startBlockNum, startTxNum, err := getStartPosition(optArgs)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	itr, err := vledger.GetTxsByChaincode(chaincodeName, startBlockNum, startTxNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions of chaincode %s, error %s", chaincodeName, err))
+	}
+	return getIndexedTransactions(itr)
+}
+
+func getTransactionsByTimestamp(vledger ledger.PeerLedger, rawStartTime, rawEndTime string, optArgs [][]byte) pb.Response {
+	startTime, err := time.Parse(time.RFC3339Nano, rawStartTime)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start time %s, error %s", rawStartTime, err))
+	}
+	endTime, err := time.Parse(time.RFC3339Nano, rawEndTime)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end time %s, error %s", rawEndTime, err))
+	}
+	startBlockNum, startTxNum, err := getStartPosition(optArgs)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	itr, err := vledger.GetTxsByTimestamp(startTime, endTime, startBlockNum, startTxNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions by timestamp, error %s", err))
+	}
+	return getIndexedTransactions(itr)
+}
+
+// getStartPosition returns the block and transaction numbers in the optional arguments,
+// each defaulting to 0
+func getStartPosition(optArgs [][]byte) (uint64, uint64, error) {
+	var position [2]uint64
+	for i, name := range []string{"block", "transaction"} {
+		if len(optArgs) <= i || len(optArgs[i]) == 0 {
+			break
+		}
+		n, err := strconv.ParseUint(string(optArgs[i]), 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("Failed to parse start %s number with error %s", name, err)
+		}
+		position[i] = n
+	}
+	return position[0], position[1], nil
+}
+
+// getIndexedTransactions returns a QueryResponse with the transactions of an iterator.
+// The query can be resumed after the last transaction returned, from its block number
+// and the following transaction number, along with its timestamp for time windows
+func getIndexedTransactions(itr commonledger.ResultsIterator) pb.Response {
+	defer itr.Close()
+
+	response := &pb.QueryResponse{}
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get transactions, error %s", err))
+		}
+		if result == nil {
+			break
+		}
+		if len(response.Results) == maxTransactionsResults {
+			response.HasMore = true
+			break
+		}
+		resultBytes, err := utils.Marshal(result.(*pb.IndexedTransaction))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		response.Results = append(response.Results, &pb.QueryResultBytes{ResultBytes: resultBytes})
+	}
+
+	bytes, err := utils.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "QSCC." + fname
 }
@@ -20,7 +20,9 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -30,6 +32,7 @@ import (
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -325,6 +328,127 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetHistoryForKey(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	addBlockForTesting(t, chainid)
+
+	getHistoryForKey := func(namespace string, request *peer2.GetHistoryForKey) peer2.Response {
+		args := [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte(namespace), utils.MarshalOrPanic(request)}
+		prop := resetProvider(resources.QSCC_GetHistoryForKey, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", args, prop)
+	}
+
+	res := getHistoryForKey("ns1", &peer2.GetHistoryForKey{Key: "key1"})
+	require.Equal(t, int32(shim.OK), res.Status, "GetHistoryForKey failed with err: %s", res.Message)
+	response := &peer2.QueryResponse{}
+	require.NoError(t, proto.Unmarshal(res.Payload, response))
+	require.Len(t, response.Results, 1)
+	assert.False(t, response.HasMore)
+	kmod := &queryresult.KeyModification{}
+	require.NoError(t, proto.Unmarshal(response.Results[0].ResultBytes, kmod))
+	assert.Equal(t, []byte("value1"), kmod.Value)
+
+	// the key was only modified in block 1
+	res = getHistoryForKey("ns1", &peer2.GetHistoryForKey{Key: "key1", Options: &peer2.HistoryQueryOptions{StartBlock: 2}})
+	require.Equal(t, int32(shim.OK), res.Status, "GetHistoryForKey failed with err: %s", res.Message)
+	require.NoError(t, proto.Unmarshal(res.Payload, response))
+	assert.Len(t, response.Results, 0)
+
+	res = getHistoryForKey("ns1", &peer2.GetHistoryForKey{Key: "key1", Collection: "coll1"})
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed because private data hash history is disabled")
+
+	res = getHistoryForKey("", &peer2.GetHistoryForKey{Key: "key1"})
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed because the namespace is empty")
+
+	args := [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("garbage")}
+	res = stub.MockInvokeWithSignedProposal("2", args, resetProvider(resources.QSCC_GetHistoryForKey, chainid, &peer2.SignedProposal{}, nil))
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed because the request is malformed")
+
+	args = [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1")}
+	res = stub.MockInvoke("3", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed due to incorrect number of arguments")
+}
+
+func TestQueryGetTransactionsByIndexedAttrs(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	block := addBlockForTesting(t, chainid)
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[0])
+	require.NoError(t, err)
+	payload, err := utils.GetPayload(env)
+	require.NoError(t, err)
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	require.NoError(t, err)
+
+	invoke := func(fname, resource string, args ...string) *peer2.QueryResponse {
+		invokeArgs := [][]byte{[]byte(fname), []byte(chainid)}
+		for _, arg := range args {
+			invokeArgs = append(invokeArgs, []byte(arg))
+		}
+		prop := resetProvider(resource, chainid, &peer2.SignedProposal{}, nil)
+		res := stub.MockInvokeWithSignedProposal("1", invokeArgs, prop)
+		if res.Status != shim.OK {
+			return nil
+		}
+		response := &peer2.QueryResponse{}
+		require.NoError(t, proto.Unmarshal(res.Payload, response))
+		assert.False(t, response.HasMore)
+		return response
+	}
+
+	response := invoke(GetTransactionsByChaincode, resources.QSCC_GetTransactionsByChaincode, "foo")
+	require.NotNil(t, response, "GetTransactionsByChaincode failed")
+	require.Len(t, response.Results, 2)
+	indexedTx := &peer2.IndexedTransaction{}
+	require.NoError(t, proto.Unmarshal(response.Results[1].ResultBytes, indexedTx))
+	assert.Equal(t, block.Header.Number, indexedTx.BlockNumber)
+	assert.Equal(t, uint64(1), indexedTx.TxNumber)
+	assert.Equal(t, int32(peer2.TxValidationCode_VALID), indexedTx.ProcessedTransaction.ValidationCode)
+	response = invoke(GetTransactionsByChaincode, resources.QSCC_GetTransactionsByChaincode, "foo", "2")
+	require.NotNil(t, response, "GetTransactionsByChaincode failed")
+	assert.Len(t, response.Results, 0)
+	// resuming after the first transaction of the block
+	response = invoke(GetTransactionsByChaincode, resources.QSCC_GetTransactionsByChaincode, "foo", fmt.Sprint(block.Header.Number), "1")
+	require.NotNil(t, response, "GetTransactionsByChaincode failed")
+	require.Len(t, response.Results, 1)
+	require.NoError(t, proto.Unmarshal(response.Results[0].ResultBytes, indexedTx))
+	assert.Equal(t, uint64(1), indexedTx.TxNumber)
+	assert.Nil(t, invoke(GetTransactionsByChaincode, resources.QSCC_GetTransactionsByChaincode, "foo", "garbage"))
+	assert.Nil(t, invoke(GetTransactionsByChaincode, resources.QSCC_GetTransactionsByChaincode, "foo", "1", "garbage"))
+	assert.Nil(t, invoke(GetTransactionsByChaincode, resources.QSCC_GetTransactionsByChaincode, ""))
+
+	response = invoke(GetTransactionsByCreator, resources.QSCC_GetTransactionsByCreator, string(shdr.Creator))
+	require.NotNil(t, response, "GetTransactionsByCreator failed")
+	assert.Len(t, response.Results, 2)
+	assert.Nil(t, invoke(GetTransactionsByCreator, resources.QSCC_GetTransactionsByCreator, ""))
+
+	now := time.Now()
+	response = invoke(GetTransactionsByTimestamp, resources.QSCC_GetTransactionsByTimestamp,
+		now.Add(-time.Hour).Format(time.RFC3339Nano), now.Add(time.Hour).Format(time.RFC3339Nano))
+	require.NotNil(t, response, "GetTransactionsByTimestamp failed")
+	// the genesis block is included
+	assert.Len(t, response.Results, 3)
+	response = invoke(GetTransactionsByTimestamp, resources.QSCC_GetTransactionsByTimestamp,
+		now.Add(time.Hour).Format(time.RFC3339Nano), now.Add(2*time.Hour).Format(time.RFC3339Nano))
+	require.NotNil(t, response, "GetTransactionsByTimestamp failed")
+	assert.Len(t, response.Results, 0)
+	assert.Nil(t, invoke(GetTransactionsByTimestamp, resources.QSCC_GetTransactionsByTimestamp,
+		now.Format(time.RFC3339Nano), now.Add(time.Hour).Format(time.RFC3339Nano), "garbage"))
+	assert.Nil(t, invoke(GetTransactionsByTimestamp, resources.QSCC_GetTransactionsByTimestamp, "yesterday", "today"))
+	assert.Nil(t, invoke(GetTransactionsByTimestamp, resources.QSCC_GetTransactionsByTimestamp, now.Format(time.RFC3339Nano)))
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	bg, _ := testutil.NewBlockGenerator(t, chainid, false)
 	ledger := peer.GetLedger(chainid)
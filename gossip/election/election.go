@@ -21,11 +21,11 @@ import (
 // Algorithm properties:
 // - Peers break symmetry by comparing IDs
 // - Each peer is either a leader or a follower,
-//   and the aim is to have exactly 1 leader if the membership view
-//   is the same for all peers
+//   and the aim is to have exactly N leaders if the membership view
+//   is the same for all peers, where N is configured (1 by default)
 // - If the network is partitioned into 2 or more sets, the number of leaders
-//   is the number of network partitions, but when the partition heals,
-//   only 1 leader should be left eventually
+//   is N times the number of network partitions, but when the partition heals,
+//   only N leaders should be left eventually
 // - Peers communicate by gossiping leadership proposal or declaration messages
 
 // The Algorithm, in pseudo code:
@@ -36,8 +36,8 @@ import (
 //
 // Invariant:
 //	Peer listens for messages from remote peers
-//	and whenever it receives a leadership declaration,
-//	leaderKnown is set to true
+//	and whenever it receives leadership declarations
+//	from N distinct peers, leaderKnown is set to true
 //
 // Startup():
 // 	wait for membership view to stabilize, or for a leadership declaration is received
@@ -50,8 +50,8 @@ import (
 // 			LeaderElection()
 //		If you are the leader:
 //			Broadcast leadership declaration
-//			If a leadership declaration was received from
-// 			a peer with a lower ID,
+//			If leadership declarations were received from
+// 			N peers with a lower ID,
 //			become a follower
 //		Else, you're a follower:
 //			If haven't received a leadership declaration within
@@ -61,11 +61,12 @@ import (
 // LeaderElection():
 // 	Gossip leadership proposal message
 //	Collect messages from other peers sent within a time period
-//	If received a leadership declaration:
+//	If received leadership declarations from N peers:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
-// 	than yourself was received, return.
+// 	If proposal messages from peers with an ID lower
+// 	than yourself, together with the leaders known,
+// 	add up to N peers, return.
 //	Else, declare yourself a leader
 
 // LeaderElectionAdapter is used by the leader election module
@@ -122,12 +123,21 @@ func noopCallback(_ bool) {
 
 // NewLeaderElectionService returns a new LeaderElectionService
 func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+	return newLeaderElectionService(adapter, id, callback, GetLeadersNum())
+}
+
+func newLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback, leadersNum int) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
+	if leadersNum < 1 {
+		panic("Number of leaders must be positive")
+	}
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
 		proposals:     util.NewSet(),
+		leaders:       util.NewSet(),
+		leadersNum:    leadersNum,
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
 		interruptChan: make(chan struct{}, 1),
@@ -147,6 +157,10 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 type leaderElectionSvcImpl struct {
 	id        peerID
 	proposals *util.Set
+	// leaders holds the IDs of peers that declared
+	// themselves as leaders recently
+	leaders    *util.Set
+	leadersNum int
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -201,11 +215,15 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	if msg.IsProposal() {
 		le.proposals.Add(string(msg.SenderID()))
 	} else if msg.IsDeclaration() {
+		le.leaders.Add(string(msg.SenderID()))
+		if le.leaders.Size() < le.leadersNum {
+			return
+		}
 		atomic.StoreInt32(&le.leaderExists, int32(1))
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.IsLeader() && le.lowerIDs(le.leaders) >= le.leadersNum {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -284,16 +302,19 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 		le.logger.Debug(le.id, ": Aborting leader election because yielding")
 		return
 	}
-	// Leader doesn't exist, let's see if there is a better candidate than us
-	// for being a leader
-	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
-			return
+	// Not enough leaders exist, let's see if there are enough better candidates
+	// than us for being a leader
+	candidates := le.lowerIDs(le.proposals)
+	for _, o := range le.leaders.ToArray() {
+		if bytes.Compare(peerID(o.(string)), le.id) >= 0 || !le.proposals.Exists(o) {
+			candidates++
 		}
 	}
-	// If we got here, there is no one that proposed being a leader
-	// that's a better candidate than us.
+	if candidates >= le.leadersNum {
+		return
+	}
+	// If we got here, there are not enough peers that proposed being a leader
+	// that are better candidates than us.
 	le.beLeader()
 	atomic.StoreInt32(&le.leaderExists, int32(1))
 }
@@ -311,6 +332,7 @@ func (le *leaderElectionSvcImpl) follower() {
 	defer le.logger.Debug(le.id, ": Exiting")
 
 	le.proposals.Clear()
+	le.leaders.Clear()
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	select {
 	case <-time.After(getLeaderAliveThreshold()):
@@ -320,6 +342,9 @@ func (le *leaderElectionSvcImpl) follower() {
 }
 
 func (le *leaderElectionSvcImpl) leader() {
+	// Leaders declare themselves periodically, so only
+	// declarations since the last one we sent are kept
+	le.leaders.Clear()
 	leaderDeclaration := le.adapter.CreateMessage(true)
 	le.adapter.Gossip(leaderDeclaration)
 	le.waitForInterrupt(getLeadershipDeclarationInterval())
@@ -342,6 +367,18 @@ func (le *leaderElectionSvcImpl) waitForMembershipStabilization(timeLimit time.D
 	}
 }
 
+// lowerIDs returns the number of peer IDs in the given set
+// which are lower than the ID of this peer
+func (le *leaderElectionSvcImpl) lowerIDs(ids *util.Set) int {
+	count := 0
+	for _, o := range ids.ToArray() {
+		if bytes.Compare(peerID(o.(string)), le.id) < 0 {
+			count++
+		}
+	}
+	return count
+}
+
 // drainInterruptChannel clears the interruptChannel
 // if needed
 func (le *leaderElectionSvcImpl) drainInterruptChannel() {
@@ -451,6 +488,16 @@ func SetLeaderElectionDuration(t time.Duration) {
 	viper.Set("peer.gossip.election.leaderElectionDuration", t)
 }
 
+// SetLeadersNum configures the number of leaders to elect
+func SetLeadersNum(n int) {
+	viper.Set("peer.gossip.election.leadersNum", n)
+}
+
+// GetLeadersNum returns the number of leaders to elect
+func GetLeadersNum() int {
+	return util.GetIntOrDefault("peer.gossip.election.leadersNum", 1)
+}
+
 func getStartupGracePeriod() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.startupGracePeriod", time.Second*15)
 }
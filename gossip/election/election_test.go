@@ -143,11 +143,15 @@ func (p *peer) isCallbackInvoked() bool {
 }
 
 func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
+	return createPeersWithLeadersNum(1, spawnInterval, ids...)
+}
+
+func createPeersWithLeadersNum(leadersNum int, spawnInterval time.Duration, ids ...int) []*peer {
 	peers := make([]*peer, len(ids))
 	peerMap := make(map[string]*peer)
 	l := &sync.RWMutex{}
 	for i, id := range ids {
-		p := createPeer(id, peerMap, l)
+		p := createPeer(id, peerMap, l, leadersNum)
 		if spawnInterval != 0 {
 			time.Sleep(spawnInterval)
 		}
@@ -156,11 +160,11 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
-func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex, leadersNum int) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
 	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	p.LeaderElectionService = newLeaderElectionService(p, idStr, p.leaderCallback, leadersNum)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -203,6 +207,32 @@ func TestInitPeersAtSameTime(t *testing.T) {
 	waitForBoolFunc(t, peers[len(peers)-1].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[len(peers)-1].id)
 }
 
+func TestMultipleLeaders(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, and 3 leaders are required
+	// expected outcome: the 3 peers that have the lowest IDs are the leaders
+	peers := createPeersWithLeadersNum(3, 0, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0)
+	time.Sleep(getStartupGracePeriod() + getLeaderElectionDuration())
+	leaders := waitForMultipleLeadersElection(t, peers, 3)
+	assert.Len(t, leaders, 3, "Expected exactly 3 leaders")
+	for _, p := range peers[len(peers)-3:] {
+		assert.True(t, p.IsLeader(), "%s isn't a leader. Leaders are: %v", p.id, leaders)
+	}
+
+	// Scenario: the lowest leader stops
+	// expected outcome: the next peer takes over, while the other leaders remain
+	peers[len(peers)-1].Stop()
+	peers[len(peers)-1].sharedLock.Lock()
+	delete(peers[len(peers)-1].peers, peers[len(peers)-1].id)
+	peers[len(peers)-1].sharedLock.Unlock()
+	remaining := peers[:len(peers)-1]
+	waitForBoolFunc(t, remaining[len(remaining)-3].IsLeader, true, "Leadership wasn't taken over by ", remaining[len(remaining)-3].id)
+	time.Sleep(getLeaderAliveThreshold() * 2)
+	leaders = waitForMultipleLeadersElection(t, remaining, 3)
+	assert.Len(t, leaders, 3, "Expected exactly 3 leaders")
+	assert.False(t, remaining[len(remaining)-4].IsLeader())
+}
+
 func TestInitPeersStartAtIntervals(t *testing.T) {
 	t.Parallel()
 	// Scenario: Peers are spawned one by one in a slow rate
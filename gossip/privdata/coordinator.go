@@ -73,6 +73,14 @@ type Coordinator interface {
 	// returns missing transaction ids
 	StoreBlock(block *common.Block, data util.PvtDataCollections) error
 
+	// ValidateBlock validates a block, possibly while the blocks preceding it
+	// are committed, and returns it ready to be committed by CommitBlock
+	ValidateBlock(block *common.Block, data util.PvtDataCollections) (*ValidatedBlock, error)
+
+	// CommitBlock commits a block returned by ValidateBlock, along with its private data.
+	// The blocks have to be committed in the order they were validated
+	CommitBlock(validatedBlock *ValidatedBlock) error
+
 	// StorePvtData used to persist private data into transient store
 	StorePvtData(txid string, privData *rwset.TxPvtReadWriteSet) error
 
@@ -115,10 +123,22 @@ type Support struct {
 	Fetcher
 }
 
+// ValidatedBlock is a block validated by ValidateBlock, waiting to be committed
+type ValidatedBlock struct {
+	Block           *common.Block
+	privateDataSets util.PvtDataCollections
+	// deps records the state the validation read, if the validation
+	// started before the blocks preceding the block were committed
+	deps *txvalidator.Dependencies
+	// deferred is set if the block has to be validated when committed
+	deferred bool
+}
+
 type coordinator struct {
 	selfSignedData common.SignedData
 	Support
 	transientBlockRetention uint64
+	tracker                 *txvalidator.CommitTracker
 }
 
 // NewCoordinator creates a new instance of coordinator
@@ -128,7 +148,12 @@ func NewCoordinator(support Support, selfSignedData common.SignedData) Coordinat
 		logger.Warning("Configuration key", transientBlockRetentionConfigKey, "isn't set, defaulting to", transientBlockRetentionDefault)
 		transientBlockRetention = transientBlockRetentionDefault
 	}
-	return &coordinator{Support: support, selfSignedData: selfSignedData, transientBlockRetention: transientBlockRetention}
+	return &coordinator{
+		Support:                 support,
+		selfSignedData:          selfSignedData,
+		transientBlockRetention: transientBlockRetention,
+		tracker:                 txvalidator.NewCommitTracker(),
+	}
 }
 
 // StorePvtData used to persist private date into transient store
@@ -142,19 +167,80 @@ func (c *coordinator) StorePvtData(txID string, privData *rwset.TxPvtReadWriteSe
 
 // StoreBlock stores block with private data into the ledger
 func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDataCollections) error {
+	validatedBlock, err := c.validateBlock(block, privateDataSets, false)
+	if err != nil {
+		return err
+	}
+	return c.CommitBlock(validatedBlock)
+}
+
+// ValidateBlock validates a block while the blocks validated before it may still
+// be committed, recording the state the validation read so that CommitBlock
+// validates the block again if a block committed meanwhile wrote this state
+func (c *coordinator) ValidateBlock(block *common.Block, privateDataSets util.PvtDataCollections) (*ValidatedBlock, error) {
+	return c.validateBlock(block, privateDataSets, true)
+}
+
+func (c *coordinator) validateBlock(block *common.Block, privateDataSets util.PvtDataCollections, ahead bool) (*ValidatedBlock, error) {
 	if block.Data == nil {
-		return errors.New("Block data is empty")
+		return nil, errors.New("Block data is empty")
 	}
 	if block.Header == nil {
-		return errors.New("Block header is nil")
+		return nil, errors.New("Block header is nil")
 	}
 	logger.Infof("Received block [%d]", block.Header.Number)
 
+	validatedBlock := &ValidatedBlock{Block: block, privateDataSets: privateDataSets}
+	if !ahead {
+		return validatedBlock, c.validate(block)
+	}
+
+	trackingValidator, isTracking := c.Validator.(txvalidator.TrackingValidator)
+	if !isTracking || isBarrier(block) {
+		// config blocks are applied when validated, so they are validated
+		// once the blocks preceding them are committed
+		logger.Debugf("Deferring the validation of block [%d] to its commit", block.Header.Number)
+		validatedBlock.deferred = true
+		return validatedBlock, nil
+	}
+
+	logger.Debugf("Validating block [%d] ahead of the commit of the blocks preceding it", block.Header.Number)
+	deps, err := trackingValidator.ValidateAndTrack(block)
+	if err != nil {
+		logger.Errorf("Validation failed: %+v", err)
+		return nil, err
+	}
+	validatedBlock.deps = deps
+	return validatedBlock, nil
+}
+
+func (c *coordinator) validate(block *common.Block) error {
 	logger.Debugf("Validating block [%d]", block.Header.Number)
 	err := c.Validator.Validate(block)
 	if err != nil {
 		logger.Errorf("Validation failed: %+v", err)
-		return err
+	}
+	return err
+}
+
+// CommitBlock commits a block returned by ValidateBlock, after validating it again
+// if its validation read state written by the blocks committed since
+func (c *coordinator) CommitBlock(validatedBlock *ValidatedBlock) error {
+	block := validatedBlock.Block
+	privateDataSets := validatedBlock.privateDataSets
+	pruneHeight := block.Header.Number
+	if validatedBlock.deferred {
+		if err := c.validate(block); err != nil {
+			return err
+		}
+	} else if validatedBlock.deps != nil {
+		pruneHeight = validatedBlock.deps.Height
+		if c.tracker.Conflicts(block, validatedBlock.deps) {
+			logger.Infof("Block [%d] depends on blocks committed after its validation, validating it again", block.Header.Number)
+			if err := c.validate(block); err != nil {
+				return err
+			}
+		}
 	}
 
 	blockAndPvtData := &ledger.BlockAndPvtData{
@@ -224,6 +310,11 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 	if err != nil {
 		return errors.Wrap(err, "commit failed")
 	}
+	if validatedBlock.deferred || validatedBlock.deps != nil {
+		// the blocks following this one may be validated ahead of its commit
+		c.tracker.Committed(block)
+		c.tracker.Prune(pruneHeight)
+	}
 
 	if len(blockAndPvtData.BlockPvtData) > 0 {
 		// Finally, purge all transactions in block - valid or not valid.
@@ -332,6 +423,26 @@ func (c *coordinator) fetchFromTransientStore(txAndSeq txAndSeqInBlock, filter l
 	} // iterating over the TxPvtRWSet results
 }
 
+// isBarrier tells whether a block has transactions other than endorser
+// transactions, such as config transactions, which can't be validated
+// ahead of the commit of the blocks preceding them
+func isBarrier(block *common.Block) bool {
+	for _, envBytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil {
+			continue
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			return true
+		}
+	}
+	return false
+}
+
 // computeOwnedRWsets identifies which block private data we already have
 func computeOwnedRWsets(block *common.Block, blockPvtData util.PvtDataCollections) (rwsetByKeys, error) {
 	lastBlockSeq := len(block.Data.Data) - 1
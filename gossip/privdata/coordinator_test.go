@@ -17,6 +17,7 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -1294,3 +1295,96 @@ func TestIgnoreReadOnlyColRWSets(t *testing.T) {
 	assert.NoError(t, err)
 	assertCommitHappened()
 }
+
+type trackingValidatorMock struct {
+	validatorMock
+	height             uint64
+	validations        int
+	trackedValidations int
+}
+
+func (v *trackingValidatorMock) Validate(block *common.Block) error {
+	v.validations++
+	return v.validatorMock.Validate(block)
+}
+
+func (v *trackingValidatorMock) ValidateAndTrack(block *common.Block) (*txvalidator.Dependencies, error) {
+	v.trackedValidations++
+	if v.err != nil {
+		return nil, v.err
+	}
+	return &txvalidator.Dependencies{Height: v.height}, nil
+}
+
+func TestCoordinatorValidateAhead(t *testing.T) {
+	peerSelfSignedData := common.SignedData{
+		Identity:  []byte{0, 1, 2},
+		Signature: []byte{3, 4, 5},
+		Data:      []byte{6, 7, 8},
+	}
+	cs := createcollectionStore(peerSelfSignedData).thatAcceptsAll()
+	var committedBlocks []uint64
+	committer := &committerMock{}
+	committer.On("CommitWithPvtData", mock.Anything).Run(func(args mock.Arguments) {
+		committedBlocks = append(committedBlocks, args.Get(0).(*ledger.BlockAndPvtData).Block.Header.Number)
+	}).Return(nil)
+	hash := util2.ComputeSHA256([]byte("rws-pre-image"))
+	bf := &blockFactory{
+		channelID: "test",
+	}
+	validator := &trackingValidatorMock{height: 1}
+	coordinator := NewCoordinator(Support{
+		CollectionStore: cs,
+		Committer:       committer,
+		Fetcher:         &fetcherMock{t: t},
+		TransientStore:  &mockTransientStore{t: t},
+		Validator:       validator,
+	}, peerSelfSignedData)
+
+	createBlock := func(blockNum uint64, txID string) *common.Block {
+		block := bf.AddReadOnlyTxn(txID, "ns3", hash, "c3").create()
+		block.Header.Number = blockNum
+		return block
+	}
+	validateAhead := func(block *common.Block) *ValidatedBlock {
+		validatedBlock, err := coordinator.ValidateBlock(block, nil)
+		assert.NoError(t, err)
+		return validatedBlock
+	}
+
+	// Blocks 2 and 3 are validated while block 1 is not committed yet.
+	// Block 3 has the ID of a transaction of block 1, so it is validated again
+	validatedBlock1 := validateAhead(createBlock(1, "tx1"))
+	validatedBlock2 := validateAhead(createBlock(2, "tx2"))
+	validatedBlock3 := validateAhead(createBlock(3, "tx1"))
+	assert.Equal(t, 3, validator.trackedValidations)
+	assert.NoError(t, coordinator.CommitBlock(validatedBlock1))
+	assert.NoError(t, coordinator.CommitBlock(validatedBlock2))
+	assert.Equal(t, 0, validator.validations)
+	assert.NoError(t, coordinator.CommitBlock(validatedBlock3))
+	assert.Equal(t, 1, validator.validations)
+
+	// A config block is validated when committed, and
+	// the block validated before its commit is validated again
+	chdr, _ := pb.Marshal(&common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), ChannelId: "test"})
+	payload, _ := pb.Marshal(&common.Payload{Header: &common.Header{ChannelHeader: chdr}})
+	env, _ := pb.Marshal(&common.Envelope{Payload: payload})
+	configBlock := bf.create()
+	configBlock.Header.Number = 4
+	configBlock.Data.Data = [][]byte{env}
+	configBlock.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{0}
+	validatedBlock4 := validateAhead(configBlock)
+	validator.height = 4
+	validatedBlock5 := validateAhead(createBlock(5, "tx5"))
+	assert.Equal(t, 4, validator.trackedValidations)
+	assert.NoError(t, coordinator.CommitBlock(validatedBlock4))
+	assert.Equal(t, 2, validator.validations)
+	assert.NoError(t, coordinator.CommitBlock(validatedBlock5))
+	assert.Equal(t, 3, validator.validations)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, committedBlocks)
+
+	// A block failing validation is not returned
+	validator.err = errors.New("failed validating block")
+	_, err := coordinator.ValidateBlock(createBlock(6, "tx6"), nil)
+	assert.EqualError(t, err, "failed validating block")
+}
@@ -9,6 +9,7 @@ package service
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/privdata"
@@ -270,7 +271,10 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 			logger.Panic("Setting both orgLeader and useLeaderElection to true isn't supported, aborting execution")
 		}
 
-		if leaderElection {
+		if !deliverclient.IsBlockGossipEnabled() {
+			logger.Debug("Block gossip is disabled, this peer pulls blocks from the ordering service independently, channel", chainID)
+			g.deliveryService[chainID].StartDeliverForChannel(chainID, support.Committer, func() {})
+		} else if leaderElection {
 			logger.Debug("Delivery uses dynamic leader election mechanism, channel", chainID)
 			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID, support.Committer))
 		} else if isStaticOrgLeader {
@@ -378,8 +382,10 @@ func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
 }
 
 func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer blocksprovider.LedgerInfo) func(bool) {
+	leaderGauge := metrics.GetRootScope().SubScope("gossip").Tagged(map[string]string{"channel": chainID}).Gauge("leader")
 	return func(isLeader bool) {
 		if isLeader {
+			leaderGauge.Update(1)
 			yield := func() {
 				g.lock.RLock()
 				le := g.leaderElection[chainID]
@@ -391,6 +397,7 @@ func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer bloc
 				logger.Errorf("Delivery service is not able to start blocks delivery for chain, due to %+v", errors.WithStack(err))
 			}
 		} else {
+			leaderGauge.Update(0)
 			logger.Info("Renounced leadership, stopping delivery service for channel", chainID)
 			if err := g.deliveryService[chainID].StopDeliverForChannel(chainID); err != nil {
 				logger.Errorf("Delivery service is not able to stop blocks delivery for chain, due to %+v", errors.WithStack(err))
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/discovery"
+)
+
+const (
+	// Weight given to the latest latency sample when updating
+	// the exponentially weighted moving average of a peer
+	defLatencySmoothingFactor = 0.3
+
+	// Latency assumed for peers we haven't requested blocks from yet
+	defInitialLatencyEstimate = defAntiEntropyStateResponseTimeout / 10
+
+	// Each block which failed MCS verification is penalized as this many failures
+	defInvalidBlockPenalty = 10
+
+	// Period of time after which failures of a peer are forgiven
+	defPeerPenaltyExpiration = 5 * time.Minute
+)
+
+// peerRecord holds the state transfer statistics of a single remote peer
+type peerRecord struct {
+	latency       time.Duration
+	failures      int
+	invalidBlocks int
+	inFlight      int
+	lastFailure   time.Time
+}
+
+// weight returns the relative likelihood of the peer to be selected
+// for a state transfer request; faster and healthier peers get higher weights
+func (r *peerRecord) weight(now time.Time) float64 {
+	if now.Sub(r.lastFailure) > defPeerPenaltyExpiration {
+		r.failures = 0
+		r.invalidBlocks = 0
+	}
+	latency := r.latency.Seconds()
+	if latency <= 0 {
+		latency = time.Millisecond.Seconds()
+	}
+	penalty := float64(1 + r.failures + defInvalidBlockPenalty*r.invalidBlocks)
+	return 1 / (latency * penalty * float64(1+r.inFlight))
+}
+
+// peerScorer tracks response latency, failures and invalid blocks
+// of remote peers and uses them to pick peers to request blocks from
+type peerScorer struct {
+	sync.Mutex
+	peers map[string]*peerRecord
+	rand  *rand.Rand
+	now   func() time.Time
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		peers: make(map[string]*peerRecord),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:   time.Now,
+	}
+}
+
+func (ps *peerScorer) record(peer *comm.RemotePeer) *peerRecord {
+	key := string(peer.PKIID)
+	r, exists := ps.peers[key]
+	if !exists {
+		r = &peerRecord{latency: defInitialLatencyEstimate}
+		ps.peers[key] = r
+	}
+	return r
+}
+
+// prune forgets the statistics of the peers which are not among the given
+// alive members, so that the peers which left do not pile up
+func (ps *peerScorer) prune(alive []discovery.NetworkMember) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	members := make(map[string]struct{}, len(alive))
+	for _, member := range alive {
+		members[string(member.PKIid)] = struct{}{}
+	}
+	for key := range ps.peers {
+		if _, exists := members[key]; !exists {
+			delete(ps.peers, key)
+		}
+	}
+}
+
+// selectPeer picks one of the given peers at random, weighted by their
+// score, and marks a request to it as in flight
+func (ps *peerScorer) selectPeer(peers []*comm.RemotePeer) *comm.RemotePeer {
+	if len(peers) == 0 {
+		return nil
+	}
+	ps.Lock()
+	defer ps.Unlock()
+
+	now := ps.now()
+	weights := make([]float64, len(peers))
+	total := float64(0)
+	for i, peer := range peers {
+		weights[i] = ps.record(peer).weight(now)
+		total += weights[i]
+	}
+
+	selected := peers[len(peers)-1]
+	threshold := ps.rand.Float64() * total
+	for i, w := range weights {
+		if threshold < w {
+			selected = peers[i]
+			break
+		}
+		threshold -= w
+	}
+	ps.record(selected).inFlight++
+	return selected
+}
+
+// responseReceived records a valid response from the peer that arrived after the given latency
+func (ps *peerScorer) responseReceived(peer *comm.RemotePeer, latency time.Duration) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	r := ps.record(peer)
+	r.done()
+	r.latency = time.Duration(defLatencySmoothingFactor*float64(latency) +
+		(1-defLatencySmoothingFactor)*float64(r.latency))
+	r.failures /= 2
+}
+
+// requestFailed records that the peer didn't respond in time or sent a malformed response
+func (ps *peerScorer) requestFailed(peer *comm.RemotePeer) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	r := ps.record(peer)
+	r.done()
+	r.failures++
+	r.lastFailure = ps.now()
+}
+
+// invalidBlockReceived records that the peer sent a block which failed MCS verification
+func (ps *peerScorer) invalidBlockReceived(peer *comm.RemotePeer) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	r := ps.record(peer)
+	r.done()
+	r.invalidBlocks++
+	r.lastFailure = ps.now()
+}
+
+func (r *peerRecord) done() {
+	if r.inFlight > 0 {
+		r.inFlight--
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func selectionCounts(ps *peerScorer, peers []*comm.RemotePeer, rounds int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i < rounds; i++ {
+		p := ps.selectPeer(peers)
+		counts[p.Endpoint]++
+		// Release the in-flight request without affecting the statistics
+		ps.Lock()
+		ps.record(p).done()
+		ps.Unlock()
+	}
+	return counts
+}
+
+func TestPeerScorerEmpty(t *testing.T) {
+	ps := newPeerScorer()
+	assert.Nil(t, ps.selectPeer(nil))
+}
+
+func TestPeerScorerPrefersLowLatency(t *testing.T) {
+	ps := newPeerScorer()
+	fast := &comm.RemotePeer{Endpoint: "fast", PKIID: []byte("fast")}
+	slow := &comm.RemotePeer{Endpoint: "slow", PKIID: []byte("slow")}
+	peers := []*comm.RemotePeer{fast, slow}
+
+	for i := 0; i < 20; i++ {
+		ps.selectPeer(peers)
+		ps.responseReceived(fast, 10*time.Millisecond)
+		ps.responseReceived(slow, time.Second)
+	}
+
+	counts := selectionCounts(ps, peers, 1000)
+	assert.True(t, counts["fast"] > 10*counts["slow"], "fast peer should be selected more often: %v", counts)
+	assert.NotZero(t, counts["slow"], "slow peer should still get some requests: %v", counts)
+}
+
+func TestPeerScorerPenalizesFailures(t *testing.T) {
+	ps := newPeerScorer()
+	good := &comm.RemotePeer{Endpoint: "good", PKIID: []byte("good")}
+	flaky := &comm.RemotePeer{Endpoint: "flaky", PKIID: []byte("flaky")}
+	malicious := &comm.RemotePeer{Endpoint: "malicious", PKIID: []byte("malicious")}
+	peers := []*comm.RemotePeer{good, flaky, malicious}
+
+	ps.requestFailed(flaky)
+	ps.requestFailed(flaky)
+	ps.invalidBlockReceived(malicious)
+
+	counts := selectionCounts(ps, peers, 1000)
+	assert.True(t, counts["good"] > counts["flaky"], "%v", counts)
+	assert.True(t, counts["flaky"] > counts["malicious"], "%v", counts)
+
+	// Penalties expire after a while
+	ps.now = func() time.Time {
+		return time.Now().Add(defPeerPenaltyExpiration * 2)
+	}
+	counts = selectionCounts(ps, peers, 3000)
+	for _, p := range peers {
+		assert.True(t, counts[p.Endpoint] > 700, "%v", counts)
+	}
+}
+
+func TestPeerScorerSpreadsInFlightRequests(t *testing.T) {
+	ps := newPeerScorer()
+	peers := []*comm.RemotePeer{
+		{Endpoint: "p1", PKIID: []byte("p1")},
+		{Endpoint: "p2", PKIID: []byte("p2")},
+	}
+
+	// Peers with outstanding requests are less likely to be selected again
+	first := ps.selectPeer(peers)
+	ps.selectPeer([]*comm.RemotePeer{first})
+	ps.selectPeer([]*comm.RemotePeer{first})
+
+	counts := selectionCounts(ps, peers, 1000)
+	assert.True(t, counts[first.Endpoint] < counts["p1"]+counts["p2"]-counts[first.Endpoint], "%v", counts)
+
+	ps.responseReceived(first, defInitialLatencyEstimate)
+	ps.responseReceived(first, defInitialLatencyEstimate)
+	ps.responseReceived(first, defInitialLatencyEstimate)
+	ps.Lock()
+	assert.Equal(t, 0, ps.record(first).inFlight)
+	ps.Unlock()
+}
+
+func TestPeerScorerForgetsPeersWhichLeft(t *testing.T) {
+	ps := newPeerScorer()
+	staying := &comm.RemotePeer{Endpoint: "staying", PKIID: []byte("staying")}
+	leaving := &comm.RemotePeer{Endpoint: "leaving", PKIID: []byte("leaving")}
+
+	ps.selectPeer([]*comm.RemotePeer{staying, leaving})
+	ps.responseReceived(staying, 10*time.Millisecond)
+	ps.requestFailed(leaving)
+	assert.Len(t, ps.peers, 2)
+
+	// The leaving peer is no longer a member of the channel
+	ps.prune([]discovery.NetworkMember{{Endpoint: "staying", PKIid: []byte("staying")}})
+	assert.Len(t, ps.peers, 1)
+	assert.Contains(t, ps.peers, "staying")
+
+	// A peer which joins again starts over
+	ps.prune([]discovery.NetworkMember{{Endpoint: "staying", PKIid: []byte("staying")}, {Endpoint: "leaving", PKIid: []byte("leaving")}})
+	assert.Equal(t, leaving, ps.selectPeer([]*comm.RemotePeer{leaving}))
+	assert.Zero(t, ps.peers["leaving"].failures)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -44,6 +45,10 @@ const (
 	defChannelBufferSize     = 100
 	defAntiEntropyMaxRetries = 3
 
+	// Maximum number of batches requested concurrently from
+	// different peers while catching up over a large block gap
+	defAntiEntropyMaxParallelRequests = 4
+
 	defMaxBlockDistance = 100
 
 	blocking    = true
@@ -93,6 +98,13 @@ type ledgerResources interface {
 	// returns missing transaction ids
 	StoreBlock(block *common.Block, data util.PvtDataCollections) error
 
+	// ValidateBlock validates a block, possibly while the blocks preceding it
+	// are committed, and returns it ready to be committed by CommitBlock
+	ValidateBlock(block *common.Block, data util.PvtDataCollections) (*privdata.ValidatedBlock, error)
+
+	// CommitBlock commits a block returned by ValidateBlock
+	CommitBlock(validatedBlock *privdata.ValidatedBlock) error
+
 	// StorePvtData used to persist private date into transient store
 	StorePvtData(txid string, privData *rwset.TxPvtReadWriteSet) error
 
@@ -135,7 +147,13 @@ type GossipStateProviderImpl struct {
 
 	ledger ledgerResources
 
-	stateResponseCh chan proto.ReceivedMessage
+	// Pending state requests, indexed by nonce, waiting for a response
+	pendingRequests map[uint64]chan proto.ReceivedMessage
+
+	pendingLock sync.Mutex
+
+	// Statistics of remote peers used to select whom to request blocks from
+	peerScorer *peerScorer
 
 	stateRequestCh chan proto.ReceivedMessage
 
@@ -146,6 +164,10 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	// Queue of validated blocks waiting to be committed, nil
+	// unless blocks are validated while the preceding ones are committed
+	commitCh chan *privdata.ValidatedBlock
 }
 
 var logger = util.GetLogger(util.LoggingStateModule, "")
@@ -214,7 +236,9 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 
 		ledger: ledger,
 
-		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		pendingRequests: make(map[uint64]chan proto.ReceivedMessage),
+
+		peerScorer: newPeerScorer(),
 
 		stateRequestCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
 
@@ -230,6 +254,14 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 	logger.Debug("Updating gossip ledger height to", height)
 	services.UpdateLedgerHeight(height, common2.ChainID(s.chainID))
 
+	if pipelineDepth := viper.GetInt("peer.validatorPipelineDepth"); pipelineDepth > 0 {
+		logger.Infof("Validating up to %d blocks ahead of the committed ones", pipelineDepth)
+		s.commitCh = make(chan *privdata.ValidatedBlock, pipelineDepth-1)
+		s.done.Add(1)
+		// Commit the blocks validated by deliverPayloads
+		go s.commitValidatedBlocks()
+	}
+
 	s.done.Add(4)
 
 	// Listen for incoming communication
@@ -342,7 +374,7 @@ func (s *GossipStateProviderImpl) directMessage(msg proto.ReceivedMessage) {
 		// no reason to process the message
 		if atomic.LoadInt32(&s.stateTransferActive) == 1 {
 			// Send signal of state response message
-			s.stateResponseCh <- msg
+			s.dispatchStateResponse(msg)
 		}
 	}
 }
@@ -449,6 +481,23 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	})
 }
 
+// dispatchStateResponse forwards the state response to the pending request with the same nonce,
+// responses to requests that were already answered or timed out are dropped
+func (s *GossipStateProviderImpl) dispatchStateResponse(msg proto.ReceivedMessage) {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+
+	responseCh, exists := s.pendingRequests[msg.GetGossipMessage().Nonce]
+	if !exists {
+		logger.Debug("Received state response for a request which is no longer pending, dropping it")
+		return
+	}
+	select {
+	case responseCh <- msg:
+	default:
+	}
+}
+
 func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage) (uint64, error) {
 	max := uint64(0)
 	// Send signal that response for given nonce has been received
@@ -462,7 +511,7 @@ func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage)
 		if err := s.mediator.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
 			err = errors.WithStack(err)
 			logger.Warningf("Error verifying block with sequence number %d, due to %+v", payload.SeqNum, err)
-			return uint64(0), err
+			return uint64(0), &invalidBlockError{error: err}
 		}
 		if max < payload.SeqNum {
 			max = payload.SeqNum
@@ -487,7 +536,6 @@ func (s *GossipStateProviderImpl) Stop() {
 		// Close all resources
 		s.ledger.Close()
 		close(s.stateRequestCh)
-		close(s.stateResponseCh)
 		close(s.stopCh)
 	})
 }
@@ -543,7 +591,21 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 						continue
 					}
 				}
-				if err := s.commitBlock(rawBlock, p); err != nil {
+				var err error
+				if s.commitCh == nil {
+					err = s.commitBlock(rawBlock, p)
+				} else if validatedBlock, validationErr := s.ledger.ValidateBlock(rawBlock, p); validationErr != nil {
+					err = validationErr
+				} else {
+					select {
+					case s.commitCh <- validatedBlock:
+					case <-s.stopCh:
+						s.stopCh <- struct{}{}
+						logger.Debug("State provider has been stopped, finishing to push new blocks.")
+						return
+					}
+				}
+				if err != nil {
 					if executionErr, isExecutionErr := err.(*vsccErrors.VSCCExecutionFailureError); isExecutionErr {
 						logger.Errorf("Failed executing VSCC due to %v. Aborting chain processing", executionErr)
 						return
@@ -559,6 +621,29 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 	}
 }
 
+// commitValidatedBlocks commits the blocks validated by deliverPayloads, while
+// deliverPayloads validates the following blocks
+func (s *GossipStateProviderImpl) commitValidatedBlocks() {
+	defer s.done.Done()
+
+	for {
+		select {
+		case validatedBlock := <-s.commitCh:
+			if err := s.commitValidatedBlock(validatedBlock); err != nil {
+				if executionErr, isExecutionErr := err.(*vsccErrors.VSCCExecutionFailureError); isExecutionErr {
+					logger.Errorf("Failed executing VSCC due to %v. Aborting chain processing", executionErr)
+					return
+				}
+				logger.Panicf("Cannot commit block to the ledger due to %+v", errors.WithStack(err))
+			}
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			logger.Debug("State provider has been stopped, finishing to commit validated blocks.")
+			return
+		}
+	}
+}
+
 func (s *GossipStateProviderImpl) antiEntropy() {
 	defer s.done.Done()
 	defer logger.Debug("State Provider stopped, stopping anti entropy procedure.")
@@ -607,59 +692,115 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 }
 
 // GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
+// numbers in the range [start...end]. Large ranges are split into batches
+// which are requested concurrently from different peers.
 func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
 	atomic.StoreInt32(&s.stateTransferActive, 1)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
 	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
-
-		gossipMsg := s.stateRequestMessage(prev, next)
+		var batches []*blocksRange
+		for from := prev; from <= end && len(batches) < defAntiEntropyMaxParallelRequests; {
+			to := min(end, from+defAntiEntropyBatchSize)
+			batches = append(batches, &blocksRange{start: from, end: to})
+			from = to + 1
+		}
 
-		responseReceived := false
-		tryCounts := 0
+		var wg sync.WaitGroup
+		wg.Add(len(batches))
+		for _, batch := range batches {
+			go func(batch *blocksRange) {
+				defer wg.Done()
+				batch.received, batch.err = s.requestBlocksBatch(batch.start, batch.end)
+			}(batch)
+		}
+		wg.Wait()
 
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
-				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
+		// Continue from the first block which wasn't received
+		for _, batch := range batches {
+			if batch.err != nil {
 				return
 			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
-			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v",
-					prev, next, errors.WithStack(err))
-				return
+			prev = batch.received + 1
+			if batch.received < batch.end {
+				break
 			}
+		}
+	}
+}
 
-			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
+// blocksRange is a range of blocks [start...end] requested in a single state request
+type blocksRange struct {
+	start    uint64
+	end      uint64
+	received uint64
+	err      error
+}
 
-			s.mediator.Send(gossipMsg, peer)
-			tryCounts++
+// invalidBlockError indicates a state response contained a block which failed verification
+type invalidBlockError struct {
+	error
+}
 
-			// Wait until timeout or response arrival
-			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
-				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %+v", prev, next, errors.WithStack(err))
-					continue
+// requestBlocksBatch requests blocks in the range [start...end] from peers
+// until a valid response is received, and returns the highest sequence
+// number received
+func (s *GossipStateProviderImpl) requestBlocksBatch(start uint64, end uint64) (uint64, error) {
+	gossipMsg := s.stateRequestMessage(start, end)
+
+	responseCh := make(chan proto.ReceivedMessage, 1)
+	s.pendingLock.Lock()
+	s.pendingRequests[gossipMsg.Nonce] = responseCh
+	s.pendingLock.Unlock()
+
+	defer func() {
+		s.pendingLock.Lock()
+		delete(s.pendingRequests, gossipMsg.Nonce)
+		s.pendingLock.Unlock()
+	}()
+
+	for tryCounts := 0; ; tryCounts++ {
+		if tryCounts > defAntiEntropyMaxRetries {
+			logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
+				start, end, tryCounts)
+			return 0, errors.Errorf("failed to get blocks in range [%d...%d]", start, end)
+		}
+		// Select peers to ask for blocks
+		peer, err := s.selectPeerToRequestFrom(end)
+		if err != nil {
+			logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v",
+				start, end, errors.WithStack(err))
+			return 0, err
+		}
+
+		logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+			"for chainID %s", peer.Endpoint, start, end, s.chainID)
+
+		sentTime := time.Now()
+		s.mediator.Send(gossipMsg, peer)
+
+		// Wait until timeout or response arrival
+		select {
+		case msg := <-responseCh:
+			// Got corresponding response for state request, can continue
+			index, err := s.handleStateResponse(msg)
+			if err != nil {
+				logger.Warningf("Wasn't able to process state response for "+
+					"blocks [%d...%d] from %s, due to %+v", start, end, peer.Endpoint, errors.WithStack(err))
+				if _, isInvalidBlock := err.(*invalidBlockError); isInvalidBlock {
+					s.peerScorer.invalidBlockReceived(peer)
+				} else {
+					s.peerScorer.requestFailed(peer)
 				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
-			case <-s.stopCh:
-				s.stopCh <- struct{}{}
-				return
+				continue
 			}
+			s.peerScorer.responseReceived(peer, time.Since(sentTime))
+			return index, nil
+		case <-time.After(defAntiEntropyStateResponseTimeout):
+			s.peerScorer.requestFailed(peer)
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			return 0, errors.New("state provider has been stopped")
 		}
 	}
 }
@@ -679,7 +820,8 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
+// Select peer which has required blocks to ask missing blocks from,
+// preferring peers which respond fast and send valid blocks
 func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
 	// Filter peers which posses required range of missing blocks
 	peers := s.filterPeers(s.hasRequiredHeight(height))
@@ -689,8 +831,9 @@ func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.
 		return nil, errors.New("there are no peers to ask for missing blocks from")
 	}
 
-	// Select peer to ask for blocks
-	return peers[util.RandomInt(n)], nil
+	// Select peer to ask for blocks, among the statistics of the peers still alive
+	s.peerScorer.prune(s.mediator.PeersOfChannel(common2.ChainID(s.chainID)))
+	return s.peerScorer.selectPeer(peers), nil
 }
 
 // filterPeers return list of peers which aligns the predicate provided
@@ -761,12 +904,25 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 		return err
 	}
 
+	s.blockCommitted(block)
+	return nil
+}
+
+func (s *GossipStateProviderImpl) commitValidatedBlock(validatedBlock *privdata.ValidatedBlock) error {
+	if err := s.ledger.CommitBlock(validatedBlock); err != nil {
+		logger.Errorf("Got error while committing(%+v)", errors.WithStack(err))
+		return err
+	}
+
+	s.blockCommitted(validatedBlock.Block)
+	return nil
+}
+
+func (s *GossipStateProviderImpl) blockCommitted(block *common.Block) {
 	// Update ledger height
 	s.mediator.UpdateLedgerHeight(block.Header.Number+1, common2.ChainID(s.chainID))
 	logger.Debugf("Channel [%s]: Created block [%d] with %d transaction(s)",
 		s.chainID, block.Header.Number, len(block.Data.Data))
-
-	return nil
 }
 
 func min(a uint64, b uint64) uint64 {
@@ -39,6 +39,7 @@ import (
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/op/go-logging"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -571,6 +572,42 @@ func TestOverPopulation(t *testing.T) {
 	assert.True(t, sp.payloads.Size() < defMaxBlockDistance)
 }
 
+func TestPipelinedCommit(t *testing.T) {
+	// Scenario: blocks are validated while the blocks preceding them are committed,
+	// and are committed in order
+	viper.Set("peer.validatorPipelineDepth", 2)
+	defer viper.Set("peer.validatorPipelineDepth", 0)
+	mc := &mockCommitter{}
+	blocksPassedToLedger := make(chan uint64, 10)
+	mc.On("CommitWithPvtData", mock.Anything).Run(func(arg mock.Arguments) {
+		blocksPassedToLedger <- arg.Get(0).(*pcomm.Block).Header.Number
+	})
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+	g := &mocks.GossipMock{}
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, make(chan proto.ReceivedMessage))
+	p := newPeerNodeWithGossip(newGossipConfig(portStartRange+800, 0), mc, noopPeerIdentityAcceptor, g)
+	defer p.shutdown()
+	assert.NotNil(t, p.s.commitCh)
+
+	for i := 1; i <= 5; i++ {
+		rawblock := pcomm.NewBlock(uint64(i), []byte{})
+		b, _ := pb.Marshal(rawblock)
+		assert.NoError(t, p.s.addPayload(&proto.Payload{
+			SeqNum: uint64(i),
+			Data:   b,
+		}, nonBlocking))
+	}
+	for i := 1; i <= 5; i++ {
+		select {
+		case seq := <-blocksPassedToLedger:
+			assert.Equal(t, uint64(i), seq)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Block %d wasn't committed", i)
+		}
+	}
+}
+
 func TestBlockingEnqueue(t *testing.T) {
 	// Scenario: In parallel, get blocks from gossip and from the orderer.
 	// The blocks from the orderer we get are X2 times the amount of blocks from gossip.
@@ -1215,6 +1252,14 @@ func (mock *coordinatorMock) StoreBlock(block *pcomm.Block, data gutil.PvtDataCo
 	return args.Error(1)
 }
 
+func (mock *coordinatorMock) ValidateBlock(block *pcomm.Block, data gutil.PvtDataCollections) (*privdata.ValidatedBlock, error) {
+	return &privdata.ValidatedBlock{Block: block}, nil
+}
+
+func (mock *coordinatorMock) CommitBlock(validatedBlock *privdata.ValidatedBlock) error {
+	return mock.StoreBlock(validatedBlock.Block, nil)
+}
+
 func (mock *coordinatorMock) LedgerHeight() (uint64, error) {
 	args := mock.Called()
 	return args.Get(0).(uint64), args.Error(1)
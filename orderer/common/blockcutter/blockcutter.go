@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter
 
 import (
+	"sort"
+	"time"
+
 	"github.com/hyperledger/fabric/common/channelconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
 
@@ -32,12 +35,41 @@ type Receiver interface {
 
 	// Cut returns the current batch and starts a new one
 	Cut() []*cb.Envelope
+
+	// Timeout returns the batch timeout of the pending batch if the classes of its
+	// messages set one other than the batch timeout of the channel, and 0 otherwise
+	Timeout() time.Duration
+}
+
+// batchClass holds the cutting parameters of a class of messages, a lower
+// priority value denoting a higher priority
+type batchClass struct {
+	priority          int
+	maxMessageCount   uint32
+	preferredMaxBytes uint32
+	timeout           time.Duration
+}
+
+// tighter returns the cutting parameters of a batch holding messages of both classes
+func (bc batchClass) tighter(other batchClass) batchClass {
+	if other.maxMessageCount < bc.maxMessageCount {
+		bc.maxMessageCount = other.maxMessageCount
+	}
+	if other.preferredMaxBytes < bc.preferredMaxBytes {
+		bc.preferredMaxBytes = other.preferredMaxBytes
+	}
+	if other.timeout < bc.timeout {
+		bc.timeout = other.timeout
+	}
+	return bc
 }
 
 type receiver struct {
 	sharedConfigManager   channelconfig.Orderer
 	pendingBatch          []*cb.Envelope
+	pendingPriorities     []int
 	pendingBatchSizeBytes uint32
+	pendingLimits         batchClass
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
@@ -47,6 +79,46 @@ func NewReceiverImpl(sharedConfigManager channelconfig.Orderer) Receiver {
 	}
 }
 
+// classOf returns the cutting parameters of the class of the message, which are
+// those of the channel unless the batch size config sets a classifier
+func (r *receiver) classOf(msg *cb.Envelope) batchClass {
+	batchSize := r.sharedConfigManager.BatchSize()
+	class := batchClass{
+		priority:          len(batchSize.Classes),
+		maxMessageCount:   batchSize.MaxMessageCount,
+		preferredMaxBytes: batchSize.PreferredMaxBytes,
+		timeout:           r.sharedConfigManager.BatchTimeout(),
+	}
+	if batchSize.Classifier == "" {
+		return class
+	}
+
+	classifier, ok := LookupClassifier(batchSize.Classifier)
+	if !ok {
+		logger.Warningf("Classifier %s is not available, using the batch size of the channel", batchSize.Classifier)
+		return class
+	}
+
+	name := classifier(msg)
+	for priority, classBatchSize := range batchSize.Classes {
+		if classBatchSize.Class != name {
+			continue
+		}
+		class.priority = priority
+		if classBatchSize.MaxMessageCount > 0 {
+			class.maxMessageCount = classBatchSize.MaxMessageCount
+		}
+		if classBatchSize.PreferredMaxBytes > 0 {
+			class.preferredMaxBytes = classBatchSize.PreferredMaxBytes
+		}
+		if timeout, ok := r.sharedConfigManager.ClassBatchTimeouts()[name]; ok {
+			class.timeout = timeout
+		}
+		break
+	}
+	return class
+}
+
 // Ordered should be invoked sequentially as messages are ordered
 //
 // messageBatches length: 0, pending: false
@@ -63,10 +135,17 @@ func NewReceiverImpl(sharedConfigManager channelconfig.Orderer) Receiver {
 //   - impossible
 //
 // Note that messageBatches can not be greater than 2.
+//
+// When the batch size config sets a classifier, the pending batch is subject to
+// the tightest batch size among the classes of its messages, which are sorted by
+// the priority of their class when the batch is cut.  As the pending batch holds
+// the messages in the order they are received, cutting it covers the same messages
+// as without classes, which keeps the block boundaries deterministic.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
+	class := r.classOf(msg)
 	messageSizeBytes := messageSizeBytes(msg)
-	if messageSizeBytes > r.sharedConfigManager.BatchSize().PreferredMaxBytes {
-		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, r.sharedConfigManager.BatchSize().PreferredMaxBytes)
+	if messageSizeBytes > class.preferredMaxBytes {
+		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, class.preferredMaxBytes)
 
 		// cut pending batch, if it has any messages
 		if len(r.pendingBatch) > 0 {
@@ -80,21 +159,29 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		return
 	}
 
-	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > r.sharedConfigManager.BatchSize().PreferredMaxBytes
+	limits := class
+	if len(r.pendingBatch) > 0 {
+		limits = r.pendingLimits.tighter(class)
+	}
+
+	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > limits.preferredMaxBytes
 
 	if messageWillOverflowBatchSizeBytes {
 		logger.Debugf("The current message, with %v bytes, will overflow the pending batch of %v bytes.", messageSizeBytes, r.pendingBatchSizeBytes)
 		logger.Debugf("Pending batch would overflow if current message is added, cutting batch now.")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
+		limits = class
 	}
 
 	logger.Debugf("Enqueuing message into batch")
 	r.pendingBatch = append(r.pendingBatch, msg)
+	r.pendingPriorities = append(r.pendingPriorities, class.priority)
 	r.pendingBatchSizeBytes += messageSizeBytes
+	r.pendingLimits = limits
 	pending = true
 
-	if uint32(len(r.pendingBatch)) >= r.sharedConfigManager.BatchSize().MaxMessageCount {
+	if uint32(len(r.pendingBatch)) >= limits.maxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
@@ -104,14 +191,45 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	return
 }
 
-// Cut returns the current batch and starts a new one
+// Cut returns the current batch, sorted by the priority of the classes of its
+// messages, and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
 	batch := r.pendingBatch
+	sort.Stable(byPriority{batch: batch, priorities: r.pendingPriorities})
 	r.pendingBatch = nil
+	r.pendingPriorities = nil
 	r.pendingBatchSizeBytes = 0
 	return batch
 }
 
+// Timeout returns the batch timeout of the pending batch if the classes of its
+// messages set one other than the batch timeout of the channel, and 0 otherwise
+func (r *receiver) Timeout() time.Duration {
+	if len(r.pendingBatch) == 0 || r.pendingLimits.timeout == r.sharedConfigManager.BatchTimeout() {
+		return 0
+	}
+	return r.pendingLimits.timeout
+}
+
+// byPriority sorts a batch by the priority of the classes of its messages
+type byPriority struct {
+	batch      []*cb.Envelope
+	priorities []int
+}
+
+func (bp byPriority) Len() int {
+	return len(bp.batch)
+}
+
+func (bp byPriority) Less(i, j int) bool {
+	return bp.priorities[i] < bp.priorities[j]
+}
+
+func (bp byPriority) Swap(i, j int) {
+	bp.batch[i], bp.batch[j] = bp.batch[j], bp.batch[i]
+	bp.priorities[i], bp.priorities[j] = bp.priorities[j], bp.priorities[i]
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
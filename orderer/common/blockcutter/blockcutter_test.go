@@ -18,6 +18,7 @@ package blockcutter
 
 import (
 	"testing"
+	"time"
 
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -105,3 +106,75 @@ func TestBatchSizePreferredMaxBytesOverflowNoPending(t *testing.T) {
 		assert.Len(t, batch, 1, "Should have had one normal tx in batch %d", i)
 	}
 }
+
+func TestBatchClasses(t *testing.T) {
+	RegisterClassifier("test", func(msg *cb.Envelope) string {
+		return string(msg.Payload)
+	})
+	bulk := &cb.Envelope{Payload: []byte("bulk")}
+	urgent := &cb.Envelope{Payload: []byte("urgent")}
+	other := &cb.Envelope{Payload: []byte("other")}
+
+	newReceiver := func() Receiver {
+		return NewReceiverImpl(&mockconfig.Orderer{
+			BatchSizeVal: &ab.BatchSize{
+				MaxMessageCount:   10,
+				AbsoluteMaxBytes:  1000,
+				PreferredMaxBytes: 100,
+				Classifier:        "test",
+				Classes: []*ab.ClassBatchSize{
+					{Class: "urgent", MaxMessageCount: 4},
+					{Class: "bulk"},
+				},
+			},
+			BatchTimeoutVal:       time.Second,
+			ClassBatchTimeoutsVal: map[string]time.Duration{"urgent": time.Millisecond, "bulk": time.Minute},
+		})
+	}
+
+	t.Run("Priority", func(t *testing.T) {
+		r := newReceiver()
+		for _, msg := range []*cb.Envelope{other, bulk, urgent} {
+			batches, pending := r.Ordered(msg)
+			assert.Empty(t, batches)
+			assert.True(t, pending)
+		}
+		assert.Equal(t, []*cb.Envelope{urgent, bulk, other}, r.Cut(), "Messages should be sorted by the priority of their class")
+	})
+
+	t.Run("TightestBatchSize", func(t *testing.T) {
+		r := newReceiver()
+		r.Ordered(bulk)
+		r.Ordered(urgent)
+		r.Ordered(bulk)
+		batches, pending := r.Ordered(bulk)
+		assert.False(t, pending)
+		assert.Equal(t, [][]*cb.Envelope{{urgent, bulk, bulk, bulk}}, batches, "The max message count of the urgent class should apply to the batch")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		r := newReceiver()
+		assert.Equal(t, time.Duration(0), r.Timeout(), "No timeout without pending messages")
+		r.Ordered(bulk)
+		assert.Equal(t, time.Minute, r.Timeout())
+		r.Ordered(other)
+		assert.Equal(t, time.Duration(0), r.Timeout(), "The channel batch timeout should apply")
+		r.Cut()
+		r.Ordered(urgent)
+		assert.Equal(t, time.Millisecond, r.Timeout())
+		r.Ordered(bulk)
+		assert.Equal(t, time.Millisecond, r.Timeout(), "The shortest batch timeout should apply")
+		r.Cut()
+		assert.Equal(t, time.Duration(0), r.Timeout())
+	})
+
+	t.Run("UnknownClassifier", func(t *testing.T) {
+		r := NewReceiverImpl(&mockconfig.Orderer{
+			BatchSizeVal:    &ab.BatchSize{MaxMessageCount: 2, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 100, Classifier: "unknown"},
+			BatchTimeoutVal: time.Second,
+		})
+		r.Ordered(bulk)
+		batches, _ := r.Ordered(urgent)
+		assert.Equal(t, [][]*cb.Envelope{{bulk, urgent}}, batches, "The batch size of the channel should apply")
+	})
+}
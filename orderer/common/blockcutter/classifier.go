@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+const (
+	// ConfigClassifier sorts the messages into the ConfigClass and NormalClass
+	// classes by the type of their channel header
+	ConfigClassifier = "config"
+
+	// ExtensionClassifier sorts the endorser transactions into classes named after
+	// the chaincode of their channel header extension
+	ExtensionClassifier = "extension"
+
+	// MSPClassifier sorts the messages into classes named after the MSP of their creator
+	MSPClassifier = "msp"
+)
+
+const (
+	// ConfigClass is the class of the config messages of the ConfigClassifier
+	ConfigClass = "config"
+
+	// NormalClass is the class of the other messages of the ConfigClassifier
+	NormalClass = "normal"
+)
+
+// Classifier sorts a message into one of the classes of the batch size config,
+// returning the empty string if it cannot.  The orderers of a channel must share
+// the same classifiers, which must be deterministic, for them to cut the same blocks.
+type Classifier func(msg *cb.Envelope) string
+
+var (
+	classifiersLock sync.RWMutex
+	classifiers     = map[string]Classifier{
+		ConfigClassifier:    classifyByType,
+		ExtensionClassifier: classifyByExtension,
+		MSPClassifier:       classifyByMSP,
+	}
+)
+
+// RegisterClassifier makes the classifier available to the channels under the given name,
+// replacing any classifier previously registered under this name
+func RegisterClassifier(name string, classifier Classifier) {
+	classifiersLock.Lock()
+	defer classifiersLock.Unlock()
+	classifiers[name] = classifier
+}
+
+// LookupClassifier returns the classifier registered under the given name
+func LookupClassifier(name string) (Classifier, bool) {
+	classifiersLock.RLock()
+	defer classifiersLock.RUnlock()
+	classifier, ok := classifiers[name]
+	return classifier, ok
+}
+
+func classifyByType(msg *cb.Envelope) string {
+	chdr, err := utils.ChannelHeader(msg)
+	if err != nil {
+		return ""
+	}
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG, cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_ORDERER_TRANSACTION:
+		return ConfigClass
+	default:
+		return NormalClass
+	}
+}
+
+func classifyByExtension(msg *cb.Envelope) string {
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil || cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+		return ""
+	}
+	ext, err := utils.GetChaincodeHeaderExtension(payload.Header)
+	if err != nil {
+		return ""
+	}
+	return ext.GetChaincodeId().GetName()
+}
+
+func classifyByMSP(msg *cb.Envelope) string {
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return ""
+	}
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, creator); err != nil {
+		return ""
+	}
+	return creator.Mspid
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeEnvelope(headerType cb.HeaderType, chaincode string, mspID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type: int32(headerType),
+					Extension: utils.MarshalOrPanic(&pb.ChaincodeHeaderExtension{
+						ChaincodeId: &pb.ChaincodeID{Name: chaincode},
+					}),
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+					Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID}),
+				}),
+			},
+		}),
+	}
+}
+
+func TestClassifiers(t *testing.T) {
+	tx := makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "mycc", "Org1MSP")
+	config := makeEnvelope(cb.HeaderType_ORDERER_TRANSACTION, "mycc", "Org2MSP")
+	garbage := &cb.Envelope{Payload: []byte("garbage")}
+
+	t.Run("Config", func(t *testing.T) {
+		classifier, ok := LookupClassifier(ConfigClassifier)
+		assert.True(t, ok)
+		assert.Equal(t, NormalClass, classifier(tx))
+		assert.Equal(t, ConfigClass, classifier(config))
+		assert.Empty(t, classifier(garbage))
+	})
+
+	t.Run("Extension", func(t *testing.T) {
+		classifier, ok := LookupClassifier(ExtensionClassifier)
+		assert.True(t, ok)
+		assert.Equal(t, "mycc", classifier(tx))
+		assert.Empty(t, classifier(config), "Only endorser transactions carry a chaincode header extension")
+		assert.Empty(t, classifier(garbage))
+	})
+
+	t.Run("MSP", func(t *testing.T) {
+		classifier, ok := LookupClassifier(MSPClassifier)
+		assert.True(t, ok)
+		assert.Equal(t, "Org1MSP", classifier(tx))
+		assert.Equal(t, "Org2MSP", classifier(config))
+		assert.Empty(t, classifier(garbage))
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		_, ok := LookupClassifier("custom")
+		assert.False(t, ok)
+		RegisterClassifier("custom", func(*cb.Envelope) string { return "custom" })
+		classifier, ok := LookupClassifier("custom")
+		assert.True(t, ok)
+		assert.Equal(t, "custom", classifier(garbage))
+	})
+}
@@ -9,6 +9,7 @@ package broadcast
 import (
 	"io"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
@@ -44,6 +45,9 @@ type ChannelSupportRegistrar interface {
 type ChannelSupport interface {
 	msgprocessor.Processor
 	Consenter
+
+	// SharedConfig returns the orderer config of the channel
+	SharedConfig() channelconfig.Orderer
 }
 
 // Consenter provides methods to send messages through consensus
@@ -65,13 +69,16 @@ type Consenter interface {
 }
 
 type handlerImpl struct {
-	sm ChannelSupportRegistrar
+	sm      ChannelSupportRegistrar
+	limiter *RateLimiter
 }
 
-// NewHandlerImpl constructs a new implementation of the Handler interface
-func NewHandlerImpl(sm ChannelSupportRegistrar) Handler {
+// NewHandlerImpl constructs a new implementation of the Handler interface,
+// throttling normal messages with the given limiter unless it is nil
+func NewHandlerImpl(sm ChannelSupportRegistrar, limiter *RateLimiter) Handler {
 	return &handlerImpl{
-		sm: sm,
+		sm:      sm,
+		limiter: limiter,
 	}
 }
 
@@ -110,6 +117,15 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
 			}
 
+			// The signature of the message was checked, so the channel and its creator can be charged
+			if bh.limiter != nil {
+				if err = bh.limiter.Admit(chdr.ChannelId, processor.SharedConfig().RateLimits(), msg); err != nil {
+					// Logged at debug level, as a client exceeding its limit would flood the log otherwise
+					logger.Debugf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: %s", chdr.ChannelId, addr, err)
+					return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
+				}
+			}
+
 			err = processor.Order(msg, configSeq)
 			if err != nil {
 				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
@@ -143,11 +159,16 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
+	if rejection, ok := errors.Cause(err).(*msgprocessor.RuleRejection); ok {
+		return rejection.Status
+	}
 	switch errors.Cause(err) {
 	case msgprocessor.ErrChannelDoesNotExist:
 		return cb.Status_NOT_FOUND
 	case msgprocessor.ErrPermissionDenied:
 		return cb.Status_FORBIDDEN
+	case msgprocessor.ErrMaintenanceMode, ErrRateLimitExceeded:
+		return cb.Status_SERVICE_UNAVAILABLE
 	default:
 		return cb.Status_BAD_REQUEST
 	}
@@ -12,7 +12,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -108,9 +111,14 @@ type mockSupport struct {
 	ProcessConfigEnv *cb.Envelope
 	ProcessConfigSeq uint64
 	ProcessErr       error
+	RateLimitsVal    *ab.RateLimits
 	rejectEnqueue    bool
 }
 
+func (ms *mockSupport) SharedConfig() channelconfig.Orderer {
+	return &mockconfig.Orderer{RateLimitsVal: ms.RateLimitsVal}
+}
+
 func (ms *mockSupport) WaitReady() error {
 	return nil
 }
@@ -152,7 +160,7 @@ func getMockSupportManager() *mockSupportManager {
 
 func TestEnqueueFailure(t *testing.T) {
 	mm := getMockSupportManager()
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, nil)
 	m := newMockB()
 	defer close(m.recvChan)
 	done := make(chan struct{})
@@ -190,9 +198,16 @@ func TestClassifyError(t *testing.T) {
 	t.Run("Forbidden", func(t *testing.T) {
 		assert.Equal(t, cb.Status_FORBIDDEN, ClassifyError(msgprocessor.ErrPermissionDenied))
 	})
+	t.Run("ServiceUnavailable", func(t *testing.T) {
+		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, ClassifyError(errors.Wrap(msgprocessor.ErrMaintenanceMode, "A wrapped error")))
+	})
 	t.Run("WrappedErr", func(t *testing.T) {
 		assert.Equal(t, cb.Status_NOT_FOUND, ClassifyError(errors.Wrap(msgprocessor.ErrChannelDoesNotExist, "A wrapped error")))
 	})
+	t.Run("RuleRejection", func(t *testing.T) {
+		rejection := &msgprocessor.RuleRejection{Status: cb.Status_REQUEST_ENTITY_TOO_LARGE, Reason: "too large"}
+		assert.Equal(t, cb.Status_REQUEST_ENTITY_TOO_LARGE, ClassifyError(errors.WithMessage(rejection, "rejected by admission rule MaxReadWriteSetSize")))
+	})
 	t.Run("DefaultBadReq", func(t *testing.T) {
 		assert.Equal(t, cb.Status_BAD_REQUEST, ClassifyError(fmt.Errorf("Foo")))
 	})
@@ -201,7 +216,7 @@ func TestClassifyError(t *testing.T) {
 func TestBadChannelId(t *testing.T) {
 	mm := getMockSupportManager()
 	mm.MsgProcessorVal = &mockSupport{ProcessErr: msgprocessor.ErrChannelDoesNotExist}
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, nil)
 	m := newMockB()
 	defer close(m.recvChan)
 	done := make(chan struct{})
@@ -226,7 +241,7 @@ func TestBadChannelId(t *testing.T) {
 func TestGoodConfigUpdate(t *testing.T) {
 	mm := getMockSupportManager()
 	mm.MsgProcessorIsConfig = true
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, nil)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -240,7 +255,7 @@ func TestBadConfigUpdate(t *testing.T) {
 	mm := getMockSupportManager()
 	mm.MsgProcessorIsConfig = true
 	mm.MsgProcessorVal.ProcessErr = fmt.Errorf("Error")
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, nil)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -251,7 +266,7 @@ func TestBadConfigUpdate(t *testing.T) {
 }
 
 func TestGracefulShutdown(t *testing.T) {
-	bh := NewHandlerImpl(nil)
+	bh := NewHandlerImpl(nil, nil)
 	m := newMockB()
 	close(m.recvChan)
 	assert.NoError(t, bh.Handle(m), "Should exit normally upon EOF")
@@ -261,7 +276,7 @@ func TestRejected(t *testing.T) {
 	mm := &mockSupportManager{
 		MsgProcessorVal: &mockSupport{ProcessErr: fmt.Errorf("Reject")},
 	}
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, nil)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -273,13 +288,91 @@ func TestRejected(t *testing.T) {
 }
 
 func TestBadStreamRecv(t *testing.T) {
-	bh := NewHandlerImpl(nil)
+	bh := NewHandlerImpl(nil, nil)
 	assert.Error(t, bh.Handle(&erroneousRecvMockB{}), "Should catch unexpected stream error")
 }
 
 func TestBadStreamSend(t *testing.T) {
 	mm := getMockSupportManager()
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, nil)
 	m := &erroneousSendMockB{recvVal: nil}
 	assert.Error(t, bh.Handle(m), "Should catch unexpected stream error")
 }
+
+func TestRateLimited(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.MsgProcessorVal.RateLimitsVal = &ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 1}}
+	bh := NewHandlerImpl(mm, NewRateLimiter(&ab.RateLimits{}, metrics.GetRootScope()))
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- nil
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have admitted the first message")
+
+	m.recvChan <- nil
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have rate limited the second message")
+	assert.Contains(t, reply.Info, "the channel reached its limit of 1 messages per second, retry after")
+}
+
+func TestRateLimitedAfterAuthentication(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.MsgProcessorVal.RateLimitsVal = &ab.RateLimits{Organization: &ab.RateLimit{MessagesPerSecond: 1}}
+	bh := NewHandlerImpl(mm, NewRateLimiter(&ab.RateLimits{}, metrics.GetRootScope()))
+
+	// A message failing authentication should not be charged to the organization it claims
+	mm.MsgProcessorVal.ProcessErr = msgprocessor.ErrPermissionDenied
+	forged := newMockB()
+	defer close(forged.recvChan)
+	go bh.Handle(forged)
+	forged.recvChan <- envelopeFrom("Org1MSP", "client1")
+	reply := <-forged.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status)
+
+	mm.MsgProcessorVal.ProcessErr = nil
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- envelopeFrom("Org1MSP", "client1")
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have admitted the first authenticated message")
+
+	m.recvChan <- envelopeFrom("Org1MSP", "client1")
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have rate limited the second message")
+	assert.Contains(t, reply.Info, "organization Org1MSP reached its limit of 1 messages per second, retry after")
+}
+
+func TestRejectedMessagesNotChargedToChannel(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.MsgProcessorVal.RateLimitsVal = &ab.RateLimits{
+		Channel:      &ab.RateLimit{MessagesPerSecond: 2},
+		Organization: &ab.RateLimit{MessagesPerSecond: 1},
+	}
+	bh := NewHandlerImpl(mm, NewRateLimiter(&ab.RateLimits{}, metrics.GetRootScope()))
+
+	broadcast := func(msg *cb.Envelope) *ab.BroadcastResponse {
+		m := newMockB()
+		defer close(m.recvChan)
+		go bh.Handle(m)
+		m.recvChan <- msg
+		return <-m.sendChan
+	}
+
+	mm.MsgProcessorVal.ProcessErr = msgprocessor.ErrPermissionDenied
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, cb.Status_FORBIDDEN, broadcast(envelopeFrom("Org1MSP", "client1")).Status)
+	}
+
+	mm.MsgProcessorVal.ProcessErr = nil
+	assert.Equal(t, cb.Status_SUCCESS, broadcast(envelopeFrom("Org1MSP", "client1")).Status, "Messages failing authentication should not have drained the channel bucket")
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, broadcast(envelopeFrom("Org1MSP", "client2")).Status, "Should have rate limited the organization")
+	assert.Equal(t, cb.Status_SUCCESS, broadcast(envelopeFrom("Org2MSP", "client1")).Status, "Messages over the organization limit should not have drained the channel bucket")
+
+	reply := broadcast(envelopeFrom("Org3MSP", "client1"))
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status)
+	assert.Contains(t, reply.Info, "the channel reached its limit of 2 messages per second")
+}
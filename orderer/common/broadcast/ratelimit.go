@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrRateLimitExceeded is returned when a message is rejected because one of
+// the rate limits it is subject to was reached
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+const (
+	channelLimit      = "channel"
+	organizationLimit = "organization"
+	clientLimit       = "client"
+
+	// minSweepThreshold is the number of buckets below which idle buckets are not evicted
+	minSweepThreshold = 1024
+)
+
+type bucketKey struct {
+	channel string
+	limit   string
+	subject string
+}
+
+// bucket is a token bucket, holding up to the burst of its limit and refilled
+// at the rate of its limit
+type bucket struct {
+	limit  ab.RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func capacity(limit *ab.RateLimit) float64 {
+	if limit.Burst == 0 {
+		return float64(limit.MessagesPerSecond)
+	}
+	return float64(limit.Burst)
+}
+
+// refill adds the tokens accumulated since the last refill, adopting the given limit
+func (b *bucket) refill(limit *ab.RateLimit, now time.Time) {
+	b.limit = *limit
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * float64(limit.MessagesPerSecond)
+		b.last = now
+	}
+	b.tokens = math.Min(b.tokens, capacity(limit))
+}
+
+// full returns whether the bucket would be full at the given time, in which case
+// it is indistinguishable from a new bucket
+func (b *bucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*float64(b.limit.MessagesPerSecond) >= capacity(&b.limit)
+}
+
+// wait returns the time until the bucket holds a token
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	wait := time.Duration((1 - b.tokens) / float64(b.limit.MessagesPerSecond) * float64(time.Second))
+	// Round up, so that retrying after the wait finds the token
+	return (wait + time.Millisecond - 1).Truncate(time.Millisecond)
+}
+
+// RateLimiter throttles the messages broadcast to the channels with token buckets
+// keyed by channel, by MSP ID and by client certificate.  The limits of the orderer
+// local configuration apply unless the channel config overrides them.
+type RateLimiter struct {
+	defaults *ab.RateLimits
+	metrics  metrics.Scope
+	now      func() time.Time
+
+	lock           sync.Mutex
+	buckets        map[bucketKey]*bucket
+	sweepThreshold int
+}
+
+// NewRateLimiter creates a RateLimiter enforcing the given default limits and
+// reporting the rejected messages to the given metrics scope
+func NewRateLimiter(defaults *ab.RateLimits, scope metrics.Scope) *RateLimiter {
+	return &RateLimiter{
+		defaults:       defaults,
+		metrics:        scope,
+		now:            time.Now,
+		buckets:        make(map[bucketKey]*bucket),
+		sweepThreshold: minSweepThreshold,
+	}
+}
+
+type limitCheck struct {
+	key   bucketKey
+	limit *ab.RateLimit
+}
+
+// addCheck appends the check of the bucket of the given limit and subject to checks,
+// unless the limit is disabled
+func addCheck(checks []limitCheck, channelID, limit, subject string, override, fallback *ab.RateLimit) []limitCheck {
+	if override == nil {
+		override = fallback
+	}
+	if override.GetMessagesPerSecond() == 0 {
+		return checks
+	}
+	return append(checks, limitCheck{
+		key:   bucketKey{channel: channelID, limit: limit, subject: subject},
+		limit: override,
+	})
+}
+
+// Admit takes a token from the buckets of the channel, and of the organization and
+// the client which created the message, or returns an error wrapping ErrRateLimitExceeded
+// and indicating when to retry if one of them is empty, in which case no token is taken.
+// The buckets of the creator are keyed on the creator carried by the message, so it must
+// only be called once the signature of the message has been checked, lest a client
+// exhaust the buckets of another, or the rejected messages the bucket of the channel.
+func (rl *RateLimiter) Admit(channelID string, overrides *ab.RateLimits, msg *cb.Envelope) error {
+	checks := addCheck(nil, channelID, channelLimit, "", overrides.GetChannel(), rl.defaults.GetChannel())
+	mspID, clientID := creatorOf(msg)
+	if mspID != "" {
+		checks = addCheck(checks, channelID, organizationLimit, mspID, overrides.GetOrganization(), rl.defaults.GetOrganization())
+		checks = addCheck(checks, channelID, clientLimit, mspID+"/"+clientID, overrides.GetClient(), rl.defaults.GetClient())
+	}
+	return rl.admit(channelID, mspID, checks)
+}
+
+// admit takes a token from each of the buckets of checks, unless one of them is empty
+func (rl *RateLimiter) admit(channelID, mspID string, checks []limitCheck) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	now := rl.now()
+
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	var exceeded *limitCheck
+	var retryAfter time.Duration
+	buckets := make([]*bucket, len(checks))
+	for i := range checks {
+		b, ok := rl.buckets[checks[i].key]
+		if !ok {
+			b = &bucket{tokens: capacity(checks[i].limit), last: now}
+			rl.buckets[checks[i].key] = b
+		}
+		b.refill(checks[i].limit, now)
+		if wait := b.wait(); wait > retryAfter {
+			exceeded, retryAfter = &checks[i], wait
+		}
+		buckets[i] = b
+	}
+
+	if exceeded != nil {
+		tags := map[string]string{"channel": channelID, "limit": exceeded.key.limit}
+		if exceeded.key.limit != channelLimit {
+			tags["msp"] = mspID
+		}
+		rl.metrics.Tagged(tags).Counter("rate_limited").Inc(1)
+
+		subject := "the channel"
+		switch exceeded.key.limit {
+		case organizationLimit:
+			subject = fmt.Sprintf("organization %s", mspID)
+		case clientLimit:
+			subject = fmt.Sprintf("client of organization %s", mspID)
+		}
+		return errors.WithMessage(ErrRateLimitExceeded, fmt.Sprintf("%s reached its limit of %d messages per second, retry after %s",
+			subject, exceeded.limit.MessagesPerSecond, retryAfter))
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	if len(rl.buckets) >= rl.sweepThreshold {
+		rl.sweep(now)
+	}
+
+	return nil
+}
+
+// sweep evicts the buckets which have been refilled, as they are equivalent to new ones
+func (rl *RateLimiter) sweep(now time.Time) {
+	for key, b := range rl.buckets {
+		if b.full(now) {
+			delete(rl.buckets, key)
+		}
+	}
+	rl.sweepThreshold = 2 * len(rl.buckets)
+	if rl.sweepThreshold < minSweepThreshold {
+		rl.sweepThreshold = minSweepThreshold
+	}
+}
+
+// creatorOf returns the MSP ID of the creator of the message and a digest of its
+// certificate, or empty strings if the message does not carry a creator
+func creatorOf(msg *cb.Envelope) (string, string) {
+	payload, err := utils.UnmarshalPayload(msg.GetPayload())
+	if err != nil || payload.Header == nil {
+		return "", ""
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return "", ""
+	}
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, creator); err != nil || creator.Mspid == "" {
+		return "", ""
+	}
+	digest := sha256.Sum256(creator.IdBytes)
+	return creator.Mspid, hex.EncodeToString(digest[:])
+}
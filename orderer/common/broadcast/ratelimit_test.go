@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func envelopeFrom(mspID string, cert string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+					Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(cert)}),
+				}),
+			},
+		}),
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func newTestRateLimiter(defaults *ab.RateLimits) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	rl := NewRateLimiter(defaults, metrics.GetRootScope())
+	rl.now = clock.Now
+	return rl, clock
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	rl, _ := newTestRateLimiter(&ab.RateLimits{})
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+	}
+	assert.Empty(t, rl.buckets)
+}
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	rl, clock := newTestRateLimiter(&ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 10, Burst: 3}})
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, rl.Admit("foo", nil, nil), "Should admit the burst")
+	}
+	err := rl.Admit("foo", nil, nil)
+	assert.Equal(t, ErrRateLimitExceeded, errors.Cause(err))
+	assert.Contains(t, err.Error(), "the channel reached its limit of 10 messages per second, retry after 100ms")
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, ClassifyError(err))
+
+	clock.Advance(50 * time.Millisecond)
+	err = rl.Admit("foo", nil, nil)
+	assert.Contains(t, err.Error(), "retry after 50ms")
+
+	clock.Advance(50 * time.Millisecond)
+	assert.NoError(t, rl.Admit("foo", nil, nil), "Should admit a message once refilled")
+
+	assert.NoError(t, rl.Admit("bar", nil, nil), "Channels should have their own buckets")
+}
+
+func TestRateLimiterOrganizationsAndClients(t *testing.T) {
+	rl, _ := newTestRateLimiter(&ab.RateLimits{
+		Organization: &ab.RateLimit{MessagesPerSecond: 3},
+		Client:       &ab.RateLimit{MessagesPerSecond: 2},
+	})
+
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+	err := rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1"))
+	assert.Contains(t, err.Error(), "client of organization Org1MSP reached its limit of 2 messages per second")
+
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client2")))
+	err = rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client2"))
+	assert.Contains(t, err.Error(), "organization Org1MSP reached its limit of 3 messages per second")
+
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org2MSP", "client1")), "Organizations should have their own buckets")
+	assert.NoError(t, rl.Admit("foo", nil, nil), "A message without creator should not be subject to these limits")
+}
+
+func TestRateLimiterRejectionTakesNoToken(t *testing.T) {
+	rl, _ := newTestRateLimiter(&ab.RateLimits{
+		Organization: &ab.RateLimit{MessagesPerSecond: 2},
+		Client:       &ab.RateLimit{MessagesPerSecond: 1},
+	})
+
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+	assert.Error(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+	assert.Error(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client2")), "The rejected messages should not have drained the organization bucket")
+
+	t.Run("Channel", func(t *testing.T) {
+		rl, _ := newTestRateLimiter(&ab.RateLimits{
+			Channel: &ab.RateLimit{MessagesPerSecond: 2},
+			Client:  &ab.RateLimit{MessagesPerSecond: 1},
+		})
+
+		assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+		assert.Error(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+		assert.Error(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client1")))
+		assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client2")), "The rejected messages should not have drained the channel bucket")
+		err := rl.Admit("foo", nil, envelopeFrom("Org1MSP", "client3"))
+		assert.Contains(t, err.Error(), "the channel reached its limit of 2 messages per second")
+	})
+}
+
+func TestRateLimiterOverrides(t *testing.T) {
+	rl, clock := newTestRateLimiter(&ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 1}})
+
+	overrides := &ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 2}}
+	assert.NoError(t, rl.Admit("foo", overrides, nil))
+	assert.NoError(t, rl.Admit("foo", overrides, nil))
+	assert.Error(t, rl.Admit("foo", overrides, nil))
+
+	t.Run("Disabled", func(t *testing.T) {
+		overrides := &ab.RateLimits{Channel: &ab.RateLimit{}}
+		assert.NoError(t, rl.Admit("foo", overrides, nil), "A limit of 0 should disable the default limit")
+	})
+
+	t.Run("Lowered", func(t *testing.T) {
+		clock.Advance(time.Second)
+		assert.NoError(t, rl.Admit("foo", nil, nil))
+		assert.Error(t, rl.Admit("foo", nil, nil), "The bucket should have adopted the default limit")
+	})
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	rl, clock := newTestRateLimiter(&ab.RateLimits{Client: &ab.RateLimit{MessagesPerSecond: 1}})
+
+	for i := 0; i < minSweepThreshold-1; i++ {
+		assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", fmt.Sprintf("client%d", i))))
+	}
+	assert.Len(t, rl.buckets, minSweepThreshold-1)
+
+	clock.Advance(time.Second)
+	assert.NoError(t, rl.Admit("foo", nil, envelopeFrom("Org1MSP", "last")))
+	assert.Len(t, rl.buckets, 1, "Only the bucket which is not refilled yet should be kept")
+	assert.Equal(t, minSweepThreshold, rl.sweepThreshold)
+}
@@ -62,11 +62,15 @@ func init() {
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
+	General        General
+	FileLedger     FileLedger
+	RAMLedger      RAMLedger
+	Kafka          Kafka
+	RateLimiting   RateLimiting
+	Replication    Replication
+	AdmissionRules AdmissionRules
+	Metrics        Metrics
+	Debug          Debug
 }
 
 // General contains config which should be common among all orderer types.
@@ -135,6 +139,17 @@ type Kafka struct {
 	Verbose bool
 	Version sarama.KafkaVersion // TODO Move this to global config
 	TLS     TLS
+	SASL    SASL
+}
+
+// SASL contains configuration for the SASL authentication of the orderer
+// with the Kafka cluster. Only the SASL/PLAIN mechanism is supported by the
+// Kafka client, which sends the password to the brokers, so TLS should be
+// enabled as well.
+type SASL struct {
+	Enabled  bool
+	User     string
+	Password string
 }
 
 // Retry contains configuration related to retries and timeouts when the
@@ -180,6 +195,64 @@ type Consumer struct {
 	RetryBackoff time.Duration
 }
 
+// RateLimiting contains configuration for the rate limits on the normal
+// messages broadcast to the orderer, which channels may override.
+type RateLimiting struct {
+	Channel      RateLimit
+	Organization RateLimit
+	Client       RateLimit
+}
+
+// RateLimit contains configuration for a token bucket limit, which is
+// disabled when MessagesPerSecond is 0.
+type RateLimit struct {
+	MessagesPerSecond uint32
+	Burst             uint32
+}
+
+// Replication contains configuration for the replication of the chains of
+// existing orderers into the ledger of a new orderer.
+type Replication struct {
+	Endpoints     []string
+	Timeout       time.Duration
+	RetryInterval time.Duration
+	MaxRetries    int
+}
+
+// AdmissionRules contains configuration for the admission rules which the channel
+// configs may select, in addition to the rules compiled into the orderer.
+type AdmissionRules struct {
+	Plugins []AdmissionRulePlugin
+}
+
+// AdmissionRulePlugin contains configuration for an admission rule loaded from a
+// Go plugin.
+type AdmissionRulePlugin struct {
+	Name    string
+	Library string
+}
+
+// Metrics contains configuration for the reporting of the orderer metrics.
+type Metrics struct {
+	Enabled        bool
+	Reporter       string
+	Interval       time.Duration
+	StatsdReporter StatsdReporter
+	PromReporter   PromReporter
+}
+
+// StatsdReporter contains configuration for pushing metrics to a statsd server.
+type StatsdReporter struct {
+	Address       string
+	FlushInterval time.Duration
+	FlushBytes    int
+}
+
+// PromReporter contains configuration for serving metrics to Prometheus.
+type PromReporter struct {
+	ListenAddress string
+}
+
 // Debug contains configuration for the orderer's debug parameters
 type Debug struct {
 	BroadcastTraceDir string
@@ -243,6 +316,27 @@ var defaults = TopLevel{
 		TLS: TLS{
 			Enabled: false,
 		},
+		SASL: SASL{
+			Enabled: false,
+		},
+	},
+	Replication: Replication{
+		Timeout:       10 * time.Second,
+		RetryInterval: 5 * time.Second,
+		MaxRetries:    12,
+	},
+	Metrics: Metrics{
+		Enabled:  false,
+		Reporter: "statsd",
+		Interval: 1 * time.Second,
+		StatsdReporter: StatsdReporter{
+			Address:       "0.0.0.0:8125",
+			FlushInterval: 2 * time.Second,
+			FlushBytes:    1432,
+		},
+		PromReporter: PromReporter{
+			ListenAddress: "0.0.0.0:8080",
+		},
 	},
 	Debug: Debug{
 		BroadcastTraceDir: "",
@@ -324,6 +418,11 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.RootCAs == nil:
 			logger.Panicf("General.Kafka.TLS.CertificatePool must be set if General.Kafka.TLS.Enabled is set to true.")
 
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.User == "":
+			logger.Panicf("Kafka.SASL.User must be set if Kafka.SASL.Enabled is set to true.")
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.Password == "":
+			logger.Panicf("Kafka.SASL.Password must be set if Kafka.SASL.Enabled is set to true.")
+
 		case c.General.Profile.Enabled && c.General.Profile.Address == "":
 			logger.Infof("Profiling enabled and General.Profile.Address unset, setting to %s", defaults.General.Profile.Address)
 			c.General.Profile.Address = defaults.General.Profile.Address
@@ -339,6 +438,24 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = defaults.General.Authentication.TimeWindow
 
+		case len(c.Replication.Endpoints) > 0 && c.Replication.Timeout == 0:
+			logger.Infof("Replication.Timeout unset, setting to %s", defaults.Replication.Timeout)
+			c.Replication.Timeout = defaults.Replication.Timeout
+		case len(c.Replication.Endpoints) > 0 && c.Replication.RetryInterval == 0:
+			logger.Infof("Replication.RetryInterval unset, setting to %s", defaults.Replication.RetryInterval)
+			c.Replication.RetryInterval = defaults.Replication.RetryInterval
+
+		case c.Metrics.Enabled && c.Metrics.Reporter == "":
+			logger.Infof("Metrics.Reporter unset, setting to %s", defaults.Metrics.Reporter)
+			c.Metrics.Reporter = defaults.Metrics.Reporter
+		case c.Metrics.Enabled && c.Metrics.Interval == 0:
+			logger.Infof("Metrics.Interval unset, setting to %s", defaults.Metrics.Interval)
+			c.Metrics.Interval = defaults.Metrics.Interval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushInterval == 0:
+			c.Metrics.StatsdReporter.FlushInterval = defaults.Metrics.StatsdReporter.FlushInterval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushBytes == 0:
+			c.Metrics.StatsdReporter.FlushBytes = defaults.Metrics.StatsdReporter.FlushBytes
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = defaults.FileLedger.Prefix
@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -123,6 +126,46 @@ func TestKafkaTLSConfig(t *testing.T) {
 	}
 }
 
+func TestKafkaSASLConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sasl        SASL
+		shouldPanic bool
+	}{
+		{"Disabled", SASL{Enabled: false}, false},
+		{"EnabledNoUser", SASL{Enabled: true, Password: "secret"}, true},
+		{"EnabledNoPassword", SASL{Enabled: true, User: "orderer"}, true},
+		{"Enabled", SASL{Enabled: true, User: "orderer", Password: "secret"}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{Kafka: Kafka{SASL: tc.sasl}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization(DummyPath) }, "should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization(DummyPath) }, "should not panic")
+			}
+		})
+	}
+}
+
+func TestKafkaSASLPasswordFromFile(t *testing.T) {
+	passwordFile, err := ioutil.TempFile("", "password")
+	assert.NoError(t, err)
+	defer os.Remove(passwordFile.Name())
+	_, err = passwordFile.WriteString("secret")
+	assert.NoError(t, err)
+	passwordFile.Close()
+
+	config := viper.New()
+	config.SetConfigType("yaml")
+	yaml := fmt.Sprintf("Kafka:\n  SASL:\n    Enabled: true\n    User: orderer\n    Password:\n      File: %s\n", passwordFile.Name())
+	assert.NoError(t, config.ReadConfig(bytes.NewReader([]byte(yaml))))
+	var uconf TopLevel
+	assert.NoError(t, viperutil.EnhancedExactUnmarshal(config, &uconf))
+	assert.Equal(t, "secret", uconf.Kafka.SASL.Password)
+}
+
 func TestSystemChannel(t *testing.T) {
 	conf, _ := Load()
 	assert.Equal(t, genesisconfig.TestChainID, conf.General.SystemChannel, "System channel ID should be '%s' by default", genesisconfig.TestChainID)
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// MaintenanceFilterSupport provides the resources needed by the maintenance filter.
+type MaintenanceFilterSupport interface {
+	// OrdererConfig returns the config.Orderer for the channel
+	// and whether the Orderer config exists
+	OrdererConfig() (channelconfig.Orderer, bool)
+
+	// ConfigtxValidator returns the configtx.Validator for the channel
+	ConfigtxValidator() configtx.Validator
+}
+
+// NewMaintenanceFilter returns a rule that, while the channel is in maintenance mode,
+// rejects every message but the config updates of the channel itself. This stops the
+// ingress of transactions, and the creation of channels on the system channel, while
+// the consensus type is migrated.
+func NewMaintenanceFilter(support MaintenanceFilterSupport) Rule {
+	return &maintenanceFilter{support: support}
+}
+
+type maintenanceFilter struct {
+	support MaintenanceFilterSupport
+}
+
+// Apply rejects the message with ErrMaintenanceMode if the channel is in maintenance mode
+// and the message is not a config update of the channel
+func (mf *maintenanceFilter) Apply(message *cb.Envelope) error {
+	ordererConf, ok := mf.support.OrdererConfig()
+	if !ok {
+		logger.Panic("Programming error: orderer config not found")
+	}
+	if ordererConf.ConsensusState() != ab.ConsensusType_STATE_MAINTENANCE {
+		return nil
+	}
+
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.Wrap(err, "could not get the channel header")
+	}
+
+	channelID := mf.support.ConfigtxValidator().ChainID()
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_CONFIG:
+		if chdr.ChannelId == channelID {
+			return nil
+		}
+		return errors.WithMessage(ErrMaintenanceMode, "channel creation is not permitted")
+	case cb.HeaderType_ORDERER_TRANSACTION:
+		return errors.WithMessage(ErrMaintenanceMode, "channel creation is not permitted")
+	default:
+		return errors.WithMessage(ErrMaintenanceMode, "only config updates of the channel are permitted")
+	}
+}
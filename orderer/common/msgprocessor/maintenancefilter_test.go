@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMaintenanceFilterSupport(state ab.ConsensusType_State) *mockconfig.Resources {
+	return &mockconfig.Resources{
+		ConfigtxValidatorVal: &mockconfigtx.Validator{ChainIDVal: "testchannel"},
+		OrdererConfigVal:     &mockconfig.Orderer{ConsensusTypeVal: "kafka", ConsensusStateVal: state},
+	}
+}
+
+func makeMaintenanceTestEnvelope(headerType cb.HeaderType, channelID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: channelID,
+				}),
+			},
+		}),
+	}
+}
+
+func TestMaintenanceFilter(t *testing.T) {
+	testCases := []struct {
+		name        string
+		state       ab.ConsensusType_State
+		headerType  cb.HeaderType
+		channelID   string
+		expectedErr string
+	}{
+		{"NormalTransaction", ab.ConsensusType_STATE_NORMAL, cb.HeaderType_ENDORSER_TRANSACTION, "testchannel", ""},
+		{"NormalChannelCreation", ab.ConsensusType_STATE_NORMAL, cb.HeaderType_ORDERER_TRANSACTION, "testchannel", ""},
+		{"MaintenanceConfigUpdate", ab.ConsensusType_STATE_MAINTENANCE, cb.HeaderType_CONFIG_UPDATE, "testchannel", ""},
+		{"MaintenanceConfig", ab.ConsensusType_STATE_MAINTENANCE, cb.HeaderType_CONFIG, "testchannel", ""},
+		{"MaintenanceTransaction", ab.ConsensusType_STATE_MAINTENANCE, cb.HeaderType_ENDORSER_TRANSACTION, "testchannel", "only config updates of the channel are permitted"},
+		{"MaintenanceChannelCreation", ab.ConsensusType_STATE_MAINTENANCE, cb.HeaderType_ORDERER_TRANSACTION, "testchannel", "channel creation is not permitted"},
+		{"MaintenanceOtherChannelConfigUpdate", ab.ConsensusType_STATE_MAINTENANCE, cb.HeaderType_CONFIG_UPDATE, "otherchannel", "channel creation is not permitted"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mf := NewMaintenanceFilter(newMaintenanceFilterSupport(tc.state))
+			err := mf.Apply(makeMaintenanceTestEnvelope(tc.headerType, tc.channelID))
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+
+	t.Run("BadChannelHeader", func(t *testing.T) {
+		mf := NewMaintenanceFilter(newMaintenanceFilterSupport(ab.ConsensusType_STATE_MAINTENANCE))
+		err := mf.Apply(&cb.Envelope{Payload: []byte("garbage")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "could not get the channel header")
+	})
+}
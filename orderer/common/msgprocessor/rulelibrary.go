@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// RuleLibrary holds the admission rules compiled into the orderer, which the
+// channel configs select by the names of its methods
+type RuleLibrary struct{}
+
+// RevokedCreator rejects the messages whose creator is not a valid identity of the
+// MSPs of the channel, such as an identity revoked by the CRLs of its MSP.
+// It takes no options.
+func (rl *RuleLibrary) RevokedCreator(support channelconfig.Resources, options map[string]string) (Rule, error) {
+	if len(options) > 0 {
+		return nil, errors.New("the rule takes no options")
+	}
+	return &revokedCreatorRule{support: support}, nil
+}
+
+type revokedCreatorRule struct {
+	support channelconfig.Resources
+}
+
+func (r *revokedCreatorRule) Apply(message *cb.Envelope) error {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return errors.New("the message has no header")
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return errors.Wrap(err, "could not get the signature header")
+	}
+
+	identity, err := r.support.MSPManager().DeserializeIdentity(shdr.Creator)
+	if err != nil {
+		return &RuleRejection{Status: cb.Status_FORBIDDEN, Reason: fmt.Sprintf("the creator of the message is not a member of the channel: %s", err)}
+	}
+	if err := identity.Validate(); err != nil {
+		return &RuleRejection{Status: cb.Status_FORBIDDEN, Reason: fmt.Sprintf("the creator of the message is not valid: %s", err)}
+	}
+	return nil
+}
+
+// ChaincodeNamespaces rejects the endorser transactions reading or writing namespaces
+// other than those listed, separated by commas, by the namespaces option.
+func (rl *RuleLibrary) ChaincodeNamespaces(support channelconfig.Resources, options map[string]string) (Rule, error) {
+	namespaces := make(map[string]bool)
+	for _, namespace := range strings.Split(options["namespaces"], ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces[namespace] = true
+		}
+	}
+	if len(namespaces) == 0 {
+		return nil, errors.New("the namespaces option lists no namespace")
+	}
+	return &chaincodeNamespacesRule{namespaces: namespaces}, nil
+}
+
+type chaincodeNamespacesRule struct {
+	namespaces map[string]bool
+}
+
+func (r *chaincodeNamespacesRule) Apply(message *cb.Envelope) error {
+	actions, err := chaincodeActions(message)
+	if err != nil {
+		return err
+	}
+	for _, action := range actions {
+		txRWSet := &rwset.TxReadWriteSet{}
+		if err := proto.Unmarshal(action.Results, txRWSet); err != nil {
+			return errors.Wrap(err, "could not unmarshal the read-write set")
+		}
+		for _, nsRWSet := range txRWSet.NsRwset {
+			if !r.namespaces[nsRWSet.Namespace] {
+				return &RuleRejection{Status: cb.Status_FORBIDDEN, Reason: fmt.Sprintf("namespace %s is not permitted", nsRWSet.Namespace)}
+			}
+		}
+	}
+	return nil
+}
+
+// MaxReadWriteSetSize rejects the endorser transactions whose read-write sets add up
+// to more bytes than the maxbytes option.
+func (rl *RuleLibrary) MaxReadWriteSetSize(support channelconfig.Resources, options map[string]string) (Rule, error) {
+	maxBytes, err := strconv.ParseUint(options["maxbytes"], 10, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid maxbytes option")
+	}
+	return &maxReadWriteSetSizeRule{maxBytes: int(maxBytes)}, nil
+}
+
+type maxReadWriteSetSizeRule struct {
+	maxBytes int
+}
+
+func (r *maxReadWriteSetSizeRule) Apply(message *cb.Envelope) error {
+	actions, err := chaincodeActions(message)
+	if err != nil {
+		return err
+	}
+	size := 0
+	for _, action := range actions {
+		size += len(action.Results)
+	}
+	if size > r.maxBytes {
+		return &RuleRejection{
+			Status: cb.Status_REQUEST_ENTITY_TOO_LARGE,
+			Reason: fmt.Sprintf("the read-write sets of the transaction add up to %d bytes, more than the maximum of %d bytes", size, r.maxBytes),
+		}
+	}
+	return nil
+}
+
+// chaincodeActions returns the chaincode actions of the message if it is an endorser transaction
+func chaincodeActions(message *cb.Envelope) ([]*pb.ChaincodeAction, error) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return nil, errors.New("the message has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the channel header")
+	}
+	if cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal the transaction")
+	}
+	var actions []*pb.ChaincodeAction
+	for _, txAction := range tx.Actions {
+		actionPayload, err := utils.GetChaincodeActionPayload(txAction.Payload)
+		if err != nil || actionPayload.Action == nil {
+			return nil, errors.New("the transaction has a malformed action payload")
+		}
+		prp, err := utils.GetProposalResponsePayload(actionPayload.Action.ProposalResponsePayload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal the proposal response payload")
+		}
+		action, err := utils.GetChaincodeAction(prp.Extension)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal the chaincode action")
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
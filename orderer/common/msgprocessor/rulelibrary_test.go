@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIdentity struct {
+	msp.Identity
+	validateErr error
+}
+
+func (mi *mockIdentity) Validate() error {
+	return mi.validateErr
+}
+
+// mockMSPManager deserializes the creators "valid" and "revoked"
+type mockMSPManager struct {
+	msp.MSPManager
+}
+
+func (mm *mockMSPManager) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	switch string(serializedIdentity) {
+	case "valid":
+		return &mockIdentity{}, nil
+	case "revoked":
+		return &mockIdentity{validateErr: errors.New("the certificate has been revoked")}, nil
+	default:
+		return nil, errors.New("unknown MSP")
+	}
+}
+
+// makeEndorserTestTransaction returns an endorser transaction created by creator with one
+// action per read-write set
+func makeEndorserTestTransaction(creator string, txRWSets ...*rwset.TxReadWriteSet) *cb.Envelope {
+	tx := &pb.Transaction{}
+	for _, txRWSet := range txRWSets {
+		tx.Actions = append(tx.Actions, &pb.TransactionAction{
+			Payload: utils.MarshalOrPanic(&pb.ChaincodeActionPayload{
+				Action: &pb.ChaincodeEndorsedAction{
+					ProposalResponsePayload: utils.MarshalOrPanic(&pb.ProposalResponsePayload{
+						Extension: utils.MarshalOrPanic(&pb.ChaincodeAction{
+							Results: utils.MarshalOrPanic(txRWSet),
+						}),
+					}),
+				},
+			}),
+		})
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "testchannel",
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+					Creator: []byte(creator),
+				}),
+			},
+			Data: utils.MarshalOrPanic(tx),
+		}),
+	}
+}
+
+func namespacesRWSet(namespaces ...string) *rwset.TxReadWriteSet {
+	txRWSet := &rwset.TxReadWriteSet{}
+	for _, namespace := range namespaces {
+		txRWSet.NsRwset = append(txRWSet.NsRwset, &rwset.NsReadWriteSet{Namespace: namespace, Rwset: []byte("rwset")})
+	}
+	return txRWSet
+}
+
+func assertRejection(t *testing.T, err error, status cb.Status, reason string) {
+	rejection, ok := err.(*RuleRejection)
+	if assert.True(t, ok, "expected a rule rejection, got %v", err) {
+		assert.Equal(t, status, rejection.Status)
+		assert.Contains(t, rejection.Reason, reason)
+	}
+}
+
+func TestRevokedCreator(t *testing.T) {
+	rl := &RuleLibrary{}
+	support := newAdmissionTestSupport()
+	support.MSPManagerVal = &mockMSPManager{}
+
+	_, err := rl.RevokedCreator(support, map[string]string{"foo": "bar"})
+	assert.EqualError(t, err, "the rule takes no options")
+
+	rule, err := rl.RevokedCreator(support, nil)
+	assert.NoError(t, err)
+
+	t.Run("ValidCreator", func(t *testing.T) {
+		assert.NoError(t, rule.Apply(makeEndorserTestTransaction("valid")))
+	})
+
+	t.Run("RevokedCreator", func(t *testing.T) {
+		err := rule.Apply(makeEndorserTestTransaction("revoked"))
+		assertRejection(t, err, cb.Status_FORBIDDEN, "the creator of the message is not valid: the certificate has been revoked")
+	})
+
+	t.Run("UnknownCreator", func(t *testing.T) {
+		err := rule.Apply(makeEndorserTestTransaction("stranger"))
+		assertRejection(t, err, cb.Status_FORBIDDEN, "the creator of the message is not a member of the channel: unknown MSP")
+	})
+
+	t.Run("NoHeader", func(t *testing.T) {
+		err := rule.Apply(&cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{})})
+		assert.EqualError(t, err, "the message has no header")
+	})
+}
+
+func TestChaincodeNamespaces(t *testing.T) {
+	rl := &RuleLibrary{}
+
+	_, err := rl.ChaincodeNamespaces(newAdmissionTestSupport(), map[string]string{"namespaces": " , "})
+	assert.EqualError(t, err, "the namespaces option lists no namespace")
+
+	rule, err := rl.ChaincodeNamespaces(newAdmissionTestSupport(), map[string]string{"namespaces": "mycc, lscc"})
+	assert.NoError(t, err)
+
+	t.Run("PermittedNamespaces", func(t *testing.T) {
+		assert.NoError(t, rule.Apply(makeEndorserTestTransaction("valid", namespacesRWSet("mycc", "lscc"), namespacesRWSet("mycc"))))
+	})
+
+	t.Run("OtherNamespace", func(t *testing.T) {
+		err := rule.Apply(makeEndorserTestTransaction("valid", namespacesRWSet("mycc"), namespacesRWSet("lscc", "othercc")))
+		assertRejection(t, err, cb.Status_FORBIDDEN, "namespace othercc is not permitted")
+	})
+
+	t.Run("NotEndorserTransaction", func(t *testing.T) {
+		assert.NoError(t, rule.Apply(makeMaintenanceTestEnvelope(cb.HeaderType_MESSAGE, "testchannel")))
+	})
+
+	t.Run("MalformedTransaction", func(t *testing.T) {
+		env := makeEndorserTestTransaction("valid")
+		payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+		payload.Data = utils.MarshalOrPanic(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: []byte("garbage")}}})
+		env.Payload = utils.MarshalOrPanic(payload)
+		assert.EqualError(t, rule.Apply(env), "the transaction has a malformed action payload")
+	})
+}
+
+func TestMaxReadWriteSetSize(t *testing.T) {
+	rl := &RuleLibrary{}
+
+	_, err := rl.MaxReadWriteSetSize(newAdmissionTestSupport(), map[string]string{"maxbytes": "-1"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid maxbytes option")
+
+	size := len(utils.MarshalOrPanic(namespacesRWSet("mycc")))
+	rule, err := rl.MaxReadWriteSetSize(newAdmissionTestSupport(), map[string]string{"maxbytes": "20"})
+	assert.NoError(t, err)
+	assert.True(t, size <= 20 && 2*size > 20, "the test read-write set is %d bytes", size)
+
+	t.Run("WithinLimit", func(t *testing.T) {
+		assert.NoError(t, rule.Apply(makeEndorserTestTransaction("valid", namespacesRWSet("mycc"))))
+	})
+
+	t.Run("OverLimit", func(t *testing.T) {
+		err := rule.Apply(makeEndorserTestTransaction("valid", namespacesRWSet("mycc"), namespacesRWSet("mycc")))
+		assertRejection(t, err, cb.Status_REQUEST_ENTITY_TOO_LARGE, "more than the maximum of 20 bytes")
+	})
+}
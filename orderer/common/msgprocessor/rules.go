@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"fmt"
+	"os"
+	"plugin"
+	"reflect"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// rulePluginFactory is the name of the constructor rule plugins must export
+const rulePluginFactory = "NewRule"
+
+// RuleRejection is returned by admission rules to reject a message with a precise status,
+// which the orderer returns to the client along with the reason of the rejection.
+type RuleRejection struct {
+	Status cb.Status
+	Reason string
+}
+
+// Error returns the reason of the rejection
+func (rr *RuleRejection) Error() string {
+	return rr.Reason
+}
+
+// RuleFactory creates an admission rule for a channel out of the resources of the channel
+// and the options the channel config selects the rule with.  Rule plugins export a
+// function of this type named NewRule.
+type RuleFactory func(support channelconfig.Resources, options map[string]string) (Rule, error)
+
+// RulePlugin configures an admission rule loaded from a Go plugin
+type RulePlugin struct {
+	Name    string
+	Library string
+}
+
+// RuleRegistry creates the admission rules the channel configs select by name, out of
+// the rules compiled into the orderer, which are the methods of RuleLibrary, and the
+// rules loaded from plugins.
+type RuleRegistry struct {
+	plugins map[string]RuleFactory
+}
+
+// NewRuleRegistry creates a RuleRegistry loading the rules of the given plugins
+func NewRuleRegistry(plugins []RulePlugin) (*RuleRegistry, error) {
+	r := &RuleRegistry{plugins: make(map[string]RuleFactory)}
+	for _, p := range plugins {
+		if _, exists := r.Factory(p.Name); exists {
+			return nil, errors.Errorf("admission rule %s is already defined", p.Name)
+		}
+		factory, err := loadRulePlugin(p.Library)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed loading admission rule %s", p.Name))
+		}
+		r.plugins[p.Name] = factory
+	}
+	return r, nil
+}
+
+// loadRulePlugin loads the rule factory of the plugin at the given path
+func loadRulePlugin(pluginPath string) (RuleFactory, error) {
+	if _, err := os.Stat(pluginPath); err != nil {
+		return nil, errors.Wrapf(err, "could not find plugin at path %s", pluginPath)
+	}
+	p, err := plugin.Open(pluginPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening plugin at path %s", pluginPath)
+	}
+	constructorSymbol, err := p.Lookup(rulePluginFactory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin must contain constructor with name %s", rulePluginFactory)
+	}
+	constructor, ok := constructorSymbol.(func(channelconfig.Resources, map[string]string) (Rule, error))
+	if !ok {
+		return nil, errors.Errorf("constructor method %s does not match expected definition", rulePluginFactory)
+	}
+	return constructor, nil
+}
+
+// Factory returns the factory of the rule with the given name, if any
+func (r *RuleRegistry) Factory(name string) (RuleFactory, bool) {
+	if factory, ok := r.plugins[name]; ok {
+		return factory, true
+	}
+
+	method := reflect.ValueOf(&RuleLibrary{}).MethodByName(name)
+	if !method.IsValid() {
+		return nil, false
+	}
+	constructor, ok := method.Interface().(func(channelconfig.Resources, map[string]string) (Rule, error))
+	if !ok {
+		return nil, false
+	}
+	return constructor, true
+}
+
+// CheckAdmissionRules makes sure that the admission rules the orderer config of the given
+// resources selects are defined and accept their options, by creating them.  It is meant
+// to reject the config updates selecting rules which cannot be created.
+func (r *RuleRegistry) CheckAdmissionRules(support channelconfig.Resources) error {
+	ordererConf, ok := support.OrdererConfig()
+	if !ok {
+		return errors.New("config does not contain orderer config")
+	}
+	_, err := r.createRules(support, ordererConf.AdmissionRules())
+	return err
+}
+
+// NewAdmissionFilter returns a rule applying to the messages broadcast to a channel
+// the admission rules its config selects, in order.  The rules are created anew when
+// the config selects other rules.  Config messages are not subject to the admission
+// rules, so that a config selecting rules which cannot be created can be amended.
+func (r *RuleRegistry) NewAdmissionFilter(support channelconfig.Resources) Rule {
+	return &admissionFilter{registry: r, support: support}
+}
+
+type namedRule struct {
+	name string
+	Rule
+}
+
+type admissionFilter struct {
+	registry *RuleRegistry
+	support  channelconfig.Resources
+
+	mutex  sync.Mutex
+	built  bool
+	config *ab.AdmissionRules
+	rules  []namedRule
+	err    error
+}
+
+// Apply rejects the message if one of the admission rules of the channel rejects it, or
+// with SERVICE_UNAVAILABLE if the admission rules of the channel cannot be created
+func (af *admissionFilter) Apply(message *cb.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.Wrap(err, "could not get the channel header")
+	}
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_CONFIG, cb.HeaderType_ORDERER_TRANSACTION:
+		return nil
+	}
+
+	rules, err := af.currentRules()
+	if err != nil {
+		return &RuleRejection{Status: cb.Status_SERVICE_UNAVAILABLE, Reason: err.Error()}
+	}
+	for _, rule := range rules {
+		if err := rule.Apply(message); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("rejected by admission rule %s", rule.name))
+		}
+	}
+	return nil
+}
+
+// currentRules returns the admission rules selected by the current config of the channel
+func (af *admissionFilter) currentRules() ([]namedRule, error) {
+	ordererConf, ok := af.support.OrdererConfig()
+	if !ok {
+		logger.Panic("Programming error: orderer config not found")
+	}
+	config := ordererConf.AdmissionRules()
+
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+	if af.built && (config == af.config || proto.Equal(config, af.config)) {
+		return af.rules, af.err
+	}
+
+	af.built, af.config = true, config
+	af.rules, af.err = af.registry.createRules(af.support, config)
+	if af.err != nil {
+		logger.Errorf("Rejecting the messages broadcast to channel %s: %s", af.channelID(), af.err)
+	}
+	return af.rules, af.err
+}
+
+// createRules creates the given admission rules for the channel of the given resources
+func (r *RuleRegistry) createRules(support channelconfig.Resources, config *ab.AdmissionRules) ([]namedRule, error) {
+	var rules []namedRule
+	for _, ruleConfig := range config.GetRules() {
+		factory, ok := r.Factory(ruleConfig.Name)
+		if !ok {
+			return nil, errors.Errorf("admission rule %s is not defined", ruleConfig.Name)
+		}
+		rule, err := factory(support, ruleConfig.Options)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed creating admission rule %s", ruleConfig.Name))
+		}
+		logger.Debugf("Created admission rule %s of channel %s with options %v", ruleConfig.Name, support.ConfigtxValidator().ChainID(), ruleConfig.Options)
+		rules = append(rules, namedRule{name: ruleConfig.Name, Rule: rule})
+	}
+	return rules, nil
+}
+
+func (af *admissionFilter) channelID() string {
+	return af.support.ConfigtxValidator().ChainID()
+}
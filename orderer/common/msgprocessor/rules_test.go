@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// txIDRule rejects the messages with the given transaction ID
+type txIDRule string
+
+func (r txIDRule) Apply(message *cb.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return err
+	}
+	if chdr.TxId == string(r) {
+		return &RuleRejection{Status: cb.Status_FORBIDDEN, Reason: fmt.Sprintf("transaction %s is not permitted", r)}
+	}
+	return nil
+}
+
+func makeAdmissionTestTransaction(txID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "testchannel",
+					TxId:      txID,
+				}),
+			},
+		}),
+	}
+}
+
+func newAdmissionTestSupport(rules ...*ab.AdmissionRule) *mockconfig.Resources {
+	ordererConfig := &mockconfig.Orderer{}
+	if len(rules) > 0 {
+		ordererConfig.AdmissionRulesVal = &ab.AdmissionRules{Rules: rules}
+	}
+	return &mockconfig.Resources{
+		ConfigtxValidatorVal: &mockconfigtx.Validator{ChainIDVal: "testchannel"},
+		OrdererConfigVal:     ordererConfig,
+	}
+}
+
+func TestRuleRegistry(t *testing.T) {
+	registry, err := NewRuleRegistry(nil)
+	assert.NoError(t, err)
+
+	t.Run("CompiledRule", func(t *testing.T) {
+		factory, ok := registry.Factory("MaxReadWriteSetSize")
+		assert.True(t, ok)
+		rule, err := factory(newAdmissionTestSupport(), map[string]string{"maxbytes": "10"})
+		assert.NoError(t, err)
+		assert.NotNil(t, rule)
+	})
+
+	t.Run("UnknownRule", func(t *testing.T) {
+		_, ok := registry.Factory("NoSuchRule")
+		assert.False(t, ok)
+	})
+
+	t.Run("PluginNameConflict", func(t *testing.T) {
+		_, err := NewRuleRegistry([]RulePlugin{{Name: "RevokedCreator", Library: "/nonexistent.so"}})
+		assert.EqualError(t, err, "admission rule RevokedCreator is already defined")
+	})
+
+	t.Run("MissingPlugin", func(t *testing.T) {
+		_, err := NewRuleRegistry([]RulePlugin{{Name: "MyRule", Library: "/nonexistent.so"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed loading admission rule MyRule: could not find plugin at path /nonexistent.so")
+	})
+}
+
+func TestCheckAdmissionRules(t *testing.T) {
+	registry, err := NewRuleRegistry(nil)
+	assert.NoError(t, err)
+
+	t.Run("NoRules", func(t *testing.T) {
+		assert.NoError(t, registry.CheckAdmissionRules(newAdmissionTestSupport()))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		support := newAdmissionTestSupport(&ab.AdmissionRule{Name: "MaxReadWriteSetSize", Options: map[string]string{"maxbytes": "10"}})
+		assert.NoError(t, registry.CheckAdmissionRules(support))
+	})
+
+	t.Run("UndefinedRule", func(t *testing.T) {
+		support := newAdmissionTestSupport(&ab.AdmissionRule{Name: "NoSuchRule"})
+		assert.EqualError(t, registry.CheckAdmissionRules(support), "admission rule NoSuchRule is not defined")
+	})
+
+	t.Run("BadOptions", func(t *testing.T) {
+		support := newAdmissionTestSupport(&ab.AdmissionRule{Name: "MaxReadWriteSetSize", Options: map[string]string{"maxbytes": "many"}})
+		err := registry.CheckAdmissionRules(support)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed creating admission rule MaxReadWriteSetSize: invalid maxbytes option")
+	})
+
+	t.Run("NoOrdererConfig", func(t *testing.T) {
+		assert.EqualError(t, registry.CheckAdmissionRules(&mockconfig.Resources{}), "config does not contain orderer config")
+	})
+}
+
+func TestAdmissionFilter(t *testing.T) {
+	registry, err := NewRuleRegistry(nil)
+	assert.NoError(t, err)
+	created := 0
+	registry.plugins["TxID"] = func(support channelconfig.Resources, options map[string]string) (Rule, error) {
+		created++
+		if options["txid"] == "" {
+			return nil, errors.New("the txid option is not set")
+		}
+		return txIDRule(options["txid"]), nil
+	}
+
+	support := newAdmissionTestSupport(&ab.AdmissionRule{Name: "TxID", Options: map[string]string{"txid": "bad"}})
+	af := registry.NewAdmissionFilter(support)
+	selectRules := func(rules ...*ab.AdmissionRule) {
+		support.OrdererConfigVal.(*mockconfig.Orderer).AdmissionRulesVal = &ab.AdmissionRules{Rules: rules}
+	}
+
+	t.Run("Accepted", func(t *testing.T) {
+		assert.NoError(t, af.Apply(makeAdmissionTestTransaction("good")))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		err := af.Apply(makeAdmissionTestTransaction("bad"))
+		assert.EqualError(t, err, "rejected by admission rule TxID: transaction bad is not permitted")
+		rejection, ok := errors.Cause(err).(*RuleRejection)
+		assert.True(t, ok)
+		assert.Equal(t, cb.Status_FORBIDDEN, rejection.Status)
+	})
+
+	t.Run("BadChannelHeader", func(t *testing.T) {
+		err := af.Apply(&cb.Envelope{Payload: []byte("garbage")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "could not get the channel header")
+	})
+
+	t.Run("RulesCreatedOncePerConfig", func(t *testing.T) {
+		created = 0
+		selectRules(&ab.AdmissionRule{Name: "TxID", Options: map[string]string{"txid": "other"}})
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, af.Apply(makeAdmissionTestTransaction("bad")))
+		}
+		// An equal config carried by another message does not create the rules again
+		selectRules(&ab.AdmissionRule{Name: "TxID", Options: map[string]string{"txid": "other"}})
+		assert.NoError(t, af.Apply(makeAdmissionTestTransaction("bad")))
+		assert.Error(t, af.Apply(makeAdmissionTestTransaction("other")))
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("ConfigMessagesNotSubjectToRules", func(t *testing.T) {
+		selectRules(&ab.AdmissionRule{Name: "NoSuchRule"})
+		for _, headerType := range []cb.HeaderType{cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_CONFIG, cb.HeaderType_ORDERER_TRANSACTION} {
+			assert.NoError(t, af.Apply(makeMaintenanceTestEnvelope(headerType, "testchannel")))
+		}
+	})
+
+	t.Run("UndefinedRule", func(t *testing.T) {
+		selectRules(&ab.AdmissionRule{Name: "NoSuchRule"})
+		err := af.Apply(makeAdmissionTestTransaction("good"))
+		assert.EqualError(t, err, "admission rule NoSuchRule is not defined")
+		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, errors.Cause(err).(*RuleRejection).Status)
+	})
+
+	t.Run("BadOptions", func(t *testing.T) {
+		selectRules(&ab.AdmissionRule{Name: "TxID"})
+		err := af.Apply(makeAdmissionTestTransaction("good"))
+		assert.EqualError(t, err, "failed creating admission rule TxID: the txid option is not set")
+		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, errors.Cause(err).(*RuleRejection).Status)
+	})
+
+	t.Run("NoRules", func(t *testing.T) {
+		support.OrdererConfigVal.(*mockconfig.Orderer).AdmissionRulesVal = nil
+		assert.NoError(t, af.Apply(makeAdmissionTestTransaction("bad")))
+	})
+}
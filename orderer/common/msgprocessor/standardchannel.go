@@ -44,18 +44,24 @@ func NewStandardChannel(support StandardChannelSupport, filters *RuleSet) *Stand
 	}
 }
 
-// CreateStandardChannelFilters creates the set of filters for a normal (non-system) chain
-func CreateStandardChannelFilters(filterSupport channelconfig.Resources) *RuleSet {
+// CreateStandardChannelFilters creates the set of filters for a normal (non-system) chain,
+// which end with the admission rules of the channel when a rule registry is given
+func CreateStandardChannelFilters(filterSupport channelconfig.Resources, ruleRegistry *RuleRegistry) *RuleSet {
 	ordererConfig, ok := filterSupport.OrdererConfig()
 	if !ok {
 		logger.Panicf("Missing orderer config")
 	}
-	return NewRuleSet([]Rule{
+	rules := []Rule{
 		EmptyRejectRule,
+		NewMaintenanceFilter(filterSupport),
 		NewExpirationRejectRule(filterSupport),
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, filterSupport),
-	})
+	}
+	if ruleRegistry != nil {
+		rules = append(rules, ruleRegistry.NewAdmissionFilter(filterSupport))
+	}
+	return NewRuleSet(rules)
 }
 
 // ClassifyMsg inspects the message to determine which type of processing is necessary
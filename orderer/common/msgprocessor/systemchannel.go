@@ -40,19 +40,25 @@ func NewSystemChannel(support StandardChannelSupport, templator ChannelConfigTem
 	}
 }
 
-// CreateSystemChannelFilters creates the set of filters for the ordering system chain.
-func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources channelconfig.Resources) *RuleSet {
+// CreateSystemChannelFilters creates the set of filters for the ordering system chain,
+// which end with the admission rules of the channel when a rule registry is given.
+func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources channelconfig.Resources, ruleRegistry *RuleRegistry) *RuleSet {
 	ordererConfig, ok := ledgerResources.OrdererConfig()
 	if !ok {
 		logger.Panicf("Cannot create system channel filters without orderer config")
 	}
-	return NewRuleSet([]Rule{
+	rules := []Rule{
 		EmptyRejectRule,
+		NewMaintenanceFilter(ledgerResources),
 		NewExpirationRejectRule(ledgerResources),
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, ledgerResources),
 		NewSystemChannelFilter(ledgerResources, chainCreator),
-	})
+	}
+	if ruleRegistry != nil {
+		rules = append(rules, ruleRegistry.NewAdmissionFilter(ledgerResources))
+	}
+	return NewRuleSet(rules)
 }
 
 // ProcessNormalMsg handles normal messages, rejecting them if they are not bound for the system channel ID
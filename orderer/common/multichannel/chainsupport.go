@@ -7,6 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"bytes"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
@@ -23,9 +27,15 @@ type ChainSupport struct {
 	*ledgerResources
 	msgprocessor.Processor
 	*BlockWriter
-	consensus.Chain
 	cutter blockcutter.Receiver
 	crypto.LocalSigner
+
+	// chainLock guards the chain, which is replaced when the consensus type
+	// of the channel is migrated
+	chainLock     sync.RWMutex
+	chain         consensus.Chain
+	consenterType string
+	switching     bool
 }
 
 func newChainSupport(
@@ -36,13 +46,7 @@ func newChainSupport(
 ) *ChainSupport {
 	// Read in the last block and metadata for the channel
 	lastBlock := blockledger.GetBlock(ledgerResources, ledgerResources.Height()-1)
-
-	metadata, err := utils.GetMetadataFromBlock(lastBlock, cb.BlockMetadataIndex_ORDERER)
-	// Assuming a block created with cb.NewBlock(), this should not
-	// error even if the orderer metadata is an empty byte slice
-	if err != nil {
-		logger.Fatalf("[channel: %s] Error extracting orderer metadata: %s", ledgerResources.ConfigtxValidator().ChainID(), err)
-	}
+	metadata := ordererMetadata(ledgerResources, lastBlock)
 
 	// Construct limited support needed as a parameter for additional support
 	cs := &ChainSupport{
@@ -52,7 +56,7 @@ func newChainSupport(
 	}
 
 	// Set up the msgprocessor
-	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs))
+	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar.ruleRegistry))
 
 	// Set up the block writer
 	cs.BlockWriter = newBlockWriter(lastBlock, registrar, cs)
@@ -64,10 +68,12 @@ func newChainSupport(
 		logger.Panicf("Error retrieving consenter of type: %s", consenterType)
 	}
 
-	cs.Chain, err = consenter.HandleChain(cs, metadata)
+	var err error
+	cs.chain, err = consenter.HandleChain(cs, metadata)
 	if err != nil {
 		logger.Panicf("[channel: %s] Error creating consenter: %s", cs.ChainID(), err)
 	}
+	cs.consenterType = consenterType
 
 	logger.Debugf("[channel: %s] Done creating channel support resources", cs.ChainID())
 
@@ -83,8 +89,105 @@ func (cs *ChainSupport) Signer() crypto.LocalSigner {
 	return cs
 }
 
+// ordererMetadata reads the metadata stored by the consenter in the ORDERER slot of a block
+func ordererMetadata(ledgerResources *ledgerResources, block *cb.Block) *cb.Metadata {
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+	// Assuming a block created with cb.NewBlock(), this should not
+	// error even if the orderer metadata is an empty byte slice
+	if err != nil {
+		logger.Fatalf("[channel: %s] Error extracting orderer metadata: %s", ledgerResources.ConfigtxValidator().ChainID(), err)
+	}
+	return metadata
+}
+
 func (cs *ChainSupport) start() {
-	cs.Chain.Start()
+	cs.Start()
+}
+
+// currentChain returns the chain of the consenter currently ordering the channel
+func (cs *ChainSupport) currentChain() consensus.Chain {
+	cs.chainLock.RLock()
+	defer cs.chainLock.RUnlock()
+	return cs.chain
+}
+
+// Order passes through to the current consensus.Chain
+func (cs *ChainSupport) Order(env *cb.Envelope, configSeq uint64) error {
+	return cs.currentChain().Order(env, configSeq)
+}
+
+// Configure passes through to the current consensus.Chain
+func (cs *ChainSupport) Configure(config *cb.Envelope, configSeq uint64) error {
+	return cs.currentChain().Configure(config, configSeq)
+}
+
+// WaitReady passes through to the current consensus.Chain
+func (cs *ChainSupport) WaitReady() error {
+	return cs.currentChain().WaitReady()
+}
+
+// Errored passes through to the current consensus.Chain
+func (cs *ChainSupport) Errored() <-chan struct{} {
+	return cs.currentChain().Errored()
+}
+
+// Start passes through to the current consensus.Chain
+func (cs *ChainSupport) Start() {
+	cs.currentChain().Start()
+}
+
+// Halt passes through to the current consensus.Chain
+func (cs *ChainSupport) Halt() {
+	cs.currentChain().Halt()
+}
+
+// WriteConfigBlock passes through to the BlockWriter, and hands the channel over to
+// another consenter once a config block changing the consensus type is written
+func (cs *ChainSupport) WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte) {
+	cs.BlockWriter.WriteConfigBlock(block, encodedMetadataValue)
+
+	cs.chainLock.Lock()
+	defer cs.chainLock.Unlock()
+	if cs.switching || cs.SharedConfig().ConsensusType() == cs.consenterType {
+		return
+	}
+	cs.switching = true
+	// The current chain is most likely the caller, the switch has to wait for it to halt
+	go cs.switchConsenter()
+}
+
+// switchConsenter halts the current chain and, once the blocks it wrote are committed,
+// creates and starts a chain of the consenter of the consensus type of the channel.
+// The ledger, and so the height of the channel, is kept as is.
+func (cs *ChainSupport) switchConsenter() {
+	previous := cs.currentChain()
+	// Halt blocks until the chain has stopped, so it writes no block past this point
+	previous.Halt()
+
+	// Wait for the last block written by the halted chain to be committed
+	cs.committingBlock.Lock()
+	cs.committingBlock.Unlock()
+
+	consenterType := cs.SharedConfig().ConsensusType()
+	consenter, ok := cs.registrar.consenters[consenterType]
+	if !ok {
+		logger.Panicf("[channel: %s] Error retrieving consenter of type: %s", cs.ChainID(), consenterType)
+	}
+
+	lastBlock := blockledger.GetBlock(cs.ledgerResources, cs.Height()-1)
+	chain, err := consenter.HandleChain(cs, ordererMetadata(cs.ledgerResources, lastBlock))
+	if err != nil {
+		logger.Panicf("[channel: %s] Error creating consenter of type %s: %s", cs.ChainID(), consenterType, err)
+	}
+
+	cs.chainLock.Lock()
+	logger.Infof("[channel: %s] Handing the channel over from consensus type %s to %s at block %d", cs.ChainID(), cs.consenterType, consenterType, lastBlock.Header.Number)
+	cs.chain = chain
+	cs.consenterType = consenterType
+	cs.switching = false
+	cs.chainLock.Unlock()
+
+	chain.Start()
 }
 
 // BlockCutter returns the blockcutter.Receiver instance for this channel.
@@ -113,6 +216,18 @@ func (cs *ChainSupport) ProposeConfigUpdate(configtx *cb.Envelope) (*cb.ConfigEn
 		return nil, errors.Wrap(err, "config update is not compatible")
 	}
 
+	if err = cs.checkConsensusType(bundle); err != nil {
+		return nil, errors.Wrap(err, "config update is not compatible")
+	}
+
+	if err = checkBatchClassifier(bundle); err != nil {
+		return nil, errors.Wrap(err, "config update is not compatible")
+	}
+
+	if err = cs.checkAdmissionRules(bundle); err != nil {
+		return nil, errors.Wrap(err, "config update is not compatible")
+	}
+
 	return env, cs.ValidateNew(bundle)
 }
 
@@ -130,3 +245,78 @@ func (cs *ChainSupport) ConfigProto() *cb.Config {
 func (cs *ChainSupport) Sequence() uint64 {
 	return cs.ConfigtxValidator().Sequence()
 }
+
+// checkConsensusType makes sure that the channel can be handed over to the consenter
+// of the consensus type of a new config, and that this consenter takes its metadata
+func (cs *ChainSupport) checkConsensusType(bundle channelconfig.Resources) error {
+	noc, ok := bundle.OrdererConfig()
+	if !ok {
+		return errors.New("config does not contain orderer config")
+	}
+	consensusType := noc.ConsensusType()
+	typeChanged := consensusType != cs.SharedConfig().ConsensusType()
+	if !typeChanged && bytes.Equal(noc.ConsensusMetadata(), cs.SharedConfig().ConsensusMetadata()) {
+		return nil
+	}
+	consenter, ok := cs.registrar.consenters[consensusType]
+	if !ok {
+		return errors.Errorf("consensus type %s is not supported by this orderer", consensusType)
+	}
+	if err := checkConsensusMetadata(consenter, consensusType, noc.ConsensusMetadata()); err != nil {
+		return err
+	}
+	if !typeChanged {
+		return nil
+	}
+	if consensusType == "kafka" {
+		// The Kafka consenter resumes from the offsets recorded in the blocks it wrote,
+		// which the blocks written by another consenter do not carry
+		return errors.New("migration to the kafka consensus type is not supported")
+	}
+	if consensusType == "solo" && len(bundle.ChannelConfig().OrdererAddresses()) != 1 {
+		// Every ordering service node would cut blocks of its own and fork the ledger
+		return errors.New("migration to the solo consensus type requires exactly one orderer address")
+	}
+	return nil
+}
+
+// checkConsensusMetadata makes sure that the consenter of a consensus type takes the metadata
+func checkConsensusMetadata(consenter consensus.Consenter, consensusType string, metadata []byte) error {
+	validator, ok := consenter.(consensus.MetadataValidator)
+	if !ok {
+		if len(metadata) != 0 {
+			return errors.Errorf("consensus type %s takes no metadata", consensusType)
+		}
+		return nil
+	}
+	if err := validator.ValidateConsensusMetadata(metadata); err != nil {
+		return errors.Wrapf(err, "invalid metadata for consensus type %s", consensusType)
+	}
+	return nil
+}
+
+// checkBatchClassifier makes sure that the block cutter can sort the messages into
+// the batch classes of a new config
+func checkBatchClassifier(bundle channelconfig.Resources) error {
+	noc, ok := bundle.OrdererConfig()
+	if !ok {
+		return errors.New("config does not contain orderer config")
+	}
+	classifier := noc.BatchSize().Classifier
+	if classifier == "" {
+		return nil
+	}
+	if _, ok := blockcutter.LookupClassifier(classifier); !ok {
+		return errors.Errorf("batch classifier %s is not supported by this orderer", classifier)
+	}
+	return nil
+}
+
+// checkAdmissionRules makes sure that the admission rules of a new config can be created
+func (cs *ChainSupport) checkAdmissionRules(bundle channelconfig.Resources) error {
+	if cs.registrar.ruleRegistry == nil {
+		// The channels are not subject to admission rules
+		return nil
+	}
+	return cs.registrar.ruleRegistry.CheckAdmissionRules(bundle)
+}
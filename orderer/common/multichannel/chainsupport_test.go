@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// handoverConsenter records the chains handed over to it
+type handoverConsenter struct {
+	mockConsenter
+	handled chan *cb.Metadata
+}
+
+func (hc *handoverConsenter) HandleChain(support consensus.ConsenterSupport, metadata *cb.Metadata) (consensus.Chain, error) {
+	hc.handled <- metadata
+	return hc.mockConsenter.HandleChain(support, metadata)
+}
+
+// ValidateConsensusMetadata rejects any metadata but other-metadata
+func (hc *handoverConsenter) ValidateConsensusMetadata(metadata []byte) error {
+	if string(metadata) != "other-metadata" {
+		return errors.Errorf("unexpected metadata %q", metadata)
+	}
+	return nil
+}
+
+func newMigrationLedgerFactory(t *testing.T) blockledger.Factory {
+	migrationConf := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile)
+	migrationConf.Orderer.Capabilities = map[string]bool{capabilities.OrdererV1_3: true}
+	lf := ramledger.New(10)
+	rl, err := lf.GetOrCreate(genesisconfig.TestChainID)
+	assert.NoError(t, err)
+	assert.NoError(t, rl.Append(encoder.New(migrationConf).GenesisBlock()))
+	return lf
+}
+
+// makeConsensusTypeUpdate creates a config update setting the consensus type of the channel
+func makeConsensusTypeUpdate(t *testing.T, cs *ChainSupport, consensusType *ab.ConsensusType) *cb.Envelope {
+	return makeOrdererValueUpdate(t, cs, channelconfig.ConsensusTypeKey, consensusType)
+}
+
+// makeOrdererValueUpdate creates a config update setting a value of the orderer group of the channel
+func makeOrdererValueUpdate(t *testing.T, cs *ChainSupport, key string, value proto.Message) *cb.Envelope {
+	return makeConfigUpdate(t, cs, func(config *cb.Config) {
+		config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[key].Value = utils.MarshalOrPanic(value)
+	})
+}
+
+// makeConfigUpdate creates a config update applying modify to the config of the channel
+func makeConfigUpdate(t *testing.T, cs *ChainSupport, modify func(config *cb.Config)) *cb.Envelope {
+	original := cs.ConfigProto()
+	updated := proto.Clone(original).(*cb.Config)
+	modify(updated)
+
+	configUpdate, err := update.Compute(original, updated)
+	assert.NoError(t, err)
+	configUpdate.ChannelId = cs.ChainID()
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, cs.ChainID(), mockCrypto(), &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, msgVersion, epoch)
+	assert.NoError(t, err)
+	return env
+}
+
+func configureAndWait(t *testing.T, cs *ChainSupport, configUpdate *cb.Envelope) {
+	config, configSeq, err := cs.ProcessConfigUpdateMsg(configUpdate)
+	assert.NoError(t, err)
+
+	it, _ := cs.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: cs.Height()}}})
+	defer it.Close()
+	assert.NoError(t, cs.Configure(config, configSeq))
+	select {
+	case <-it.ReadyChan():
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status)
+	case <-time.After(time.Second):
+		t.Fatalf("Config block not produced after timeout")
+	}
+}
+
+func TestConsensusTypeMigration(t *testing.T) {
+	other := &handoverConsenter{handled: make(chan *cb.Metadata, 1)}
+	consenters := map[string]consensus.Consenter{
+		"solo":  &mockConsenter{},
+		"other": other,
+	}
+	manager := NewRegistrar(newMigrationLedgerFactory(t), consenters, mockCrypto(), nil)
+	cs, ok := manager.GetChain(genesisconfig.TestChainID)
+	assert.True(t, ok)
+	soloChain := cs.currentChain()
+
+	t.Run("ChangeTypeInNormal", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{Type: "other", Metadata: []byte("other-metadata")}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not in, or is leaving, maintenance mode")
+	})
+
+	t.Run("EnterMaintenance", func(t *testing.T) {
+		configureAndWait(t, cs, makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{Type: "solo", State: ab.ConsensusType_STATE_MAINTENANCE}))
+		assert.Equal(t, ab.ConsensusType_STATE_MAINTENANCE, cs.SharedConfig().ConsensusState())
+
+		_, err := cs.ProcessNormalMsg(makeNormalTx(genesisconfig.TestChainID, 1))
+		assert.Equal(t, msgprocessor.ErrMaintenanceMode, errors.Cause(err))
+	})
+
+	t.Run("UnknownConsensusType", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{Type: "kafka", State: ab.ConsensusType_STATE_MAINTENANCE}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "consensus type kafka is not supported by this orderer")
+	})
+
+	t.Run("MetadataOfConsenterWithoutMetadata", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{
+			Type:     "solo",
+			Metadata: []byte("solo-metadata"),
+			State:    ab.ConsensusType_STATE_MAINTENANCE,
+		}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "consensus type solo takes no metadata")
+	})
+
+	t.Run("InvalidMetadata", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{
+			Type:     "other",
+			Metadata: []byte("bad-metadata"),
+			State:    ab.ConsensusType_STATE_MAINTENANCE,
+		}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid metadata for consensus type other: unexpected metadata")
+	})
+
+	t.Run("ChangeTypeInMaintenance", func(t *testing.T) {
+		height := cs.Height()
+		configureAndWait(t, cs, makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{
+			Type:     "other",
+			Metadata: []byte("other-metadata"),
+			State:    ab.ConsensusType_STATE_MAINTENANCE,
+		}))
+
+		select {
+		case <-other.handled:
+		case <-time.After(time.Second):
+			t.Fatalf("The channel was not handed over to the new consenter")
+		}
+		for deadline := time.Now().Add(time.Second); cs.currentChain() == soloChain; time.Sleep(10 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("The chain of the new consenter was not started")
+			}
+		}
+		assert.Equal(t, "other", cs.SharedConfig().ConsensusType())
+		assert.Equal(t, []byte("other-metadata"), cs.SharedConfig().ConsensusMetadata())
+		assert.Equal(t, height+1, cs.Height(), "The ledger is kept by the new consenter")
+	})
+
+	t.Run("ToSoloWithSeveralOrdererAddresses", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(makeConfigUpdate(t, cs, func(config *cb.Config) {
+			config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value = utils.MarshalOrPanic(&ab.ConsensusType{
+				Type:  "solo",
+				State: ab.ConsensusType_STATE_MAINTENANCE,
+			})
+			config.ChannelGroup.Values[channelconfig.OrdererAddressesKey].Value = utils.MarshalOrPanic(&cb.OrdererAddresses{
+				Addresses: []string{"127.0.0.1:7050", "127.0.0.1:8050"},
+			})
+		}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "migration to the solo consensus type requires exactly one orderer address")
+	})
+
+	t.Run("ExitMaintenance", func(t *testing.T) {
+		configureAndWait(t, cs, makeConsensusTypeUpdate(t, cs, &ab.ConsensusType{
+			Type:     "other",
+			Metadata: []byte("other-metadata"),
+		}))
+		assert.Equal(t, ab.ConsensusType_STATE_NORMAL, cs.SharedConfig().ConsensusState())
+
+		_, err := cs.ProcessNormalMsg(makeNormalTx(genesisconfig.TestChainID, 1))
+		assert.NoError(t, err)
+	})
+}
+
+func TestBatchClassifier(t *testing.T) {
+	manager := NewRegistrar(newMigrationLedgerFactory(t), map[string]consensus.Consenter{"solo": &mockConsenter{}}, mockCrypto(), nil)
+	cs, ok := manager.GetChain(genesisconfig.TestChainID)
+	assert.True(t, ok)
+
+	batchSize := proto.Clone(cs.SharedConfig().BatchSize()).(*ab.BatchSize)
+	batchSize.Classes = []*ab.ClassBatchSize{{Class: "SampleOrg", MaxMessageCount: 1}}
+
+	t.Run("Unknown", func(t *testing.T) {
+		batchSize.Classifier = "unknown"
+		_, _, err := cs.ProcessConfigUpdateMsg(makeOrdererValueUpdate(t, cs, channelconfig.BatchSizeKey, batchSize))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "batch classifier unknown is not supported by this orderer")
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		batchSize.Classifier = blockcutter.MSPClassifier
+		_, _, err := cs.ProcessConfigUpdateMsg(makeOrdererValueUpdate(t, cs, channelconfig.BatchSizeKey, batchSize))
+		assert.NoError(t, err)
+	})
+}
+
+func TestAdmissionRulesOfConfigUpdates(t *testing.T) {
+	ruleRegistry, err := msgprocessor.NewRuleRegistry(nil)
+	assert.NoError(t, err)
+	manager := NewRegistrar(newMigrationLedgerFactory(t), map[string]consensus.Consenter{"solo": &mockConsenter{}}, mockCrypto(), ruleRegistry)
+	cs, ok := manager.GetChain(genesisconfig.TestChainID)
+	assert.True(t, ok)
+
+	selectRules := func(rules ...*ab.AdmissionRule) *cb.Envelope {
+		return makeConfigUpdate(t, cs, func(config *cb.Config) {
+			config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.AdmissionRulesKey] = &cb.ConfigValue{
+				Value:     utils.MarshalOrPanic(&ab.AdmissionRules{Rules: rules}),
+				ModPolicy: channelconfig.AdminsPolicyKey,
+			}
+		})
+	}
+
+	t.Run("UndefinedRule", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(selectRules(&ab.AdmissionRule{Name: "NoSuchRule"}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "admission rule NoSuchRule is not defined")
+	})
+
+	t.Run("BadOptions", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(selectRules(&ab.AdmissionRule{Name: "MaxReadWriteSetSize"}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed creating admission rule MaxReadWriteSetSize")
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		_, _, err := cs.ProcessConfigUpdateMsg(selectRules(&ab.AdmissionRule{Name: "MaxReadWriteSetSize", Options: map[string]string{"maxbytes": "1024"}}))
+		assert.NoError(t, err)
+	})
+}
@@ -103,6 +103,7 @@ type Registrar struct {
 	systemChannelID string
 	systemChannel   *ChainSupport
 	templator       msgprocessor.ChannelConfigTemplator
+	ruleRegistry    *msgprocessor.RuleRegistry
 	callbacks       []func(bundle *channelconfig.Bundle)
 }
 
@@ -120,14 +121,16 @@ func getConfigTx(reader blockledger.Reader) *cb.Envelope {
 	return utils.ExtractEnvelopeOrPanic(configBlock, 0)
 }
 
-// NewRegistrar produces an instance of a *Registrar.
+// NewRegistrar produces an instance of a *Registrar.  The channels apply the admission
+// rules their configs select out of the given rule registry, unless it is nil.
 func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]consensus.Consenter,
-	signer crypto.LocalSigner, callbacks ...func(bundle *channelconfig.Bundle)) *Registrar {
+	signer crypto.LocalSigner, ruleRegistry *msgprocessor.RuleRegistry, callbacks ...func(bundle *channelconfig.Bundle)) *Registrar {
 	r := &Registrar{
 		chains:        make(map[string]*ChainSupport),
 		ledgerFactory: ledgerFactory,
 		consenters:    consenters,
 		signer:        signer,
+		ruleRegistry:  ruleRegistry,
 		callbacks:     callbacks,
 	}
 
@@ -154,7 +157,7 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 				consenters,
 				signer)
 			r.templator = msgprocessor.NewDefaultTemplator(chain)
-			chain.Processor = msgprocessor.NewSystemChannel(chain, r.templator, msgprocessor.CreateSystemChannelFilters(r, chain))
+			chain.Processor = msgprocessor.NewSystemChannel(chain, r.templator, msgprocessor.CreateSystemChannelFilters(r, chain, r.ruleRegistry))
 
 			// Retrieve genesis block to log its hash. See FAB-5450 for the purpose
 			iter, pos := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
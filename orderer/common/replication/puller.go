@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/comm"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// BlockSource supplies the blocks of the channels to replicate
+type BlockSource interface {
+	// Height returns the height of the chain of the given channel
+	Height(channel string) (uint64, error)
+
+	// PullBlocks passes the blocks of the given channel from start to end, inclusive, to the
+	// consume function in order.  A block the function returns an error for is pulled again
+	// from another source, until the retries are exhausted.
+	PullBlocks(channel string, start, end uint64, consume func(block *cb.Block) error) error
+}
+
+// DeliverSource is a BlockSource pulling the blocks from the orderers at the given endpoints
+// over the AtomicBroadcast Deliver API.  It tries the orderers in order, moving on to the next
+// one when an orderer cannot be reached or sends a block which cannot be consumed, and waits
+// for the retry interval before trying them again when none of them could supply a block.
+type DeliverSource struct {
+	Endpoints     []string
+	Client        *comm.GRPCClient
+	Signer        crypto.LocalSigner
+	Timeout       time.Duration
+	RetryInterval time.Duration
+	MaxRetries    int
+}
+
+// Height returns the highest height of the chain of the channel among the orderers
+func (ds *DeliverSource) Height(channel string) (uint64, error) {
+	var height uint64
+	var err error
+	for attempt := 0; attempt <= ds.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(ds.RetryInterval)
+		}
+		reached := false
+		for _, endpoint := range ds.Endpoints {
+			var last *cb.Block
+			seek := &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}
+			pullErr := ds.pull(endpoint, channel, seek, seek, func(block *cb.Block) error {
+				last = block
+				return nil
+			}, func() bool { return last != nil })
+			if pullErr != nil {
+				err = pullErr
+				logger.Warningf("Failed retrieving the height of channel %s from %s: %s", channel, endpoint, pullErr)
+				continue
+			}
+			reached = true
+			if last.Header.Number+1 > height {
+				height = last.Header.Number + 1
+			}
+		}
+		if reached {
+			return height, nil
+		}
+	}
+	return 0, errors.WithMessage(err, fmt.Sprintf("failed retrieving the height of channel %s from any orderer", channel))
+}
+
+// PullBlocks pulls the given blocks of the channel from the orderers
+func (ds *DeliverSource) PullBlocks(channel string, start, end uint64, consume func(block *cb.Block) error) error {
+	next := start
+	var err error
+	for attempt := 0; attempt <= ds.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(ds.RetryInterval)
+		}
+		for _, endpoint := range ds.Endpoints {
+			before := next
+			err = ds.pull(endpoint, channel, specified(next), specified(end), func(block *cb.Block) error {
+				if block.GetHeader().GetNumber() != next {
+					return errors.Errorf("expected block [%d] but got block [%d]", next, block.GetHeader().GetNumber())
+				}
+				if err := consume(block); err != nil {
+					return err
+				}
+				next++
+				return nil
+			}, func() bool { return next > end })
+			if err == nil {
+				return nil
+			}
+			logger.Warningf("Failed pulling block [%d] of channel %s from %s: %s", next, channel, endpoint, err)
+			if next > before {
+				// The orderer made progress, so it is worth starting over
+				attempt = 0
+			}
+		}
+	}
+	return errors.WithMessage(err, fmt.Sprintf("failed pulling block [%d] of channel %s from any orderer", next, channel))
+}
+
+func specified(number uint64) *ab.SeekPosition {
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: number}}}
+}
+
+// pull passes the blocks the orderer at the given endpoint delivers from start to stop
+// to the consume function, until done returns true
+func (ds *DeliverSource) pull(endpoint, channel string, start, stop *ab.SeekPosition, consume func(block *cb.Block) error, done func() bool) error {
+	conn, err := ds.Client.NewConnection(endpoint, "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed opening the deliver stream")
+	}
+
+	var tlsCertHash []byte
+	if cert := ds.Client.Certificate(); len(cert.Certificate) > 0 {
+		tlsCertHash = util.ComputeSHA256(cert.Certificate[0])
+	}
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_DELIVER_SEEK_INFO, channel, ds.Signer, &ab.SeekInfo{
+		Start:    start,
+		Stop:     stop,
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}, int32(0), uint64(0), tlsCertHash)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the seek request")
+	}
+	if err := stream.Send(env); err != nil {
+		return errors.Wrap(err, "failed sending the seek request")
+	}
+
+	type response struct {
+		msg *ab.DeliverResponse
+		err error
+	}
+	for !done() {
+		responses := make(chan response, 1)
+		go func() {
+			msg, err := stream.Recv()
+			responses <- response{msg: msg, err: err}
+		}()
+
+		var resp response
+		select {
+		case resp = <-responses:
+		case <-time.After(ds.Timeout):
+			return errors.Errorf("timed out waiting for a response after %s", ds.Timeout)
+		}
+		if resp.err != nil {
+			return errors.Wrap(resp.err, "failed receiving from the deliver stream")
+		}
+
+		switch t := resp.msg.Type.(type) {
+		case *ab.DeliverResponse_Status:
+			return errors.Errorf("received status %s", t.Status)
+		case *ab.DeliverResponse_Block:
+			if err := consume(t.Block); err != nil {
+				return errors.WithMessage(err, "received a bad block")
+			}
+		default:
+			return errors.Errorf("received an unknown response %T", t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+)
+
+const pkgLogID = "orderer/common/replication"
+
+var logger *logging.Logger
+
+func init() {
+	logger = flogging.MustGetLogger(pkgLogID)
+}
+
+// Replicator replicates the chains of all the channels of existing orderers into the
+// ledger of a new orderer, so that it can start its consenters without replaying the
+// history of the channels from the consensus service.  The chains are verified from the
+// genesis block of the system channel, which is the trust anchor of the new orderer.
+type Replicator struct {
+	source           BlockSource
+	ledgerFactory    blockledger.Factory
+	bootBlock        *cb.Block
+	newPolicyManager channelconfig.PolicyManagerFactory
+}
+
+// NewReplicator creates a Replicator pulling the blocks from the given source into the
+// ledgers of the given factory, starting from the given genesis block of the system channel
+func NewReplicator(source BlockSource, lf blockledger.Factory, bootBlock *cb.Block) *Replicator {
+	return &Replicator{
+		source:           source,
+		ledgerFactory:    lf,
+		bootBlock:        bootBlock,
+		newPolicyManager: channelconfig.NewPolicyManagerFromEnvelope,
+	}
+}
+
+type channel struct {
+	id      string
+	genesis *cb.Block
+}
+
+// ReplicateChains replicates the system channel and the channels it created up to
+// their current height.  The system channel is written last, so that a replication
+// which is interrupted before it completes resumes from the ledgers written so far.
+func (r *Replicator) ReplicateChains() error {
+	systemChannel, err := utils.GetChainIDFromBlock(r.bootBlock)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting the system channel ID from the genesis block")
+	}
+
+	height, err := r.source.Height(systemChannel)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Replicating the %d blocks of system channel %s", height, systemChannel)
+
+	channels, err := r.discoverChannels(systemChannel, height)
+	if err != nil {
+		return err
+	}
+	for _, ch := range channels {
+		chHeight, err := r.source.Height(ch.id)
+		if err != nil {
+			return err
+		}
+		if err := r.replicate(ch.id, ch.genesis, chHeight); err != nil {
+			return err
+		}
+	}
+
+	return r.replicate(systemChannel, r.bootBlock, height)
+}
+
+// discoverChannels verifies the blocks of the system channel, without writing them,
+// and returns the channels created by its orderer transactions, in order of creation
+func (r *Replicator) discoverChannels(systemChannel string, height uint64) ([]channel, error) {
+	var channels []channel
+	created := make(map[string]bool)
+	verifier := newChainVerifier(systemChannel, r.bootBlock, r.newPolicyManager)
+	err := r.source.PullBlocks(systemChannel, 0, height-1, func(block *cb.Block) error {
+		if err := verifier.Verify(block); err != nil {
+			return err
+		}
+		var blockChannels []channel
+		for i := range block.Data.Data {
+			configtx, err := createdChannelConfig(block, i)
+			if err != nil {
+				return err
+			}
+			if configtx == nil {
+				continue
+			}
+			chdr, err := utils.ChannelHeader(configtx)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("failed extracting the channel created by block [%d]", block.Header.Number))
+			}
+			genesis, err := genesisBlockOf(configtx)
+			if err != nil {
+				return err
+			}
+			blockChannels = append(blockChannels, channel{id: chdr.ChannelId, genesis: genesis})
+		}
+
+		verifier.Accept(block)
+		for _, ch := range blockChannels {
+			if created[ch.id] {
+				continue
+			}
+			logger.Debugf("Block [%d] of system channel %s created channel %s", block.Header.Number, systemChannel, ch.id)
+			created[ch.id] = true
+			channels = append(channels, ch)
+		}
+		return nil
+	})
+	return channels, err
+}
+
+// createdChannelConfig returns the config transaction of the channel created by the
+// envelope at the given index of the block, or nil if it does not create a channel
+func createdChannelConfig(block *cb.Block, index int) (*cb.Envelope, error) {
+	env, err := utils.ExtractEnvelope(block, index)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed extracting envelope %d of block [%d]", index, block.Header.Number))
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil || payload.Header == nil {
+		return nil, errors.Errorf("envelope %d of block [%d] has no header", index, block.Header.Number)
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed unmarshaling the channel header of envelope %d of block [%d]", index, block.Header.Number))
+	}
+	if cb.HeaderType(chdr.Type) != cb.HeaderType_ORDERER_TRANSACTION {
+		return nil, nil
+	}
+	configtx, err := utils.UnmarshalEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed unmarshaling the config transaction of envelope %d of block [%d]", index, block.Header.Number))
+	}
+	return configtx, nil
+}
+
+// replicate verifies and appends the blocks of the channel the ledger does not have yet
+func (r *Replicator) replicate(channelID string, genesis *cb.Block, height uint64) error {
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed creating the ledger of channel %s", channelID))
+	}
+	verifier, err := resumeChainVerifier(channelID, genesis, ledger, r.newPolicyManager)
+	if err != nil {
+		return err
+	}
+
+	start := ledger.Height()
+	if start >= height {
+		logger.Infof("Channel %s is already replicated up to block [%d]", channelID, start-1)
+		return nil
+	}
+	logger.Infof("Replicating blocks [%d, %d] of channel %s", start, height-1, channelID)
+
+	return r.source.PullBlocks(channelID, start, height-1, func(block *cb.Block) error {
+		if err := verifier.Verify(block); err != nil {
+			return err
+		}
+		if err := ledger.Append(block); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed appending block [%d] of channel %s", block.Header.Number, channelID))
+		}
+		verifier.Accept(block)
+		return nil
+	})
+}
@@ -0,0 +1,357 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/core/comm"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	flogging.SetModuleLevel(pkgLogID, "DEBUG")
+}
+
+const systemChannel = "system"
+
+// deliverServer serves the blocks of its channels over the Deliver API
+type deliverServer struct {
+	sync.Mutex
+	blocks   map[string][]*cb.Block
+	requests int
+}
+
+func (ds *deliverServer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	panic("Should not be used")
+}
+
+func (ds *deliverServer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	env, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	seekInfo := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seekInfo); err != nil {
+		return err
+	}
+
+	ds.Lock()
+	ds.requests++
+	blocks, ok := ds.blocks[chdr.ChannelId]
+	ds.Unlock()
+	if !ok {
+		return srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Status{Status: cb.Status_NOT_FOUND}})
+	}
+
+	position := func(pos *ab.SeekPosition) uint64 {
+		if specified := pos.GetSpecified(); specified != nil {
+			return specified.Number
+		}
+		return uint64(len(blocks) - 1)
+	}
+	for i := position(seekInfo.Start); i <= position(seekInfo.Stop) && i < uint64(len(blocks)); i++ {
+		if err := srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Block{Block: blocks[i]}}); err != nil {
+			return err
+		}
+	}
+	return srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Status{Status: cb.Status_SUCCESS}})
+}
+
+func startDeliverServer(t *testing.T, blocks map[string][]*cb.Block) (*deliverServer, string, func()) {
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{SecOpts: &comm.SecureOptions{}})
+	assert.NoError(t, err)
+	ds := &deliverServer{blocks: blocks}
+	ab.RegisterAtomicBroadcastServer(srv.Server(), ds)
+	go srv.Start()
+	return ds, srv.Address(), srv.Stop
+}
+
+func sign(block *cb.Block, signer string) {
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Signatures: []*cb.MetadataSignature{{
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte(signer)}),
+			Signature:       []byte("signature"),
+		}},
+	})
+}
+
+func nextBlock(previous *cb.Block, envs ...*cb.Envelope) *cb.Block {
+	block := cb.NewBlock(previous.Header.Number+1, previous.Header.Hash())
+	for _, env := range envs {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	block.Header.DataHash = block.Data.Hash()
+	sign(block, "orderer")
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 0}),
+	})
+	return block
+}
+
+func envelope(channel string, headerType cb.HeaderType, data []byte) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType), ChannelId: channel}),
+			},
+			Data: data,
+		}),
+	}
+}
+
+// makeChains returns the chains of a system channel which created channel foo,
+// and of channel foo, with the genesis block of foo created as the orderers do
+func makeChains(t *testing.T) map[string][]*cb.Block {
+	conf := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile)
+	systemGenesis := encoder.New(conf).GenesisBlockForChannel(systemChannel)
+	fooConfigtx, err := utils.ExtractEnvelope(encoder.New(conf).GenesisBlockForChannel("foo"), 0)
+	assert.NoError(t, err)
+
+	rl, err := ramledger.New(10).GetOrCreate("foo")
+	assert.NoError(t, err)
+	fooChain := []*cb.Block{blockledger.CreateNextBlock(rl, []*cb.Envelope{fooConfigtx})}
+	for i := 1; i < 4; i++ {
+		fooChain = append(fooChain, nextBlock(fooChain[i-1], envelope("foo", cb.HeaderType_ENDORSER_TRANSACTION, []byte(fmt.Sprintf("tx%d", i)))))
+	}
+
+	systemChain := []*cb.Block{systemGenesis}
+	systemChain = append(systemChain, nextBlock(systemGenesis, envelope(systemChannel, cb.HeaderType_ORDERER_TRANSACTION, utils.MarshalOrPanic(fooConfigtx))))
+
+	return map[string][]*cb.Block{systemChannel: systemChain, "foo": fooChain}
+}
+
+// tamper returns the chains with the given block altered, and signed by an identity
+// which is not the orderer
+func tamper(chains map[string][]*cb.Block, channel string, number int) map[string][]*cb.Block {
+	tampered := make(map[string][]*cb.Block)
+	for channel, chain := range chains {
+		tampered[channel] = append([]*cb.Block(nil), chain...)
+	}
+	block := proto.Clone(chains[channel][number]).(*cb.Block)
+	block.Data.Data = append(block.Data.Data, []byte("tampered"))
+	block.Header.DataHash = block.Data.Hash()
+	sign(block, "mallory")
+	tampered[channel][number] = block
+	return tampered
+}
+
+func newTestSource(t *testing.T, endpoints ...string) *DeliverSource {
+	client, err := comm.NewGRPCClient(comm.ClientConfig{Timeout: 500 * time.Millisecond})
+	assert.NoError(t, err)
+	return &DeliverSource{
+		Endpoints:     endpoints,
+		Client:        client,
+		Signer:        &mockcrypto.LocalSigner{},
+		Timeout:       time.Second,
+		RetryInterval: 10 * time.Millisecond,
+		MaxRetries:    1,
+	}
+}
+
+func assertReplicated(t *testing.T, lf blockledger.Factory, chains map[string][]*cb.Block) {
+	for channel, chain := range chains {
+		rl, err := lf.GetOrCreate(channel)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(len(chain)), rl.Height(), "Channel %s should have been replicated", channel)
+		for i, block := range chain {
+			assert.True(t, proto.Equal(block, blockledger.GetBlock(rl, uint64(i))), "Block [%d] of channel %s should have been replicated", i, channel)
+		}
+	}
+}
+
+func TestReplicateChains(t *testing.T) {
+	chains := makeChains(t)
+
+	// The first orderer sends a tampered block of channel foo, so the replicator
+	// has to switch to the second orderer to pull it
+	badServer, badEndpoint, stopBad := startDeliverServer(t, tamper(chains, "foo", 2))
+	defer stopBad()
+	_, goodEndpoint, stopGood := startDeliverServer(t, chains)
+	defer stopGood()
+
+	lf := ramledger.New(10)
+	source := newTestSource(t, "127.0.0.1:1", badEndpoint, goodEndpoint)
+	replicator := NewReplicator(source, lf, chains[systemChannel][0])
+	replicator.newPolicyManager = signedByOrderer
+	assert.NoError(t, replicator.ReplicateChains())
+	assertReplicated(t, lf, chains)
+	assert.NotZero(t, badServer.requests)
+}
+
+func TestReplicateChainsResume(t *testing.T) {
+	chains := makeChains(t)
+	_, endpoint, stop := startDeliverServer(t, chains)
+	defer stop()
+
+	lf := ramledger.New(10)
+	rl, _ := lf.GetOrCreate("foo")
+	assert.NoError(t, rl.Append(chains["foo"][0]))
+	assert.NoError(t, rl.Append(chains["foo"][1]))
+
+	source := newTestSource(t, endpoint)
+	assert.NoError(t, NewReplicator(source, lf, chains[systemChannel][0]).ReplicateChains())
+	assertReplicated(t, lf, chains)
+}
+
+func TestReplicateChainsResumeSystemChannel(t *testing.T) {
+	chains := makeChains(t)
+	_, endpoint, stop := startDeliverServer(t, chains)
+	defer stop()
+
+	// The replication was interrupted while the system channel was written
+	lf := ramledger.New(10)
+	rl, _ := lf.GetOrCreate("foo")
+	for _, block := range chains["foo"] {
+		assert.NoError(t, rl.Append(block))
+	}
+	rl, _ = lf.GetOrCreate(systemChannel)
+	assert.NoError(t, rl.Append(chains[systemChannel][0]))
+
+	source := newTestSource(t, endpoint)
+	assert.NoError(t, NewReplicator(source, lf, chains[systemChannel][0]).ReplicateChains())
+	assertReplicated(t, lf, chains)
+}
+
+func TestReplicateChainsFailure(t *testing.T) {
+	chains := makeChains(t)
+
+	t.Run("BadBlock", func(t *testing.T) {
+		_, endpoint, stop := startDeliverServer(t, tamper(chains, "foo", 3))
+		defer stop()
+
+		lf := ramledger.New(10)
+		source := newTestSource(t, endpoint)
+		replicator := NewReplicator(source, lf, chains[systemChannel][0])
+		replicator.newPolicyManager = signedByOrderer
+		err := replicator.ReplicateChains()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed pulling block [3] of channel foo from any orderer")
+		assert.Equal(t, []string{"foo"}, lf.ChainIDs(), "The system channel should not have been written")
+	})
+
+	t.Run("UntrustedGenesisBlock", func(t *testing.T) {
+		_, endpoint, stop := startDeliverServer(t, chains)
+		defer stop()
+
+		otherGenesis := encoder.New(genesisconfig.Load(genesisconfig.SampleInsecureKafkaProfile)).GenesisBlockForChannel(systemChannel)
+		source := newTestSource(t, endpoint)
+		err := NewReplicator(source, ramledger.New(10), otherGenesis).ReplicateChains()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "genesis block of channel system does not match the trusted genesis block")
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		source := newTestSource(t, "127.0.0.1:1")
+		err := NewReplicator(source, ramledger.New(10), chains[systemChannel][0]).ReplicateChains()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed retrieving the height of channel system from any orderer")
+	})
+}
+
+// signerPolicy is satisfied by the signatures of the identity it is named after
+type signerPolicy string
+
+func (p signerPolicy) Evaluate(signatureSet []*cb.SignedData) error {
+	for _, sd := range signatureSet {
+		if string(sd.Identity) == string(p) {
+			return nil
+		}
+	}
+	return errors.New("not signed by " + string(p))
+}
+
+type signerPolicyManager struct {
+	signerPolicy
+}
+
+func (m *signerPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	return m.signerPolicy, true
+}
+
+func (m *signerPolicyManager) Manager(path []string) (policies.Manager, bool) {
+	return m, true
+}
+
+// signedByOrderer requires the blocks to be signed by the orderer, as the sample
+// configs accept any signature
+func signedByOrderer(env *cb.Envelope) (policies.Manager, error) {
+	return &signerPolicyManager{signerPolicy("orderer")}, nil
+}
+
+func TestChainVerifier(t *testing.T) {
+	chains := makeChains(t)
+	chain := chains["foo"]
+
+	// Block 2 is a config block, after which the blocks have to be signed by orderer2
+	config := envelope("foo", cb.HeaderType_CONFIG, nil)
+	configBlock := nextBlock(chain[1], config)
+	afterConfig := nextBlock(configBlock)
+	signedByOrderer2 := nextBlock(configBlock)
+	sign(signedByOrderer2, "orderer2")
+
+	verifier := newChainVerifier("foo", chain[0], func(env *cb.Envelope) (policies.Manager, error) {
+		if proto.Equal(env, config) {
+			return &signerPolicyManager{signerPolicy("orderer2")}, nil
+		}
+		return &signerPolicyManager{signerPolicy("orderer")}, nil
+	})
+
+	verifyAndAccept := func(block *cb.Block) error {
+		if err := verifier.Verify(block); err != nil {
+			return err
+		}
+		verifier.Accept(block)
+		return nil
+	}
+
+	assert.EqualError(t, verifier.Verify(chain[1]), "expected block [0] of channel foo but got block [1]")
+	assert.EqualError(t, verifier.Verify(chains[systemChannel][0]), "genesis block of channel foo does not match the trusted genesis block")
+	assert.NoError(t, verifyAndAccept(chain[0]))
+
+	assert.EqualError(t, verifier.Verify(nextBlock(chains[systemChannel][0])), "previous hash of block [1] of channel foo does not match the hash of block [0]")
+	assert.NoError(t, verifyAndAccept(chain[1]))
+	assert.NoError(t, verifyAndAccept(configBlock))
+
+	err := verifier.Verify(afterConfig)
+	assert.EqualError(t, err, "signatures of block [3] of channel foo do not satisfy the block validation policy: not signed by orderer2")
+	assert.NoError(t, verifyAndAccept(signedByOrderer2))
+
+	t.Run("Resume", func(t *testing.T) {
+		rl, _ := ramledger.New(10).GetOrCreate("foo")
+		for _, block := range []*cb.Block{chain[0], chain[1], configBlock} {
+			assert.NoError(t, rl.Append(block))
+		}
+		resumed, err := resumeChainVerifier("foo", chain[0], rl, verifier.newPolicyManager)
+		assert.NoError(t, err)
+		assert.Error(t, resumed.Verify(afterConfig), "The resumed verifier should apply the last config block")
+		assert.NoError(t, resumed.Verify(signedByOrderer2))
+	})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// genesisBlockOf returns the genesis block the orderers create for a channel out of
+// the config transaction embedded in the system channel transaction creating it
+func genesisBlockOf(configtx *cb.Envelope) (*cb.Block, error) {
+	data, err := proto.Marshal(configtx)
+	if err != nil {
+		return nil, err
+	}
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{data}
+	block.Header.DataHash = block.Data.Hash()
+	return block, nil
+}
+
+// chainVerifier verifies that the blocks of a channel, received in order, extend the
+// genesis block it trusts, and that each block after the genesis block is signed
+// according to the BlockValidation policy of the last config block preceding it
+type chainVerifier struct {
+	channel          string
+	genesisHash      []byte
+	newPolicyManager channelconfig.PolicyManagerFactory
+
+	next          uint64
+	lastHash      []byte
+	policyManager policies.Manager
+
+	// verified is the policy manager in effect after the last verified block
+	// which has not been accepted yet
+	verified policies.Manager
+}
+
+func newChainVerifier(channel string, genesis *cb.Block, newPolicyManager channelconfig.PolicyManagerFactory) *chainVerifier {
+	return &chainVerifier{
+		channel:          channel,
+		genesisHash:      genesis.Header.Hash(),
+		newPolicyManager: newPolicyManager,
+	}
+}
+
+// resumeChainVerifier returns a chainVerifier for the blocks following the blocks
+// of the given ledger, which were verified when they were appended
+func resumeChainVerifier(channel string, genesis *cb.Block, rl blockledger.Reader, newPolicyManager channelconfig.PolicyManagerFactory) (*chainVerifier, error) {
+	verifier := newChainVerifier(channel, genesis, newPolicyManager)
+	height := rl.Height()
+	if height == 0 {
+		return verifier, nil
+	}
+
+	lastBlock := blockledger.GetBlock(rl, height-1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed retrieving block [%d] of channel %s", height-1, channel)
+	}
+	configBlock := lastBlock
+	if !utils.IsConfigBlock(lastBlock) {
+		index, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed retrieving the last config index of channel %s", channel))
+		}
+		if configBlock = blockledger.GetBlock(rl, index); configBlock == nil {
+			return nil, errors.Errorf("failed retrieving config block [%d] of channel %s", index, channel)
+		}
+	}
+	policyManager, err := verifier.policyManagerOf(configBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier.next = height
+	verifier.lastHash = lastBlock.Header.Hash()
+	verifier.policyManager = policyManager
+	return verifier, nil
+}
+
+// Verify verifies the next block of the channel, which is only taken into account
+// for the verification of the following blocks once it is accepted
+func (v *chainVerifier) Verify(block *cb.Block) error {
+	v.verified = nil
+	if block.Header == nil || block.Data == nil {
+		return errors.Errorf("block of channel %s has no header or no data", v.channel)
+	}
+	if block.Header.Number != v.next {
+		return errors.Errorf("expected block [%d] of channel %s but got block [%d]", v.next, v.channel, block.Header.Number)
+	}
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return errors.Errorf("data hash of block [%d] of channel %s does not match its data", block.Header.Number, v.channel)
+	}
+
+	if v.next == 0 {
+		if !bytes.Equal(block.Header.Hash(), v.genesisHash) {
+			return errors.Errorf("genesis block of channel %s does not match the trusted genesis block", v.channel)
+		}
+	} else {
+		if !bytes.Equal(block.Header.PreviousHash, v.lastHash) {
+			return errors.Errorf("previous hash of block [%d] of channel %s does not match the hash of block [%d]",
+				block.Header.Number, v.channel, v.next-1)
+		}
+		if err := v.verifySignatures(block); err != nil {
+			return err
+		}
+	}
+
+	policyManager := v.policyManager
+	if utils.IsConfigBlock(block) {
+		var err error
+		if policyManager, err = v.policyManagerOf(block); err != nil {
+			return err
+		}
+	}
+
+	v.verified = policyManager
+	return nil
+}
+
+// Accept takes the last verified block into account for the verification of the following blocks
+func (v *chainVerifier) Accept(block *cb.Block) {
+	v.next++
+	v.lastHash = block.Header.Hash()
+	v.policyManager = v.verified
+	v.verified = nil
+}
+
+func (v *chainVerifier) policyManagerOf(configBlock *cb.Block) (policies.Manager, error) {
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed extracting the config of block [%d] of channel %s", configBlock.Header.Number, v.channel))
+	}
+	policyManager, err := v.newPolicyManager(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed loading the config of block [%d] of channel %s", configBlock.Header.Number, v.channel))
+	}
+	return policyManager, nil
+}
+
+func (v *chainVerifier) verifySignatures(block *cb.Block) error {
+	if v.policyManager == nil {
+		return errors.Errorf("no config to verify block [%d] of channel %s against", block.Header.Number, v.channel)
+	}
+
+	signatureSet, err := utils.GetSignatureSetFromBlock(block)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("invalid signatures of block [%d] of channel %s", block.Header.Number, v.channel))
+	}
+
+	policy, _ := v.policyManager.GetPolicy(policies.BlockValidation)
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("signatures of block [%d] of channel %s do not satisfy the block validation policy", block.Header.Number, v.channel))
+	}
+	return nil
+}
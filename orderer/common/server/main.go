@@ -20,13 +20,16 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
@@ -39,6 +42,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/performance"
+	"github.com/hyperledger/fabric/orderer/common/replication"
 	"github.com/op/go-logging"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -102,7 +106,9 @@ func Start(cmd string, conf *config.TopLevel) {
 
 	manager := initializeMultichannelRegistrar(conf, signer, tlsCallback)
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, signer, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	initializeMetrics(conf)
+	limiter := broadcast.NewRateLimiter(rateLimits(&conf.RateLimiting), metrics.GetRootScope().SubScope("broadcast"))
+	server := NewServer(manager, signer, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, limiter)
 
 	switch cmd {
 	case start.FullCommand(): // "start" command
@@ -136,6 +142,46 @@ func initializeProfilingService(conf *config.TopLevel) {
 	}
 }
 
+// Initialize the metrics reporting if enabled.
+func initializeMetrics(conf *config.TopLevel) {
+	err := metrics.Init(metrics.Opts{
+		Enabled:  conf.Metrics.Enabled,
+		Reporter: conf.Metrics.Reporter,
+		Interval: conf.Metrics.Interval,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conf.Metrics.StatsdReporter.Address,
+			FlushInterval: conf.Metrics.StatsdReporter.FlushInterval,
+			FlushBytes:    conf.Metrics.StatsdReporter.FlushBytes,
+		},
+		PromReporterOpts: metrics.PromReporterOpts{
+			ListenAddress: conf.Metrics.PromReporter.ListenAddress,
+		},
+	})
+	if err != nil {
+		logger.Panicf("Failed initializing metrics: %s", err)
+	}
+	if conf.Metrics.Enabled {
+		go func() {
+			if err := metrics.Start(); err != nil {
+				logger.Errorf("Error starting metrics server: %s", err)
+			}
+		}()
+	}
+}
+
+// rateLimits converts the rate limits of the local configuration to the
+// defaults channels may override.
+func rateLimits(conf *config.RateLimiting) *ab.RateLimits {
+	convert := func(limit config.RateLimit) *ab.RateLimit {
+		return &ab.RateLimit{MessagesPerSecond: limit.MessagesPerSecond, Burst: limit.Burst}
+	}
+	return &ab.RateLimits{
+		Channel:      convert(conf.Channel),
+		Organization: convert(conf.Organization),
+		Client:       convert(conf.Client),
+	}
+}
+
 func initializeServerConfig(conf *config.TopLevel) comm.ServerConfig {
 	// secure server config
 	secureOpts := &comm.SecureOptions{
@@ -194,7 +240,7 @@ func initializeServerConfig(conf *config.TopLevel) comm.ServerConfig {
 	return comm.ServerConfig{SecOpts: secureOpts, KaOpts: kaOpts}
 }
 
-func initializeBootstrapChannel(conf *config.TopLevel, lf blockledger.Factory) {
+func extractGenesisBlock(conf *config.TopLevel) *cb.Block {
 	var genesisBlock *cb.Block
 
 	// Select the bootstrapping mechanism
@@ -206,6 +252,11 @@ func initializeBootstrapChannel(conf *config.TopLevel, lf blockledger.Factory) {
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
+	return genesisBlock
+}
+
+func initializeBootstrapChannel(conf *config.TopLevel, lf blockledger.Factory) {
+	genesisBlock := extractGenesisBlock(conf)
 
 	chainID, err := utils.GetChainIDFromBlock(genesisBlock)
 	if err != nil {
@@ -248,6 +299,10 @@ func initializeLocalMsp(conf *config.TopLevel) {
 func initializeMultichannelRegistrar(conf *config.TopLevel, signer crypto.LocalSigner,
 	callbacks ...func(bundle *channelconfig.Bundle)) *multichannel.Registrar {
 	lf, _ := createLedgerFactory(conf)
+	// Are we onboarding from existing orderers?
+	if len(conf.Replication.Endpoints) > 0 {
+		replicateChains(conf, lf, signer)
+	}
 	// Are we bootstrapping?
 	if len(lf.ChainIDs()) == 0 {
 		initializeBootstrapChannel(conf, lf)
@@ -259,7 +314,81 @@ func initializeMultichannelRegistrar(conf *config.TopLevel, signer crypto.LocalS
 	consenters["solo"] = solo.New()
 	consenters["kafka"] = kafka.New(conf.Kafka)
 
-	return multichannel.NewRegistrar(lf, consenters, signer, callbacks...)
+	ruleRegistry, err := newRuleRegistry(conf)
+	if err != nil {
+		logger.Panicf("Failed loading the admission rules: %s", err)
+	}
+
+	return multichannel.NewRegistrar(lf, consenters, signer, ruleRegistry, callbacks...)
+}
+
+// newRuleRegistry creates the registry of the admission rules the channels may select
+func newRuleRegistry(conf *config.TopLevel) (*msgprocessor.RuleRegistry, error) {
+	var plugins []msgprocessor.RulePlugin
+	for _, p := range conf.AdmissionRules.Plugins {
+		plugins = append(plugins, msgprocessor.RulePlugin{Name: p.Name, Library: p.Library})
+	}
+	return msgprocessor.NewRuleRegistry(plugins)
+}
+
+// replicateChains replicates the chains of the orderers at the replication endpoints.
+// The blocks the ledger already has are not pulled again, so that a replication which
+// was interrupted, possibly while the system channel was written, is completed.
+func replicateChains(conf *config.TopLevel, lf blockledger.Factory, signer crypto.LocalSigner) {
+	bootBlock := extractGenesisBlock(conf)
+
+	client, err := comm.NewGRPCClient(replicationClientConfig(conf))
+	if err != nil {
+		logger.Fatal("Failed to create the replication client:", err)
+	}
+	source := &replication.DeliverSource{
+		Endpoints:     conf.Replication.Endpoints,
+		Client:        client,
+		Signer:        signer,
+		Timeout:       conf.Replication.Timeout,
+		RetryInterval: conf.Replication.RetryInterval,
+		MaxRetries:    conf.Replication.MaxRetries,
+	}
+	logger.Infof("Replicating the chains from %v", conf.Replication.Endpoints)
+	if err := replication.NewReplicator(source, lf, bootBlock).ReplicateChains(); err != nil {
+		logger.Panicf("Failed replicating the chains: %s", err)
+	}
+	logger.Info("Replicated the chains")
+}
+
+// replicationClientConfig returns the config of the client connecting to the orderers
+// to replicate, which presents the TLS certificate of the orderer.
+func replicationClientConfig(conf *config.TopLevel) comm.ClientConfig {
+	clientConfig := comm.ClientConfig{
+		SecOpts: &comm.SecureOptions{UseTLS: conf.General.TLS.Enabled},
+		KaOpts:  comm.DefaultKeepaliveOptions(),
+		Timeout: conf.Replication.Timeout,
+	}
+	if !conf.General.TLS.Enabled {
+		return clientConfig
+	}
+
+	certificate, err := ioutil.ReadFile(conf.General.TLS.Certificate)
+	if err != nil {
+		logger.Fatalf("Failed to load client Certificate file '%s' (%s)", conf.General.TLS.Certificate, err)
+	}
+	key, err := ioutil.ReadFile(conf.General.TLS.PrivateKey)
+	if err != nil {
+		logger.Fatalf("Failed to load PrivateKey file '%s' (%s)", conf.General.TLS.PrivateKey, err)
+	}
+	var serverRootCAs [][]byte
+	for _, serverRoot := range conf.General.TLS.RootCAs {
+		root, err := ioutil.ReadFile(serverRoot)
+		if err != nil {
+			logger.Fatalf("Failed to load ServerRootCAs file '%s' (%s)", serverRoot, err)
+		}
+		serverRootCAs = append(serverRootCAs, root)
+	}
+	clientConfig.SecOpts.Certificate = certificate
+	clientConfig.SecOpts.Key = key
+	clientConfig.SecOpts.RequireClientCert = true
+	clientConfig.SecOpts.ServerRootCAs = serverRootCAs
+	return clientConfig
 }
 
 func updateTrustedRoots(srv *comm.GRPCServer, rootCASupport *comm.CASupport,
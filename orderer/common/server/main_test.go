@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
 )
@@ -155,6 +156,7 @@ func TestInitializeBootstrapChannel(t *testing.T) {
 		{"provisional", "ram", false},
 		{"provisional", "file", false},
 		{"provisional", "json", false},
+		{"provisional", "kv", false},
 		{"invalid", "ram", true},
 		{"file", "ram", true},
 	}
@@ -241,6 +243,56 @@ func TestInitializeMultiChainManager(t *testing.T) {
 	})
 }
 
+func TestReplicateChains(t *testing.T) {
+	conf := genesisConfig(t)
+	conf.Replication = config.Replication{
+		Endpoints:     []string{"127.0.0.1:1"},
+		Timeout:       100 * time.Millisecond,
+		RetryInterval: time.Millisecond,
+	}
+
+	t.Run("Unreachable", func(t *testing.T) {
+		lf, _ := createLedgerFactory(conf)
+		assert.Panics(t, func() {
+			replicateChains(conf, lf, localmsp.NewSigner())
+		})
+	})
+
+	t.Run("PartialSystemChannel", func(t *testing.T) {
+		// An interrupted replication leaves the ledger of the system channel partial,
+		// which must not prevent the replication from being completed
+		lf, _ := createLedgerFactory(conf)
+		systemChannel, err := utils.GetChainIDFromBlock(extractGenesisBlock(conf))
+		assert.NoError(t, err)
+		_, err = lf.GetOrCreate(systemChannel)
+		assert.NoError(t, err)
+		assert.Panics(t, func() {
+			replicateChains(conf, lf, localmsp.NewSigner())
+		}, "The replication should not be skipped")
+	})
+}
+
+func TestReplicationClientConfig(t *testing.T) {
+	conf := &config.TopLevel{Replication: config.Replication{Timeout: time.Second}}
+	clientConfig := replicationClientConfig(conf)
+	assert.False(t, clientConfig.SecOpts.UseTLS)
+	assert.Equal(t, time.Second, clientConfig.Timeout)
+
+	conf.General.TLS = config.TLS{
+		Enabled:     true,
+		Certificate: filepath.Join("testdata", "tls", "server.crt"),
+		PrivateKey:  filepath.Join("testdata", "tls", "server.key"),
+		RootCAs:     []string{filepath.Join("testdata", "tls", "ca.crt")},
+	}
+	clientConfig = replicationClientConfig(conf)
+	assert.True(t, clientConfig.SecOpts.RequireClientCert)
+	assert.NotEmpty(t, clientConfig.SecOpts.Certificate)
+	assert.NotEmpty(t, clientConfig.SecOpts.Key)
+	assert.Len(t, clientConfig.SecOpts.ServerRootCAs, 1)
+	_, err := comm.NewGRPCClient(clientConfig)
+	assert.NoError(t, err)
+}
+
 func TestInitializeGrpcServer(t *testing.T) {
 	// get a free random port
 	listenAddr := func() string {
@@ -60,18 +60,19 @@ func (rs *responseSender) SendStatusResponse(status cb.Status) error {
 	return rs.Send(reply)
 }
 
-func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
+func (rs *responseSender) SendBlockResponse(block *cb.Block, resumeToken []byte) error {
 	response := &ab.DeliverResponse{
-		Type: &ab.DeliverResponse_Block{Block: block},
+		Type:        &ab.DeliverResponse_Block{Block: block},
+		ResumeToken: resumeToken,
 	}
 	return rs.Send(response)
 }
 
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, _ crypto.LocalSigner, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+func NewServer(r *multichannel.Registrar, _ crypto.LocalSigner, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool, limiter *broadcast.RateLimiter) ab.AtomicBroadcastServer {
 	s := &server{
 		dh:        deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS),
-		bh:        broadcast.NewHandlerImpl(broadcastSupport{Registrar: r}),
+		bh:        broadcast.NewHandlerImpl(broadcastSupport{Registrar: r}, limiter),
 		debug:     debug,
 		Registrar: r,
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
 	jsonledger "github.com/hyperledger/fabric/common/ledger/blockledger/json"
+	kvledger "github.com/hyperledger/fabric/common/ledger/blockledger/kv"
 	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
 	config "github.com/hyperledger/fabric/orderer/common/localconfig"
 )
@@ -42,6 +43,13 @@ func createLedgerFactory(conf *config.TopLevel) (blockledger.Factory, string) {
 		}
 		logger.Debug("Ledger dir:", ld)
 		lf = jsonledger.New(ld)
+	case "kv":
+		ld = conf.FileLedger.Location
+		if ld == "" {
+			ld = createTempDir(conf.FileLedger.Prefix)
+		}
+		logger.Debug("Ledger dir:", ld)
+		lf = kvledger.New(ld)
 	case "ram":
 		fallthrough
 	default:
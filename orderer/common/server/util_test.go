@@ -28,6 +28,8 @@ func TestCreateLedgerFactory(t *testing.T) {
 		{"JSONwithPathUnset", "json", "", "test-prefix", false},
 		{"FilewithPathSet", "file", filepath.Join(os.TempDir(), "test-dir"), "", false},
 		{"FilewithPathUnset", "file", "", "test-prefix", false},
+		{"KVwithPathSet", "kv", filepath.Join(os.TempDir(), "test-kv-dir"), "", false},
+		{"KVwithPathUnset", "kv", "", "test-prefix", false},
 	}
 
 	conf, err := config.Load()
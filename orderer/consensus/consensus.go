@@ -25,6 +25,14 @@ type Consenter interface {
 	HandleChain(support ConsenterSupport, metadata *cb.Metadata) (Chain, error)
 }
 
+// MetadataValidator is implemented by the Consenters which take metadata in the
+// consensus type of the channel config. The consensus types whose Consenter does
+// not implement it take no metadata.
+type MetadataValidator interface {
+	// ValidateConsensusMetadata returns an error if the metadata set by a config update is not valid.
+	ValidateConsensusMetadata(metadata []byte) error
+}
+
 // Chain defines a way to inject messages for ordering.
 // Note, that in order to allow flexibility in the implementation, it is the responsibility of the implementer
 // to take the ordered messages, send them through the blockcutter.Receiver supplied via HandleChain to cut blocks,
@@ -71,6 +79,8 @@ type Chain interface {
 	Start()
 
 	// Halt frees the resources which were allocated for this Chain.
+	// It blocks until the Chain has stopped, so that no block is written
+	// to the ledger once Halt returns.
 	Halt()
 }
 
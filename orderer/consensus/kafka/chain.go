@@ -109,6 +109,8 @@ type chainImpl struct {
 	startChan chan struct{}
 	// timer controls the batch timeout of cutting pending messages into block
 	timer <-chan time.Time
+	// timerDeadline is the time at which the running timer expires
+	timerDeadline time.Time
 }
 
 // Errored returns a channel which will close when a partition consumer error
@@ -126,7 +128,8 @@ func (chain *chainImpl) Start() {
 	go startThread(chain)
 }
 
-// Halt frees the resources which were allocated for this Chain. Implements the
+// Halt frees the resources which were allocated for this Chain, and returns
+// once the messages are no longer processed to blocks. Implements the
 // consensus.Chain interface.
 func (chain *chainImpl) Halt() {
 	select {
@@ -138,6 +141,9 @@ func (chain *chainImpl) Halt() {
 			// multiple times (by a single thread) w/o panicking. Recal that a
 			// receive from a closed channel returns (the zero value) immediately.
 			logger.Warningf("[channel: %s] Halting of chain requested again", chain.ChainID())
+			// The first call may still be waiting for the chain to stop, so
+			// wait as well: no block is written once Halt() returns
+			<-chain.doneProcessingMessagesToBlocks
 		default:
 			logger.Criticalf("[channel: %s] Halting of chain requested", chain.ChainID())
 			// stat shutdown of chain
@@ -534,6 +540,21 @@ func newProducerMessage(channel channel, pld []byte) *sarama.ProducerMessage {
 	}
 }
 
+// startTimer starts the batch timer of the pending messages, unless it is already
+// running with an earlier deadline
+func (chain *chainImpl) startTimer() {
+	timeout := chain.BlockCutter().Timeout()
+	if timeout == 0 {
+		timeout = chain.SharedConfig().BatchTimeout()
+	}
+	deadline := time.Now().Add(timeout)
+	if chain.timer != nil && !deadline.Before(chain.timerDeadline) {
+		return
+	}
+	chain.timer, chain.timerDeadline = time.After(timeout), deadline
+	logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), timeout.String())
+}
+
 func (chain *chainImpl) processConnect(channelName string) error {
 	logger.Debugf("[channel: %s] It's a connect message - ignoring", channelName)
 	return nil
@@ -553,10 +574,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 		if len(batches) == 0 {
 			// If no block is cut, we update the `lastOriginalOffsetProcessed`, start the timer if necessary and return
 			chain.lastOriginalOffsetProcessed = newOffset
-			if chain.timer == nil {
-				chain.timer = time.After(chain.SharedConfig().BatchTimeout())
-				logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), chain.SharedConfig().BatchTimeout().String())
-			}
+			chain.startTimer()
 			return
 		}
 
@@ -907,9 +925,10 @@ func setupParentConsumerForChannel(retryOptions localconfig.Retry, haltChan chan
 	logger.Infof("[channel: %s] Setting up the parent consumer for this channel...", channel.topic())
 
 	retryMsg := "Connecting to the Kafka cluster"
+	classifier := newConnectionErrorClassifier(brokers, brokerConfig)
 	setupParentConsumer := newRetryProcess(retryOptions, haltChan, channel, retryMsg, func() error {
 		parentConsumer, err = sarama.NewConsumer(brokers, brokerConfig)
-		return err
+		return classifier.classify(err)
 	})
 
 	return parentConsumer, setupParentConsumer.retry()
@@ -923,9 +942,10 @@ func setupProducerForChannel(retryOptions localconfig.Retry, haltChan chan struc
 	logger.Infof("[channel: %s] Setting up the producer for this channel...", channel.topic())
 
 	retryMsg := "Connecting to the Kafka cluster"
+	classifier := newConnectionErrorClassifier(brokers, brokerConfig)
 	setupProducer := newRetryProcess(retryOptions, haltChan, channel, retryMsg, func() error {
 		producer, err = sarama.NewSyncProducer(brokers, brokerConfig)
-		return err
+		return classifier.classify(err)
 	})
 
 	return producer, setupProducer.retry()
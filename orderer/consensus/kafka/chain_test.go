@@ -162,6 +162,31 @@ func TestChain(t *testing.T) {
 		assert.NotPanics(t, func() { chain.Halt() }, "Calling Halt() more than once shouldn't panic")
 	})
 
+	t.Run("HaltWhileHalting", func(t *testing.T) {
+		_, mockBroker, mockSupport := newMocks(t)
+		defer func() { mockBroker.Close() }()
+		chain, _ := newChain(mockConsenter, mockSupport, newestOffset-1, lastOriginalOffsetProcessed, lastResubmittedConfigOffset)
+
+		chain.Start()
+		select {
+		case <-chain.startChan:
+			logger.Debug("startChan is closed as it should be")
+		case <-time.After(shortTimeout):
+			t.Fatal("startChan should have been closed by now")
+		}
+
+		// Another halt has started, but the messages may still be processed
+		close(chain.haltChan)
+		chain.Halt()
+
+		select {
+		case <-chain.doneProcessingMessagesToBlocks:
+			logger.Debug("doneProcessingMessagesToBlocks is closed as it should be")
+		default:
+			t.Fatal("Halt() should not return before the processing of messages to blocks is done")
+		}
+	})
+
 	t.Run("StartWithProducerForChannelError", func(t *testing.T) {
 		_, mockBroker, mockSupport := newMocks(t)
 		defer func() { mockBroker.Close() }()
@@ -3102,6 +3127,7 @@ func TestDeliverSession(t *testing.T) {
 		// setup mock blockcutter
 		blockcutter := &mockReceiver{}
 		blockcutter.On("Ordered", mock.Anything).Return([][]*cb.Envelope{{&cb.Envelope{}}}, false)
+		blockcutter.On("Timeout").Return(time.Duration(0))
 
 		// setup mock chain support and mock method calls
 		support := &mockConsenterSupport{}
@@ -3381,6 +3407,11 @@ func (r *mockReceiver) Cut() []*cb.Envelope {
 	return args.Get(0).([]*cb.Envelope)
 }
 
+func (r *mockReceiver) Timeout() time.Duration {
+	args := r.Called()
+	return args.Get(0).(time.Duration)
+}
+
 type mockConsenterSupport struct {
 	mock.Mock
 }
@@ -110,7 +110,7 @@ func extractEncodedOffset(marshalledOrdererMetadata []byte) int64 {
 }
 
 func newMockBrokerConfig(tlsConfig localconfig.TLS, retryOptions localconfig.Retry, kafkaVersion sarama.KafkaVersion, chosenStaticPartition int32) *sarama.Config {
-	brokerConfig := newBrokerConfig(tlsConfig, retryOptions, kafkaVersion, chosenStaticPartition)
+	brokerConfig := newBrokerConfig(tlsConfig, localconfig.SASL{}, retryOptions, kafkaVersion, chosenStaticPartition)
 	brokerConfig.ClientID = "test"
 	return brokerConfig
 }
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Shopify/sarama"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 )
 
@@ -59,7 +60,11 @@ func (rp *retryProcess) try(interval, total time.Duration) (err error) {
 		return
 	}
 
-	logger.Debugf("[channel: %s] Initial attempt failed = %s", rp.channel.topic(), err)
+	if _, ok := err.(*authenticationError); ok {
+		logger.Errorf("[channel: %s] Initial attempt failed = %s", rp.channel.topic(), err)
+	} else {
+		logger.Debugf("[channel: %s] Initial attempt failed = %s", rp.channel.topic(), err)
+	}
 
 	tickInterval := time.NewTicker(interval)
 	tickTotal := time.NewTicker(total)
@@ -82,7 +87,103 @@ func (rp *retryProcess) try(interval, total time.Duration) (err error) {
 				return
 			}
 
+			if _, ok := err.(*authenticationError); ok {
+				logger.Errorf("[channel: %s] Need to retry because process failed = %s", rp.channel.topic(), err)
+				continue
+			}
 			logger.Debugf("[channel: %s] Need to retry because process failed = %s", rp.channel.topic(), err)
 		}
 	}
 }
+
+// authenticationError is returned when the Kafka cluster can be reached but
+// rejects the SASL credentials of the orderer
+type authenticationError struct {
+	broker string
+	err    error
+}
+
+func (e *authenticationError) Error() string {
+	return fmt.Sprintf("SASL authentication with Kafka broker %s failed, check the Kafka.SASL settings: %s", e.broker, e.err)
+}
+
+// connectionErrorClassifier classifies the errors of a streak of failed
+// attempts to connect to the Kafka cluster. Probing the brokers is costly, so
+// only the first failure of a streak is classified, and the outcome is reused
+// until an attempt succeeds
+type connectionErrorClassifier struct {
+	brokers      []string
+	brokerConfig *sarama.Config
+	classified   bool
+	authErr      *authenticationError
+}
+
+func newConnectionErrorClassifier(brokers []string, brokerConfig *sarama.Config) *connectionErrorClassifier {
+	return &connectionErrorClassifier{brokers: brokers, brokerConfig: brokerConfig}
+}
+
+// classify returns an authentication error if the streak which err belongs
+// to was classified as such, and err otherwise. A nil err ends the streak
+func (c *connectionErrorClassifier) classify(err error) error {
+	if err == nil {
+		c.classified = false
+		c.authErr = nil
+		return nil
+	}
+	if !c.classified {
+		c.classified = true
+		if authErr, ok := classifyConnectionError(c.brokers, c.brokerConfig, err).(*authenticationError); ok {
+			c.authErr = authErr
+		}
+	}
+	if c.authErr != nil {
+		return c.authErr
+	}
+	return err
+}
+
+// classifyConnectionError tells an authentication failure apart from a
+// connectivity failure when connecting to the Kafka cluster failed. Sarama
+// reports both as the cluster being unreachable, so each broker is probed with
+// and without SASL: a broker which accepts the connection but not the SASL
+// handshake rejected the credentials. Any other error is returned as is
+func classifyConnectionError(brokers []string, brokerConfig *sarama.Config, err error) error {
+	if err == nil || !brokerConfig.Net.SASL.Enable {
+		return err
+	}
+
+	plainConfig := *brokerConfig
+	plainConfig.Net.SASL.Enable = false
+	plainConfig.Net.SASL.User = ""
+	plainConfig.Net.SASL.Password = ""
+
+	for _, addr := range brokers {
+		authErr := probeBroker(addr, brokerConfig)
+		if authErr == nil {
+			// The credentials were accepted, the failure lies elsewhere
+			return err
+		}
+		if probeBroker(addr, &plainConfig) == nil {
+			return &authenticationError{broker: addr, err: authErr}
+		}
+	}
+	return err
+}
+
+// probeBroker opens and closes a connection to a broker, and returns the error
+// that prevented the connection
+func probeBroker(addr string, brokerConfig *sarama.Config) error {
+	broker := sarama.NewBroker(addr)
+	if err := broker.Open(brokerConfig); err != nil {
+		return err
+	}
+	connected, err := broker.Connected()
+	if connected {
+		broker.Close()
+		return nil
+	}
+	if err == nil {
+		err = sarama.ErrNotConnected
+	}
+	return err
+}
@@ -7,9 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package kafka
 
 import (
+	"encoding/binary"
 	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/Shopify/sarama"
+	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +46,190 @@ func TestRetry(t *testing.T) {
 		assert.Error(t, rp.retry(), "Expected retry to return an error")
 	})
 }
+
+func TestClassifyConnectionError(t *testing.T) {
+	mockChannel := newChannel(channelNameForTest(t), defaultPartition)
+
+	mockBroker := sarama.NewMockBroker(t, 0)
+	defer mockBroker.Close()
+
+	standIn := newSASLStandIn(t, "orderer", "secret", mockBroker.Addr())
+	defer standIn.close()
+
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(standIn.addr(), mockBroker.BrokerID()).
+			SetLeader(mockChannel.topic(), mockChannel.partition(), mockBroker.BrokerID()),
+	})
+
+	saslBrokerConfig := func(user, password string) *sarama.Config {
+		sasl := localconfig.SASL{Enabled: true, User: user, Password: password}
+		return newBrokerConfig(mockLocalConfig.General.TLS, sasl, mockRetryOptions, mockLocalConfig.Kafka.Version, defaultPartition)
+	}
+
+	t.Run("Authenticated", func(t *testing.T) {
+		producer, err := setupProducerForChannel(mockRetryOptions, make(chan struct{}), []string{standIn.addr()}, saslBrokerConfig("orderer", "secret"), mockChannel)
+		assert.NoError(t, err, "Expected the producer to authenticate with the stand-in")
+		assert.NoError(t, producer.Close())
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		brokerConfig := saslBrokerConfig("orderer", "wrong")
+		_, err := sarama.NewSyncProducer([]string{standIn.addr()}, brokerConfig)
+		assert.Error(t, err)
+
+		classified := classifyConnectionError([]string{standIn.addr()}, brokerConfig, err)
+		assert.IsType(t, &authenticationError{}, classified)
+		assert.Contains(t, classified.Error(), standIn.addr())
+		assert.Contains(t, classified.Error(), "Kafka.SASL")
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		unreachable := listener.Addr().String()
+		listener.Close()
+
+		brokerConfig := saslBrokerConfig("orderer", "secret")
+		_, err = sarama.NewSyncProducer([]string{unreachable}, brokerConfig)
+		assert.Error(t, err)
+		assert.Equal(t, err, classifyConnectionError([]string{unreachable}, brokerConfig, err))
+	})
+
+	t.Run("SASLDisabled", func(t *testing.T) {
+		err := fmt.Errorf("foo")
+		assert.Equal(t, err, classifyConnectionError([]string{standIn.addr()}, mockBrokerConfig, err))
+	})
+}
+
+func TestConnectionErrorClassifier(t *testing.T) {
+	standIn := newSASLStandIn(t, "orderer", "secret", "127.0.0.1:0")
+	defer standIn.close()
+
+	sasl := localconfig.SASL{Enabled: true, User: "orderer", Password: "wrong"}
+	brokerConfig := newBrokerConfig(mockLocalConfig.General.TLS, sasl, mockRetryOptions, mockLocalConfig.Kafka.Version, defaultPartition)
+	classifier := newConnectionErrorClassifier([]string{standIn.addr()}, brokerConfig)
+	err := fmt.Errorf("foo")
+
+	classified := classifier.classify(err)
+	assert.IsType(t, &authenticationError{}, classified)
+	probes := atomic.LoadInt32(&standIn.accepted)
+	assert.NotZero(t, probes)
+
+	assert.Equal(t, classified, classifier.classify(err), "Expected the streak to keep its classification")
+	assert.Equal(t, probes, atomic.LoadInt32(&standIn.accepted), "Expected the brokers to be probed once per streak")
+
+	assert.NoError(t, classifier.classify(nil))
+	assert.IsType(t, &authenticationError{}, classifier.classify(err))
+	assert.True(t, atomic.LoadInt32(&standIn.accepted) > probes, "Expected a new streak to be classified again")
+}
+
+func TestRetryAuthenticationError(t *testing.T) {
+	mockChannel := newChannel(channelNameForTest(t), defaultPartition)
+	authErr := &authenticationError{broker: "127.0.0.1:9092", err: io.EOF}
+	rp := newRetryProcess(mockRetryOptions, make(chan struct{}), mockChannel, "foo", func() error { return authErr })
+	assert.Equal(t, authErr, rp.retry(), "Expected retry to return the authentication error")
+}
+
+// saslStandIn stands in for a Kafka broker requiring SASL/PLAIN authentication:
+// it performs the handshake and the authentication, and then relays the
+// connection to a mock broker
+type saslStandIn struct {
+	t        *testing.T
+	listener net.Listener
+	user     string
+	password string
+	backend  string
+	accepted int32
+	wg       sync.WaitGroup
+}
+
+func newSASLStandIn(t *testing.T, user, password, backend string) *saslStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	s := &saslStandIn{t: t, listener: listener, user: user, password: password, backend: backend}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+func (s *saslStandIn) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *saslStandIn) close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *saslStandIn) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(&s.accepted, 1)
+		go s.handle(conn)
+	}
+}
+
+func (s *saslStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+
+	// SaslHandshakeRequest: api key, version, correlation ID, client ID, mechanism
+	request, err := readFrame(conn)
+	if err != nil || len(request) < 8 {
+		return
+	}
+	correlationID := binary.BigEndian.Uint32(request[4:8])
+	const mechanism = "PLAIN"
+	response := make([]byte, 4+2+4+2+len(mechanism))
+	binary.BigEndian.PutUint32(response[0:], correlationID)
+	binary.BigEndian.PutUint16(response[4:], 0)
+	binary.BigEndian.PutUint32(response[6:], 1)
+	binary.BigEndian.PutUint16(response[10:], uint16(len(mechanism)))
+	copy(response[12:], mechanism)
+	if err := writeFrame(conn, response); err != nil {
+		return
+	}
+
+	// The PLAIN authentication bytes are not wrapped in a Kafka request
+	auth, err := readFrame(conn)
+	if err != nil || string(auth) != "\x00"+s.user+"\x00"+s.password {
+		// Kafka brokers close the connection on failed authentication
+		return
+	}
+	if _, err := conn.Write(make([]byte, 4)); err != nil {
+		return
+	}
+
+	backend, err := net.Dial("tcp", s.backend)
+	if err != nil {
+		return
+	}
+	defer backend.Close()
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(backend, conn); done <- struct{}{} }()
+	go func() { io.Copy(conn, backend); done <- struct{}{} }()
+	<-done
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	frame := make([]byte, binary.BigEndian.Uint32(header))
+	_, err := io.ReadFull(r, frame)
+	return frame, err
+}
+
+func writeFrame(w io.Writer, frame []byte) error {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(frame)))
+	_, err := w.Write(append(header, frame...))
+	return err
+}
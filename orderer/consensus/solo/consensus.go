@@ -18,6 +18,7 @@ package solo
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -40,6 +41,9 @@ type chain struct {
 	support  consensus.ConsenterSupport
 	sendChan chan *message
 	exitChan chan struct{}
+	// doneChan is closed once a started chain stopped writing blocks
+	doneChan chan struct{}
+	started  int32
 }
 
 type message struct {
@@ -65,13 +69,20 @@ func newChain(support consensus.ConsenterSupport) *chain {
 		support:  support,
 		sendChan: make(chan *message),
 		exitChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
 	}
 }
 
 func (ch *chain) Start() {
-	go ch.main()
+	atomic.StoreInt32(&ch.started, 1)
+	go func() {
+		defer close(ch.doneChan)
+		ch.main()
+	}()
 }
 
+// Halt stops the chain and, if it was started, waits for it to stop writing
+// blocks, so that the chain can be handed over to another consenter
 func (ch *chain) Halt() {
 	select {
 	case <-ch.exitChan:
@@ -79,6 +90,9 @@ func (ch *chain) Halt() {
 	default:
 		close(ch.exitChan)
 	}
+	if atomic.LoadInt32(&ch.started) == 1 {
+		<-ch.doneChan
+	}
 }
 
 func (ch *chain) WaitReady() error {
@@ -116,8 +130,17 @@ func (ch *chain) Errored() <-chan struct{} {
 	return ch.exitChan
 }
 
+// batchTimeout returns the batch timeout of the pending batch
+func (ch *chain) batchTimeout() time.Duration {
+	if timeout := ch.support.BlockCutter().Timeout(); timeout > 0 {
+		return timeout
+	}
+	return ch.support.SharedConfig().BatchTimeout()
+}
+
 func (ch *chain) main() {
 	var timer <-chan time.Time
+	var deadline time.Time
 	var err error
 
 	for {
@@ -134,17 +157,21 @@ func (ch *chain) main() {
 						continue
 					}
 				}
-				batches, _ := ch.support.BlockCutter().Ordered(msg.normalMsg)
-				if len(batches) == 0 && timer == nil {
-					timer = time.After(ch.support.SharedConfig().BatchTimeout())
-					continue
-				}
+				batches, pending := ch.support.BlockCutter().Ordered(msg.normalMsg)
 				for _, batch := range batches {
 					block := ch.support.CreateNextBlock(batch)
 					ch.support.WriteBlock(block, nil)
 				}
-				if len(batches) > 0 {
+
+				now := time.Now()
+				timeout := ch.batchTimeout()
+				switch {
+				case !pending:
 					timer = nil
+				case timer == nil || len(batches) > 0 || now.Add(timeout).Before(deadline):
+					// The pending batch is new, or a message of a class with a shorter
+					// batch timeout brought its deadline forward
+					timer, deadline = time.After(timeout), now.Add(timeout)
 				}
 			} else {
 				// ConfigMsg
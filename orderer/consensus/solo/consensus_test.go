@@ -166,6 +166,35 @@ func TestBatchTimer(t *testing.T) {
 	}
 }
 
+func TestBatchTimerOfPendingClass(t *testing.T) {
+	batchTimeout, _ := time.ParseDuration("1h")
+	support := &mockmultichannel.ConsenterSupport{
+		Blocks:          make(chan *cb.Block),
+		BlockCutterVal:  mockblockcutter.NewReceiver(),
+		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
+	}
+	support.BlockCutterVal.TimeoutVal, _ = time.ParseDuration("1ms")
+	defer close(support.BlockCutterVal.Block)
+	bs := newChain(support)
+	wg := goWithWait(bs.main)
+	defer bs.Halt()
+
+	syncQueueMessage(testMessage, bs, support.BlockCutterVal)
+
+	select {
+	case <-support.Blocks:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a block to be cut because of the timeout of the pending class but did not")
+	}
+
+	bs.Halt()
+	select {
+	case <-support.Blocks:
+		t.Fatalf("Expected no invocations of Append")
+	case <-wg.done:
+	}
+}
+
 func TestBatchTimerHaltOnFilledBatch(t *testing.T) {
 	batchTimeout, _ := time.ParseDuration("1h")
 	support := &mockmultichannel.ConsenterSupport{
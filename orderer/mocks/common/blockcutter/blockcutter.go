@@ -17,6 +17,8 @@ limitations under the License.
 package blockcutter
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/op/go-logging"
@@ -44,6 +46,9 @@ type Receiver struct {
 	// CurBatch is the currently outstanding messages in the batch
 	CurBatch []*cb.Envelope
 
+	// TimeoutVal is returned as the result of Timeout()
+	TimeoutVal time.Duration
+
 	// Block is a channel which is read from before returning from Ordered, it is useful for synchronization
 	// If you do not wish synchronization for whatever reason, simply close the channel
 	Block chan struct{}
@@ -99,3 +104,8 @@ func (mbc *Receiver) Cut() []*cb.Envelope {
 	mbc.CurBatch = nil
 	return res
 }
+
+// Timeout returns TimeoutVal
+func (mbc *Receiver) Timeout() time.Duration {
+	return mbc.TimeoutVal
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/external"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/container"
@@ -48,6 +49,9 @@ func checkSpec(spec *pb.ChaincodeSpec) error {
 // getChaincodeDeploymentSpec get chaincode deployment spec given the chaincode spec
 func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.ChaincodeDeploymentSpec, error) {
 	var codePackageBytes []byte
+	if chaincode.IsDevMode() == false && crtPkg && connectionFile != common.UndefinedParamValue {
+		return getExternalChaincodeDeploymentSpec(spec, connectionFile)
+	}
 	if chaincode.IsDevMode() == false && crtPkg {
 		var err error
 		if err = checkSpec(spec); err != nil {
@@ -64,6 +68,33 @@ func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.Chainc
 	return chaincodeDeploymentSpec, nil
 }
 
+// getExternalChaincodeDeploymentSpec returns the deployment spec of a chaincode
+// run as a service, whose code package is made of the given connection file
+func getExternalChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, connFile string) (*pb.ChaincodeDeploymentSpec, error) {
+	if spec == nil {
+		return nil, errors.New("Expected chaincode specification, nil received")
+	}
+
+	contents, err := ioutil.ReadFile(connFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading connection file %s", connFile)
+	}
+	conn := &external.Connection{}
+	if err = json.Unmarshal(contents, conn); err != nil {
+		return nil, errors.Wrapf(err, "invalid connection file %s", connFile)
+	}
+	codePackageBytes, err := external.NewConnectionPackage(conn)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid connection file %s", connFile))
+	}
+
+	return &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: spec,
+		CodePackage:   codePackageBytes,
+		ExecEnv:       pb.ChaincodeDeploymentSpec_EXTERNAL,
+	}, nil
+}
+
 // getChaincodeSpec get chaincode spec from the cli cmd pramameters
 func getChaincodeSpec(cmd *cobra.Command) (*pb.ChaincodeSpec, error) {
 	spec := &pb.ChaincodeSpec{}
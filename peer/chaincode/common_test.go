@@ -18,6 +18,9 @@ package chaincode
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -25,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/core/chaincode/external"
 	"github.com/hyperledger/fabric/peer/common"
 	common2 "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -216,3 +220,36 @@ func TestCollectionParsing(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, cc)
 }
+
+func TestGetExternalChaincodeDeploymentSpec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "connection")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	spec := &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc", Version: "1.0"}}
+
+	connFile := filepath.Join(dir, "connection.json")
+	err = ioutil.WriteFile(connFile, []byte(`{"address": "mycc:9999", "dial_timeout": "10s"}`), 0644)
+	require.NoError(t, err)
+	cds, err := getExternalChaincodeDeploymentSpec(spec, connFile)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.ChaincodeDeploymentSpec_EXTERNAL, cds.ExecEnv)
+	conn, err := external.ParseConnectionPackage(cds.CodePackage)
+	assert.NoError(t, err)
+	assert.Equal(t, "mycc:9999", conn.Address)
+
+	// the package passes the validation of deployment specs
+	_, err = utils.GetChaincodeDeploymentSpec(utils.MarshalOrPanic(cds))
+	assert.NoError(t, err)
+
+	_, err = getExternalChaincodeDeploymentSpec(spec, filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	err = ioutil.WriteFile(connFile, []byte(`{"tls_required": true}`), 0644)
+	require.NoError(t, err)
+	_, err = getExternalChaincodeDeploymentSpec(spec, connFile)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "address not provided")
+
+	_, err = getExternalChaincodeDeploymentSpec(nil, connFile)
+	assert.Error(t, err)
+}
@@ -71,6 +71,7 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 		"path",
 		"name",
 		"version",
+		"connection",
 	}
 	attachFlags(chaincodePackageCmd, flagList)
 
@@ -54,6 +54,15 @@ var (
 	channelID     string
 	channelTxFile string
 	timeout       int
+
+	// listtxs related variables
+	creatorCertFile string
+	creatorMSPID    string
+	chaincodeName   string
+	startTime       string
+	endTime         string
+	startBlock      uint64
+	startTx         uint64
 )
 
 // Cmd returns the cobra command for Node
@@ -67,6 +76,7 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(listtxsCmd(cf))
 
 	return channelCmd
 }
@@ -90,6 +100,13 @@ func resetFlags() {
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create.")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.IntVarP(&timeout, "timeout", "t", 5, "Channel creation timeout")
+	flags.StringVarP(&creatorCertFile, "creator", "", "", "Path to the PEM certificate of the identity which submitted the transactions to list")
+	flags.StringVarP(&creatorMSPID, "mspid", "", "", "MSP ID of the identity which submitted the transactions to list")
+	flags.StringVarP(&chaincodeName, "chaincode", "n", "", "Name of the chaincode invoked by the transactions to list")
+	flags.StringVarP(&startTime, "starttime", "", "", "Start (inclusive) of the time window of the transactions to list, in RFC3339 format")
+	flags.StringVarP(&endTime, "endtime", "", "", "End (exclusive) of the time window of the transactions to list, in RFC3339 format")
+	flags.Uint64VarP(&startBlock, "startblock", "", 0, "Number of the block from which to list the transactions. For a time window, it only applies to the transactions at the start time")
+	flags.Uint64VarP(&startTx, "starttx", "", 0, "Number of the transaction of the start block from which to list the transactions")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
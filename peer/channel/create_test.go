@@ -27,6 +27,7 @@ import (
 	"regexp"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
@@ -104,7 +105,8 @@ var once sync.Once
 
 /// mock deliver client for UT
 type mockDeliverClient struct {
-	err error
+	err       error
+	timestamp time.Time
 }
 
 func (m *mockDeliverClient) readBlock() (*cb.Block, error) {
@@ -126,6 +128,11 @@ func (m *mockDeliverClient) getNewestBlock() (*cb.Block, error) {
 	return m.readBlock()
 }
 
+func (m *mockDeliverClient) getBlockByTimestamp(t time.Time) (*cb.Block, error) {
+	m.timestamp = t
+	return m.readBlock()
+}
+
 func (m *mockDeliverClient) Close() error {
 	return nil
 }
@@ -221,7 +228,7 @@ func TestCreateChainWithWaitSuccess(t *testing.T) {
 	mockCF := &ChannelCmdFactory{
 		BroadcastFactory: mockBroadcastClientFactory,
 		Signer:           signer,
-		DeliverClient:    &mockDeliverClient{nil},
+		DeliverClient:    &mockDeliverClient{},
 	}
 	fakeOrderer := newOrderer(8101, t)
 	defer fakeOrderer.Shutdown()
@@ -253,7 +260,7 @@ func TestCreateChainWithTimeoutErr(t *testing.T) {
 	mockCF := &ChannelCmdFactory{
 		BroadcastFactory: mockBroadcastClientFactory,
 		Signer:           signer,
-		DeliverClient:    &mockDeliverClient{sendErr},
+		DeliverClient:    &mockDeliverClient{err: sendErr},
 	}
 	fakeOrderer := newOrderer(8102, t)
 	defer fakeOrderer.Shutdown()
@@ -333,7 +340,7 @@ func TestCreateChainDeliverFail(t *testing.T) {
 			return common.GetMockBroadcastClient(sendErr), nil
 		},
 		Signer:        signer,
-		DeliverClient: &mockDeliverClient{sendErr},
+		DeliverClient: &mockDeliverClient{err: sendErr},
 	}
 
 	cmd := createCmd(mockCF)
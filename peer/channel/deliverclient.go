@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/util"
 	pcommon "github.com/hyperledger/fabric/peer/common"
@@ -33,6 +34,7 @@ type deliverClientIntf interface {
 	getSpecifiedBlock(num uint64) (*common.Block, error)
 	getOldestBlock() (*common.Block, error)
 	getNewestBlock() (*common.Block, error)
+	getBlockByTimestamp(t time.Time) (*common.Block, error)
 	Close() error
 }
 
@@ -61,13 +63,14 @@ func newDeliverClient(chainID string) (*deliverClient, error) {
 
 func seekHelper(
 	chainID string,
-	position *ab.SeekPosition,
+	start *ab.SeekPosition,
+	stop *ab.SeekPosition,
 	tlsCertHash []byte,
 ) *common.Envelope {
 
 	seekInfo := &ab.SeekInfo{
-		Start:    position,
-		Stop:     position,
+		Start:    start,
+		Stop:     stop,
 		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
 	}
 
@@ -82,22 +85,37 @@ func seekHelper(
 }
 
 func (r *deliverClient) seekSpecified(blockNumber uint64) error {
-	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{
+	position := &ab.SeekPosition{
 		Type: &ab.SeekPosition_Specified{
 			Specified: &ab.SeekSpecified{
-				Number: blockNumber}}}, r.tlsCertHash))
+				Number: blockNumber}}}
+	return r.client.Send(seekHelper(r.chainID, position, position, r.tlsCertHash))
 }
 
 func (r *deliverClient) seekOldest() error {
-	return r.client.Send(seekHelper(r.chainID,
-		&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{
-			Oldest: &ab.SeekOldest{}}}, r.tlsCertHash))
+	position := &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{
+		Oldest: &ab.SeekOldest{}}}
+	return r.client.Send(seekHelper(r.chainID, position, position, r.tlsCertHash))
 }
 
 func (r *deliverClient) seekNewest() error {
+	position := &ab.SeekPosition{Type: &ab.SeekPosition_Newest{
+		Newest: &ab.SeekNewest{}}}
+	return r.client.Send(seekHelper(r.chainID, position, position, r.tlsCertHash))
+}
+
+// seekTimestamp seeks the first block timestamped at or after the given time, which
+// the stop position designates as well when set to the oldest block
+func (r *deliverClient) seekTimestamp(t time.Time) error {
+	timestamp, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return err
+	}
 	return r.client.Send(seekHelper(r.chainID,
-		&ab.SeekPosition{Type: &ab.SeekPosition_Newest{
-			Newest: &ab.SeekNewest{}}}, r.tlsCertHash))
+		&ab.SeekPosition{Type: &ab.SeekPosition_Timestamp{
+			Timestamp: &ab.SeekTimestamp{Timestamp: timestamp}}},
+		&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{
+			Oldest: &ab.SeekOldest{}}}, r.tlsCertHash))
 }
 
 func (r *deliverClient) readBlock() (*common.Block, error) {
@@ -148,6 +166,16 @@ func (r *deliverClient) getNewestBlock() (*common.Block, error) {
 	return r.readBlock()
 }
 
+func (r *deliverClient) getBlockByTimestamp(t time.Time) (*common.Block, error) {
+	err := r.seekTimestamp(t)
+	if err != nil {
+		logger.Errorf("Received error: %s", err)
+		return nil, err
+	}
+
+	return r.readBlock()
+}
+
 func (r *deliverClient) Close() error {
 	return r.client.CloseSend()
 }
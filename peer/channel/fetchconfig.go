@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -29,9 +30,9 @@ import (
 
 func fetchCmd(cf *ChannelCmdFactory) *cobra.Command {
 	fetchCmd := &cobra.Command{
-		Use:   "fetch <newest|oldest|config|(number)> [outputfile]",
+		Use:   "fetch <newest|oldest|config|(number)|(timestamp)> [outputfile]",
 		Short: "Fetch a block",
-		Long:  "Fetch a specified block, writing it to a file.",
+		Long:  "Fetch a specified block, writing it to a file. A timestamp in RFC 3339 format, such as 2018-01-02T15:04:05Z, fetches the first block created at or after that time.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return fetch(cmd, args, cf)
 		},
@@ -54,7 +55,7 @@ func fetch(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
 	}
 
 	if len(args) == 0 {
-		return fmt.Errorf("fetch target required, oldest, newest, config, or a number")
+		return fmt.Errorf("fetch target required, oldest, newest, config, a number or a timestamp")
 	}
 
 	if len(args) > 2 {
@@ -79,11 +80,15 @@ func fetch(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
 		}
 		block, err = cf.DeliverClient.getSpecifiedBlock(lc)
 	default:
-		num, err2 := strconv.Atoi(args[0])
+		if num, err2 := strconv.Atoi(args[0]); err2 == nil {
+			block, err = cf.DeliverClient.getSpecifiedBlock(uint64(num))
+			break
+		}
+		t, err2 := time.Parse(time.RFC3339, args[0])
 		if err2 != nil {
 			return fmt.Errorf("fetch target illegal: %s", args[0])
 		}
-		block, err = cf.DeliverClient.getSpecifiedBlock(uint64(num))
+		block, err = cf.DeliverClient.getBlockByTimestamp(t)
 	}
 
 	if err != nil {
@@ -19,6 +19,7 @@ package channel
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/peer/common"
 	"github.com/stretchr/testify/assert"
@@ -68,3 +69,27 @@ func TestFetchChain(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestFetchBlockByTimestamp(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockchain := "mockchain"
+	deliverClient := &mockDeliverClient{}
+	mockCF := &ChannelCmdFactory{DeliverClient: deliverClient}
+
+	cmd := fetchCmd(mockCF)
+	defer os.Remove(mockchain + ".block")
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockchain, "2018-01-02T15:04:05Z", mockchain + ".block"})
+
+	assert.NoError(t, cmd.Execute(), "fetch by timestamp expected to succeed")
+	assert.True(t, deliverClient.timestamp.Equal(time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC)))
+	_, err := os.Stat(mockchain + ".block")
+	assert.NoError(t, err, "expected the block to be fetched")
+
+	cmd = fetchCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockchain, "yesterday"})
+	assert.EqualError(t, cmd.Execute(), "fetch target illegal: yesterday")
+}
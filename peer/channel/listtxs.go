@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func listtxsCmd(cf *ChannelCmdFactory) *cobra.Command {
+	listtxsCmd := &cobra.Command{
+		Use:   "listtxs",
+		Short: "list the transactions of a specified channel by creator, chaincode or time window.",
+		Long: "list the transactions of a specified channel submitted by an identity ('--creator' and '--mspid'), " +
+			"invoking a chaincode ('--chaincode') or within a time window ('--starttime' and '--endtime'). Requires '-c'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listtxs(cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"creator",
+		"mspid",
+		"chaincode",
+		"starttime",
+		"endtime",
+		"startblock",
+		"starttx",
+	}
+	attachFlags(listtxsCmd, flagList)
+
+	return listtxsCmd
+}
+
+// getListTxsArgs returns the qscc arguments of the transactions query selected by the flags
+func getListTxsArgs() ([][]byte, error) {
+	var queries int
+	for _, selected := range []bool{creatorCertFile != "", chaincodeName != "", startTime != "" || endTime != ""} {
+		if selected {
+			queries++
+		}
+	}
+	if queries != 1 {
+		return nil, errors.New("Must supply exactly one of a creator, a chaincode or a time window")
+	}
+	startBlockArg := []byte(strconv.FormatUint(startBlock, 10))
+	startTxArg := []byte(strconv.FormatUint(startTx, 10))
+
+	switch {
+	case creatorCertFile != "":
+		if creatorMSPID == "" {
+			return nil, errors.New("Must supply the MSP ID of the creator")
+		}
+		cert, err := ioutil.ReadFile(creatorCertFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot read the certificate of the creator")
+		}
+		creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: creatorMSPID, IdBytes: cert})
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{[]byte(qscc.GetTransactionsByCreator), []byte(channelID), creator, startBlockArg, startTxArg}, nil
+	case chaincodeName != "":
+		return [][]byte{[]byte(qscc.GetTransactionsByChaincode), []byte(channelID), []byte(chaincodeName), startBlockArg, startTxArg}, nil
+	default:
+		if startTime == "" || endTime == "" {
+			return nil, errors.New("Must supply both the start and the end of the time window")
+		}
+		return [][]byte{[]byte(qscc.GetTransactionsByTimestamp), []byte(channelID), []byte(startTime), []byte(endTime), startBlockArg, startTxArg}, nil
+	}
+}
+
+func (cc *endorserClient) getTransactions(args [][]byte) (*pb.QueryResponse, error) {
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input:       &pb.ChaincodeInput{Args: args},
+		},
+	}
+
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.GetStatus(), proposalResp.Response.GetMessage())
+	}
+
+	response := &pb.QueryResponse{}
+	if err := proto.Unmarshal(proposalResp.Response.Payload, response); err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+	return response, nil
+}
+
+func listtxs(cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+
+	args, err := getListTxsArgs()
+	if err != nil {
+		return err
+	}
+
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+	response, err := client.getTransactions(args)
+	if err != nil {
+		return err
+	}
+
+	var lastTx *pb.IndexedTransaction
+	var lastTimestamp time.Time
+	for _, result := range response.Results {
+		indexedTx := &pb.IndexedTransaction{}
+		if err := proto.Unmarshal(result.ResultBytes, indexedTx); err != nil {
+			return errors.Wrap(err, "cannot read transaction")
+		}
+		chdr, err := utils.ChannelHeader(indexedTx.ProcessedTransaction.GetTransactionEnvelope())
+		if err != nil {
+			return errors.WithMessage(err, "cannot read transaction header")
+		}
+		timestamp, err := ptypes.Timestamp(chdr.Timestamp)
+		if err != nil {
+			return errors.Wrap(err, "cannot read transaction timestamp")
+		}
+		fmt.Printf("Block [%d] transaction [%d] ID [%s] timestamp [%s] validation code [%s]\n",
+			indexedTx.BlockNumber, indexedTx.TxNumber, chdr.TxId, timestamp.Format(time.RFC3339Nano),
+			pb.TxValidationCode(indexedTx.ProcessedTransaction.ValidationCode))
+		lastTx, lastTimestamp = indexedTx, timestamp
+	}
+	if response.HasMore && lastTx != nil {
+		fmt.Println(moreTransactionsMessage(lastTx, lastTimestamp))
+	}
+
+	return nil
+}
+
+// moreTransactionsMessage tells how to resume the listing after the last transaction
+// listed, the transactions of a time window being ordered by timestamp first
+func moreTransactionsMessage(lastTx *pb.IndexedTransaction, lastTimestamp time.Time) string {
+	resumeFlags := fmt.Sprintf("--startblock %d --starttx %d", lastTx.BlockNumber, lastTx.TxNumber+1)
+	if startTime != "" {
+		resumeFlags = fmt.Sprintf("--starttime %s %s", lastTimestamp.Format(time.RFC3339Nano), resumeFlags)
+	}
+	return fmt.Sprintf("More transactions are available, resume the listing with %s", resumeFlags)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTxs(t *testing.T) {
+	InitMSP()
+
+	chdr := utils.MakeChannelHeader(cb.HeaderType_ENDORSER_TRANSACTION, 0, mockChannel, 0)
+	chdr.TxId = "txid"
+	payload := &cb.Payload{Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(chdr)}}
+	indexedTx := &pb.IndexedTransaction{
+		BlockNumber: 5,
+		TxNumber:    1,
+		ProcessedTransaction: &pb.ProcessedTransaction{
+			TransactionEnvelope: &cb.Envelope{Payload: utils.MarshalOrPanic(payload)},
+		},
+	}
+	mockPayload, err := proto.Marshal(&pb.QueryResponse{
+		Results: []*pb.QueryResultBytes{{ResultBytes: utils.MarshalOrPanic(indexedTx)}},
+		HasMore: true,
+	})
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200, Payload: mockPayload},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	dir, err := ioutil.TempDir("", "listtxs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, ioutil.WriteFile(certFile, []byte("cert"), 0600))
+
+	testCases := []struct {
+		name    string
+		args    []string
+		success bool
+	}{
+		{name: "chaincode", args: []string{"-c", mockChannel, "--chaincode", "mycc", "--startblock", "3"}, success: true},
+		{name: "creator", args: []string{"-c", mockChannel, "--creator", certFile, "--mspid", "Org1MSP"}, success: true},
+		{name: "time window", args: []string{"-c", mockChannel, "--starttime", "2018-01-01T00:00:00Z", "--endtime", "2018-02-01T00:00:00Z"}, success: true},
+		{name: "resumed", args: []string{"-c", mockChannel, "--chaincode", "mycc", "--startblock", "5", "--starttx", "2"}, success: true},
+		{name: "missing channel", args: []string{"--chaincode", "mycc"}},
+		{name: "missing query", args: []string{"-c", mockChannel}},
+		{name: "several queries", args: []string{"-c", mockChannel, "--chaincode", "mycc", "--creator", certFile, "--mspid", "Org1MSP"}},
+		{name: "missing msp id", args: []string{"-c", mockChannel, "--creator", certFile}},
+		{name: "missing certificate", args: []string{"-c", mockChannel, "--creator", filepath.Join(dir, "missing.pem"), "--mspid", "Org1MSP"}},
+		{name: "missing end time", args: []string{"-c", mockChannel, "--starttime", "2018-01-01T00:00:00Z"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resetFlags()
+			cmd := listtxsCmd(mockCF)
+			AddFlags(cmd)
+			cmd.SetArgs(tc.args)
+			if tc.success {
+				assert.NoError(t, cmd.Execute())
+			} else {
+				assert.Error(t, cmd.Execute())
+			}
+		})
+	}
+}
+
+func TestListTxsBadResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	mockCF := &ChannelCmdFactory{
+		EndorserClient: common.GetMockEndorserClient(&pb.ProposalResponse{
+			Response:    &pb.Response{Status: 500, Message: "Attribute not indexed"},
+			Endorsement: &pb.Endorsement{},
+		}, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := listtxsCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--chaincode", "mycc"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Attribute not indexed")
+}
+
+func TestMoreTransactionsMessage(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+
+	lastTx := &pb.IndexedTransaction{BlockNumber: 5, TxNumber: 1}
+	lastTimestamp := time.Date(2018, 1, 2, 3, 4, 5, 6, time.UTC)
+	assert.Equal(t, "More transactions are available, resume the listing with --startblock 5 --starttx 2",
+		moreTransactionsMessage(lastTx, lastTimestamp))
+
+	// the transactions of a time window are resumed from the timestamp of the last one
+	startTime = "2018-01-01T00:00:00Z"
+	assert.Equal(t, "More transactions are available, resume the listing with --starttime 2018-01-02T03:04:05.000000006Z --startblock 5 --starttx 2",
+		moreTransactionsMessage(lastTx, lastTimestamp))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -272,6 +273,16 @@ func serve(args []string) error {
 		certs.TLSClientCert.Store(&clientCert)
 	}
 
+	if err = metrics.Init(metrics.NewOpts()); err != nil {
+		return errors.Wrap(err, "failed initializing metrics")
+	}
+	go func() {
+		if metricsErr := metrics.Start(); metricsErr != nil {
+			logger.Errorf("Error starting metrics server: %s", metricsErr)
+		}
+	}()
+	defer metrics.Shutdown()
+
 	err = service.InitGossipService(serializedIdentity, peerEndpoint.Address, peerServer.Server(), certs,
 		messageCryptoService, secAdv, secureDialOpts, bootstrap...)
 	if err != nil {
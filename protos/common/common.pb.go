@@ -67,6 +67,7 @@ const (
 	HeaderType_DELIVER_SEEK_INFO    HeaderType = 5
 	HeaderType_CHAINCODE_PACKAGE    HeaderType = 6
 	HeaderType_PEER_RESOURCE_UPDATE HeaderType = 7
+	HeaderType_DELIVER_ACK          HeaderType = 8
 )
 
 var HeaderType_name = map[int32]string{
@@ -78,6 +79,7 @@ var HeaderType_name = map[int32]string{
 	5: "DELIVER_SEEK_INFO",
 	6: "CHAINCODE_PACKAGE",
 	7: "PEER_RESOURCE_UPDATE",
+	8: "DELIVER_ACK",
 }
 var HeaderType_value = map[string]int32{
 	"MESSAGE":              0,
@@ -88,6 +90,7 @@ var HeaderType_value = map[string]int32{
 	"DELIVER_SEEK_INFO":    5,
 	"CHAINCODE_PACKAGE":    6,
 	"PEER_RESOURCE_UPDATE": 7,
+	"DELIVER_ACK":          8,
 }
 
 func (x HeaderType) String() string {
@@ -510,65 +513,65 @@ func init() {
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 950 bytes of a gzipped FileDescriptorProto
+	// 953 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x18, 0x5d, 0xc7, 0xf9, 0xd1, 0x7c, 0x6e, 0x5a, 0x77, 0xd2, 0xb2, 0xa6, 0xb0, 0xda, 0xca, 0xb0,
-	0xa8, 0xb4, 0x52, 0x2a, 0xca, 0x05, 0x8e, 0x8e, 0x3d, 0x69, 0xad, 0xa6, 0x76, 0x18, 0x3b, 0x8b,
-	0xd8, 0x45, 0xb2, 0x9c, 0x64, 0x9a, 0x44, 0x24, 0x76, 0x64, 0x4f, 0xaa, 0xf6, 0xcc, 0x1d, 0x21,
-	0xc1, 0x95, 0xbf, 0x82, 0x7f, 0x80, 0x23, 0x7f, 0x10, 0x88, 0x2b, 0x1a, 0x8f, 0xed, 0x4d, 0xca,
-	0x4a, 0x9c, 0x32, 0xef, 0xcd, 0xcb, 0xf7, 0xbd, 0xf9, 0xde, 0xd8, 0x86, 0xf6, 0x38, 0x5e, 0x2e,
-	0xe3, 0xe8, 0x42, 0xfc, 0x74, 0x56, 0x49, 0xcc, 0x62, 0x54, 0x17, 0xe8, 0xf8, 0xe5, 0x34, 0x8e,
-	0xa7, 0x0b, 0x7a, 0x91, 0xb1, 0xa3, 0xf5, 0xdd, 0x05, 0x9b, 0x2f, 0x69, 0xca, 0xc2, 0xe5, 0x4a,
-	0x08, 0x75, 0x1d, 0xa0, 0x1f, 0xa6, 0xcc, 0x8c, 0xa3, 0xbb, 0xf9, 0x14, 0x1d, 0x42, 0x6d, 0x1e,
-	0x4d, 0xe8, 0x83, 0x26, 0x9d, 0x48, 0xa7, 0x55, 0x22, 0x80, 0xfe, 0x16, 0x76, 0x6e, 0x29, 0x0b,
-	0x27, 0x21, 0x0b, 0xb9, 0xe2, 0x3e, 0x5c, 0xac, 0x69, 0xa6, 0xd8, 0x25, 0x02, 0xa0, 0xaf, 0x01,
-	0xd2, 0xf9, 0x34, 0x0a, 0xd9, 0x3a, 0xa1, 0xa9, 0x56, 0x39, 0x91, 0x4f, 0x95, 0xcb, 0x0f, 0x3b,
-	0xb9, 0xa3, 0xe2, 0xbf, 0x5e, 0xa1, 0x20, 0x1b, 0x62, 0xfd, 0x7b, 0x38, 0xf8, 0x8f, 0x00, 0x7d,
-	0x0e, 0x6a, 0x29, 0x09, 0x66, 0x34, 0x9c, 0xd0, 0x24, 0x6f, 0xb8, 0x5f, 0xf2, 0xd7, 0x19, 0x8d,
-	0x3e, 0x86, 0x66, 0x49, 0x69, 0x95, 0x4c, 0xf3, 0x8e, 0xd0, 0xdf, 0x40, 0x3d, 0xd7, 0xbd, 0x82,
-	0xbd, 0xf1, 0x2c, 0x8c, 0x22, 0xba, 0xd8, 0x2e, 0xd8, 0xca, 0xd9, 0x5c, 0xf6, 0xbe, 0xce, 0x95,
-	0xf7, 0x76, 0xd6, 0x7f, 0xac, 0x40, 0xcb, 0xdc, 0xfa, 0x33, 0x82, 0x2a, 0x7b, 0x5c, 0x89, 0xd9,
-	0xd4, 0x48, 0xb6, 0x46, 0x1a, 0x34, 0xee, 0x69, 0x92, 0xce, 0xe3, 0x28, 0xab, 0x53, 0x23, 0x05,
-	0x44, 0x5f, 0x41, 0xb3, 0x4c, 0x43, 0x93, 0x4f, 0xa4, 0x53, 0xe5, 0xf2, 0xb8, 0x23, 0xf2, 0xea,
-	0x14, 0x79, 0x75, 0xfc, 0x42, 0x41, 0xde, 0x89, 0xd1, 0x0b, 0x80, 0xe2, 0x2c, 0xf3, 0x89, 0x56,
-	0x3d, 0x91, 0x4e, 0x9b, 0xa4, 0x99, 0x33, 0xf6, 0x04, 0xb5, 0xa1, 0xc6, 0x1e, 0xf8, 0x4e, 0x2d,
-	0xdb, 0xa9, 0xb2, 0x07, 0x7b, 0xc2, 0x83, 0xa3, 0xab, 0x78, 0x3c, 0xd3, 0xea, 0x22, 0xda, 0x0c,
-	0xf0, 0xe9, 0xd1, 0x07, 0x46, 0xa3, 0xcc, 0x5f, 0x43, 0x4c, 0xaf, 0x24, 0x90, 0x0e, 0x2d, 0xb6,
-	0x48, 0x83, 0x31, 0x4d, 0x58, 0x30, 0x0b, 0xd3, 0x99, 0xb6, 0x93, 0x29, 0x14, 0xb6, 0x48, 0x4d,
-	0x9a, 0xb0, 0xeb, 0x30, 0x9d, 0xe9, 0x06, 0xec, 0x7b, 0x4f, 0x22, 0xd1, 0xa0, 0x31, 0x4e, 0x68,
-	0xc8, 0xe2, 0x62, 0xc6, 0x05, 0xe4, 0x26, 0xa2, 0x38, 0x1a, 0x17, 0x41, 0x09, 0xa0, 0x63, 0x68,
-	0x0c, 0xc2, 0xc7, 0x45, 0x1c, 0x4e, 0xd0, 0x67, 0x50, 0xdf, 0x48, 0x47, 0xb9, 0xdc, 0x2b, 0x2e,
-	0x91, 0x28, 0x4d, 0xf2, 0x5d, 0x3e, 0x69, 0x7e, 0x63, 0xf2, 0x3a, 0xd9, 0x5a, 0xef, 0xc2, 0x0e,
-	0x8e, 0xee, 0xe9, 0x22, 0x16, 0x53, 0x5f, 0x89, 0x92, 0x85, 0x85, 0x1c, 0xfe, 0xcf, 0x7d, 0xf9,
-	0x49, 0x82, 0x5a, 0x77, 0x11, 0x8f, 0x7f, 0x40, 0xe7, 0x4f, 0x9c, 0xb4, 0x0b, 0x27, 0xd9, 0xf6,
-	0x13, 0x3b, 0xaf, 0x36, 0xec, 0x28, 0x97, 0x07, 0x5b, 0x52, 0x2b, 0x64, 0xa1, 0x70, 0x88, 0xbe,
-	0x80, 0x9d, 0x65, 0x7e, 0xd7, 0xf3, 0xc0, 0x8f, 0xb6, 0xa4, 0xc5, 0x83, 0x40, 0x4a, 0x99, 0x3e,
-	0x05, 0x65, 0xa3, 0x21, 0xfa, 0x00, 0xea, 0xd1, 0x7a, 0x39, 0xca, 0x5d, 0x55, 0x49, 0x8e, 0xd0,
-	0x27, 0xd0, 0x5a, 0x25, 0xf4, 0x7e, 0x1e, 0xaf, 0x53, 0x91, 0x94, 0x38, 0xd9, 0x6e, 0x41, 0xf2,
-	0xa8, 0xd0, 0x47, 0xd0, 0xe4, 0x35, 0x85, 0x40, 0xce, 0x04, 0x3b, 0x9c, 0xc8, 0x72, 0x7c, 0x09,
-	0xcd, 0xd2, 0x6e, 0x39, 0x5e, 0xe9, 0x44, 0x2e, 0xc7, 0x7b, 0x0e, 0xad, 0x2d, 0x93, 0xe8, 0x78,
-	0xe3, 0x34, 0x42, 0x58, 0xe2, 0xb3, 0x3f, 0x24, 0xa8, 0x7b, 0x2c, 0x64, 0xeb, 0x14, 0x29, 0xd0,
-	0x18, 0x3a, 0x37, 0x8e, 0xfb, 0xad, 0xa3, 0x3e, 0x43, 0xbb, 0xd0, 0xf0, 0x86, 0xa6, 0x89, 0x3d,
-	0x4f, 0xfd, 0x53, 0x42, 0x2a, 0x28, 0x5d, 0xc3, 0x0a, 0x08, 0xfe, 0x66, 0x88, 0x3d, 0x5f, 0xfd,
-	0x59, 0x46, 0x7b, 0xd0, 0xec, 0xb9, 0xa4, 0x6b, 0x5b, 0x16, 0x76, 0xd4, 0x5f, 0x32, 0xec, 0xb8,
-	0x7e, 0xd0, 0x73, 0x87, 0x8e, 0xa5, 0xfe, 0x2a, 0xa3, 0x17, 0xa0, 0xe5, 0xea, 0x00, 0x3b, 0xbe,
-	0xed, 0x7f, 0x17, 0xf8, 0xae, 0x1b, 0xf4, 0x0d, 0x72, 0x85, 0xd5, 0xdf, 0x64, 0x74, 0x0c, 0x47,
-	0xb6, 0xe3, 0x63, 0xe2, 0x18, 0xfd, 0xc0, 0xc3, 0xe4, 0x35, 0x26, 0x01, 0x26, 0xc4, 0x25, 0xea,
-	0x5f, 0x32, 0x3a, 0x84, 0x7d, 0x5e, 0xca, 0xbe, 0x1d, 0xf4, 0xf1, 0x2d, 0x76, 0x7c, 0x6c, 0xa9,
-	0x7f, 0xcb, 0x48, 0x83, 0x36, 0x17, 0xda, 0x26, 0x0e, 0x86, 0x8e, 0xf1, 0xda, 0xb0, 0xfb, 0x46,
-	0xb7, 0x8f, 0xd5, 0x7f, 0xe4, 0xb3, 0xdf, 0x25, 0x00, 0x31, 0x75, 0x9f, 0x3f, 0xc7, 0x0a, 0x34,
-	0x6e, 0xb1, 0xe7, 0x19, 0x57, 0x58, 0x7d, 0x86, 0x00, 0xea, 0xa6, 0xeb, 0xf4, 0xec, 0x2b, 0x55,
-	0x42, 0x07, 0xd0, 0x12, 0xeb, 0x60, 0x38, 0xb0, 0x0c, 0x1f, 0xab, 0x15, 0xa4, 0xc1, 0x21, 0x76,
-	0x2c, 0x97, 0x78, 0x98, 0x04, 0x3e, 0x31, 0x1c, 0xcf, 0x30, 0x7d, 0xdb, 0x75, 0x54, 0x19, 0x3d,
-	0x87, 0xb6, 0x4b, 0x2c, 0x4c, 0x9e, 0x6c, 0x54, 0xd1, 0x11, 0x1c, 0x58, 0xb8, 0x6f, 0x73, 0xc7,
-	0x1e, 0xc6, 0x37, 0x81, 0xed, 0xf4, 0x5c, 0xb5, 0xc6, 0x69, 0xf3, 0xda, 0xb0, 0x1d, 0xd3, 0xb5,
-	0x70, 0x30, 0x30, 0xcc, 0x1b, 0xde, 0xbf, 0xce, 0x1b, 0x0c, 0x30, 0x26, 0x01, 0xc1, 0x9e, 0x3b,
-	0x24, 0xdc, 0xbb, 0x68, 0xdd, 0x38, 0x7b, 0x0b, 0x68, 0x2b, 0x25, 0x9b, 0xbf, 0xc1, 0xd1, 0x1e,
-	0x80, 0x67, 0x5f, 0x39, 0x86, 0x3f, 0x24, 0xd8, 0x53, 0x9f, 0xa1, 0x7d, 0x50, 0xfa, 0x86, 0xe7,
-	0x07, 0xe5, 0x21, 0x9e, 0x43, 0x7b, 0xc3, 0x8f, 0x17, 0xf4, 0xec, 0xbe, 0x8f, 0x89, 0x5a, 0xe1,
-	0xc7, 0xce, 0x0d, 0xab, 0x72, 0xd7, 0x83, 0x4f, 0xe3, 0x64, 0xda, 0x99, 0x3d, 0xae, 0x68, 0xb2,
-	0xa0, 0x93, 0x29, 0x4d, 0x3a, 0x77, 0xe1, 0x28, 0x99, 0x8f, 0xc5, 0xfb, 0x2a, 0xcd, 0x2f, 0xf3,
-	0x9b, 0xf3, 0xe9, 0x9c, 0xcd, 0xd6, 0x23, 0x0e, 0x2f, 0x36, 0xc4, 0x17, 0x42, 0x2c, 0x3e, 0x46,
-	0x69, 0xfe, 0xc1, 0x1a, 0xd5, 0x33, 0xf8, 0xe5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xe2, 0x37,
-	0xfd, 0x02, 0xc8, 0x06, 0x00, 0x00,
+	0x18, 0xdd, 0xc4, 0xf9, 0xf9, 0xb9, 0x69, 0xa7, 0x93, 0x96, 0x35, 0x85, 0xd5, 0x56, 0x86, 0x45,
+	0xa5, 0x95, 0x52, 0x51, 0x2e, 0x70, 0x74, 0xec, 0x69, 0x6b, 0x35, 0xb5, 0xcb, 0xd8, 0x59, 0xc4,
+	0x2e, 0x92, 0xe5, 0x26, 0xd3, 0x24, 0x22, 0xb1, 0x23, 0x7b, 0x52, 0xb5, 0x67, 0xee, 0x08, 0x09,
+	0xae, 0xfc, 0x2f, 0x1c, 0x38, 0xf0, 0x07, 0x81, 0xb8, 0xa2, 0xf1, 0xd8, 0xde, 0xa4, 0xac, 0xc4,
+	0x29, 0x7e, 0x6f, 0xde, 0x7c, 0xdf, 0x9b, 0xef, 0x4d, 0x6c, 0xe8, 0x8e, 0xe2, 0xc5, 0x22, 0x8e,
+	0x4e, 0xe5, 0x4f, 0x6f, 0x99, 0xc4, 0x3c, 0xc6, 0x0d, 0x89, 0x0e, 0x5e, 0x4e, 0xe2, 0x78, 0x32,
+	0x67, 0xa7, 0x19, 0x7b, 0xbb, 0xba, 0x3b, 0xe5, 0xb3, 0x05, 0x4b, 0x79, 0xb8, 0x58, 0x4a, 0xa1,
+	0xae, 0x03, 0x0c, 0xc2, 0x94, 0x9b, 0x71, 0x74, 0x37, 0x9b, 0xe0, 0x3d, 0xa8, 0xcf, 0xa2, 0x31,
+	0x7b, 0xd0, 0x2a, 0x87, 0x95, 0xa3, 0x1a, 0x95, 0x40, 0x7f, 0x0b, 0xad, 0x6b, 0xc6, 0xc3, 0x71,
+	0xc8, 0x43, 0xa1, 0xb8, 0x0f, 0xe7, 0x2b, 0x96, 0x29, 0xb6, 0xa8, 0x04, 0xf8, 0x6b, 0x80, 0x74,
+	0x36, 0x89, 0x42, 0xbe, 0x4a, 0x58, 0xaa, 0x55, 0x0f, 0x95, 0x23, 0xf5, 0xec, 0xc3, 0x5e, 0xee,
+	0xa8, 0xd8, 0xeb, 0x15, 0x0a, 0xba, 0x26, 0xd6, 0xbf, 0x87, 0xdd, 0xff, 0x08, 0xf0, 0xe7, 0x80,
+	0x4a, 0x49, 0x30, 0x65, 0xe1, 0x98, 0x25, 0x79, 0xc3, 0x9d, 0x92, 0xbf, 0xcc, 0x68, 0xfc, 0x31,
+	0xb4, 0x4b, 0x4a, 0xab, 0x66, 0x9a, 0x77, 0x84, 0xfe, 0x06, 0x1a, 0xb9, 0xee, 0x15, 0x6c, 0x8f,
+	0xa6, 0x61, 0x14, 0xb1, 0xf9, 0x66, 0xc1, 0x4e, 0xce, 0xe6, 0xb2, 0xf7, 0x75, 0xae, 0xbe, 0xb7,
+	0xb3, 0xfe, 0x63, 0x15, 0x3a, 0xe6, 0xc6, 0x66, 0x0c, 0x35, 0xfe, 0xb8, 0x94, 0xb3, 0xa9, 0xd3,
+	0xec, 0x19, 0x6b, 0xd0, 0xbc, 0x67, 0x49, 0x3a, 0x8b, 0xa3, 0xac, 0x4e, 0x9d, 0x16, 0x10, 0x7f,
+	0x05, 0xed, 0x32, 0x0d, 0x4d, 0x39, 0xac, 0x1c, 0xa9, 0x67, 0x07, 0x3d, 0x99, 0x57, 0xaf, 0xc8,
+	0xab, 0xe7, 0x17, 0x0a, 0xfa, 0x4e, 0x8c, 0x5f, 0x00, 0x14, 0x67, 0x99, 0x8d, 0xb5, 0xda, 0x61,
+	0xe5, 0xa8, 0x4d, 0xdb, 0x39, 0x63, 0x8f, 0x71, 0x17, 0xea, 0xfc, 0x41, 0xac, 0xd4, 0xb3, 0x95,
+	0x1a, 0x7f, 0xb0, 0xc7, 0x22, 0x38, 0xb6, 0x8c, 0x47, 0x53, 0xad, 0x21, 0xa3, 0xcd, 0x80, 0x98,
+	0x1e, 0x7b, 0xe0, 0x2c, 0xca, 0xfc, 0x35, 0xe5, 0xf4, 0x4a, 0x02, 0xeb, 0xd0, 0xe1, 0xf3, 0x34,
+	0x18, 0xb1, 0x84, 0x07, 0xd3, 0x30, 0x9d, 0x6a, 0xad, 0x4c, 0xa1, 0xf2, 0x79, 0x6a, 0xb2, 0x84,
+	0x5f, 0x86, 0xe9, 0x54, 0x37, 0x60, 0xc7, 0x7b, 0x12, 0x89, 0x06, 0xcd, 0x51, 0xc2, 0x42, 0x1e,
+	0x17, 0x33, 0x2e, 0xa0, 0x30, 0x11, 0xc5, 0xd1, 0xa8, 0x08, 0x4a, 0x02, 0x9d, 0x40, 0xf3, 0x26,
+	0x7c, 0x9c, 0xc7, 0xe1, 0x18, 0x7f, 0x06, 0x8d, 0xb5, 0x74, 0xd4, 0xb3, 0xed, 0xe2, 0x12, 0xc9,
+	0xd2, 0xb4, 0x31, 0x2d, 0x27, 0x2d, 0x6e, 0x4c, 0x5e, 0x27, 0x7b, 0xd6, 0xfb, 0xd0, 0x22, 0xd1,
+	0x3d, 0x9b, 0xc7, 0x72, 0xea, 0x4b, 0x59, 0xb2, 0xb0, 0x90, 0xc3, 0xff, 0xb9, 0x2f, 0x3f, 0x55,
+	0xa0, 0xde, 0x9f, 0xc7, 0xa3, 0x1f, 0xf0, 0xc9, 0x13, 0x27, 0xdd, 0xc2, 0x49, 0xb6, 0xfc, 0xc4,
+	0xce, 0xab, 0x35, 0x3b, 0xea, 0xd9, 0xee, 0x86, 0xd4, 0x0a, 0x79, 0x28, 0x1d, 0xe2, 0x2f, 0xa0,
+	0xb5, 0xc8, 0xef, 0x7a, 0x1e, 0xf8, 0xfe, 0x86, 0xb4, 0xf8, 0x23, 0xd0, 0x52, 0xa6, 0x4f, 0x40,
+	0x5d, 0x6b, 0x88, 0x3f, 0x80, 0x46, 0xb4, 0x5a, 0xdc, 0xe6, 0xae, 0x6a, 0x34, 0x47, 0xf8, 0x13,
+	0xe8, 0x2c, 0x13, 0x76, 0x3f, 0x8b, 0x57, 0xa9, 0x4c, 0x4a, 0x9e, 0x6c, 0xab, 0x20, 0x45, 0x54,
+	0xf8, 0x23, 0x68, 0x8b, 0x9a, 0x52, 0xa0, 0x64, 0x82, 0x96, 0x20, 0xb2, 0x1c, 0x5f, 0x42, 0xbb,
+	0xb4, 0x5b, 0x8e, 0xb7, 0x72, 0xa8, 0x94, 0xe3, 0x3d, 0x81, 0xce, 0x86, 0x49, 0x7c, 0xb0, 0x76,
+	0x1a, 0x29, 0x2c, 0xf1, 0xf1, 0xef, 0x15, 0x68, 0x78, 0x3c, 0xe4, 0xab, 0x14, 0xab, 0xd0, 0x1c,
+	0x3a, 0x57, 0x8e, 0xfb, 0xad, 0x83, 0x9e, 0xe1, 0x2d, 0x68, 0x7a, 0x43, 0xd3, 0x24, 0x9e, 0x87,
+	0xfe, 0xac, 0x60, 0x04, 0x6a, 0xdf, 0xb0, 0x02, 0x4a, 0xbe, 0x19, 0x12, 0xcf, 0x47, 0x3f, 0x2b,
+	0x78, 0x1b, 0xda, 0xe7, 0x2e, 0xed, 0xdb, 0x96, 0x45, 0x1c, 0xf4, 0x4b, 0x86, 0x1d, 0xd7, 0x0f,
+	0xce, 0xdd, 0xa1, 0x63, 0xa1, 0x5f, 0x15, 0xfc, 0x02, 0xb4, 0x5c, 0x1d, 0x10, 0xc7, 0xb7, 0xfd,
+	0xef, 0x02, 0xdf, 0x75, 0x83, 0x81, 0x41, 0x2f, 0x08, 0xfa, 0x4d, 0xc1, 0x07, 0xb0, 0x6f, 0x3b,
+	0x3e, 0xa1, 0x8e, 0x31, 0x08, 0x3c, 0x42, 0x5f, 0x13, 0x1a, 0x10, 0x4a, 0x5d, 0x8a, 0xfe, 0x52,
+	0xf0, 0x1e, 0xec, 0x88, 0x52, 0xf6, 0xf5, 0xcd, 0x80, 0x5c, 0x13, 0xc7, 0x27, 0x16, 0xfa, 0x5b,
+	0xc1, 0x1a, 0x74, 0x85, 0xd0, 0x36, 0x49, 0x30, 0x74, 0x8c, 0xd7, 0x86, 0x3d, 0x30, 0xfa, 0x03,
+	0x82, 0xfe, 0x51, 0x8e, 0xff, 0xa8, 0x00, 0xc8, 0xa9, 0xfb, 0xe2, 0x7f, 0xac, 0x42, 0xf3, 0x9a,
+	0x78, 0x9e, 0x71, 0x41, 0xd0, 0x33, 0x0c, 0xd0, 0x30, 0x5d, 0xe7, 0xdc, 0xbe, 0x40, 0x15, 0xbc,
+	0x0b, 0x1d, 0xf9, 0x1c, 0x0c, 0x6f, 0x2c, 0xc3, 0x27, 0xa8, 0x8a, 0x35, 0xd8, 0x23, 0x8e, 0xe5,
+	0x52, 0x8f, 0xd0, 0xc0, 0xa7, 0x86, 0xe3, 0x19, 0xa6, 0x6f, 0xbb, 0x0e, 0x52, 0xf0, 0x73, 0xe8,
+	0xba, 0xd4, 0x22, 0xf4, 0xc9, 0x42, 0x0d, 0xef, 0xc3, 0xae, 0x45, 0x06, 0xb6, 0x70, 0xec, 0x11,
+	0x72, 0x15, 0xd8, 0xce, 0xb9, 0x8b, 0xea, 0x82, 0x36, 0x2f, 0x0d, 0xdb, 0x31, 0x5d, 0x8b, 0x04,
+	0x37, 0x86, 0x79, 0x25, 0xfa, 0x37, 0x44, 0x83, 0x1b, 0x42, 0x68, 0x40, 0x89, 0xe7, 0x0e, 0xa9,
+	0x49, 0x8a, 0xd6, 0x4d, 0xbc, 0x03, 0x6a, 0x51, 0xc7, 0x30, 0xaf, 0x50, 0xeb, 0xf8, 0x2d, 0xe0,
+	0x8d, 0xd8, 0x6c, 0xf1, 0x4a, 0xc7, 0xdb, 0x00, 0x9e, 0x7d, 0xe1, 0x18, 0xfe, 0x90, 0x12, 0x0f,
+	0x3d, 0x13, 0xdb, 0x06, 0x86, 0xe7, 0x07, 0xe5, 0xa9, 0x9e, 0x43, 0x77, 0xcd, 0xa0, 0x17, 0x9c,
+	0xdb, 0x03, 0x9f, 0x50, 0x54, 0x15, 0x73, 0xc8, 0x4f, 0x80, 0x94, 0xbe, 0x07, 0x9f, 0xc6, 0xc9,
+	0xa4, 0x37, 0x7d, 0x5c, 0xb2, 0x64, 0xce, 0xc6, 0x13, 0x96, 0xf4, 0xee, 0xc2, 0xdb, 0x64, 0x36,
+	0x92, 0x2f, 0xb0, 0x34, 0xbf, 0xdd, 0x6f, 0x4e, 0x26, 0x33, 0x3e, 0x5d, 0xdd, 0x0a, 0x78, 0xba,
+	0x26, 0x3e, 0x95, 0x62, 0xf9, 0x75, 0x4a, 0xf3, 0x2f, 0xd8, 0x6d, 0x23, 0x83, 0x5f, 0xfe, 0x3b,
+	0x00, 0xac, 0x48, 0x51, 0x6c, 0xd9, 0x06, 0x00, 0x00,
 }
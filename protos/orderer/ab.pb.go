@@ -14,9 +14,13 @@ It has these top-level messages:
 	SeekNewest
 	SeekOldest
 	SeekSpecified
+	SeekTimestamp
 	SeekPosition
 	SeekInfo
 	DeliverResponse
+	FlowControl
+	DeliverAck
+	ResumeToken
 	ConsensusType
 	BatchSize
 	BatchTimeout
@@ -34,6 +38,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import common "github.com/hyperledger/fabric/protos/common"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -70,7 +75,7 @@ var SeekInfo_SeekBehavior_value = map[string]int32{
 func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
-func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
+func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6, 0} }
 
 type BroadcastResponse struct {
 	// Status code, which may be used to programatically respond to success/failure
@@ -130,18 +135,38 @@ func (m *SeekSpecified) GetNumber() uint64 {
 	return 0
 }
 
+// SeekTimestamp designates the first block whose transactions are timestamped at or
+// after the given time as a start position, and the last block whose transactions are
+// timestamped at or before it as a stop position
+type SeekTimestamp struct {
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *SeekTimestamp) Reset()                    { *m = SeekTimestamp{} }
+func (m *SeekTimestamp) String() string            { return proto.CompactTextString(m) }
+func (*SeekTimestamp) ProtoMessage()               {}
+func (*SeekTimestamp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SeekTimestamp) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 type SeekPosition struct {
 	// Types that are valid to be assigned to Type:
 	//	*SeekPosition_Newest
 	//	*SeekPosition_Oldest
 	//	*SeekPosition_Specified
+	//	*SeekPosition_Timestamp
 	Type isSeekPosition_Type `protobuf_oneof:"Type"`
 }
 
 func (m *SeekPosition) Reset()                    { *m = SeekPosition{} }
 func (m *SeekPosition) String() string            { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()               {}
-func (*SeekPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*SeekPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 type isSeekPosition_Type interface {
 	isSeekPosition_Type()
@@ -156,10 +181,14 @@ type SeekPosition_Oldest struct {
 type SeekPosition_Specified struct {
 	Specified *SeekSpecified `protobuf:"bytes,3,opt,name=specified,oneof"`
 }
+type SeekPosition_Timestamp struct {
+	Timestamp *SeekTimestamp `protobuf:"bytes,4,opt,name=timestamp,oneof"`
+}
 
 func (*SeekPosition_Newest) isSeekPosition_Type()    {}
 func (*SeekPosition_Oldest) isSeekPosition_Type()    {}
 func (*SeekPosition_Specified) isSeekPosition_Type() {}
+func (*SeekPosition_Timestamp) isSeekPosition_Type() {}
 
 func (m *SeekPosition) GetType() isSeekPosition_Type {
 	if m != nil {
@@ -189,12 +218,20 @@ func (m *SeekPosition) GetSpecified() *SeekSpecified {
 	return nil
 }
 
+func (m *SeekPosition) GetTimestamp() *SeekTimestamp {
+	if x, ok := m.GetType().(*SeekPosition_Timestamp); ok {
+		return x.Timestamp
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*SeekPosition) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _SeekPosition_OneofMarshaler, _SeekPosition_OneofUnmarshaler, _SeekPosition_OneofSizer, []interface{}{
 		(*SeekPosition_Newest)(nil),
 		(*SeekPosition_Oldest)(nil),
 		(*SeekPosition_Specified)(nil),
+		(*SeekPosition_Timestamp)(nil),
 	}
 }
 
@@ -217,6 +254,11 @@ func _SeekPosition_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Specified); err != nil {
 			return err
 		}
+	case *SeekPosition_Timestamp:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Timestamp); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("SeekPosition.Type has unexpected type %T", x)
@@ -251,6 +293,14 @@ func _SeekPosition_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.B
 		err := b.DecodeMessage(msg)
 		m.Type = &SeekPosition_Specified{msg}
 		return true, err
+	case 4: // Type.timestamp
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SeekTimestamp)
+		err := b.DecodeMessage(msg)
+		m.Type = &SeekPosition_Timestamp{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -275,6 +325,11 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *SeekPosition_Timestamp:
+		s := proto.Size(x.Timestamp)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -291,15 +346,18 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // as they are created, behavior should be set to BLOCK_UNTIL_READY and the stop should be set to
 // specified with a number of MAX_UINT64
 type SeekInfo struct {
-	Start    *SeekPosition         `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
-	Stop     *SeekPosition         `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
-	Behavior SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	Start        *SeekPosition         `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
+	Stop         *SeekPosition         `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
+	Behavior     SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	FlowControl  *FlowControl          `protobuf:"bytes,4,opt,name=flow_control,json=flowControl" json:"flow_control,omitempty"`
+	ResumeTokens bool                  `protobuf:"varint,5,opt,name=resume_tokens,json=resumeTokens" json:"resume_tokens,omitempty"`
+	ResumeToken  []byte                `protobuf:"bytes,6,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (m *SeekInfo) Reset()                    { *m = SeekInfo{} }
 func (m *SeekInfo) String() string            { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()               {}
-func (*SeekInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*SeekInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *SeekInfo) GetStart() *SeekPosition {
 	if m != nil {
@@ -322,17 +380,39 @@ func (m *SeekInfo) GetBehavior() SeekInfo_SeekBehavior {
 	return SeekInfo_BLOCK_UNTIL_READY
 }
 
+func (m *SeekInfo) GetFlowControl() *FlowControl {
+	if m != nil {
+		return m.FlowControl
+	}
+	return nil
+}
+
+func (m *SeekInfo) GetResumeTokens() bool {
+	if m != nil {
+		return m.ResumeTokens
+	}
+	return false
+}
+
+func (m *SeekInfo) GetResumeToken() []byte {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return nil
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
-	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
+	Type        isDeliverResponse_Type `protobuf_oneof:"Type"`
+	ResumeToken []byte                 `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()               {}
-func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 type isDeliverResponse_Type interface {
 	isDeliverResponse_Type()
@@ -369,6 +449,13 @@ func (m *DeliverResponse) GetBlock() *common.Block {
 	return nil
 }
 
+func (m *DeliverResponse) GetResumeToken() []byte {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
@@ -438,14 +525,94 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// FlowControl asks the deliver service to wait for the acknowledgment of the
+// blocks it sent before sending more than window of them
+type FlowControl struct {
+	Window uint32 `protobuf:"varint,1,opt,name=window" json:"window,omitempty"`
+}
+
+func (m *FlowControl) Reset()                    { *m = FlowControl{} }
+func (m *FlowControl) String() string            { return proto.CompactTextString(m) }
+func (*FlowControl) ProtoMessage()               {}
+func (*FlowControl) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *FlowControl) GetWindow() uint32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+// DeliverAck acknowledges all the blocks up to and including number
+type DeliverAck struct {
+	Number uint64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+}
+
+func (m *DeliverAck) Reset()                    { *m = DeliverAck{} }
+func (m *DeliverAck) String() string            { return proto.CompactTextString(m) }
+func (*DeliverAck) ProtoMessage()               {}
+func (*DeliverAck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *DeliverAck) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+// ResumeToken is the content of the opaque resume tokens issued by the deliver service
+type ResumeToken struct {
+	ChannelId string                `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Next      uint64                `protobuf:"varint,2,opt,name=next" json:"next,omitempty"`
+	Stop      uint64                `protobuf:"varint,3,opt,name=stop" json:"stop,omitempty"`
+	Behavior  SeekInfo_SeekBehavior `protobuf:"varint,4,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+}
+
+func (m *ResumeToken) Reset()                    { *m = ResumeToken{} }
+func (m *ResumeToken) String() string            { return proto.CompactTextString(m) }
+func (*ResumeToken) ProtoMessage()               {}
+func (*ResumeToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ResumeToken) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ResumeToken) GetNext() uint64 {
+	if m != nil {
+		return m.Next
+	}
+	return 0
+}
+
+func (m *ResumeToken) GetStop() uint64 {
+	if m != nil {
+		return m.Stop
+	}
+	return 0
+}
+
+func (m *ResumeToken) GetBehavior() SeekInfo_SeekBehavior {
+	if m != nil {
+		return m.Behavior
+	}
+	return SeekInfo_BLOCK_UNTIL_READY
+}
+
 func init() {
 	proto.RegisterType((*BroadcastResponse)(nil), "orderer.BroadcastResponse")
 	proto.RegisterType((*SeekNewest)(nil), "orderer.SeekNewest")
 	proto.RegisterType((*SeekOldest)(nil), "orderer.SeekOldest")
 	proto.RegisterType((*SeekSpecified)(nil), "orderer.SeekSpecified")
+	proto.RegisterType((*SeekTimestamp)(nil), "orderer.SeekTimestamp")
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
+	proto.RegisterType((*FlowControl)(nil), "orderer.FlowControl")
+	proto.RegisterType((*DeliverAck)(nil), "orderer.DeliverAck")
+	proto.RegisterType((*ResumeToken)(nil), "orderer.ResumeToken")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
 }
 
@@ -625,37 +792,50 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0x12, 0x51,
-	0x10, 0xc6, 0x59, 0xa5, 0xb4, 0x8c, 0x2d, 0xa5, 0xa7, 0x69, 0x43, 0xb8, 0x30, 0xcd, 0x26, 0x55,
-	0x8c, 0xba, 0x6b, 0x30, 0xf1, 0x42, 0x4d, 0x0c, 0x6b, 0xdb, 0x40, 0x24, 0x60, 0x16, 0x7a, 0xa1,
-	0x37, 0x64, 0x77, 0x19, 0x60, 0xed, 0xb2, 0x67, 0x73, 0xce, 0x01, 0xd3, 0xa7, 0xf0, 0x45, 0x7c,
-	0x24, 0x1f, 0xc6, 0x9c, 0x3f, 0xbb, 0x48, 0xd5, 0x5e, 0xb1, 0x33, 0xf3, 0xfb, 0xce, 0x7c, 0x33,
-	0x19, 0xa0, 0x4e, 0xd9, 0x14, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x8c, 0x51, 0x41, 0xc9, 0xae, 0xc9,
-	0x34, 0x8f, 0x23, 0xba, 0x5c, 0xd2, 0xd4, 0xd5, 0x3f, 0xba, 0x6a, 0x0f, 0xe1, 0xc8, 0x63, 0x34,
-	0x98, 0x46, 0x01, 0x17, 0x3e, 0xf2, 0x8c, 0xa6, 0x1c, 0xc9, 0x13, 0xa8, 0x70, 0x11, 0x88, 0x15,
-	0x6f, 0x58, 0x67, 0x56, 0xab, 0xd6, 0xae, 0x39, 0x46, 0x33, 0x52, 0x59, 0xdf, 0x54, 0x09, 0x81,
-	0x72, 0x9c, 0xce, 0x68, 0xe3, 0xc1, 0x99, 0xd5, 0xaa, 0xfa, 0xea, 0xdb, 0xde, 0x07, 0x18, 0x21,
-	0xde, 0x0c, 0xf0, 0x3b, 0x72, 0x91, 0x47, 0xc3, 0x64, 0x2a, 0xa3, 0xa7, 0x70, 0x20, 0xa3, 0x51,
-	0x86, 0x51, 0x3c, 0x8b, 0x71, 0x4a, 0x4e, 0xa1, 0x92, 0xae, 0x96, 0x21, 0x32, 0xd5, 0xa8, 0xec,
-	0x9b, 0xc8, 0xfe, 0x69, 0xc1, 0xbe, 0x24, 0x3f, 0x53, 0x1e, 0x8b, 0x98, 0xa6, 0xe4, 0x25, 0x54,
-	0x52, 0xf5, 0xa2, 0x02, 0x1f, 0xb5, 0x8f, 0x1d, 0x33, 0x95, 0xb3, 0x69, 0xd6, 0x2d, 0xf9, 0x06,
-	0x92, 0x38, 0x55, 0x2d, 0x95, 0xb5, 0xbb, 0xb8, 0x76, 0x23, 0x71, 0x0d, 0x91, 0x37, 0x50, 0xe5,
-	0xb9, 0xa7, 0xc6, 0x43, 0xa5, 0x38, 0xdd, 0x52, 0x14, 0x8e, 0xbb, 0x25, 0x7f, 0x83, 0x7a, 0x15,
-	0x28, 0x8f, 0x6f, 0x33, 0xb4, 0x7f, 0x59, 0xb0, 0x27, 0xb1, 0x5e, 0x3a, 0xa3, 0xe4, 0x39, 0xec,
-	0x70, 0x11, 0xb0, 0xdc, 0xe9, 0xc9, 0xd6, 0x43, 0xf9, 0x40, 0xbe, 0x66, 0xc8, 0x33, 0x28, 0x73,
-	0x41, 0x33, 0x63, 0xf3, 0x3f, 0xac, 0x42, 0xc8, 0x5b, 0xd8, 0x0b, 0x71, 0x11, 0xac, 0x63, 0xca,
-	0x94, 0xc7, 0x5a, 0xfb, 0xf1, 0x16, 0x2e, 0x9b, 0xab, 0x0f, 0xcf, 0x50, 0x7e, 0xc1, 0xdb, 0xef,
-	0xf5, 0x3a, 0xf3, 0x0a, 0x39, 0x81, 0x23, 0xaf, 0x3f, 0xfc, 0xf8, 0x69, 0x72, 0x3d, 0x18, 0xf7,
-	0xfa, 0x13, 0xff, 0xb2, 0x73, 0xf1, 0xa5, 0x5e, 0x92, 0xe9, 0xab, 0x4e, 0xaf, 0x3f, 0xe9, 0x5d,
-	0x4d, 0x06, 0xc3, 0xb1, 0x49, 0x5b, 0xf6, 0x37, 0x38, 0xbc, 0xc0, 0x24, 0x5e, 0x23, 0x2b, 0x2e,
-	0xa4, 0x75, 0xff, 0x85, 0xc8, 0xdd, 0x9a, 0x1b, 0x39, 0x87, 0x9d, 0x30, 0xa1, 0xd1, 0x8d, 0x19,
-	0xf1, 0x20, 0x07, 0x3d, 0x99, 0xec, 0x96, 0x7c, 0x5d, 0xcd, 0x57, 0xd9, 0xfe, 0x61, 0xc1, 0x61,
-	0x47, 0xd0, 0x65, 0x1c, 0x15, 0x67, 0x49, 0x3e, 0x40, 0x75, 0x13, 0xd4, 0xf3, 0x07, 0x2e, 0xd3,
-	0x35, 0x26, 0x34, 0xc3, 0x66, 0xb3, 0x58, 0xc3, 0x5f, 0x97, 0x6c, 0x97, 0x5a, 0xd6, 0x2b, 0x8b,
-	0xbc, 0x83, 0x5d, 0x33, 0xc0, 0x3f, 0xe4, 0x8d, 0x42, 0x7e, 0x67, 0x48, 0x2d, 0xf6, 0xae, 0xe1,
-	0x9c, 0xb2, 0xb9, 0xb3, 0xb8, 0xcd, 0x90, 0x25, 0x38, 0x9d, 0x23, 0x73, 0x66, 0x41, 0xc8, 0xe2,
-	0x48, 0xff, 0x83, 0x78, 0x2e, 0xff, 0xfa, 0x62, 0x1e, 0x8b, 0xc5, 0x2a, 0x94, 0x0d, 0xdc, 0x3f,
-	0x68, 0x57, 0xd3, 0xae, 0xa6, 0x5d, 0x43, 0x87, 0x15, 0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x88, 0xa4, 0x39, 0xb1, 0x03, 0x00, 0x00,
+	// 712 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0x6d, 0x6f, 0xda, 0x48,
+	0x10, 0xc7, 0x31, 0x21, 0x24, 0x0c, 0x90, 0x87, 0xcd, 0x25, 0xb2, 0x90, 0xee, 0x8e, 0xf3, 0x5d,
+	0xae, 0x54, 0x6d, 0x4d, 0x45, 0xa5, 0xb6, 0x6a, 0x2b, 0x55, 0x90, 0x07, 0x05, 0x35, 0x0a, 0xd5,
+	0x86, 0xbc, 0x68, 0xdf, 0x20, 0x3f, 0x2c, 0x60, 0x61, 0xbc, 0xd6, 0xae, 0x09, 0xcd, 0x87, 0xa8,
+	0xda, 0x2f, 0xd8, 0x77, 0xfd, 0x20, 0xd5, 0x3e, 0xd8, 0x90, 0x92, 0x46, 0xea, 0x2b, 0xef, 0xcc,
+	0xfe, 0x66, 0x76, 0xfe, 0xe3, 0x9d, 0x85, 0x1d, 0xca, 0x7c, 0xc2, 0x08, 0x6b, 0x3a, 0xae, 0x1d,
+	0x33, 0x9a, 0x50, 0xb4, 0xa1, 0x3d, 0xb5, 0x3d, 0x8f, 0x4e, 0xa7, 0x34, 0x6a, 0xaa, 0x8f, 0xda,
+	0xad, 0xfd, 0x3d, 0xa2, 0x74, 0x14, 0x92, 0xa6, 0xb4, 0xdc, 0xd9, 0xb0, 0x99, 0x04, 0x53, 0xc2,
+	0x13, 0x67, 0x1a, 0x2b, 0xc0, 0xea, 0xc1, 0x6e, 0x87, 0x51, 0xc7, 0xf7, 0x1c, 0x9e, 0x60, 0xc2,
+	0x63, 0x1a, 0x71, 0x82, 0xfe, 0x87, 0x22, 0x4f, 0x9c, 0x64, 0xc6, 0x4d, 0xa3, 0x6e, 0x34, 0xb6,
+	0x5a, 0x5b, 0xb6, 0x4e, 0x7a, 0x29, 0xbd, 0x58, 0xef, 0x22, 0x04, 0x85, 0x20, 0x1a, 0x52, 0x33,
+	0x5f, 0x37, 0x1a, 0x25, 0x2c, 0xd7, 0x56, 0x05, 0xe0, 0x92, 0x90, 0xc9, 0x05, 0x99, 0x13, 0x9e,
+	0xa4, 0x56, 0x2f, 0xf4, 0x85, 0xf5, 0x00, 0xaa, 0xc2, 0xba, 0x8c, 0x89, 0x17, 0x0c, 0x03, 0xe2,
+	0xa3, 0x03, 0x28, 0x46, 0xb3, 0xa9, 0x4b, 0x98, 0x3c, 0xa8, 0x80, 0xb5, 0x65, 0x75, 0x15, 0xd8,
+	0x4f, 0x8b, 0x45, 0x2f, 0xa1, 0x94, 0x55, 0x2e, 0xd9, 0x72, 0xab, 0x66, 0x2b, 0x6d, 0x76, 0xaa,
+	0xcd, 0xce, 0x70, 0xbc, 0x80, 0xad, 0xef, 0x06, 0x54, 0x44, 0xae, 0xf7, 0x94, 0x07, 0x49, 0x40,
+	0x23, 0xf4, 0x04, 0x8a, 0x91, 0x2c, 0x4e, 0xe7, 0xd9, 0xb3, 0x75, 0x07, 0xed, 0x45, 0xdd, 0x67,
+	0x39, 0xac, 0x21, 0x81, 0x53, 0x59, 0xbd, 0x99, 0xbf, 0x03, 0x57, 0xc2, 0x04, 0xae, 0x20, 0xf4,
+	0x1c, 0x4a, 0x3c, 0x95, 0x67, 0xae, 0xc9, 0x88, 0x83, 0x5b, 0x11, 0x99, 0xf8, 0xb3, 0x1c, 0x5e,
+	0xa0, 0x22, 0x6e, 0x21, 0xb0, 0x70, 0x47, 0x5c, 0x26, 0x4e, 0xc4, 0x65, 0x68, 0xa7, 0x08, 0x85,
+	0xfe, 0x4d, 0x4c, 0xac, 0x6f, 0x79, 0xd8, 0x14, 0x58, 0x37, 0x1a, 0x52, 0xf4, 0x08, 0xd6, 0x79,
+	0xe2, 0xb0, 0x54, 0xe1, 0xfe, 0xad, 0x44, 0x69, 0x23, 0xb0, 0x62, 0xd0, 0x43, 0x28, 0xf0, 0x84,
+	0xc6, 0x66, 0xfe, 0x3e, 0x56, 0x22, 0xe8, 0x15, 0x6c, 0xba, 0x64, 0xec, 0x5c, 0x07, 0x94, 0x49,
+	0x6d, 0x5b, 0xad, 0xbf, 0x6e, 0xe1, 0xe2, 0x70, 0xb9, 0xe8, 0x68, 0x0a, 0x67, 0x3c, 0x7a, 0x01,
+	0x95, 0x61, 0x48, 0xe7, 0x03, 0x8f, 0x46, 0x09, 0xa3, 0xa1, 0xd6, 0xf8, 0x47, 0x16, 0x7f, 0x1a,
+	0xd2, 0xf9, 0x91, 0xda, 0xc3, 0xe5, 0xe1, 0xc2, 0x40, 0xff, 0x42, 0x95, 0x11, 0x3e, 0x9b, 0x92,
+	0x41, 0x42, 0x27, 0x24, 0xe2, 0xe6, 0x7a, 0xdd, 0x68, 0x6c, 0xe2, 0x8a, 0x72, 0xf6, 0xa5, 0x0f,
+	0xfd, 0x03, 0x95, 0x65, 0xc8, 0x2c, 0xd6, 0x8d, 0x46, 0x05, 0x97, 0x97, 0x18, 0xeb, 0x0d, 0x54,
+	0x96, 0x4b, 0x43, 0xfb, 0xb0, 0xdb, 0x39, 0xef, 0x1d, 0xbd, 0x1b, 0x5c, 0x5d, 0xf4, 0xbb, 0xe7,
+	0x03, 0x7c, 0xd2, 0x3e, 0xfe, 0xb0, 0x93, 0x13, 0xee, 0xd3, 0x76, 0xf7, 0x7c, 0xd0, 0x3d, 0x1d,
+	0x5c, 0xf4, 0xfa, 0xda, 0x6d, 0x58, 0x9f, 0x0d, 0xd8, 0x3e, 0x26, 0x61, 0x70, 0x4d, 0x58, 0x36,
+	0x26, 0x8d, 0xfb, 0xc7, 0x44, 0xdc, 0x0a, 0x3d, 0x28, 0x87, 0xb0, 0xee, 0x86, 0xd4, 0x9b, 0xe8,
+	0x26, 0x57, 0x53, 0xb0, 0x23, 0x9c, 0x67, 0x39, 0xac, 0x76, 0x57, 0x54, 0xac, 0xad, 0xa8, 0xc8,
+	0xfe, 0xf7, 0x21, 0x94, 0x97, 0x3a, 0x26, 0x06, 0x69, 0x1e, 0x44, 0x3e, 0x9d, 0xcb, 0x52, 0xaa,
+	0x58, 0x5b, 0xd6, 0x7f, 0x00, 0xba, 0xea, 0xb6, 0x37, 0xf9, 0xe5, 0xb8, 0x7d, 0x35, 0xa0, 0x8c,
+	0x17, 0x87, 0xa0, 0x3f, 0x01, 0xbc, 0xb1, 0x13, 0x45, 0x24, 0x1c, 0x04, 0xbe, 0x64, 0x4b, 0xb8,
+	0xa4, 0x3d, 0x5d, 0x5f, 0x8c, 0x7d, 0x44, 0x3e, 0xa9, 0x81, 0x28, 0x60, 0xb9, 0x16, 0x3e, 0x79,
+	0x8b, 0xd6, 0x94, 0x6f, 0xe5, 0xba, 0x14, 0x7e, 0xef, 0xba, 0xb4, 0xbe, 0x18, 0xb0, 0xdd, 0x4e,
+	0xe8, 0x34, 0xf0, 0xb2, 0xe7, 0x09, 0xbd, 0x85, 0xd2, 0xc2, 0xd8, 0x49, 0x7b, 0x78, 0x12, 0x5d,
+	0x93, 0x90, 0xc6, 0xa4, 0x56, 0xcb, 0x92, 0xaf, 0xbc, 0x68, 0x56, 0xae, 0x61, 0x3c, 0x35, 0xd0,
+	0x6b, 0xd8, 0xd0, 0xdd, 0xb8, 0x23, 0xdc, 0xcc, 0xc2, 0x7f, 0xfa, 0xcf, 0x2a, 0xb8, 0x73, 0x05,
+	0x87, 0x94, 0x8d, 0xec, 0xf1, 0x4d, 0x4c, 0x58, 0x48, 0xfc, 0x11, 0x61, 0xf6, 0xd0, 0x71, 0x59,
+	0xe0, 0xa9, 0x07, 0x88, 0xa7, 0xe1, 0x1f, 0x1f, 0x8f, 0x82, 0x64, 0x3c, 0x73, 0xc5, 0x01, 0xcd,
+	0x25, 0xba, 0xa9, 0x68, 0xf5, 0x14, 0xf3, 0xa6, 0xa6, 0xdd, 0xa2, 0xb4, 0x9f, 0xfd, 0x18, 0x00,
+	0x55, 0x09, 0x77, 0x11, 0xda, 0x05, 0x00, 0x00,
 }
@@ -12,8 +12,37 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// State defines the orderer mode of operation, typically for consensus-type migration.
+// NORMAL is during normal operation, when consensus-type migration is not, and can not, take place.
+// MAINTENANCE is when the consensus-type can be changed.
+type ConsensusType_State int32
+
+const (
+	ConsensusType_STATE_NORMAL      ConsensusType_State = 0
+	ConsensusType_STATE_MAINTENANCE ConsensusType_State = 1
+)
+
+var ConsensusType_State_name = map[int32]string{
+	0: "STATE_NORMAL",
+	1: "STATE_MAINTENANCE",
+}
+var ConsensusType_State_value = map[string]int32{
+	"STATE_NORMAL":      0,
+	"STATE_MAINTENANCE": 1,
+}
+
+func (x ConsensusType_State) String() string {
+	return proto.EnumName(ConsensusType_State_name, int32(x))
+}
+func (ConsensusType_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0, 0} }
+
 type ConsensusType struct {
+	// The consensus type: "solo" or "kafka".
 	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// Opaque metadata, dependent on the consensus type.
+	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The state signals the ordering service to go into maintenance mode, typically for consensus-type migration.
+	State ConsensusType_State `protobuf:"varint,3,opt,name=state,enum=orderer.ConsensusType_State" json:"state,omitempty"`
 }
 
 func (m *ConsensusType) Reset()                    { *m = ConsensusType{} }
@@ -28,6 +57,20 @@ func (m *ConsensusType) GetType() string {
 	return ""
 }
 
+func (m *ConsensusType) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ConsensusType) GetState() ConsensusType_State {
+	if m != nil {
+		return m.State
+	}
+	return ConsensusType_STATE_NORMAL
+}
+
 type BatchSize struct {
 	// Simply specified as number of messages for now, in the future
 	// we may want to allow this to be specified by size in bytes
@@ -38,6 +81,13 @@ type BatchSize struct {
 	// The byte count of the serialized messages in a batch should not
 	// exceed this value.
 	PreferredMaxBytes uint32 `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes" json:"preferred_max_bytes,omitempty"`
+	// The name of the classifier sorting the messages into the classes
+	// below, no classes apply when unset.
+	Classifier string `protobuf:"bytes,4,opt,name=classifier" json:"classifier,omitempty"`
+	// The batch sizes of the classes, in decreasing order of priority.
+	// Messages of other classes have the lowest priority and the batch
+	// size above.
+	Classes []*ClassBatchSize `protobuf:"bytes,5,rep,name=classes" json:"classes,omitempty"`
 }
 
 func (m *BatchSize) Reset()                    { *m = BatchSize{} }
@@ -66,10 +116,27 @@ func (m *BatchSize) GetPreferredMaxBytes() uint32 {
 	return 0
 }
 
+func (m *BatchSize) GetClassifier() string {
+	if m != nil {
+		return m.Classifier
+	}
+	return ""
+}
+
+func (m *BatchSize) GetClasses() []*ClassBatchSize {
+	if m != nil {
+		return m.Classes
+	}
+	return nil
+}
+
 type BatchTimeout struct {
 	// Any duration string parseable by ParseDuration():
 	// https://golang.org/pkg/time/#ParseDuration
 	Timeout string `protobuf:"bytes,1,opt,name=timeout" json:"timeout,omitempty"`
+	// The batch timeouts of the classes of the batch size, the timeout
+	// above applies to the classes not listed.
+	Classes []*ClassBatchTimeout `protobuf:"bytes,2,rep,name=classes" json:"classes,omitempty"`
 }
 
 func (m *BatchTimeout) Reset()                    { *m = BatchTimeout{} }
@@ -84,6 +151,13 @@ func (m *BatchTimeout) GetTimeout() string {
 	return ""
 }
 
+func (m *BatchTimeout) GetClasses() []*ClassBatchTimeout {
+	if m != nil {
+		return m.Classes
+	}
+	return nil
+}
+
 // Carries a list of bootstrap brokers, i.e. this is not the exclusive set of
 // brokers an ordering service
 type KafkaBrokers struct {
@@ -121,36 +195,227 @@ func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	return 0
 }
 
+// RateLimit is a token bucket limit on the messages broadcast to the orderer
+type RateLimit struct {
+	MessagesPerSecond uint32 `protobuf:"varint,1,opt,name=messages_per_second,json=messagesPerSecond" json:"messages_per_second,omitempty"`
+	Burst             uint32 `protobuf:"varint,2,opt,name=burst" json:"burst,omitempty"`
+}
+
+func (m *RateLimit) Reset()                    { *m = RateLimit{} }
+func (m *RateLimit) String() string            { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()               {}
+func (*RateLimit) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+func (m *RateLimit) GetMessagesPerSecond() uint32 {
+	if m != nil {
+		return m.MessagesPerSecond
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+// RateLimits is the message which conveys the broadcast rate limits of a channel, an unset limit
+// leaves the one of the orderer local configuration in place
+type RateLimits struct {
+	Channel      *RateLimit `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Organization *RateLimit `protobuf:"bytes,2,opt,name=organization" json:"organization,omitempty"`
+	Client       *RateLimit `protobuf:"bytes,3,opt,name=client" json:"client,omitempty"`
+}
+
+func (m *RateLimits) Reset()                    { *m = RateLimits{} }
+func (m *RateLimits) String() string            { return proto.CompactTextString(m) }
+func (*RateLimits) ProtoMessage()               {}
+func (*RateLimits) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+func (m *RateLimits) GetChannel() *RateLimit {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *RateLimits) GetOrganization() *RateLimit {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *RateLimits) GetClient() *RateLimit {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+// ClassBatchSize is the batch size of a class of messages, a value of 0 leaves
+// the one of the channel in place
+type ClassBatchSize struct {
+	Class             string `protobuf:"bytes,1,opt,name=class" json:"class,omitempty"`
+	MaxMessageCount   uint32 `protobuf:"varint,2,opt,name=max_message_count,json=maxMessageCount" json:"max_message_count,omitempty"`
+	PreferredMaxBytes uint32 `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes" json:"preferred_max_bytes,omitempty"`
+}
+
+func (m *ClassBatchSize) Reset()                    { *m = ClassBatchSize{} }
+func (m *ClassBatchSize) String() string            { return proto.CompactTextString(m) }
+func (*ClassBatchSize) ProtoMessage()               {}
+func (*ClassBatchSize) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *ClassBatchSize) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
+}
+
+func (m *ClassBatchSize) GetMaxMessageCount() uint32 {
+	if m != nil {
+		return m.MaxMessageCount
+	}
+	return 0
+}
+
+func (m *ClassBatchSize) GetPreferredMaxBytes() uint32 {
+	if m != nil {
+		return m.PreferredMaxBytes
+	}
+	return 0
+}
+
+// ClassBatchTimeout is the batch timeout of a class of messages
+type ClassBatchTimeout struct {
+	Class   string `protobuf:"bytes,1,opt,name=class" json:"class,omitempty"`
+	Timeout string `protobuf:"bytes,2,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *ClassBatchTimeout) Reset()                    { *m = ClassBatchTimeout{} }
+func (m *ClassBatchTimeout) String() string            { return proto.CompactTextString(m) }
+func (*ClassBatchTimeout) ProtoMessage()               {}
+func (*ClassBatchTimeout) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+func (m *ClassBatchTimeout) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
+}
+
+func (m *ClassBatchTimeout) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+// AdmissionRule selects a rule the orderer applies to the messages broadcast to the channel
+type AdmissionRule struct {
+	Name    string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Options map[string]string `protobuf:"bytes,2,rep,name=options" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *AdmissionRule) Reset()                    { *m = AdmissionRule{} }
+func (m *AdmissionRule) String() string            { return proto.CompactTextString(m) }
+func (*AdmissionRule) ProtoMessage()               {}
+func (*AdmissionRule) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *AdmissionRule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AdmissionRule) GetOptions() map[string]string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// AdmissionRules is the message which conveys the rules the orderer applies, in order and in
+// addition to its standard filters, to the messages broadcast to the channel
+type AdmissionRules struct {
+	Rules []*AdmissionRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty"`
+}
+
+func (m *AdmissionRules) Reset()                    { *m = AdmissionRules{} }
+func (m *AdmissionRules) String() string            { return proto.CompactTextString(m) }
+func (*AdmissionRules) ProtoMessage()               {}
+func (*AdmissionRules) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *AdmissionRules) GetRules() []*AdmissionRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
 	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterType((*RateLimit)(nil), "orderer.RateLimit")
+	proto.RegisterType((*RateLimits)(nil), "orderer.RateLimits")
+	proto.RegisterType((*ClassBatchSize)(nil), "orderer.ClassBatchSize")
+	proto.RegisterType((*ClassBatchTimeout)(nil), "orderer.ClassBatchTimeout")
+	proto.RegisterType((*AdmissionRule)(nil), "orderer.AdmissionRule")
+	proto.RegisterType((*AdmissionRules)(nil), "orderer.AdmissionRules")
+	proto.RegisterEnum("orderer.ConsensusType_State", ConsensusType_State_name, ConsensusType_State_value)
 }
 
 func init() { proto.RegisterFile("orderer/configuration.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0xd0, 0xcd, 0x4a, 0xc3, 0x40,
-	0x10, 0x07, 0x70, 0x62, 0x8b, 0xb5, 0x8b, 0x45, 0xbb, 0xbd, 0x04, 0x7a, 0x29, 0x11, 0xa1, 0x48,
-	0x49, 0x40, 0xdf, 0x20, 0x3d, 0x4a, 0x2f, 0xb1, 0x5e, 0xbc, 0x94, 0x4d, 0x3a, 0x49, 0x96, 0x36,
-	0x3b, 0x61, 0x76, 0x03, 0x89, 0xef, 0xe1, 0xfb, 0xca, 0x6e, 0x52, 0xed, 0x6d, 0x3e, 0x7e, 0x0b,
-	0xb3, 0x7f, 0xb6, 0x44, 0x3a, 0x02, 0x01, 0x45, 0x19, 0xaa, 0x5c, 0x16, 0x0d, 0x09, 0x23, 0x51,
-	0x85, 0x35, 0xa1, 0x41, 0x3e, 0x19, 0x96, 0xc1, 0x13, 0x9b, 0x6d, 0x51, 0x69, 0x50, 0xba, 0xd1,
-	0xfb, 0xae, 0x06, 0xce, 0xd9, 0xd8, 0x74, 0x35, 0xf8, 0xde, 0xca, 0x5b, 0x4f, 0x13, 0x57, 0x07,
-	0x3f, 0x1e, 0x9b, 0xc6, 0xc2, 0x64, 0xe5, 0x87, 0xfc, 0x06, 0xfe, 0xc2, 0xe6, 0x95, 0x68, 0x0f,
-	0x15, 0x68, 0x2d, 0x0a, 0x38, 0x64, 0xd8, 0x28, 0xe3, 0xf8, 0x2c, 0x79, 0xa8, 0x44, 0xbb, 0xeb,
-	0xe7, 0x5b, 0x3b, 0xe6, 0x1b, 0xc6, 0x45, 0xaa, 0xf1, 0xdc, 0x18, 0x38, 0xd8, 0x47, 0x69, 0x67,
-	0x40, 0xfb, 0x37, 0x0e, 0x3f, 0x5e, 0x36, 0x3b, 0xd1, 0xc6, 0x76, 0xce, 0x43, 0xb6, 0xa8, 0x09,
-	0x72, 0x20, 0x82, 0xe3, 0x15, 0x1f, 0x39, 0x3e, 0xff, 0x5b, 0x5d, 0x7c, 0xb0, 0x66, 0xf7, 0xee,
-	0xac, 0xbd, 0xac, 0x00, 0x1b, 0xc3, 0x7d, 0x36, 0x31, 0x7d, 0x39, 0x9c, 0x7f, 0x69, 0xad, 0x7c,
-	0x17, 0xf9, 0x49, 0xc4, 0x84, 0x27, 0x20, 0x6d, 0x65, 0xda, 0x97, 0xbe, 0xb7, 0x1a, 0x59, 0x39,
-	0xb4, 0xc1, 0x2b, 0x5b, 0x6c, 0x4b, 0xa1, 0x14, 0x9c, 0x13, 0xd0, 0x86, 0x64, 0x66, 0x53, 0xd3,
-	0x7c, 0xc9, 0xa6, 0xf6, 0xa0, 0xff, 0xcf, 0x8e, 0x93, 0xbb, 0x4a, 0xb4, 0xee, 0x97, 0xf1, 0x27,
-	0x7b, 0x46, 0x2a, 0xc2, 0xb2, 0xab, 0x81, 0xce, 0x70, 0x2c, 0x80, 0xc2, 0x5c, 0xa4, 0x24, 0xb3,
-	0x3e, 0x6d, 0x1d, 0x0e, 0x69, 0x7f, 0x6d, 0x0a, 0x69, 0xca, 0x26, 0x0d, 0x33, 0xac, 0xa2, 0x2b,
-	0x1d, 0xf5, 0x3a, 0xea, 0x75, 0x34, 0xe8, 0xf4, 0xd6, 0xf5, 0x6f, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x0c, 0x47, 0xa2, 0x55, 0xca, 0x01, 0x00, 0x00,
+	// 686 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5f, 0x6f, 0x12, 0x4f,
+	0x14, 0xfd, 0x2d, 0x94, 0x52, 0x6e, 0xa1, 0x3f, 0x98, 0xfa, 0x67, 0xd3, 0x1a, 0x43, 0xd6, 0x98,
+	0x90, 0xa6, 0x59, 0x14, 0x8d, 0x31, 0x4d, 0x34, 0x01, 0xc2, 0x83, 0xb1, 0x50, 0x5d, 0xf0, 0xc5,
+	0x07, 0xc9, 0xb0, 0x5c, 0x60, 0x52, 0x76, 0x87, 0xcc, 0xcc, 0x1a, 0xe8, 0xa3, 0x1f, 0xc4, 0x37,
+	0xbf, 0x9b, 0x1f, 0xc3, 0xcc, 0xec, 0xb2, 0x40, 0xa4, 0x0f, 0xbe, 0xdd, 0x3f, 0xe7, 0x9e, 0xbd,
+	0x73, 0xee, 0xc9, 0xc2, 0x39, 0x17, 0x63, 0x14, 0x28, 0xea, 0x3e, 0x0f, 0x27, 0x6c, 0x1a, 0x09,
+	0xaa, 0x18, 0x0f, 0xdd, 0x85, 0xe0, 0x8a, 0x93, 0x7c, 0xd2, 0x74, 0x7e, 0x59, 0x50, 0x6a, 0xf3,
+	0x50, 0x62, 0x28, 0x23, 0x39, 0x58, 0x2d, 0x90, 0x10, 0x38, 0x50, 0xab, 0x05, 0xda, 0x56, 0xd5,
+	0xaa, 0x15, 0x3c, 0x13, 0x93, 0x33, 0x38, 0x0a, 0x50, 0xd1, 0x31, 0x55, 0xd4, 0xce, 0x54, 0xad,
+	0x5a, 0xd1, 0x4b, 0x73, 0xd2, 0x80, 0x9c, 0x54, 0x54, 0xa1, 0x9d, 0xad, 0x5a, 0xb5, 0x93, 0xc6,
+	0x13, 0x37, 0xa1, 0x76, 0x77, 0x68, 0xdd, 0xbe, 0xc6, 0x78, 0x31, 0xd4, 0x79, 0x01, 0x39, 0x93,
+	0x93, 0x32, 0x14, 0xfb, 0x83, 0xe6, 0xa0, 0x33, 0xec, 0xdd, 0x78, 0xdd, 0xe6, 0x75, 0xf9, 0x3f,
+	0xf2, 0x10, 0x2a, 0x71, 0xa5, 0xdb, 0xfc, 0xd0, 0x1b, 0x74, 0x7a, 0xcd, 0x5e, 0xbb, 0x53, 0xb6,
+	0x9c, 0xdf, 0x16, 0x14, 0x5a, 0x54, 0xf9, 0xb3, 0x3e, 0xbb, 0x43, 0x72, 0x01, 0x95, 0x80, 0x2e,
+	0x87, 0x01, 0x4a, 0x49, 0xa7, 0x38, 0xf4, 0x79, 0x14, 0x2a, 0xb3, 0x70, 0xc9, 0xfb, 0x3f, 0xa0,
+	0xcb, 0x6e, 0x5c, 0x6f, 0xeb, 0x32, 0xb9, 0x04, 0x42, 0x47, 0x92, 0xcf, 0x23, 0x85, 0x43, 0x3d,
+	0x34, 0x5a, 0x29, 0x94, 0xe6, 0x15, 0x25, 0xaf, 0xbc, 0xee, 0x74, 0xe9, 0xb2, 0xa5, 0xeb, 0xc4,
+	0x85, 0xd3, 0x85, 0xc0, 0x09, 0x0a, 0x81, 0xe3, 0x2d, 0x78, 0xd6, 0xc0, 0x2b, 0x69, 0x2b, 0xc5,
+	0x3f, 0x05, 0xf0, 0xe7, 0x54, 0x4a, 0x36, 0x61, 0x28, 0xec, 0x03, 0xa3, 0xd9, 0x56, 0x85, 0xbc,
+	0x84, 0xbc, 0xc9, 0x50, 0xda, 0xb9, 0x6a, 0xb6, 0x76, 0xdc, 0x78, 0xbc, 0xd1, 0x47, 0xd7, 0xd3,
+	0x37, 0x79, 0x6b, 0x9c, 0xf3, 0x0d, 0x8a, 0xa6, 0x3a, 0x60, 0x01, 0xf2, 0x48, 0x11, 0x1b, 0xf2,
+	0x2a, 0x0e, 0x93, 0x9b, 0xac, 0x53, 0xf2, 0x7a, 0x43, 0x9e, 0x31, 0xe4, 0x67, 0x7b, 0xc8, 0x13,
+	0x9a, 0x0d, 0x7f, 0x0d, 0x8a, 0x1f, 0xe9, 0xe4, 0x96, 0xb6, 0x04, 0xbf, 0x45, 0x21, 0x35, 0xff,
+	0x28, 0x0e, 0x6d, 0xab, 0x9a, 0xd5, 0xfc, 0x49, 0xea, 0x34, 0xe0, 0xb4, 0x3d, 0xa3, 0x61, 0x88,
+	0x73, 0x0f, 0xa5, 0x12, 0xcc, 0xd7, 0x0e, 0x92, 0xe4, 0x1c, 0x0a, 0x5a, 0x99, 0x8d, 0xea, 0x07,
+	0xde, 0x51, 0x40, 0x97, 0x46, 0x6e, 0xe7, 0x33, 0x14, 0x3c, 0xaa, 0xf0, 0x9a, 0x05, 0x4c, 0x69,
+	0x35, 0x93, 0x1b, 0xc9, 0xe1, 0x02, 0xc5, 0x50, 0xa2, 0xcf, 0xc3, 0x71, 0x72, 0xa9, 0xca, 0xba,
+	0xf5, 0x09, 0x45, 0xdf, 0x34, 0xc8, 0x03, 0xc8, 0x8d, 0x22, 0x21, 0x55, 0x72, 0x9e, 0x38, 0x71,
+	0x7e, 0x5a, 0x00, 0x29, 0xa7, 0x24, 0x97, 0x90, 0xf7, 0xe3, 0xad, 0x0c, 0xd1, 0x71, 0x83, 0xa4,
+	0xaf, 0x4e, 0x51, 0xde, 0x1a, 0x42, 0xde, 0x40, 0x91, 0x8b, 0x29, 0x0d, 0xd9, 0x9d, 0xf1, 0xbf,
+	0x9d, 0xb9, 0x77, 0x64, 0x07, 0x47, 0x2e, 0xe0, 0xd0, 0x9f, 0x33, 0x0c, 0x95, 0x9d, 0xbd, 0x77,
+	0x22, 0x41, 0x38, 0x3f, 0x2c, 0x38, 0xd9, 0xbd, 0xa6, 0x7e, 0x89, 0xd1, 0x3b, 0x39, 0x59, 0x9c,
+	0xec, 0xf7, 0x6d, 0x66, 0xbf, 0x6f, 0xff, 0xd1, 0x89, 0x4e, 0x1b, 0x2a, 0x7f, 0x1d, 0xfd, 0x9e,
+	0x35, 0xb6, 0x1c, 0x95, 0xd9, 0x71, 0x94, 0x96, 0xba, 0xd4, 0x1c, 0x07, 0x4c, 0x4a, 0xc6, 0x43,
+	0x2f, 0x9a, 0x9b, 0xdf, 0x41, 0x48, 0x83, 0xf4, 0x77, 0xa0, 0x63, 0xf2, 0x0e, 0xf2, 0x7c, 0x61,
+	0xbc, 0x90, 0xf8, 0xee, 0x59, 0x2a, 0xce, 0xce, 0xb0, 0x7b, 0x13, 0xa3, 0x3a, 0xa1, 0x12, 0x2b,
+	0x6f, 0x3d, 0x73, 0x76, 0x05, 0xc5, 0xed, 0x06, 0x29, 0x43, 0xf6, 0x16, 0x57, 0xc9, 0x17, 0x74,
+	0xa8, 0xd7, 0xfe, 0x4e, 0xe7, 0x11, 0x26, 0xeb, 0xc5, 0xc9, 0x55, 0xe6, 0xad, 0xe5, 0xbc, 0x87,
+	0x93, 0x9d, 0x4f, 0x68, 0x3b, 0xe4, 0x84, 0x0e, 0x8c, 0x79, 0x8f, 0x1b, 0x8f, 0xf6, 0xaf, 0xe2,
+	0xc5, 0xa0, 0xd6, 0x17, 0x78, 0xce, 0xc5, 0xd4, 0x9d, 0xad, 0x16, 0x28, 0xe6, 0x38, 0x9e, 0xa2,
+	0x70, 0x27, 0x74, 0x24, 0x98, 0x1f, 0xff, 0x18, 0xe5, 0x7a, 0xfa, 0xeb, 0xe5, 0x94, 0xa9, 0x59,
+	0x34, 0x72, 0x7d, 0x1e, 0xd4, 0xb7, 0xd0, 0xf5, 0x18, 0x5d, 0x8f, 0xd1, 0xf5, 0x04, 0x3d, 0x3a,
+	0x34, 0xf9, 0xab, 0x3f, 0x03, 0x00, 0x02, 0xf7, 0x61, 0x62, 0x75, 0x05, 0x00, 0x00,
 }
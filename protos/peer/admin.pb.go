@@ -40,6 +40,12 @@ It has these top-level messages:
 	QueryStateClose
 	QueryResultBytes
 	QueryResponse
+	GetStateMultiple
+	GetStateMultipleResult
+	WriteRecord
+	WriteBatchState
+	ChaincodeAdditionalParams
+	HistoryQueryOptions
 	AnchorPeers
 	AnchorPeer
 	ChaincodeReg
@@ -82,6 +88,7 @@ It has these top-level messages:
 	TransactionAction
 	ChaincodeActionPayload
 	ChaincodeEndorsedAction
+	IndexedTransaction
 */
 package peer
 
@@ -68,17 +68,20 @@ func (ChaincodeSpec_Type) EnumDescriptor() ([]byte, []int) { return fileDescript
 type ChaincodeDeploymentSpec_ExecutionEnvironment int32
 
 const (
-	ChaincodeDeploymentSpec_DOCKER ChaincodeDeploymentSpec_ExecutionEnvironment = 0
-	ChaincodeDeploymentSpec_SYSTEM ChaincodeDeploymentSpec_ExecutionEnvironment = 1
+	ChaincodeDeploymentSpec_DOCKER   ChaincodeDeploymentSpec_ExecutionEnvironment = 0
+	ChaincodeDeploymentSpec_SYSTEM   ChaincodeDeploymentSpec_ExecutionEnvironment = 1
+	ChaincodeDeploymentSpec_EXTERNAL ChaincodeDeploymentSpec_ExecutionEnvironment = 2
 )
 
 var ChaincodeDeploymentSpec_ExecutionEnvironment_name = map[int32]string{
 	0: "DOCKER",
 	1: "SYSTEM",
+	2: "EXTERNAL",
 }
 var ChaincodeDeploymentSpec_ExecutionEnvironment_value = map[string]int32{
-	"DOCKER": 0,
-	"SYSTEM": 1,
+	"DOCKER":   0,
+	"SYSTEM":   1,
+	"EXTERNAL": 2,
 }
 
 func (x ChaincodeDeploymentSpec_ExecutionEnvironment) String() string {
@@ -289,46 +292,47 @@ func init() {
 func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
+	// 663 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6f, 0xda, 0x4a,
 	0x14, 0x8d, 0x81, 0x7c, 0x5d, 0x03, 0xcf, 0x6f, 0x1e, 0xef, 0x3d, 0xc4, 0xa6, 0xd4, 0x9b, 0xd2,
-	0xa8, 0x32, 0x12, 0x8d, 0xaa, 0xaa, 0xaa, 0x22, 0x11, 0xec, 0x44, 0x6e, 0x29, 0x44, 0x0e, 0xa9,
-	0xd4, 0x6e, 0x90, 0xb1, 0x2f, 0x66, 0x14, 0x33, 0x63, 0xd9, 0x83, 0x15, 0xd6, 0xfd, 0x41, 0xfd,
-	0x07, 0xfd, 0x6b, 0xad, 0x66, 0x1c, 0x48, 0xd2, 0x64, 0xd9, 0x15, 0x73, 0x0f, 0xe7, 0x7e, 0x9c,
-	0x33, 0xd7, 0x03, 0x8d, 0x04, 0x31, 0xed, 0x06, 0x0b, 0x9f, 0xb2, 0x80, 0x87, 0x68, 0x25, 0x29,
-	0x17, 0x9c, 0xec, 0xa9, 0x9f, 0xac, 0xf5, 0x2c, 0xe2, 0x3c, 0x8a, 0xb1, 0xab, 0xc2, 0xd9, 0x6a,
-	0xde, 0x15, 0x74, 0x89, 0x99, 0xf0, 0x97, 0x49, 0x41, 0x34, 0xc7, 0xa0, 0x0f, 0x36, 0xb9, 0xae,
-	0x4d, 0x08, 0x54, 0x12, 0x5f, 0x2c, 0x9a, 0x5a, 0x5b, 0xeb, 0x1c, 0x7a, 0xea, 0x2c, 0x31, 0xe6,
-	0x2f, 0xb1, 0x59, 0x2a, 0x30, 0x79, 0x26, 0x4d, 0xd8, 0xcf, 0x31, 0xcd, 0x28, 0x67, 0xcd, 0xb2,
-	0x82, 0x37, 0xa1, 0xf9, 0x5d, 0x83, 0xfa, 0x5d, 0x45, 0x96, 0xac, 0x84, 0x2c, 0xe0, 0xa7, 0x51,
-	0xd6, 0xd4, 0xda, 0xe5, 0x4e, 0xd5, 0x53, 0x67, 0xe2, 0x82, 0x1e, 0x62, 0xc0, 0x53, 0x5f, 0x50,
-	0xce, 0xb2, 0x66, 0xa9, 0x5d, 0xee, 0xe8, 0xbd, 0x17, 0xc5, 0x50, 0x99, 0xf5, 0xb0, 0x80, 0x65,
-	0xdf, 0x31, 0x1d, 0x26, 0xd2, 0xb5, 0x77, 0x3f, 0xb7, 0x75, 0x02, 0xc6, 0xef, 0x04, 0x62, 0x40,
-	0xf9, 0x1a, 0xd7, 0xb7, 0x32, 0xe4, 0x91, 0x34, 0x60, 0x37, 0xf7, 0xe3, 0x55, 0x21, 0xa3, 0xea,
-	0x15, 0xc1, 0xbb, 0xd2, 0x5b, 0xcd, 0xfc, 0xa9, 0x41, 0x6d, 0xdb, 0xf0, 0x32, 0xc1, 0x80, 0x58,
-	0x50, 0x11, 0xeb, 0x04, 0x55, 0x7a, 0xbd, 0xd7, 0x7a, 0x34, 0x95, 0x24, 0x59, 0x93, 0x75, 0x82,
-	0x9e, 0xe2, 0x91, 0x37, 0x50, 0xdd, 0x5e, 0xc0, 0x94, 0x86, 0xaa, 0x85, 0xde, 0xfb, 0xe7, 0xb1,
-	0x1a, 0xdb, 0xd3, 0xb7, 0x44, 0x37, 0x24, 0xaf, 0x60, 0x97, 0x4a, 0x81, 0xca, 0x43, 0xbd, 0xf7,
-	0xdf, 0xd3, 0xf2, 0xbd, 0x82, 0x24, 0x3d, 0x97, 0xb7, 0xc7, 0x57, 0xa2, 0x59, 0x69, 0x6b, 0x9d,
-	0x5d, 0x6f, 0x13, 0x9a, 0x27, 0x50, 0x91, 0xd3, 0x90, 0x1a, 0x1c, 0x5e, 0x8d, 0x6c, 0xe7, 0xcc,
-	0x1d, 0x39, 0xb6, 0xb1, 0x43, 0x00, 0xf6, 0xce, 0xc7, 0xc3, 0xfe, 0xe8, 0xdc, 0xd0, 0xc8, 0x01,
-	0x54, 0x46, 0x63, 0xdb, 0x31, 0x4a, 0x64, 0x1f, 0xca, 0x83, 0xbe, 0x67, 0x94, 0x25, 0xf4, 0xa1,
-	0xff, 0xb9, 0x6f, 0x54, 0xcc, 0x1f, 0x25, 0xf8, 0x7f, 0xdb, 0xd3, 0xc6, 0x24, 0xe6, 0xeb, 0x25,
-	0x32, 0xa1, 0xbc, 0x78, 0x0f, 0xf5, 0x3b, 0x6d, 0x59, 0x82, 0x81, 0x72, 0x45, 0xef, 0xfd, 0xfb,
-	0xa4, 0x2b, 0x5e, 0x2d, 0x78, 0xe0, 0x64, 0x1f, 0xea, 0x38, 0x9f, 0x63, 0x20, 0x68, 0x8e, 0xd3,
-	0xd0, 0x17, 0x78, 0xeb, 0x4d, 0xcb, 0x2a, 0x16, 0xd3, 0xda, 0x2c, 0xa6, 0x35, 0xd9, 0x2c, 0xa6,
-	0x57, 0xdb, 0x66, 0xd8, 0xbe, 0x40, 0xf2, 0x1c, 0xaa, 0xaa, 0x77, 0xe2, 0x07, 0xd7, 0x7e, 0x84,
-	0xca, 0xab, 0xaa, 0xa7, 0x4b, 0xec, 0xa2, 0x80, 0xc8, 0x18, 0x0e, 0xf0, 0x06, 0x83, 0x29, 0xb2,
-	0x5c, 0x59, 0x53, 0xef, 0x1d, 0x3f, 0x9a, 0xee, 0xa1, 0x2c, 0xcb, 0xb9, 0xc1, 0x60, 0x25, 0x17,
-	0xc6, 0x61, 0x39, 0x4d, 0x39, 0x93, 0x7f, 0x78, 0xfb, 0xb2, 0x8a, 0xc3, 0x72, 0xd3, 0x82, 0xc6,
-	0x53, 0x04, 0xe9, 0xa8, 0x3d, 0x1e, 0x7c, 0x74, 0xbc, 0xc2, 0xdd, 0xcb, 0x2f, 0x97, 0x13, 0xe7,
-	0x93, 0xa1, 0x99, 0xdf, 0xb4, 0x7b, 0x06, 0xba, 0x2c, 0xe7, 0x81, 0x5a, 0xc6, 0x3f, 0x60, 0xe0,
-	0x11, 0xfc, 0x4d, 0xc3, 0x69, 0x84, 0x0c, 0x8b, 0xfd, 0x9e, 0xfa, 0x71, 0x74, 0xfb, 0x25, 0xfe,
-	0x45, 0xc3, 0xf3, 0x2d, 0xde, 0x8f, 0xa3, 0xa3, 0x63, 0x68, 0x0c, 0x38, 0x9b, 0xd3, 0x10, 0x99,
-	0xa0, 0x7e, 0x4c, 0xc5, 0x7a, 0x88, 0x39, 0xc6, 0x72, 0xd2, 0x8b, 0xab, 0xd3, 0xa1, 0x3b, 0x30,
-	0x76, 0x88, 0x01, 0xd5, 0xc1, 0x78, 0x74, 0xe6, 0xda, 0xce, 0x68, 0xe2, 0xf6, 0x87, 0x86, 0x76,
-	0x3a, 0x06, 0x93, 0xa7, 0x91, 0xb5, 0x58, 0x27, 0x98, 0xc6, 0x18, 0x46, 0x98, 0x5a, 0x73, 0x7f,
-	0x96, 0xd2, 0x60, 0x33, 0x9f, 0x7c, 0x60, 0xbe, 0xbe, 0x8c, 0xa8, 0x58, 0xac, 0x66, 0x56, 0xc0,
-	0x97, 0xdd, 0x7b, 0xd4, 0x6e, 0x41, 0x2d, 0xde, 0x97, 0xac, 0x2b, 0xa9, 0xb3, 0xe2, 0xed, 0x79,
-	0xfd, 0x2b, 0x00, 0x00, 0xff, 0xff, 0xd6, 0x66, 0x39, 0xf9, 0x9a, 0x04, 0x00, 0x00,
+	0xa8, 0x32, 0x12, 0x8d, 0xaa, 0xaa, 0x8a, 0x22, 0x39, 0xd8, 0x89, 0xdc, 0x52, 0x88, 0x1c, 0x52,
+	0xb5, 0xdd, 0x20, 0x63, 0x5f, 0x8c, 0x15, 0x33, 0x63, 0xd9, 0x83, 0x15, 0xd6, 0xfd, 0x41, 0xfd,
+	0x23, 0xfd, 0x4f, 0xad, 0x66, 0x1c, 0x08, 0x69, 0xb2, 0xec, 0x8a, 0xb9, 0x87, 0x73, 0x3f, 0xce,
+	0x99, 0xeb, 0x81, 0x46, 0x82, 0x98, 0x76, 0xfd, 0xb9, 0x17, 0x51, 0x9f, 0x05, 0x68, 0x24, 0x29,
+	0xe3, 0x8c, 0xec, 0xc9, 0x9f, 0xac, 0xf5, 0x2c, 0x64, 0x2c, 0x8c, 0xb1, 0x2b, 0xc3, 0xe9, 0x72,
+	0xd6, 0xe5, 0xd1, 0x02, 0x33, 0xee, 0x2d, 0x92, 0x82, 0xa8, 0x8f, 0x40, 0xed, 0xaf, 0x73, 0x1d,
+	0x8b, 0x10, 0xa8, 0x24, 0x1e, 0x9f, 0x37, 0x95, 0xb6, 0xd2, 0x39, 0x74, 0xe5, 0x59, 0x60, 0xd4,
+	0x5b, 0x60, 0xb3, 0x54, 0x60, 0xe2, 0x4c, 0x9a, 0xb0, 0x9f, 0x63, 0x9a, 0x45, 0x8c, 0x36, 0xcb,
+	0x12, 0x5e, 0x87, 0xfa, 0x77, 0x05, 0xea, 0xf7, 0x15, 0x69, 0xb2, 0xe4, 0xa2, 0x80, 0x97, 0x86,
+	0x59, 0x53, 0x69, 0x97, 0x3b, 0x55, 0x57, 0x9e, 0x89, 0x03, 0x6a, 0x80, 0x3e, 0x4b, 0x3d, 0x1e,
+	0x31, 0x9a, 0x35, 0x4b, 0xed, 0x72, 0x47, 0xed, 0xbd, 0x28, 0x86, 0xca, 0x8c, 0x87, 0x05, 0x0c,
+	0xeb, 0x9e, 0x69, 0x53, 0x9e, 0xae, 0xdc, 0xed, 0xdc, 0xd6, 0x29, 0x68, 0xbf, 0x13, 0x88, 0x06,
+	0xe5, 0x1b, 0x5c, 0xdd, 0xc9, 0x10, 0x47, 0xd2, 0x80, 0xdd, 0xdc, 0x8b, 0x97, 0x85, 0x8c, 0xaa,
+	0x5b, 0x04, 0xef, 0x4a, 0x6f, 0x15, 0xfd, 0xa7, 0x02, 0xb5, 0x4d, 0xc3, 0xab, 0x04, 0x7d, 0x62,
+	0x40, 0x85, 0xaf, 0x12, 0x94, 0xe9, 0xf5, 0x5e, 0xeb, 0xd1, 0x54, 0x82, 0x64, 0x8c, 0x57, 0x09,
+	0xba, 0x92, 0x47, 0xde, 0x40, 0x75, 0x73, 0x01, 0x93, 0x28, 0x90, 0x2d, 0xd4, 0xde, 0x3f, 0x8f,
+	0xd5, 0x58, 0xae, 0xba, 0x21, 0x3a, 0x01, 0x79, 0x05, 0xbb, 0x91, 0x10, 0x28, 0x3d, 0x54, 0x7b,
+	0xff, 0x3d, 0x2d, 0xdf, 0x2d, 0x48, 0xc2, 0x73, 0x71, 0x7b, 0x6c, 0xc9, 0x9b, 0x95, 0xb6, 0xd2,
+	0xd9, 0x75, 0xd7, 0xa1, 0x7e, 0x0a, 0x15, 0x31, 0x0d, 0xa9, 0xc1, 0xe1, 0xf5, 0xd0, 0xb2, 0xcf,
+	0x9d, 0xa1, 0x6d, 0x69, 0x3b, 0x04, 0x60, 0xef, 0x62, 0x34, 0x30, 0x87, 0x17, 0x9a, 0x42, 0x0e,
+	0xa0, 0x32, 0x1c, 0x59, 0xb6, 0x56, 0x22, 0xfb, 0x50, 0xee, 0x9b, 0xae, 0x56, 0x16, 0xd0, 0x7b,
+	0xf3, 0x93, 0xa9, 0x55, 0xf4, 0x1f, 0x25, 0xf8, 0x7f, 0xd3, 0xd3, 0xc2, 0x24, 0x66, 0xab, 0x05,
+	0x52, 0x2e, 0xbd, 0x38, 0x81, 0xfa, 0xbd, 0xb6, 0x2c, 0x41, 0x5f, 0xba, 0xa2, 0xf6, 0xfe, 0x7d,
+	0xd2, 0x15, 0xb7, 0xe6, 0x6f, 0x87, 0xc4, 0x84, 0x3a, 0xce, 0x66, 0xe8, 0xf3, 0x28, 0xc7, 0x49,
+	0xe0, 0x71, 0xbc, 0xf3, 0xa6, 0x65, 0x14, 0x8b, 0x69, 0xac, 0x17, 0xd3, 0x18, 0xaf, 0x17, 0xd3,
+	0xad, 0x6d, 0x32, 0x2c, 0x8f, 0x23, 0x79, 0x0e, 0x55, 0xd9, 0x3b, 0xf1, 0xfc, 0x1b, 0x2f, 0x44,
+	0xe9, 0x55, 0xd5, 0x55, 0x05, 0x76, 0x59, 0x40, 0x64, 0x04, 0x07, 0x78, 0x8b, 0xfe, 0x04, 0x69,
+	0x2e, 0xad, 0xa9, 0xf7, 0x8e, 0x1f, 0x4d, 0xf7, 0x50, 0x96, 0x61, 0xdf, 0xa2, 0xbf, 0x14, 0x0b,
+	0x63, 0xd3, 0x3c, 0x4a, 0x19, 0x15, 0x7f, 0xb8, 0xfb, 0xa2, 0x8a, 0x4d, 0x73, 0xfd, 0x04, 0x1a,
+	0x4f, 0x11, 0x84, 0xa3, 0xd6, 0xa8, 0xff, 0xc1, 0x76, 0x0b, 0x77, 0xaf, 0xbe, 0x5c, 0x8d, 0xed,
+	0x8f, 0x9a, 0x42, 0xaa, 0x70, 0x60, 0x7f, 0x1e, 0xdb, 0xee, 0xd0, 0x1c, 0x68, 0x25, 0xfd, 0x9b,
+	0xb2, 0x65, 0xa7, 0x43, 0x73, 0xe6, 0xcb, 0xd5, 0xfc, 0x03, 0x76, 0x1e, 0xc1, 0xdf, 0x51, 0x30,
+	0x09, 0x91, 0x62, 0xb1, 0xed, 0x13, 0x2f, 0x0e, 0xef, 0xbe, 0xcb, 0xbf, 0xa2, 0xe0, 0x62, 0x83,
+	0x9b, 0x71, 0x78, 0x74, 0x0c, 0x8d, 0x3e, 0xa3, 0xb3, 0x28, 0x40, 0xca, 0x23, 0x2f, 0x8e, 0xf8,
+	0x6a, 0x80, 0x39, 0xc6, 0x62, 0xee, 0xcb, 0xeb, 0xb3, 0x81, 0xd3, 0xd7, 0x76, 0x88, 0x06, 0xd5,
+	0xfe, 0x68, 0x78, 0xee, 0x58, 0xf6, 0x70, 0xec, 0x98, 0x03, 0x4d, 0x39, 0x1b, 0x81, 0xce, 0xd2,
+	0xd0, 0x98, 0xaf, 0x12, 0x4c, 0x63, 0x0c, 0x42, 0x4c, 0x8d, 0x99, 0x37, 0x4d, 0x23, 0x7f, 0x3d,
+	0x9f, 0x78, 0x6e, 0xbe, 0xbe, 0x0c, 0x23, 0x3e, 0x5f, 0x4e, 0x0d, 0x9f, 0x2d, 0xba, 0x5b, 0xd4,
+	0x6e, 0x41, 0x2d, 0x5e, 0x9b, 0xac, 0x2b, 0xa8, 0xd3, 0xe2, 0x25, 0x7a, 0xfd, 0x6b, 0x00, 0x9a,
+	0xfa, 0x69, 0x63, 0xa8, 0x04, 0x00, 0x00,
 }
@@ -40,6 +40,8 @@ const (
 	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
 	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
 	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_MULTIPLE  ChaincodeMessage_Type = 20
+	ChaincodeMessage_WRITE_BATCH_STATE   ChaincodeMessage_Type = 21
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -62,6 +64,8 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	17: "QUERY_STATE_CLOSE",
 	18: "KEEPALIVE",
 	19: "GET_HISTORY_FOR_KEY",
+	20: "GET_STATE_MULTIPLE",
+	21: "WRITE_BATCH_STATE",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
 	"UNDEFINED":           0,
@@ -83,6 +87,8 @@ var ChaincodeMessage_Type_value = map[string]int32{
 	"QUERY_STATE_CLOSE":   17,
 	"KEEPALIVE":           18,
 	"GET_HISTORY_FOR_KEY": 19,
+	"GET_STATE_MULTIPLE":  20,
+	"WRITE_BATCH_STATE":   21,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -295,7 +301,9 @@ func (m *GetQueryResult) GetCollection() string {
 }
 
 type GetHistoryForKey struct {
-	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Key        string               `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Collection string               `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	Options    *HistoryQueryOptions `protobuf:"bytes,3,opt,name=options" json:"options,omitempty"`
 }
 
 func (m *GetHistoryForKey) Reset()                    { *m = GetHistoryForKey{} }
@@ -310,6 +318,20 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *GetHistoryForKey) GetOptions() *HistoryQueryOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type QueryStateNext struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 }
@@ -390,6 +412,202 @@ func (m *QueryResponse) GetId() string {
 	return ""
 }
 
+type GetStateMultiple struct {
+	Keys       []string `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
+
+func (m *GetStateMultiple) Reset()                    { *m = GetStateMultiple{} }
+func (m *GetStateMultiple) String() string            { return proto.CompactTextString(m) }
+func (*GetStateMultiple) ProtoMessage()               {}
+func (*GetStateMultiple) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+
+func (m *GetStateMultiple) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetStateMultiple) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+type GetStateMultipleResult struct {
+	Values [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (m *GetStateMultipleResult) Reset()                    { *m = GetStateMultipleResult{} }
+func (m *GetStateMultipleResult) String() string            { return proto.CompactTextString(m) }
+func (*GetStateMultipleResult) ProtoMessage()               {}
+func (*GetStateMultipleResult) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+
+func (m *GetStateMultipleResult) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type WriteRecord struct {
+	Key        string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value      []byte                `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Collection string                `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
+	Type       ChaincodeMessage_Type `protobuf:"varint,4,opt,name=type,enum=protos.ChaincodeMessage_Type" json:"type,omitempty"`
+}
+
+func (m *WriteRecord) Reset()                    { *m = WriteRecord{} }
+func (m *WriteRecord) String() string            { return proto.CompactTextString(m) }
+func (*WriteRecord) ProtoMessage()               {}
+func (*WriteRecord) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
+
+func (m *WriteRecord) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WriteRecord) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WriteRecord) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *WriteRecord) GetType() ChaincodeMessage_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ChaincodeMessage_UNDEFINED
+}
+
+type WriteBatchState struct {
+	Records []*WriteRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *WriteBatchState) Reset()                    { *m = WriteBatchState{} }
+func (m *WriteBatchState) String() string            { return proto.CompactTextString(m) }
+func (*WriteBatchState) ProtoMessage()               {}
+func (*WriteBatchState) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{14} }
+
+func (m *WriteBatchState) GetRecords() []*WriteRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// ChaincodeAdditionalParams are the optional features of the protocol the peer
+// supports, sent to the chaincode with REGISTERED
+type ChaincodeAdditionalParams struct {
+	UseWriteBatch          bool   `protobuf:"varint,1,opt,name=use_write_batch,json=useWriteBatch" json:"use_write_batch,omitempty"`
+	MaxSizeWriteBatch      uint32 `protobuf:"varint,2,opt,name=max_size_write_batch,json=maxSizeWriteBatch" json:"max_size_write_batch,omitempty"`
+	UseGetMultipleKeys     bool   `protobuf:"varint,3,opt,name=use_get_multiple_keys,json=useGetMultipleKeys" json:"use_get_multiple_keys,omitempty"`
+	MaxSizeGetMultipleKeys uint32 `protobuf:"varint,4,opt,name=max_size_get_multiple_keys,json=maxSizeGetMultipleKeys" json:"max_size_get_multiple_keys,omitempty"`
+}
+
+func (m *ChaincodeAdditionalParams) Reset()                    { *m = ChaincodeAdditionalParams{} }
+func (m *ChaincodeAdditionalParams) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeAdditionalParams) ProtoMessage()               {}
+func (*ChaincodeAdditionalParams) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{15} }
+
+func (m *ChaincodeAdditionalParams) GetUseWriteBatch() bool {
+	if m != nil {
+		return m.UseWriteBatch
+	}
+	return false
+}
+
+func (m *ChaincodeAdditionalParams) GetMaxSizeWriteBatch() uint32 {
+	if m != nil {
+		return m.MaxSizeWriteBatch
+	}
+	return 0
+}
+
+func (m *ChaincodeAdditionalParams) GetUseGetMultipleKeys() bool {
+	if m != nil {
+		return m.UseGetMultipleKeys
+	}
+	return false
+}
+
+func (m *ChaincodeAdditionalParams) GetMaxSizeGetMultipleKeys() uint32 {
+	if m != nil {
+		return m.MaxSizeGetMultipleKeys
+	}
+	return 0
+}
+
+// HistoryQueryOptions restricts and orders the results of a history query.
+// Fields left at their zero value do not restrict the results
+type HistoryQueryOptions struct {
+	StartBlock uint64                      `protobuf:"varint,1,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	EndBlock   uint64                      `protobuf:"varint,2,opt,name=end_block,json=endBlock" json:"end_block,omitempty"`
+	StartTime  *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	EndTime    *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime" json:"end_time,omitempty"`
+	Reverse    bool                        `protobuf:"varint,5,opt,name=reverse" json:"reverse,omitempty"`
+	Limit      uint32                      `protobuf:"varint,6,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *HistoryQueryOptions) Reset()                    { *m = HistoryQueryOptions{} }
+func (m *HistoryQueryOptions) String() string            { return proto.CompactTextString(m) }
+func (*HistoryQueryOptions) ProtoMessage()               {}
+func (*HistoryQueryOptions) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{16} }
+
+func (m *HistoryQueryOptions) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryOptions) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryOptions) GetStartTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryOptions) GetEndTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryOptions) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *HistoryQueryOptions) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*GetState)(nil), "protos.GetState")
@@ -402,6 +620,12 @@ func init() {
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
 	proto.RegisterType((*QueryResponse)(nil), "protos.QueryResponse")
+	proto.RegisterType((*GetStateMultiple)(nil), "protos.GetStateMultiple")
+	proto.RegisterType((*GetStateMultipleResult)(nil), "protos.GetStateMultipleResult")
+	proto.RegisterType((*WriteRecord)(nil), "protos.WriteRecord")
+	proto.RegisterType((*WriteBatchState)(nil), "protos.WriteBatchState")
+	proto.RegisterType((*ChaincodeAdditionalParams)(nil), "protos.ChaincodeAdditionalParams")
+	proto.RegisterType((*HistoryQueryOptions)(nil), "protos.HistoryQueryOptions")
 	proto.RegisterEnum("protos.ChaincodeMessage_Type", ChaincodeMessage_Type_name, ChaincodeMessage_Type_value)
 }
 
@@ -512,57 +736,78 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0xc7, 0x8f, 0x00, 0xc1, 0x0c, 0x09, 0xec, 0x6d, 0xae, 0xa9, 0x0f, 0xe9, 0x5a, 0x8a, 0xfa,
-	0x40, 0x5f, 0xa0, 0xa5, 0x7d, 0xe8, 0xc3, 0x49, 0x15, 0xc1, 0x1b, 0x62, 0x85, 0xd8, 0xdc, 0xda,
-	0x39, 0x1d, 0x7d, 0xb1, 0x1c, 0xbc, 0x67, 0xac, 0x1a, 0xaf, 0x6b, 0x2f, 0xa7, 0xf3, 0x67, 0xe8,
-	0x07, 0xeb, 0xd7, 0xaa, 0xd6, 0xc6, 0x84, 0x23, 0x8a, 0x4e, 0xea, 0x13, 0xfe, 0xcf, 0xfc, 0x66,
-	0xe6, 0x3f, 0xd6, 0xb2, 0x86, 0xd7, 0x31, 0x63, 0xc9, 0x68, 0xb5, 0x76, 0x83, 0x68, 0xc5, 0x3d,
-	0xe6, 0xa4, 0xeb, 0x60, 0x33, 0x8c, 0x13, 0x2e, 0x38, 0x3e, 0xcd, 0x7f, 0xd2, 0x6e, 0xf7, 0x08,
-	0x61, 0x9f, 0x58, 0x24, 0x0a, 0xa6, 0x7b, 0x91, 0xe7, 0xe2, 0x84, 0xc7, 0x3c, 0x75, 0xc3, 0x5d,
-	0xf0, 0x7b, 0x9f, 0x73, 0x3f, 0x64, 0xa3, 0x5c, 0x3d, 0x6c, 0x3f, 0x8e, 0x44, 0xb0, 0x61, 0xa9,
-	0x70, 0x37, 0x71, 0x01, 0xf4, 0xff, 0xa9, 0x03, 0x9a, 0x96, 0xfd, 0xee, 0x58, 0x9a, 0xba, 0x3e,
-	0xc3, 0xbf, 0x40, 0x4d, 0x64, 0x31, 0x53, 0x2b, 0xbd, 0xca, 0xa0, 0x3d, 0x7e, 0x53, 0xa0, 0xe9,
-	0xf0, 0x98, 0x1b, 0xda, 0x59, 0xcc, 0x68, 0x8e, 0xe2, 0xdf, 0xa1, 0xb9, 0x6f, 0xad, 0x9e, 0xf4,
-	0x2a, 0x83, 0xd6, 0xb8, 0x3b, 0x2c, 0x86, 0x0f, 0xcb, 0xe1, 0x43, 0xbb, 0x24, 0xe8, 0x23, 0x8c,
-	0x55, 0x68, 0xc4, 0x6e, 0x16, 0x72, 0xd7, 0x53, 0xab, 0xbd, 0xca, 0xe0, 0x8c, 0x96, 0x12, 0x63,
-	0xa8, 0x89, 0xcf, 0x81, 0xa7, 0xd6, 0x7a, 0x95, 0x41, 0x93, 0xe6, 0xcf, 0x78, 0x0c, 0x4a, 0xb9,
-	0xa2, 0x5a, 0xcf, 0xc7, 0x5c, 0x96, 0xf6, 0xac, 0xc0, 0x8f, 0x98, 0xb7, 0xd8, 0x65, 0xe9, 0x9e,
-	0xc3, 0x7f, 0x40, 0xe7, 0xe8, 0x95, 0xa9, 0xa7, 0x5f, 0x96, 0xee, 0x37, 0x23, 0x32, 0x4b, 0xdb,
-	0xab, 0x2f, 0x34, 0x7e, 0x03, 0xb0, 0x5a, 0xbb, 0x51, 0xc4, 0x42, 0x27, 0xf0, 0xd4, 0x46, 0x6e,
-	0xa7, 0xb9, 0x8b, 0xe8, 0x5e, 0xff, 0xdf, 0x13, 0xa8, 0xc9, 0x57, 0x81, 0xcf, 0xa1, 0x79, 0x6f,
-	0x68, 0xe4, 0x5a, 0x37, 0x88, 0x86, 0x5e, 0xe0, 0x33, 0x50, 0x28, 0x99, 0xe9, 0x96, 0x4d, 0x28,
-	0xaa, 0xe0, 0x36, 0x40, 0xa9, 0x88, 0x86, 0x4e, 0xb0, 0x02, 0x35, 0xdd, 0xd0, 0x6d, 0x54, 0xc5,
-	0x4d, 0xa8, 0x53, 0x32, 0xd1, 0x96, 0xa8, 0x86, 0x3b, 0xd0, 0xb2, 0xe9, 0xc4, 0xb0, 0x26, 0x53,
-	0x5b, 0x37, 0x0d, 0x54, 0x97, 0x2d, 0xa7, 0xe6, 0xdd, 0x62, 0x4e, 0x6c, 0xa2, 0xa1, 0x53, 0x89,
-	0x12, 0x4a, 0x4d, 0x8a, 0x1a, 0x32, 0x33, 0x23, 0xb6, 0x63, 0xd9, 0x13, 0x9b, 0x20, 0x45, 0xca,
-	0xc5, 0x7d, 0x29, 0x9b, 0x52, 0x6a, 0x64, 0xbe, 0x93, 0x80, 0x5f, 0x01, 0xd2, 0x8d, 0xf7, 0xe6,
-	0x2d, 0x71, 0xa6, 0x37, 0x13, 0xdd, 0x98, 0x9a, 0x1a, 0x41, 0xad, 0xc2, 0xa0, 0xb5, 0x30, 0x0d,
-	0x8b, 0xa0, 0x73, 0x7c, 0x09, 0x78, 0xdf, 0xd0, 0xb9, 0x5a, 0x3a, 0x74, 0x62, 0xcc, 0x08, 0x6a,
-	0xcb, 0x5a, 0x19, 0x7f, 0x77, 0x4f, 0xe8, 0xd2, 0xa1, 0xc4, 0xba, 0x9f, 0xdb, 0xa8, 0x23, 0xa3,
-	0x45, 0xa4, 0xe0, 0x0d, 0xf2, 0xc1, 0x46, 0x08, 0x7f, 0x03, 0x2f, 0x0f, 0xa3, 0xd3, 0xb9, 0x69,
-	0x11, 0xf4, 0x52, 0xba, 0xb9, 0x25, 0x64, 0x31, 0x99, 0xeb, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8,
-	0x90, 0x1d, 0x6f, 0x74, 0xcb, 0x36, 0xe9, 0xd2, 0xb9, 0x36, 0xa9, 0x73, 0x4b, 0x96, 0xe8, 0xa2,
-	0xff, 0x16, 0x94, 0x19, 0x13, 0x96, 0x70, 0x05, 0xc3, 0x08, 0xaa, 0x7f, 0xb1, 0x2c, 0x3f, 0x83,
-	0x4d, 0x2a, 0x1f, 0xf1, 0x77, 0x00, 0x2b, 0x1e, 0x86, 0x6c, 0x25, 0x02, 0x1e, 0xe5, 0x87, 0xac,
-	0x49, 0x0f, 0x22, 0x7d, 0x0a, 0xca, 0x62, 0xfb, 0x6c, 0xf5, 0x2b, 0xa8, 0x7f, 0x72, 0xc3, 0x2d,
-	0xcb, 0x0b, 0xcf, 0x68, 0x21, 0x8e, 0x7a, 0x56, 0x9f, 0xf4, 0x7c, 0x0b, 0x8a, 0xc6, 0xc2, 0xff,
-	0xeb, 0x88, 0x41, 0xa7, 0xdc, 0xe7, 0x2a, 0xa3, 0x6e, 0xe4, 0x33, 0xdc, 0x05, 0x25, 0x15, 0x6e,
-	0x22, 0x6e, 0xf7, 0x9d, 0xf6, 0x1a, 0x5f, 0xc2, 0x29, 0x8b, 0x3c, 0x99, 0x29, 0x5a, 0xed, 0xd4,
-	0x57, 0x4d, 0x5e, 0x43, 0x7b, 0xc6, 0xc4, 0xbb, 0x2d, 0x4b, 0x32, 0xca, 0xd2, 0x6d, 0x28, 0xe4,
-	0xb2, 0x7f, 0x4b, 0xb9, 0x1b, 0x51, 0x88, 0xaf, 0xda, 0xfd, 0x11, 0xd0, 0x8c, 0x89, 0x9b, 0x20,
-	0x15, 0x3c, 0xc9, 0xae, 0x79, 0x22, 0x67, 0x3f, 0x59, 0xba, 0xdf, 0x83, 0x76, 0x3e, 0x2a, 0x5f,
-	0xcb, 0x60, 0x9f, 0x05, 0x6e, 0xc3, 0x49, 0xe0, 0xed, 0x90, 0x93, 0xc0, 0xeb, 0xff, 0x00, 0x9d,
-	0x47, 0x62, 0x1a, 0xf2, 0x94, 0x3d, 0x41, 0x7e, 0x03, 0x74, 0xe0, 0xf7, 0x2a, 0x13, 0x2c, 0xc5,
-	0x3d, 0x68, 0x25, 0x8f, 0x32, 0x87, 0xcf, 0xe8, 0x61, 0xa8, 0x1f, 0xc1, 0x79, 0x59, 0x15, 0xf3,
-	0x28, 0x65, 0x78, 0x0c, 0x8d, 0x22, 0x2f, 0xf1, 0xea, 0xa0, 0x35, 0x56, 0xcb, 0xbf, 0xf4, 0x71,
-	0x77, 0x5a, 0x82, 0xf8, 0x35, 0x28, 0x6b, 0x37, 0x75, 0x36, 0x3c, 0x29, 0xce, 0x82, 0x42, 0x1b,
-	0x6b, 0x37, 0xbd, 0xe3, 0x49, 0xe9, 0xb2, 0x5a, 0xba, 0x1c, 0x7f, 0x38, 0xb8, 0x1c, 0xad, 0x6d,
-	0x1c, 0xf3, 0x44, 0x60, 0x0d, 0x14, 0xca, 0xfc, 0x20, 0x15, 0x2c, 0xc1, 0xea, 0x73, 0x57, 0x63,
-	0xf7, 0xd9, 0x4c, 0xff, 0xc5, 0xa0, 0xf2, 0x73, 0xe5, 0xca, 0x84, 0x3e, 0x4f, 0xfc, 0xe1, 0x3a,
-	0x8b, 0x59, 0x12, 0x32, 0xcf, 0x67, 0xc9, 0xf0, 0xa3, 0xfb, 0x90, 0x04, 0xab, 0xb2, 0x4e, 0xde,
-	0xe6, 0x7f, 0xfe, 0xe4, 0x07, 0x62, 0xbd, 0x7d, 0x18, 0xae, 0xf8, 0x66, 0x74, 0x80, 0x8e, 0x0a,
-	0xb4, 0xb8, 0xd5, 0xd3, 0x91, 0x44, 0x1f, 0x8a, 0x4f, 0xc4, 0xaf, 0xff, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x18, 0x6a, 0x79, 0xe7, 0x46, 0x06, 0x00, 0x00,
+	// 1162 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xd1, 0x72, 0xda, 0x46,
+	0x14, 0x0d, 0x06, 0x1b, 0x71, 0xb1, 0x41, 0x59, 0x3b, 0x2e, 0x21, 0x93, 0xc6, 0xd5, 0x43, 0xc7,
+	0x7d, 0x28, 0x24, 0xb4, 0x99, 0x69, 0x3b, 0x99, 0x69, 0x31, 0xac, 0x31, 0x63, 0x0c, 0x64, 0x91,
+	0x93, 0xb8, 0x2f, 0x1a, 0x19, 0xdd, 0x80, 0x26, 0x42, 0x52, 0xa5, 0x25, 0x35, 0xe9, 0x0f, 0xf4,
+	0x9b, 0xfa, 0x3b, 0xfd, 0x86, 0xf6, 0xb9, 0xb3, 0xbb, 0x12, 0xc6, 0xa4, 0x1e, 0xb7, 0x9d, 0x3e,
+	0xa1, 0x73, 0xef, 0xb9, 0xe7, 0x9e, 0xbb, 0xbb, 0x62, 0x05, 0x0f, 0x43, 0xc4, 0xa8, 0x3e, 0x9e,
+	0xda, 0xae, 0x3f, 0x0e, 0x1c, 0xb4, 0xe2, 0xa9, 0x3b, 0xab, 0x85, 0x51, 0xc0, 0x03, 0xb2, 0x25,
+	0x7f, 0xe2, 0x6a, 0x75, 0x8d, 0x82, 0xef, 0xd1, 0xe7, 0x8a, 0x53, 0xdd, 0x95, 0xb9, 0x30, 0x0a,
+	0xc2, 0x20, 0xb6, 0xbd, 0x24, 0xf8, 0x64, 0x12, 0x04, 0x13, 0x0f, 0xeb, 0x12, 0x5d, 0xce, 0xdf,
+	0xd6, 0xb9, 0x3b, 0xc3, 0x98, 0xdb, 0xb3, 0x50, 0x11, 0x8c, 0xdf, 0x36, 0x41, 0x6f, 0xa5, 0x7a,
+	0x67, 0x18, 0xc7, 0xf6, 0x04, 0xc9, 0x33, 0xc8, 0xf1, 0x45, 0x88, 0x95, 0xcc, 0x41, 0xe6, 0xb0,
+	0xd4, 0x78, 0xac, 0xa8, 0x71, 0x6d, 0x9d, 0x57, 0x33, 0x17, 0x21, 0x32, 0x49, 0x25, 0xdf, 0x40,
+	0x61, 0x29, 0x5d, 0xd9, 0x38, 0xc8, 0x1c, 0x16, 0x1b, 0xd5, 0x9a, 0x6a, 0x5e, 0x4b, 0x9b, 0xd7,
+	0xcc, 0x94, 0xc1, 0xae, 0xc9, 0xa4, 0x02, 0xf9, 0xd0, 0x5e, 0x78, 0x81, 0xed, 0x54, 0xb2, 0x07,
+	0x99, 0xc3, 0x6d, 0x96, 0x42, 0x42, 0x20, 0xc7, 0xaf, 0x5c, 0xa7, 0x92, 0x3b, 0xc8, 0x1c, 0x16,
+	0x98, 0x7c, 0x26, 0x0d, 0xd0, 0xd2, 0x11, 0x2b, 0x9b, 0xb2, 0xcd, 0x7e, 0x6a, 0x6f, 0xe4, 0x4e,
+	0x7c, 0x74, 0x86, 0x49, 0x96, 0x2d, 0x79, 0xe4, 0x7b, 0x28, 0xaf, 0x2d, 0x59, 0x65, 0xeb, 0x66,
+	0xe9, 0x72, 0x32, 0x2a, 0xb2, 0xac, 0x34, 0xbe, 0x81, 0xc9, 0x63, 0x80, 0xf1, 0xd4, 0xf6, 0x7d,
+	0xf4, 0x2c, 0xd7, 0xa9, 0xe4, 0xa5, 0x9d, 0x42, 0x12, 0xe9, 0x3a, 0xc6, 0x9f, 0x1b, 0x90, 0x13,
+	0x4b, 0x41, 0x76, 0xa0, 0x70, 0xde, 0x6f, 0xd3, 0xe3, 0x6e, 0x9f, 0xb6, 0xf5, 0x7b, 0x64, 0x1b,
+	0x34, 0x46, 0x3b, 0xdd, 0x91, 0x49, 0x99, 0x9e, 0x21, 0x25, 0x80, 0x14, 0xd1, 0xb6, 0xbe, 0x41,
+	0x34, 0xc8, 0x75, 0xfb, 0x5d, 0x53, 0xcf, 0x92, 0x02, 0x6c, 0x32, 0xda, 0x6c, 0x5f, 0xe8, 0x39,
+	0x52, 0x86, 0xa2, 0xc9, 0x9a, 0xfd, 0x51, 0xb3, 0x65, 0x76, 0x07, 0x7d, 0x7d, 0x53, 0x48, 0xb6,
+	0x06, 0x67, 0xc3, 0x1e, 0x35, 0x69, 0x5b, 0xdf, 0x12, 0x54, 0xca, 0xd8, 0x80, 0xe9, 0x79, 0x91,
+	0xe9, 0x50, 0xd3, 0x1a, 0x99, 0x4d, 0x93, 0xea, 0x9a, 0x80, 0xc3, 0xf3, 0x14, 0x16, 0x04, 0x6c,
+	0xd3, 0x5e, 0x02, 0x81, 0xec, 0x81, 0xde, 0xed, 0xbf, 0x1a, 0x9c, 0x52, 0xab, 0x75, 0xd2, 0xec,
+	0xf6, 0x5b, 0x83, 0x36, 0xd5, 0x8b, 0xca, 0xe0, 0x68, 0x38, 0xe8, 0x8f, 0xa8, 0xbe, 0x43, 0xf6,
+	0x81, 0x2c, 0x05, 0xad, 0xa3, 0x0b, 0x8b, 0x35, 0xfb, 0x1d, 0xaa, 0x97, 0x44, 0xad, 0x88, 0xbf,
+	0x3c, 0xa7, 0xec, 0xc2, 0x62, 0x74, 0x74, 0xde, 0x33, 0xf5, 0xb2, 0x88, 0xaa, 0x88, 0xe2, 0xf7,
+	0xe9, 0x1b, 0x53, 0xd7, 0xc9, 0x03, 0xb8, 0xbf, 0x1a, 0x6d, 0xf5, 0x06, 0x23, 0xaa, 0xdf, 0x17,
+	0x6e, 0x4e, 0x29, 0x1d, 0x36, 0x7b, 0xdd, 0x57, 0x54, 0x27, 0xe4, 0x13, 0xd8, 0x15, 0x8a, 0x27,
+	0xdd, 0x91, 0x39, 0x60, 0x17, 0xd6, 0xf1, 0x80, 0x59, 0xa7, 0xf4, 0x42, 0xdf, 0xbd, 0x69, 0xe1,
+	0xec, 0xbc, 0x67, 0x76, 0x87, 0x3d, 0xaa, 0xef, 0x09, 0xd9, 0xd7, 0xac, 0x2b, 0x6c, 0x35, 0xcd,
+	0xd6, 0x49, 0x32, 0xd5, 0x03, 0xe3, 0x05, 0x68, 0x1d, 0xe4, 0x23, 0x6e, 0x73, 0x24, 0x3a, 0x64,
+	0xdf, 0xe1, 0x42, 0x1e, 0xd9, 0x02, 0x13, 0x8f, 0xe4, 0x53, 0x80, 0x71, 0xe0, 0x79, 0x38, 0xe6,
+	0x6e, 0xe0, 0xcb, 0x33, 0x59, 0x60, 0x2b, 0x11, 0x83, 0x81, 0x36, 0x9c, 0xdf, 0x5a, 0xbd, 0x07,
+	0x9b, 0xef, 0x6d, 0x6f, 0x8e, 0xb2, 0x70, 0x9b, 0x29, 0xb0, 0xa6, 0x99, 0xfd, 0x48, 0xf3, 0x05,
+	0x68, 0x6d, 0xf4, 0xfe, 0xab, 0x23, 0x84, 0x72, 0x3a, 0xcf, 0xd1, 0x82, 0xd9, 0xfe, 0x04, 0x49,
+	0x15, 0xb4, 0x98, 0xdb, 0x11, 0x3f, 0x5d, 0x2a, 0x2d, 0x31, 0xd9, 0x87, 0x2d, 0xf4, 0x1d, 0x91,
+	0x51, 0x52, 0x09, 0xba, 0xd3, 0xe4, 0x31, 0x94, 0x3a, 0xc8, 0x5f, 0xce, 0x31, 0x5a, 0x30, 0x8c,
+	0xe7, 0x1e, 0x17, 0xc3, 0xfe, 0x24, 0x60, 0xd2, 0x42, 0x81, 0x3b, 0xed, 0xfe, 0x02, 0x7a, 0x07,
+	0xf9, 0x89, 0x1b, 0xf3, 0x20, 0x5a, 0x1c, 0x07, 0x91, 0xe8, 0xfd, 0xaf, 0x87, 0x26, 0xcf, 0x21,
+	0x1f, 0x84, 0xe2, 0x29, 0x96, 0x56, 0x8b, 0x8d, 0x47, 0xe9, 0x5b, 0x99, 0x28, 0x4b, 0xa3, 0x03,
+	0x45, 0x61, 0x29, 0xd7, 0x38, 0x80, 0x92, 0x4c, 0xc8, 0xd5, 0xea, 0xe3, 0x15, 0x27, 0x25, 0xd8,
+	0x70, 0x9d, 0xa4, 0xf3, 0x86, 0xeb, 0x18, 0x9f, 0x41, 0xf9, 0x9a, 0xd1, 0xf2, 0x82, 0x18, 0x3f,
+	0xa2, 0x7c, 0x0d, 0xfa, 0xca, 0x32, 0x1c, 0x2d, 0x38, 0xc6, 0xe4, 0x00, 0x8a, 0xd1, 0x35, 0x94,
+	0xe4, 0x6d, 0xb6, 0x1a, 0x32, 0x7c, 0xd8, 0x49, 0xab, 0xc2, 0xc0, 0x8f, 0x91, 0x34, 0x20, 0xaf,
+	0xf2, 0x82, 0x9e, 0x3d, 0x2c, 0x36, 0x2a, 0xe9, 0x08, 0xeb, 0xea, 0x2c, 0x25, 0x92, 0x87, 0xa0,
+	0x4d, 0xed, 0xd8, 0x9a, 0x05, 0x91, 0x3a, 0x62, 0x1a, 0xcb, 0x4f, 0xed, 0xf8, 0x2c, 0x88, 0x52,
+	0x97, 0xd9, 0xa5, 0xcb, 0x63, 0xb9, 0xce, 0x72, 0x8c, 0xb3, 0xb9, 0xc7, 0xdd, 0xd0, 0x43, 0xf1,
+	0xdf, 0xf8, 0x0e, 0x17, 0xaa, 0x5f, 0x81, 0xc9, 0xe7, 0x3b, 0xf7, 0xeb, 0x29, 0xec, 0xaf, 0xeb,
+	0x24, 0xfb, 0xbf, 0x0f, 0x5b, 0xf2, 0x7c, 0x2b, 0xbd, 0x6d, 0x96, 0x20, 0xe3, 0xd7, 0x0c, 0x14,
+	0x5f, 0x47, 0x2e, 0x47, 0x86, 0xe3, 0x20, 0x72, 0xfe, 0xaf, 0xd7, 0x64, 0x79, 0xc1, 0xe4, 0xfe,
+	0xf1, 0x05, 0x63, 0xfc, 0x00, 0x65, 0xe9, 0xe4, 0xc8, 0xe6, 0xe3, 0xa9, 0x7a, 0xc1, 0xbe, 0x14,
+	0xcb, 0x2e, 0x7c, 0xa5, 0xcb, 0xbe, 0x9b, 0x0a, 0xad, 0x78, 0x66, 0x29, 0xc7, 0xf8, 0x3d, 0x03,
+	0x0f, 0x97, 0x1d, 0x9a, 0x8e, 0xe3, 0x0a, 0x2b, 0xb6, 0x37, 0xb4, 0x23, 0x7b, 0x16, 0x93, 0xcf,
+	0xa1, 0x3c, 0x8f, 0xd1, 0xfa, 0x59, 0x54, 0x5a, 0x97, 0xa2, 0x89, 0x1c, 0x53, 0x63, 0x3b, 0xf3,
+	0x18, 0xaf, 0x3b, 0x93, 0x3a, 0xec, 0xcd, 0xec, 0x2b, 0x2b, 0x76, 0x3f, 0xdc, 0x24, 0x8b, 0xf9,
+	0x77, 0xd8, 0xfd, 0x99, 0x7d, 0x35, 0x72, 0x3f, 0xac, 0x16, 0x3c, 0x83, 0x07, 0x42, 0x78, 0x82,
+	0xdc, 0x9a, 0x25, 0xab, 0x6e, 0xc9, 0xad, 0xcb, 0x4a, 0x79, 0x32, 0x8f, 0xb1, 0x83, 0x3c, 0xdd,
+	0x90, 0x53, 0xb1, 0x91, 0xdf, 0x41, 0x75, 0xd9, 0xe3, 0xe3, 0xba, 0x9c, 0xec, 0xb4, 0x9f, 0x74,
+	0x5a, 0xab, 0x35, 0xfe, 0xc8, 0xc0, 0xee, 0xdf, 0xbc, 0x38, 0xe4, 0x09, 0x14, 0xe5, 0x1f, 0x87,
+	0x75, 0xe9, 0x05, 0xe3, 0x77, 0x72, 0xb6, 0x1c, 0x03, 0x19, 0x3a, 0x12, 0x11, 0xf2, 0x08, 0x0a,
+	0xe8, 0x3b, 0x49, 0x7a, 0x43, 0xa6, 0x35, 0xf4, 0x1d, 0x95, 0xfc, 0x16, 0x14, 0xd5, 0x12, 0xf7,
+	0x76, 0x25, 0x7b, 0xf7, 0xfd, 0x2e, 0xd9, 0x02, 0x93, 0xe7, 0x20, 0x64, 0x54, 0x61, 0xee, 0xce,
+	0xc2, 0x3c, 0xfa, 0x8e, 0x2c, 0xab, 0x88, 0xcd, 0x7d, 0x8f, 0x51, 0x8c, 0xf2, 0x9e, 0xd7, 0x58,
+	0x0a, 0xc5, 0x91, 0xf3, 0xdc, 0x99, 0xab, 0x2e, 0xf1, 0x1d, 0xa6, 0x40, 0xe3, 0xcd, 0xca, 0x77,
+	0xcc, 0x68, 0x1e, 0x86, 0x41, 0xc4, 0x49, 0x1b, 0x34, 0x86, 0x13, 0x37, 0xe6, 0x18, 0x91, 0xca,
+	0x6d, 0x87, 0xac, 0x7a, 0x6b, 0xc6, 0xb8, 0x77, 0x98, 0x79, 0x9a, 0x39, 0x1a, 0x80, 0x11, 0x44,
+	0x93, 0xda, 0x74, 0x11, 0x62, 0xe4, 0xa1, 0x33, 0xc1, 0xa8, 0xf6, 0xd6, 0xbe, 0x8c, 0xdc, 0x71,
+	0x5a, 0x27, 0x3e, 0xbc, 0x7e, 0xfc, 0x62, 0xe2, 0xf2, 0xe9, 0xfc, 0xb2, 0x36, 0x0e, 0x66, 0xf5,
+	0x15, 0x6a, 0x5d, 0x51, 0xd5, 0x07, 0x58, 0x5c, 0x17, 0xd4, 0x4b, 0xf5, 0x35, 0xf7, 0xd5, 0x5f,
+	0x03, 0x00, 0xc0, 0x83, 0x15, 0x6f, 0xf1, 0x09, 0x00, 0x00,
 }
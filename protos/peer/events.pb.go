@@ -743,7 +743,8 @@ type DeliverResponse struct {
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
-	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
+	Type        isDeliverResponse_Type `protobuf_oneof:"Type"`
+	ResumeToken []byte                 `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
@@ -797,6 +798,13 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetResumeToken() []byte {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
@@ -1179,69 +1187,70 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 1009 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xb6, 0x62, 0xc7, 0xb1, 0x8e, 0xe3, 0xd4, 0xd9, 0xb4, 0xa9, 0xc6, 0x05, 0x5a, 0xc4, 0xc0,
-	0x04, 0x2e, 0xec, 0x60, 0x3a, 0x0c, 0xd3, 0x0b, 0x98, 0xf8, 0x27, 0xc8, 0x34, 0x4d, 0x32, 0x1b,
-	0x87, 0x8b, 0x5e, 0xa0, 0x59, 0xcb, 0x6b, 0x59, 0xad, 0x2c, 0x79, 0x76, 0xd7, 0x99, 0xe4, 0x11,
-	0x78, 0x03, 0xde, 0x80, 0x19, 0x5e, 0x85, 0x17, 0xe2, 0x92, 0xd1, 0x6a, 0x57, 0x52, 0x6c, 0xda,
-	0x21, 0x57, 0xf6, 0xf9, 0xfb, 0xf6, 0xfc, 0x7c, 0x67, 0x57, 0xb0, 0xbf, 0xa4, 0x94, 0x75, 0xe8,
-	0x0d, 0x8d, 0x04, 0x6f, 0x2f, 0x59, 0x2c, 0x62, 0x54, 0x95, 0x3f, 0xbc, 0x75, 0xe0, 0xc5, 0x8b,
-	0x45, 0x1c, 0x75, 0xd2, 0x9f, 0xd4, 0xd8, 0x7a, 0xee, 0xc7, 0xb1, 0x1f, 0xd2, 0x8e, 0x94, 0x26,
-	0xab, 0x59, 0x47, 0x04, 0x0b, 0xca, 0x05, 0x59, 0x2c, 0x95, 0x43, 0x4b, 0x02, 0x7a, 0x73, 0x12,
-	0x44, 0x5e, 0x3c, 0xa5, 0xae, 0x84, 0x56, 0xb6, 0x43, 0x69, 0x13, 0x8c, 0x44, 0x9c, 0x78, 0x22,
-	0xd0, 0xa0, 0xf6, 0x25, 0xec, 0xf6, 0x75, 0x00, 0xa6, 0x3e, 0xfa, 0x1c, 0x76, 0x73, 0x80, 0x60,
-	0x6a, 0x19, 0x2f, 0x8c, 0x23, 0x13, 0xd7, 0x33, 0xdd, 0x68, 0x8a, 0x3e, 0x05, 0x90, 0xc8, 0x6e,
-	0x44, 0x16, 0xd4, 0xda, 0x92, 0x0e, 0xa6, 0xd4, 0x9c, 0x93, 0x05, 0xb5, 0xff, 0x34, 0xa0, 0x36,
-	0x8a, 0x04, 0x65, 0x94, 0x0b, 0x74, 0xac, 0x7d, 0xc5, 0xdd, 0x92, 0x4a, 0xb0, 0xbd, 0xee, 0x7e,
-	0x7a, 0x34, 0x6f, 0x0f, 0x13, 0xcb, 0xf8, 0x6e, 0x49, 0x55, 0x78, 0xf2, 0x17, 0x0d, 0x00, 0xe5,
-	0x09, 0x30, 0xea, 0xbb, 0x41, 0x34, 0x8b, 0xe5, 0x29, 0xf5, 0xee, 0x63, 0x1d, 0x59, 0x4c, 0xd9,
-	0x29, 0xe1, 0xa6, 0x57, 0x90, 0x47, 0xd1, 0x2c, 0x46, 0x16, 0xec, 0x48, 0xdd, 0x68, 0x60, 0x95,
-	0x65, 0x82, 0x5a, 0xec, 0x99, 0xb0, 0xa3, 0x9c, 0xec, 0x97, 0x50, 0xc3, 0xd4, 0x0f, 0xb8, 0xa0,
-	0x0c, 0x1d, 0x41, 0x35, 0x9d, 0x84, 0x65, 0xbc, 0x28, 0x1f, 0xd5, 0xbb, 0x4d, 0x7d, 0x94, 0x2e,
-	0x05, 0x2b, 0xbb, 0xfd, 0x06, 0x4c, 0x4c, 0xdf, 0x51, 0xd9, 0x44, 0xf4, 0x05, 0x6c, 0x89, 0x5b,
-	0x59, 0x57, 0xbd, 0x7b, 0xa0, 0x43, 0xc6, 0x79, 0x97, 0xf1, 0x96, 0xb8, 0x45, 0xcf, 0xc0, 0xa4,
-	0x8c, 0xc5, 0xcc, 0x5d, 0x70, 0x5f, 0xf5, 0xab, 0x26, 0x15, 0x6f, 0xb8, 0x6f, 0x7f, 0x0f, 0x70,
-	0x1d, 0xb1, 0x87, 0xa7, 0xf1, 0x87, 0x01, 0x8d, 0xd3, 0x20, 0x4c, 0xb4, 0xd3, 0x5e, 0x18, 0x7b,
-	0xef, 0x93, 0xb9, 0x78, 0x73, 0x12, 0x45, 0x34, 0xcc, 0x07, 0x67, 0x2a, 0xcd, 0x68, 0x8a, 0x0e,
-	0xa1, 0x1a, 0xad, 0x16, 0x13, 0xca, 0x64, 0x0a, 0x15, 0xac, 0x24, 0x74, 0x09, 0x4f, 0x66, 0x0a,
-	0xc7, 0x2d, 0xf0, 0x83, 0x5b, 0x15, 0x99, 0xc1, 0x33, 0x9d, 0x81, 0x3e, 0xac, 0x58, 0xdd, 0xe3,
-	0xd9, 0xa6, 0x92, 0xdb, 0xff, 0x18, 0x70, 0xf0, 0x1f, 0xde, 0x08, 0x41, 0x45, 0xdc, 0x66, 0xa9,
-	0xc9, 0xff, 0xe8, 0x2b, 0xa8, 0x48, 0x6a, 0x6c, 0x49, 0x6a, 0xa0, 0xb6, 0x62, 0xbc, 0x43, 0xc9,
-	0x94, 0x32, 0xc9, 0x0d, 0x69, 0x47, 0xa7, 0x80, 0xc4, 0xad, 0x7b, 0x43, 0xc2, 0x60, 0x4a, 0x12,
-	0x30, 0x37, 0x99, 0xb6, 0x9c, 0xed, 0x5e, 0xd7, 0xca, 0x1a, 0x7f, 0xfb, 0x6b, 0xe6, 0xd0, 0x4f,
-	0xd8, 0xd0, 0x14, 0x6b, 0x1a, 0x74, 0x0d, 0x07, 0x85, 0x22, 0xdd, 0xbc, 0xd6, 0x64, 0x82, 0xf6,
-	0x47, 0x6a, 0x3d, 0x49, 0x3d, 0x9d, 0x12, 0x46, 0x62, 0x43, 0xdb, 0xab, 0x42, 0x65, 0x40, 0x04,
-	0xb1, 0xdf, 0x41, 0xeb, 0xc3, 0xb1, 0xe8, 0x0c, 0xf6, 0x73, 0x6e, 0xeb, 0xa3, 0xd3, 0x41, 0x3f,
-	0x5f, 0x3f, 0x3a, 0xa3, 0x78, 0x1a, 0x5c, 0xe0, 0xb8, 0x42, 0xb3, 0xdf, 0xc2, 0xd3, 0x0f, 0x38,
-	0xa3, 0x9f, 0xe0, 0xd1, 0xda, 0x35, 0xa0, 0x38, 0x7a, 0xb8, 0xb1, 0x41, 0x72, 0x09, 0xf1, 0x9e,
-	0x77, 0x4f, 0xb6, 0x5f, 0x43, 0xfd, 0x2a, 0xf0, 0x23, 0x3a, 0x95, 0x22, 0xfa, 0x04, 0x4c, 0x1e,
-	0xf8, 0x11, 0x11, 0x2b, 0x96, 0x6e, 0xf1, 0x2e, 0xce, 0x15, 0xe8, 0x33, 0xb5, 0xe4, 0xbd, 0x3b,
-	0x41, 0xb9, 0x9c, 0xe4, 0x2e, 0x2e, 0x68, 0xec, 0xbf, 0xcb, 0xb0, 0x9d, 0xe2, 0xb4, 0xa1, 0xa6,
-	0xa9, 0xae, 0x12, 0xca, 0x08, 0xae, 0x37, 0xd1, 0x29, 0xe1, 0xcc, 0x07, 0x7d, 0x09, 0xdb, 0x93,
-	0x84, 0xdb, 0x6a, 0xff, 0x1b, 0x9a, 0x1e, 0x92, 0xf0, 0x4e, 0x09, 0xa7, 0x56, 0x74, 0xb2, 0x59,
-	0x6e, 0xf9, 0x63, 0xe5, 0x3a, 0xa5, 0xf5, 0x82, 0xd1, 0xb7, 0x60, 0x32, 0xbd, 0xd5, 0x8a, 0x0d,
-	0xfb, 0x79, 0x6a, 0xca, 0xe0, 0x94, 0x70, 0xee, 0x85, 0x5e, 0x02, 0xac, 0xb2, 0xcd, 0xb5, 0xb6,
-	0x65, 0x0c, 0xd2, 0x31, 0xf9, 0x4e, 0x3b, 0x25, 0x5c, 0xf0, 0x43, 0x3f, 0xc2, 0x5e, 0xb6, 0x6e,
-	0x69, 0x6d, 0x3b, 0x32, 0xf2, 0xc9, 0x3a, 0x01, 0x74, 0x8d, 0x8d, 0xd9, 0xbd, 0x2d, 0x4f, 0x6e,
-	0x36, 0x46, 0x89, 0x88, 0x99, 0x55, 0x95, 0x9d, 0xd6, 0x22, 0xfa, 0x01, 0xcc, 0xec, 0x45, 0xb0,
-	0x6a, 0x12, 0xb4, 0xd5, 0x4e, 0xdf, 0x8c, 0xb6, 0x7e, 0x33, 0xda, 0x63, 0xed, 0x81, 0x73, 0x67,
-	0x64, 0x43, 0x43, 0x84, 0xdc, 0xf5, 0x28, 0x13, 0xee, 0x9c, 0xf0, 0xb9, 0x65, 0x4a, 0xe4, 0xba,
-	0x08, 0x79, 0x9f, 0x32, 0xe1, 0x10, 0x3e, 0xef, 0xed, 0xa8, 0x19, 0xda, 0x7f, 0x19, 0xf0, 0x68,
-	0x40, 0xc3, 0xe0, 0x86, 0x32, 0x4c, 0xf9, 0x32, 0x8e, 0x38, 0x4d, 0xae, 0x2d, 0x2e, 0x88, 0x58,
-	0x71, 0x75, 0xc5, 0xef, 0xe9, 0x41, 0x5d, 0x49, 0xad, 0x53, 0xc2, 0xca, 0xfe, 0x7f, 0x27, 0xba,
-	0xd9, 0xa5, 0xf2, 0x43, 0xba, 0x94, 0xec, 0x63, 0x72, 0x79, 0x7c, 0x73, 0x0d, 0x66, 0xf6, 0xca,
-	0xa0, 0x5d, 0xa8, 0xe1, 0xe1, 0xcf, 0xa3, 0xab, 0xf1, 0x10, 0x37, 0x4b, 0xc8, 0x84, 0xed, 0xde,
-	0xd9, 0x45, 0xff, 0x75, 0xd3, 0x40, 0x0d, 0x30, 0xfb, 0xce, 0xc9, 0xe8, 0xbc, 0x7f, 0x31, 0x18,
-	0x36, 0xb7, 0x12, 0x11, 0x0f, 0x7f, 0x19, 0xf6, 0xc7, 0xa3, 0x8b, 0xf3, 0x66, 0x19, 0xed, 0x43,
-	0xe3, 0x74, 0x74, 0x36, 0x1e, 0xe2, 0xe1, 0x20, 0x0d, 0xa8, 0x74, 0x5f, 0x41, 0x55, 0xc2, 0x72,
-	0x74, 0x0c, 0x95, 0xfe, 0x9c, 0x08, 0x94, 0x5d, 0xfe, 0x85, 0xb5, 0x69, 0x35, 0xee, 0xbd, 0x74,
-	0x76, 0xe9, 0xc8, 0x38, 0x36, 0xba, 0xbf, 0x1b, 0xb0, 0xa3, 0xfa, 0x87, 0x5e, 0xe5, 0x7f, 0x9b,
-	0xba, 0x13, 0xc3, 0xe8, 0x86, 0x86, 0xf1, 0x92, 0xb6, 0x9e, 0xea, 0xe8, 0xb5, 0x6e, 0xa7, 0x38,
-	0xa8, 0x97, 0x8d, 0x41, 0xf7, 0xe2, 0xc1, 0x18, 0xbd, 0xdf, 0xc0, 0x8e, 0x99, 0xdf, 0x9e, 0xdf,
-	0x2d, 0x29, 0x0b, 0xe9, 0xd4, 0xa7, 0xac, 0x3d, 0x23, 0x13, 0x16, 0x78, 0x3a, 0x2c, 0xf9, 0x6a,
-	0xe8, 0x35, 0xd2, 0x5a, 0x2f, 0x89, 0xf7, 0x9e, 0xf8, 0xf4, 0xed, 0xd7, 0x7e, 0x20, 0xe6, 0xab,
-	0x49, 0x72, 0x56, 0xa7, 0x10, 0xd9, 0x49, 0x23, 0xd3, 0xcf, 0x13, 0xde, 0x49, 0x22, 0x27, 0xe9,
-	0xf7, 0xcc, 0x77, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xfc, 0x9e, 0x06, 0xeb, 0x08, 0x00,
+	// 1025 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x72, 0xda, 0xc6,
+	0x17, 0x07, 0x83, 0x31, 0x3a, 0x80, 0x83, 0xd7, 0x89, 0xa3, 0x21, 0xff, 0x7f, 0x93, 0xa8, 0xd3,
+	0x8e, 0xdb, 0x0b, 0x70, 0x69, 0xa6, 0xd3, 0xc9, 0x45, 0x3b, 0xe6, 0xc3, 0x15, 0x8d, 0x63, 0x7b,
+	0xd6, 0xb8, 0x17, 0xb9, 0xa8, 0x46, 0x88, 0x83, 0x50, 0x0c, 0x12, 0xb3, 0xbb, 0x78, 0xec, 0x47,
+	0xe8, 0x1b, 0xf4, 0x0d, 0xfa, 0x30, 0x9d, 0xbe, 0x4f, 0x2f, 0x3b, 0x5a, 0xed, 0x4a, 0x0a, 0x6e,
+	0x32, 0xf5, 0x15, 0x3a, 0x5f, 0xbf, 0x3d, 0x1f, 0xbf, 0xb3, 0x0b, 0xec, 0xad, 0x10, 0x59, 0x07,
+	0x6f, 0x30, 0x14, 0xbc, 0xbd, 0x62, 0x91, 0x88, 0x48, 0x45, 0xfe, 0xf0, 0xd6, 0xbe, 0x17, 0x2d,
+	0x97, 0x51, 0xd8, 0x49, 0x7e, 0x12, 0x63, 0xeb, 0xb9, 0x1f, 0x45, 0xfe, 0x02, 0x3b, 0x52, 0x9a,
+	0xac, 0x67, 0x1d, 0x11, 0x2c, 0x91, 0x0b, 0x77, 0xb9, 0x52, 0x0e, 0x2d, 0x09, 0xe8, 0xcd, 0xdd,
+	0x20, 0xf4, 0xa2, 0x29, 0x3a, 0x12, 0x5a, 0xd9, 0x0e, 0xa4, 0x4d, 0x30, 0x37, 0xe4, 0xae, 0x27,
+	0x02, 0x0d, 0x6a, 0x5d, 0x40, 0xbd, 0xaf, 0x03, 0x28, 0xfa, 0xe4, 0x25, 0xd4, 0x33, 0x80, 0x60,
+	0x6a, 0x16, 0x5f, 0x14, 0x0f, 0x0d, 0x5a, 0x4b, 0x75, 0xa3, 0x29, 0xf9, 0x3f, 0x80, 0x44, 0x76,
+	0x42, 0x77, 0x89, 0xe6, 0x96, 0x74, 0x30, 0xa4, 0xe6, 0xcc, 0x5d, 0xa2, 0xf5, 0x47, 0x11, 0xaa,
+	0xa3, 0x50, 0x20, 0x43, 0x2e, 0xc8, 0x91, 0xf6, 0x15, 0x77, 0x2b, 0x94, 0x60, 0xbb, 0xdd, 0xbd,
+	0xe4, 0x68, 0xde, 0x1e, 0xc6, 0x96, 0xf1, 0xdd, 0x0a, 0x55, 0x78, 0xfc, 0x49, 0x06, 0x40, 0xb2,
+	0x04, 0x18, 0xfa, 0x4e, 0x10, 0xce, 0x22, 0x79, 0x4a, 0xad, 0xfb, 0x58, 0x47, 0xe6, 0x53, 0xb6,
+	0x0b, 0xb4, 0xe9, 0xe5, 0xe4, 0x51, 0x38, 0x8b, 0x88, 0x09, 0x3b, 0x52, 0x37, 0x1a, 0x98, 0x25,
+	0x99, 0xa0, 0x16, 0x7b, 0x06, 0xec, 0x28, 0x27, 0xeb, 0x15, 0x54, 0x29, 0xfa, 0x01, 0x17, 0xc8,
+	0xc8, 0x21, 0x54, 0x92, 0x49, 0x98, 0xc5, 0x17, 0xa5, 0xc3, 0x5a, 0xb7, 0xa9, 0x8f, 0xd2, 0xa5,
+	0x50, 0x65, 0xb7, 0xde, 0x82, 0x41, 0xf1, 0x3d, 0xca, 0x26, 0x92, 0xcf, 0x61, 0x4b, 0xdc, 0xca,
+	0xba, 0x6a, 0xdd, 0x7d, 0x1d, 0x32, 0xce, 0xba, 0x4c, 0xb7, 0xc4, 0x2d, 0x79, 0x06, 0x06, 0x32,
+	0x16, 0x31, 0x67, 0xc9, 0x7d, 0xd5, 0xaf, 0xaa, 0x54, 0xbc, 0xe5, 0xbe, 0xf5, 0x1d, 0xc0, 0x55,
+	0xc8, 0x1e, 0x9e, 0xc6, 0xef, 0x45, 0x68, 0x9c, 0x04, 0x8b, 0x58, 0x3b, 0xed, 0x2d, 0x22, 0xef,
+	0x3a, 0x9e, 0x8b, 0x37, 0x77, 0xc3, 0x10, 0x17, 0xd9, 0xe0, 0x0c, 0xa5, 0x19, 0x4d, 0xc9, 0x01,
+	0x54, 0xc2, 0xf5, 0x72, 0x82, 0x4c, 0xa6, 0x50, 0xa6, 0x4a, 0x22, 0x17, 0xf0, 0x64, 0xa6, 0x70,
+	0x9c, 0x1c, 0x3f, 0xb8, 0x59, 0x96, 0x19, 0x3c, 0xd3, 0x19, 0xe8, 0xc3, 0xf2, 0xd5, 0x3d, 0x9e,
+	0xdd, 0x57, 0x72, 0xeb, 0xef, 0x22, 0xec, 0xff, 0x8b, 0x37, 0x21, 0x50, 0x16, 0xb7, 0x69, 0x6a,
+	0xf2, 0x9b, 0x7c, 0x09, 0x65, 0x49, 0x8d, 0x2d, 0x49, 0x0d, 0xd2, 0x56, 0x8c, 0xb7, 0xd1, 0x9d,
+	0x22, 0x93, 0xdc, 0x90, 0x76, 0x72, 0x02, 0x44, 0xdc, 0x3a, 0x37, 0xee, 0x22, 0x98, 0xba, 0x31,
+	0x98, 0x13, 0x4f, 0x5b, 0xce, 0x76, 0xb7, 0x6b, 0xa6, 0x8d, 0xbf, 0xfd, 0x25, 0x75, 0xe8, 0xc7,
+	0x6c, 0x68, 0x8a, 0x0d, 0x0d, 0xb9, 0x82, 0xfd, 0x5c, 0x91, 0x4e, 0x56, 0x6b, 0x3c, 0x41, 0xeb,
+	0x13, 0xb5, 0x1e, 0x27, 0x9e, 0x76, 0x81, 0x12, 0x71, 0x4f, 0xdb, 0xab, 0x40, 0x79, 0xe0, 0x0a,
+	0xd7, 0x7a, 0x0f, 0xad, 0x8f, 0xc7, 0x92, 0x53, 0xd8, 0xcb, 0xb8, 0xad, 0x8f, 0x4e, 0x06, 0xfd,
+	0x7c, 0xf3, 0xe8, 0x94, 0xe2, 0x49, 0x70, 0x8e, 0xe3, 0x0a, 0xcd, 0x7a, 0x07, 0x4f, 0x3f, 0xe2,
+	0x4c, 0x7e, 0x84, 0x47, 0x1b, 0xd7, 0x80, 0xe2, 0xe8, 0xc1, 0xbd, 0x0d, 0x92, 0x4b, 0x48, 0x77,
+	0xbd, 0x0f, 0x64, 0xeb, 0x0d, 0xd4, 0x2e, 0x03, 0x3f, 0xc4, 0xa9, 0x14, 0xc9, 0xff, 0xc0, 0xe0,
+	0x81, 0x1f, 0xba, 0x62, 0xcd, 0x92, 0x2d, 0xae, 0xd3, 0x4c, 0x41, 0x3e, 0x53, 0x4b, 0xde, 0xbb,
+	0x13, 0xc8, 0xe5, 0x24, 0xeb, 0x34, 0xa7, 0xb1, 0xfe, 0x2c, 0xc1, 0x76, 0x82, 0xd3, 0x86, 0xaa,
+	0xa6, 0xba, 0x4a, 0x28, 0x25, 0xb8, 0xde, 0x44, 0xbb, 0x40, 0x53, 0x1f, 0xf2, 0x05, 0x6c, 0x4f,
+	0x62, 0x6e, 0xab, 0xfd, 0x6f, 0x68, 0x7a, 0x48, 0xc2, 0xdb, 0x05, 0x9a, 0x58, 0xc9, 0xf1, 0xfd,
+	0x72, 0x4b, 0x9f, 0x2a, 0xd7, 0x2e, 0x6c, 0x16, 0x4c, 0xbe, 0x01, 0x83, 0xe9, 0xad, 0x56, 0x6c,
+	0xd8, 0xcb, 0x52, 0x53, 0x06, 0xbb, 0x40, 0x33, 0x2f, 0xf2, 0x0a, 0x60, 0x9d, 0x6e, 0xae, 0xb9,
+	0x2d, 0x63, 0x88, 0x8e, 0xc9, 0x76, 0xda, 0x2e, 0xd0, 0x9c, 0x1f, 0xf9, 0x01, 0x76, 0xd3, 0x75,
+	0x4b, 0x6a, 0xdb, 0x91, 0x91, 0x4f, 0x36, 0x09, 0xa0, 0x6b, 0x6c, 0xcc, 0xf2, 0x0a, 0x79, 0xb3,
+	0x31, 0x74, 0x45, 0xc4, 0xcc, 0x8a, 0xec, 0xb4, 0x16, 0xc9, 0xf7, 0x60, 0xa4, 0x2f, 0x82, 0x59,
+	0x95, 0xa0, 0xad, 0x76, 0xf2, 0x66, 0xb4, 0xf5, 0x9b, 0xd1, 0x1e, 0x6b, 0x0f, 0x9a, 0x39, 0x13,
+	0x0b, 0x1a, 0x62, 0xc1, 0x1d, 0x0f, 0x99, 0x70, 0xe6, 0x2e, 0x9f, 0x9b, 0x86, 0x44, 0xae, 0x89,
+	0x05, 0xef, 0x23, 0x13, 0xb6, 0xcb, 0xe7, 0xbd, 0x1d, 0x35, 0x43, 0xeb, 0xaf, 0x22, 0x3c, 0x1a,
+	0xe0, 0x22, 0xb8, 0x41, 0x46, 0x91, 0xaf, 0xa2, 0x90, 0x63, 0x7c, 0x6d, 0x71, 0xe1, 0x8a, 0x35,
+	0x57, 0x57, 0xfc, 0xae, 0x1e, 0xd4, 0xa5, 0xd4, 0xda, 0x05, 0xaa, 0xec, 0xff, 0x75, 0xa2, 0xf7,
+	0xbb, 0x54, 0x7a, 0x50, 0x97, 0x5e, 0x42, 0x9d, 0x21, 0x5f, 0x2f, 0xd1, 0x11, 0xd1, 0x35, 0x26,
+	0x13, 0xad, 0xd3, 0x5a, 0xa2, 0x1b, 0xc7, 0xaa, 0x78, 0x65, 0xe3, 0xfb, 0xe5, 0xeb, 0x2b, 0x30,
+	0xd2, 0x87, 0x88, 0xd4, 0xa1, 0x4a, 0x87, 0x3f, 0x8d, 0x2e, 0xc7, 0x43, 0xda, 0x2c, 0x10, 0x03,
+	0xb6, 0x7b, 0xa7, 0xe7, 0xfd, 0x37, 0xcd, 0x22, 0x69, 0x80, 0xd1, 0xb7, 0x8f, 0x47, 0x67, 0xfd,
+	0xf3, 0xc1, 0xb0, 0xb9, 0x15, 0x8b, 0x74, 0xf8, 0xf3, 0xb0, 0x3f, 0x1e, 0x9d, 0x9f, 0x35, 0x4b,
+	0x64, 0x0f, 0x1a, 0x27, 0xa3, 0xd3, 0xf1, 0x90, 0x0e, 0x07, 0x49, 0x40, 0xb9, 0xfb, 0x1a, 0x2a,
+	0x12, 0x96, 0x93, 0x23, 0x28, 0xf7, 0xe7, 0xae, 0x20, 0xe9, 0xfb, 0x90, 0xdb, 0xac, 0x56, 0xe3,
+	0x83, 0xc7, 0xd0, 0x2a, 0x1c, 0x16, 0x8f, 0x8a, 0xdd, 0xdf, 0x8a, 0xb0, 0xa3, 0x5a, 0x4c, 0x5e,
+	0x67, 0x9f, 0x4d, 0xdd, 0xac, 0x61, 0x78, 0x83, 0x8b, 0x68, 0x85, 0xad, 0xa7, 0x3a, 0x7a, 0x63,
+	0x20, 0x09, 0x0e, 0xe9, 0xa5, 0x93, 0xd2, 0xed, 0x7a, 0x30, 0x46, 0xef, 0x57, 0xb0, 0x22, 0xe6,
+	0xb7, 0xe7, 0x77, 0x2b, 0x64, 0x0b, 0x9c, 0xfa, 0xc8, 0xda, 0x33, 0x77, 0xc2, 0x02, 0x4f, 0x87,
+	0xad, 0x10, 0x59, 0xaf, 0x91, 0xd4, 0x7a, 0xe1, 0x7a, 0xd7, 0xae, 0x8f, 0xef, 0xbe, 0xf2, 0x03,
+	0x31, 0x5f, 0x4f, 0xe2, 0xb3, 0x3a, 0xb9, 0xc8, 0x4e, 0x12, 0x99, 0xfc, 0x83, 0xe1, 0x9d, 0x38,
+	0x72, 0x92, 0xfc, 0xe5, 0xf9, 0xf6, 0x9f, 0x01, 0x00, 0x54, 0x94, 0x30, 0x44, 0x0e, 0x09, 0x00,
 	0x00,
 }
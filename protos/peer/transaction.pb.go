@@ -295,6 +295,44 @@ func (m *ChaincodeEndorsedAction) GetEndorsements() []*Endorsement {
 	return nil
 }
 
+// IndexedTransaction is a transaction found through the block index, along
+// with its position in the chain. It is returned by the queries listing the
+// transactions of a creator, of a chaincode or of a time window.
+type IndexedTransaction struct {
+	// The number of the block containing the transaction
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=blockNumber" json:"blockNumber,omitempty"`
+	// The position of the transaction in the block
+	TxNumber uint64 `protobuf:"varint,2,opt,name=txNumber" json:"txNumber,omitempty"`
+	// The transaction Envelope and its validation code
+	ProcessedTransaction *ProcessedTransaction `protobuf:"bytes,3,opt,name=processedTransaction" json:"processedTransaction,omitempty"`
+}
+
+func (m *IndexedTransaction) Reset()                    { *m = IndexedTransaction{} }
+func (m *IndexedTransaction) String() string            { return proto.CompactTextString(m) }
+func (*IndexedTransaction) ProtoMessage()               {}
+func (*IndexedTransaction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{6} }
+
+func (m *IndexedTransaction) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *IndexedTransaction) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *IndexedTransaction) GetProcessedTransaction() *ProcessedTransaction {
+	if m != nil {
+		return m.ProcessedTransaction
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignedTransaction)(nil), "protos.SignedTransaction")
 	proto.RegisterType((*ProcessedTransaction)(nil), "protos.ProcessedTransaction")
@@ -302,64 +340,68 @@ func init() {
 	proto.RegisterType((*TransactionAction)(nil), "protos.TransactionAction")
 	proto.RegisterType((*ChaincodeActionPayload)(nil), "protos.ChaincodeActionPayload")
 	proto.RegisterType((*ChaincodeEndorsedAction)(nil), "protos.ChaincodeEndorsedAction")
+	proto.RegisterType((*IndexedTransaction)(nil), "protos.IndexedTransaction")
 	proto.RegisterEnum("protos.TxValidationCode", TxValidationCode_name, TxValidationCode_value)
 }
 
 func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor12) }
 
 var fileDescriptor12 = []byte{
-	// 833 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xd1, 0x6e, 0xe2, 0x46,
-	0x14, 0x2d, 0xd9, 0x4d, 0xd2, 0x0c, 0xd9, 0x64, 0x32, 0x10, 0x42, 0x50, 0xd4, 0x5d, 0xf1, 0x50,
-	0x6d, 0x5b, 0x09, 0xa4, 0xec, 0x43, 0xa5, 0xaa, 0x2f, 0x83, 0x3d, 0x09, 0x56, 0xcd, 0x8c, 0x35,
-	0x1e, 0x08, 0xe9, 0x43, 0x47, 0x06, 0xcf, 0x12, 0x54, 0xb0, 0x2d, 0xdb, 0x59, 0x35, 0xaf, 0xfd,
-	0x80, 0xf6, 0x3f, 0xfb, 0x13, 0xad, 0xc6, 0x63, 0x03, 0xc9, 0x76, 0x5f, 0x30, 0x73, 0xcf, 0xb9,
-	0xf7, 0x9c, 0x7b, 0xaf, 0x3d, 0xa0, 0x95, 0x28, 0x95, 0xf6, 0xf3, 0x34, 0x88, 0xb2, 0x60, 0x9e,
-	0x2f, 0xe3, 0xa8, 0x97, 0xa4, 0x71, 0x1e, 0xa3, 0x83, 0xe2, 0x91, 0x75, 0xde, 0x2e, 0xe2, 0x78,
-	0xb1, 0x52, 0xfd, 0xe2, 0x38, 0x7b, 0xfc, 0xd8, 0xcf, 0x97, 0x6b, 0x95, 0xe5, 0xc1, 0x3a, 0x31,
-	0xc4, 0xce, 0x55, 0x51, 0x20, 0x49, 0xe3, 0x24, 0xce, 0x82, 0x95, 0x4c, 0x55, 0x96, 0xc4, 0x51,
-	0xa6, 0x4a, 0xb4, 0x31, 0x8f, 0xd7, 0xeb, 0x38, 0xea, 0x9b, 0x87, 0x09, 0x76, 0x7f, 0x03, 0x67,
-	0xfe, 0x72, 0x11, 0xa9, 0x50, 0x6c, 0x65, 0xd1, 0x0f, 0xe0, 0x6c, 0xc7, 0x85, 0x9c, 0x3d, 0xe5,
-	0x2a, 0x6b, 0xd7, 0xde, 0xd5, 0xde, 0x1f, 0x73, 0xb8, 0x03, 0x0c, 0x74, 0x1c, 0x5d, 0x81, 0xa3,
-	0x6c, 0xb9, 0x88, 0x82, 0xfc, 0x31, 0x55, 0xed, 0xbd, 0x82, 0xb4, 0x0d, 0x74, 0xff, 0xac, 0x81,
-	0xa6, 0x97, 0xc6, 0x73, 0x95, 0x65, 0xcf, 0x35, 0x06, 0xa0, 0xb1, 0x53, 0x8a, 0x44, 0x9f, 0xd4,
-	0x2a, 0x4e, 0x54, 0xa1, 0x52, 0xbf, 0x86, 0xbd, 0xd2, 0x64, 0x15, 0xe7, 0xff, 0x47, 0x46, 0xdf,
-	0x82, 0x93, 0x4f, 0xc1, 0x6a, 0x19, 0x06, 0x3a, 0x6a, 0xc5, 0xa1, 0xd1, 0xdf, 0xe7, 0x2f, 0xa2,
-	0xdd, 0x01, 0xa8, 0xef, 0x4a, 0x7f, 0x00, 0x87, 0xe6, 0x9f, 0x6e, 0xea, 0xd5, 0xfb, 0xfa, 0xf5,
-	0xa5, 0x19, 0x46, 0xd6, 0xdb, 0x61, 0xe1, 0xe2, 0x97, 0x57, 0xcc, 0x2e, 0x01, 0x67, 0x9f, 0xa1,
-	0xa8, 0x05, 0x0e, 0x1e, 0x54, 0x10, 0xaa, 0xb4, 0x9c, 0x4e, 0x79, 0x42, 0x6d, 0x70, 0x98, 0x04,
-	0x4f, 0xab, 0x38, 0x08, 0xcb, 0x89, 0x54, 0xc7, 0xee, 0xdf, 0x35, 0xd0, 0xb2, 0x1e, 0x82, 0x65,
-	0x34, 0x8f, 0x43, 0x65, 0xaa, 0x78, 0x06, 0x42, 0x3f, 0x83, 0xce, 0xbc, 0x42, 0xe4, 0x66, 0x89,
-	0x55, 0x1d, 0x23, 0xd0, 0xde, 0x30, 0xbc, 0x92, 0x50, 0x65, 0xff, 0x08, 0x0e, 0x8c, 0xb5, 0x42,
-	0xb1, 0x7e, 0xfd, 0xb6, 0xea, 0x69, 0xa3, 0x46, 0xa2, 0x30, 0x4e, 0x33, 0x15, 0x96, 0x9d, 0x95,
-	0xf4, 0xee, 0x5f, 0x35, 0x70, 0xf1, 0x05, 0x0e, 0xfa, 0x09, 0x5c, 0x7e, 0xf6, 0x36, 0xbd, 0x70,
-	0x74, 0x51, 0x11, 0x78, 0x89, 0x6f, 0x0d, 0x1d, 0x2b, 0x53, 0x6d, 0xad, 0xa2, 0x3c, 0x6b, 0xef,
-	0x15, 0xa3, 0x6e, 0x54, 0xb6, 0xc8, 0x16, 0xe3, 0xcf, 0x88, 0xdf, 0xff, 0xf3, 0x1a, 0x40, 0xf1,
-	0xc7, 0xe4, 0xd9, 0x0a, 0xd1, 0x11, 0xd8, 0x9f, 0x60, 0xd7, 0xb1, 0xe1, 0x57, 0x08, 0x82, 0x63,
-	0xea, 0xb8, 0x92, 0xd0, 0x09, 0x71, 0x99, 0x47, 0x60, 0x0d, 0x9d, 0x82, 0xfa, 0x00, 0xdb, 0xd2,
-	0xc3, 0xf7, 0x2e, 0xc3, 0x36, 0xdc, 0x43, 0xe7, 0xe0, 0x4c, 0x07, 0x2c, 0x36, 0x1a, 0x31, 0x2a,
-	0x87, 0x04, 0xdb, 0x84, 0xc3, 0x57, 0xe8, 0x12, 0x9c, 0x17, 0x61, 0x4e, 0xb0, 0x60, 0x5c, 0xfa,
-	0xce, 0x2d, 0xc5, 0x62, 0xcc, 0x09, 0x7c, 0x8d, 0xde, 0x81, 0x2b, 0x87, 0x16, 0x0a, 0x92, 0x50,
-	0x9b, 0x71, 0x9f, 0x70, 0x29, 0x38, 0xa6, 0x3e, 0xb6, 0x84, 0xc3, 0x28, 0xdc, 0x47, 0xdf, 0x80,
-	0x4e, 0xc5, 0xb0, 0x18, 0xbd, 0x71, 0x6e, 0x9f, 0xe1, 0x07, 0xa8, 0x03, 0x5a, 0x63, 0xea, 0x8f,
-	0x3d, 0x8f, 0x71, 0x41, 0x6c, 0x29, 0xa6, 0x1b, 0x3f, 0x87, 0x95, 0x1f, 0x8f, 0x33, 0x8f, 0xf9,
-	0xd8, 0x95, 0x62, 0xea, 0xd8, 0xf0, 0x6b, 0x84, 0xc0, 0x89, 0x3d, 0xf6, 0x5c, 0xc7, 0xc2, 0x82,
-	0x98, 0xd8, 0x91, 0x96, 0x29, 0x0d, 0x8c, 0x08, 0x15, 0xd2, 0x63, 0xae, 0x63, 0xdd, 0xcb, 0x1b,
-	0xec, 0xb8, 0xda, 0x28, 0x40, 0x2d, 0x80, 0x46, 0x13, 0xcb, 0x92, 0x9c, 0x60, 0x63, 0xc4, 0x75,
-	0x2c, 0x01, 0xeb, 0xba, 0x37, 0x6f, 0x88, 0xa9, 0x60, 0xa3, 0x17, 0xd0, 0x31, 0x6a, 0x80, 0xd3,
-	0x31, 0xfd, 0x85, 0xb2, 0x3b, 0xaa, 0x5d, 0x89, 0x7b, 0x8f, 0xc0, 0x37, 0xda, 0xae, 0xc0, 0xfc,
-	0x96, 0x08, 0x69, 0x0d, 0xb1, 0x43, 0x25, 0x65, 0x42, 0xde, 0xb0, 0x31, 0xb5, 0xe1, 0x09, 0x6a,
-	0x02, 0x38, 0xc2, 0xdc, 0x1f, 0x16, 0x4e, 0x25, 0xe1, 0x9c, 0x71, 0x78, 0x5a, 0xcd, 0x5d, 0x4c,
-	0xcb, 0x96, 0xa1, 0x6e, 0x8b, 0x4c, 0x3d, 0x87, 0x13, 0xdb, 0x14, 0xb1, 0x98, 0x4d, 0xe0, 0x99,
-	0x6e, 0x61, 0x73, 0x94, 0x13, 0xc2, 0x7d, 0x87, 0xd1, 0xad, 0x1f, 0x84, 0xda, 0xa0, 0xa9, 0xa7,
-	0x61, 0xd6, 0x22, 0xc9, 0x54, 0x10, 0xaa, 0x29, 0xb0, 0xa1, 0x9b, 0x2b, 0x16, 0x34, 0xc4, 0x94,
-	0x12, 0xb7, 0x5a, 0x5c, 0xb3, 0xca, 0xe0, 0xc4, 0xf7, 0x18, 0xf5, 0xc9, 0x66, 0xb2, 0xe7, 0xe8,
-	0x0d, 0x38, 0x2a, 0x90, 0x3b, 0x9f, 0x08, 0xd8, 0xd2, 0xce, 0x1d, 0xd7, 0x25, 0xb7, 0xd8, 0x95,
-	0x77, 0xdc, 0x11, 0x44, 0x47, 0x2f, 0xd0, 0x25, 0x68, 0x56, 0xab, 0x63, 0x62, 0x48, 0xb8, 0x9e,
-	0x90, 0xcf, 0x28, 0xfc, 0xb7, 0x36, 0x98, 0x83, 0x6e, 0x9c, 0x2e, 0x7a, 0x0f, 0x4f, 0x89, 0x4a,
-	0x57, 0x2a, 0x5c, 0xa8, 0xb4, 0xf7, 0x31, 0x98, 0xa5, 0xcb, 0x79, 0xf5, 0x9e, 0xea, 0x2b, 0x75,
-	0x80, 0x76, 0x3e, 0x7d, 0x2f, 0x98, 0xff, 0x1e, 0x2c, 0xd4, 0xaf, 0xdf, 0x2d, 0x96, 0xf9, 0xc3,
-	0xe3, 0x4c, 0xdf, 0x54, 0xfd, 0x9d, 0xf4, 0xbe, 0x49, 0x37, 0x97, 0x74, 0xd6, 0xd7, 0xe9, 0x33,
-	0x73, 0x81, 0x7f, 0xf8, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x19, 0x1c, 0xb2, 0xe4, 0xe1, 0x05, 0x00,
-	0x00,
+	// 890 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xcb, 0x6e, 0xe3, 0x36,
+	0x14, 0xad, 0x33, 0x79, 0x4c, 0xe8, 0x4c, 0xc2, 0xd0, 0x8e, 0xe3, 0x18, 0x41, 0x27, 0xf0, 0xa2,
+	0x98, 0xb6, 0x80, 0x0d, 0x64, 0x16, 0x05, 0x8a, 0x6e, 0x68, 0x89, 0x89, 0x85, 0xca, 0xa4, 0x40,
+	0xd1, 0x8e, 0xd3, 0x45, 0x09, 0xd9, 0xe2, 0x38, 0xc6, 0xd8, 0x92, 0x20, 0x29, 0x83, 0x64, 0xdb,
+	0x0f, 0x68, 0x7f, 0xa2, 0x5f, 0xd7, 0x9f, 0x68, 0x41, 0x3d, 0xfc, 0xc8, 0xa4, 0x1b, 0xcb, 0x3c,
+	0xe7, 0xdc, 0x7b, 0xcf, 0xbd, 0xbc, 0x90, 0x40, 0x23, 0x52, 0x2a, 0xee, 0xa6, 0xb1, 0x17, 0x24,
+	0xde, 0x34, 0x9d, 0x87, 0x41, 0x27, 0x8a, 0xc3, 0x34, 0x44, 0xfb, 0xd9, 0x23, 0x69, 0xbd, 0x9f,
+	0x85, 0xe1, 0x6c, 0xa1, 0xba, 0xd9, 0x71, 0xf2, 0xf8, 0xa9, 0x9b, 0xce, 0x97, 0x2a, 0x49, 0xbd,
+	0x65, 0x94, 0x0b, 0x5b, 0x97, 0x59, 0x82, 0x28, 0x0e, 0xa3, 0x30, 0xf1, 0x16, 0x32, 0x56, 0x49,
+	0x14, 0x06, 0x89, 0x2a, 0xd8, 0xda, 0x34, 0x5c, 0x2e, 0xc3, 0xa0, 0x9b, 0x3f, 0x72, 0xb0, 0xfd,
+	0x3b, 0x38, 0x75, 0xe7, 0xb3, 0x40, 0xf9, 0x62, 0x5d, 0x16, 0xfd, 0x08, 0x4e, 0x37, 0x5c, 0xc8,
+	0xc9, 0x73, 0xaa, 0x92, 0x66, 0xe5, 0xaa, 0xf2, 0xe1, 0x88, 0xc3, 0x0d, 0xa2, 0xa7, 0x71, 0x74,
+	0x09, 0x0e, 0x93, 0xf9, 0x2c, 0xf0, 0xd2, 0xc7, 0x58, 0x35, 0x77, 0x32, 0xd1, 0x1a, 0x68, 0xff,
+	0x51, 0x01, 0x75, 0x27, 0x0e, 0xa7, 0x2a, 0x49, 0xb6, 0x6b, 0xf4, 0x40, 0x6d, 0x23, 0x15, 0x09,
+	0xbe, 0xa8, 0x45, 0x18, 0xa9, 0xac, 0x4a, 0xf5, 0x1a, 0x76, 0x0a, 0x93, 0x25, 0xce, 0x5f, 0x13,
+	0xa3, 0xef, 0xc0, 0xf1, 0x17, 0x6f, 0x31, 0xf7, 0x3d, 0x8d, 0x1a, 0xa1, 0x9f, 0xd7, 0xdf, 0xe3,
+	0x2f, 0xd0, 0x76, 0x0f, 0x54, 0x37, 0x4b, 0x7f, 0x04, 0x07, 0xf9, 0x3f, 0xdd, 0xd4, 0x9b, 0x0f,
+	0xd5, 0xeb, 0x8b, 0x7c, 0x18, 0x49, 0x67, 0x43, 0x85, 0xb3, 0x5f, 0x5e, 0x2a, 0xdb, 0x04, 0x9c,
+	0x7e, 0xc5, 0xa2, 0x06, 0xd8, 0x7f, 0x50, 0x9e, 0xaf, 0xe2, 0x62, 0x3a, 0xc5, 0x09, 0x35, 0xc1,
+	0x41, 0xe4, 0x3d, 0x2f, 0x42, 0xcf, 0x2f, 0x26, 0x52, 0x1e, 0xdb, 0x7f, 0x55, 0x40, 0xc3, 0x78,
+	0xf0, 0xe6, 0xc1, 0x34, 0xf4, 0x55, 0x9e, 0xc5, 0xc9, 0x29, 0xf4, 0x0b, 0x68, 0x4d, 0x4b, 0x46,
+	0xae, 0x2e, 0xb1, 0xcc, 0x93, 0x17, 0x68, 0xae, 0x14, 0x4e, 0x21, 0x28, 0xa3, 0x7f, 0x02, 0xfb,
+	0xb9, 0xb5, 0xac, 0x62, 0xf5, 0xfa, 0x7d, 0xd9, 0xd3, 0xaa, 0x1a, 0x09, 0xfc, 0x30, 0x4e, 0x94,
+	0x5f, 0x74, 0x56, 0xc8, 0xdb, 0x7f, 0x56, 0xc0, 0xf9, 0xff, 0x68, 0xd0, 0xcf, 0xe0, 0xe2, 0xab,
+	0x6d, 0x7a, 0xe1, 0xe8, 0xbc, 0x14, 0xf0, 0x82, 0x5f, 0x1b, 0x3a, 0x52, 0x79, 0xb6, 0xa5, 0x0a,
+	0xd2, 0xa4, 0xb9, 0x93, 0x8d, 0xba, 0x56, 0xda, 0x22, 0x6b, 0x8e, 0x6f, 0x09, 0xdb, 0x7f, 0x57,
+	0x00, 0xb2, 0x02, 0x5f, 0x3d, 0x6d, 0x2f, 0xcc, 0x15, 0xa8, 0x4e, 0x16, 0xe1, 0xf4, 0x33, 0x7d,
+	0x5c, 0x4e, 0x8a, 0x81, 0xef, 0xf2, 0x4d, 0x08, 0xb5, 0xc0, 0xdb, 0xf4, 0xa9, 0xa0, 0x77, 0x32,
+	0x7a, 0x75, 0x46, 0x0e, 0xa8, 0x47, 0xaf, 0xac, 0x61, 0xf3, 0x4d, 0x36, 0xac, 0xcb, 0xd2, 0xd5,
+	0x6b, 0xab, 0xca, 0x5f, 0x8d, 0xfc, 0xe1, 0x9f, 0x5d, 0x00, 0xc5, 0xd3, 0x68, 0x6b, 0xd3, 0xd0,
+	0x21, 0xd8, 0x1b, 0x61, 0xdb, 0x32, 0xe1, 0x37, 0x08, 0x82, 0x23, 0x6a, 0xd9, 0x92, 0xd0, 0x11,
+	0xb1, 0x99, 0x43, 0x60, 0x05, 0x9d, 0x80, 0x6a, 0x0f, 0x9b, 0xd2, 0xc1, 0xf7, 0x36, 0xc3, 0x26,
+	0xdc, 0x41, 0x67, 0xe0, 0x54, 0x03, 0x06, 0x1b, 0x0c, 0x18, 0x95, 0x7d, 0x82, 0x4d, 0xc2, 0xe1,
+	0x1b, 0x74, 0x01, 0xce, 0x32, 0x98, 0x13, 0x2c, 0x18, 0x97, 0xae, 0x75, 0x4b, 0xb1, 0x18, 0x72,
+	0x02, 0x77, 0xd1, 0x15, 0xb8, 0xb4, 0x68, 0x56, 0x41, 0x12, 0x6a, 0x32, 0xee, 0x12, 0x2e, 0x05,
+	0xc7, 0xd4, 0xc5, 0x86, 0xb0, 0x18, 0x85, 0x7b, 0xe8, 0x5b, 0xd0, 0x2a, 0x15, 0x06, 0xa3, 0x37,
+	0xd6, 0xed, 0x16, 0xbf, 0x8f, 0x5a, 0xa0, 0x31, 0xa4, 0xee, 0xd0, 0x71, 0x18, 0x17, 0xc4, 0x94,
+	0x62, 0xbc, 0xf2, 0x73, 0x50, 0xfa, 0x71, 0x38, 0x73, 0x98, 0x8b, 0x6d, 0x29, 0xc6, 0x96, 0x09,
+	0xdf, 0x22, 0x04, 0x8e, 0xcd, 0xa1, 0x63, 0x5b, 0x06, 0x16, 0x24, 0xc7, 0x0e, 0x75, 0x99, 0xc2,
+	0xc0, 0x80, 0x50, 0x21, 0x1d, 0x66, 0x5b, 0xc6, 0xbd, 0xbc, 0xc1, 0x96, 0xad, 0x8d, 0x02, 0xd4,
+	0x00, 0x68, 0x30, 0x32, 0x0c, 0xc9, 0x09, 0xce, 0x8d, 0xd8, 0x96, 0x21, 0x60, 0x55, 0xf7, 0xe6,
+	0xf4, 0x31, 0x15, 0x6c, 0xf0, 0x82, 0x3a, 0x42, 0x35, 0x70, 0x32, 0xa4, 0xbf, 0x52, 0x76, 0x47,
+	0xb5, 0x2b, 0x71, 0xef, 0x10, 0xf8, 0x4e, 0xdb, 0x15, 0x98, 0xdf, 0x12, 0x21, 0x8d, 0x3e, 0xb6,
+	0xa8, 0xa4, 0x4c, 0xc8, 0x1b, 0x36, 0xa4, 0x26, 0x3c, 0x46, 0x75, 0x00, 0x07, 0x98, 0xbb, 0xfd,
+	0xcc, 0xa9, 0x24, 0x9c, 0x33, 0x0e, 0x4f, 0xca, 0xb9, 0x8b, 0x71, 0xd1, 0x32, 0xd4, 0x6d, 0x91,
+	0xb1, 0x63, 0x71, 0x62, 0xe6, 0x49, 0x0c, 0x66, 0x12, 0x78, 0xaa, 0x5b, 0x58, 0x1d, 0xe5, 0x88,
+	0x70, 0xd7, 0x62, 0x74, 0xed, 0x07, 0xa1, 0x26, 0xa8, 0xeb, 0x69, 0xe4, 0xd7, 0x22, 0xc9, 0x58,
+	0x10, 0xaa, 0x25, 0xb0, 0xa6, 0x9b, 0xcb, 0x2e, 0xa8, 0x8f, 0x29, 0x25, 0x76, 0x79, 0x71, 0xf5,
+	0x32, 0x82, 0x13, 0xd7, 0x61, 0xd4, 0x25, 0xab, 0xc9, 0x9e, 0xa1, 0x77, 0xe0, 0x30, 0x63, 0xee,
+	0x5c, 0x22, 0x60, 0x43, 0x3b, 0xb7, 0x6c, 0x9b, 0xdc, 0x62, 0x5b, 0xde, 0x71, 0x4b, 0x10, 0x8d,
+	0x9e, 0xa3, 0x0b, 0x50, 0x2f, 0xaf, 0x8e, 0x89, 0x3e, 0xe1, 0x7a, 0x42, 0x2e, 0xa3, 0xf0, 0xdf,
+	0x4a, 0x6f, 0x0a, 0xda, 0x61, 0x3c, 0xeb, 0x3c, 0x3c, 0x47, 0x2a, 0x5e, 0x28, 0x7f, 0xa6, 0xe2,
+	0xce, 0x27, 0x6f, 0x12, 0xcf, 0xa7, 0xe5, 0xe2, 0xea, 0x37, 0x7f, 0x0f, 0x6d, 0xec, 0xa7, 0xe3,
+	0x4d, 0x3f, 0x7b, 0x33, 0xf5, 0xdb, 0xf7, 0xb3, 0x79, 0xfa, 0xf0, 0x38, 0xd1, 0x2f, 0xd4, 0xee,
+	0x46, 0x78, 0x37, 0x0f, 0xcf, 0xbf, 0x25, 0x49, 0x57, 0x87, 0x4f, 0xf2, 0xef, 0xcc, 0xc7, 0xff,
+	0x06, 0x00, 0x4b, 0x85, 0xd0, 0x40, 0x88, 0x06, 0x00, 0x00,
 }
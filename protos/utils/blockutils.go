@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
@@ -101,6 +102,35 @@ func GetLastConfigIndexFromBlockOrPanic(block *cb.Block) uint64 {
 	return index
 }
 
+// GetSignatureSetFromBlock returns the signatures of the orderers over the header
+// of the block, to be evaluated against the BlockValidation policy of the channel
+func GetSignatureSetFromBlock(block *cb.Block) ([]*cb.SignedData, error) {
+	if block.Header == nil {
+		return nil, fmt.Errorf("the block has no header")
+	}
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_SIGNATURES) {
+		return nil, fmt.Errorf("the block has no signatures metadata")
+	}
+	metadata, err := GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling the signatures metadata: %s", err)
+	}
+
+	signatureSet := make([]*cb.SignedData, 0, len(metadata.Signatures))
+	for _, metadataSignature := range metadata.Signatures {
+		shdr, err := GetSignatureHeader(metadataSignature.SignatureHeader)
+		if err != nil {
+			return nil, fmt.Errorf("failed unmarshaling a signature header: %s", err)
+		}
+		signatureSet = append(signatureSet, &cb.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, metadataSignature.SignatureHeader, block.Header.Bytes()),
+			Signature: metadataSignature.Signature,
+		})
+	}
+	return signatureSet, nil
+}
+
 // GetBlockFromBlockBytes marshals the bytes into Block
 func GetBlockFromBlockBytes(blockBytes []byte) (*cb.Block, error) {
 	block := &cb.Block{}
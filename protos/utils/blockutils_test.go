@@ -215,3 +215,42 @@ func TestGetLastConfigIndexFromBlock(t *testing.T) {
 		_ = utils.GetLastConfigIndexFromBlockOrPanic(block)
 	}, "Expected panic with malformed last config metadata")
 }
+
+func TestGetSignatureSetFromBlock(t *testing.T) {
+	block := common.NewBlock(1, []byte("previous"))
+	shdr := utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("orderer"), Nonce: []byte("nonce")})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Value:      []byte("value"),
+		Signatures: []*cb.MetadataSignature{{SignatureHeader: shdr, Signature: []byte("signature")}},
+	})
+
+	signatureSet, err := utils.GetSignatureSetFromBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, []*cb.SignedData{{
+		Identity:  []byte("orderer"),
+		Data:      append(append([]byte("value"), shdr...), block.Header.Bytes()...),
+		Signature: []byte("signature"),
+	}}, signatureSet)
+
+	// malformed signature header
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Signatures: []*cb.MetadataSignature{{SignatureHeader: []byte("bad signature header")}},
+	})
+	_, err = utils.GetSignatureSetFromBlock(block)
+	assert.Error(t, err, "Expected error with malformed signature header")
+
+	// malformed metadata
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = []byte("bad metadata")
+	_, err = utils.GetSignatureSetFromBlock(block)
+	assert.Error(t, err, "Expected error with malformed metadata")
+
+	// missing metadata
+	block.Metadata = nil
+	_, err = utils.GetSignatureSetFromBlock(block)
+	assert.Error(t, err, "Expected error with missing metadata")
+
+	// missing header
+	block.Header = nil
+	_, err = utils.GetSignatureSetFromBlock(block)
+	assert.Error(t, err, "Expected error with missing header")
+}
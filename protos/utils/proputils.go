@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/external"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -155,6 +156,16 @@ func GetChaincodeDeploymentSpec(code []byte) (*peer.ChaincodeDeploymentSpec, err
 		return nil, err
 	}
 
+	// chaincodes run as external services carry connection information
+	// instead of code
+	if cds.ExecEnv == peer.ChaincodeDeploymentSpec_EXTERNAL {
+		if len(cds.CodePackage) == 0 {
+			return cds, nil
+		}
+		_, err = external.ParseConnectionPackage(cds.CodePackage)
+		return cds, err
+	}
+
 	// FAB-2122: Validate the CDS according to platform specific requirements
 	platform, err := platforms.Find(cds.ChaincodeSpec.Type)
 	if err != nil {